@@ -0,0 +1,77 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of go-tau.
+//
+// go-tau is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-tau is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-tau. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/cmd/utils"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	reindexCommand = cli.Command{
+		Action:    utils.MigrateFlags(reindex),
+		Name:      "reindex",
+		Usage:     "Rebuild the transaction lookup index",
+		ArgsUsage: "",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.CacheFlag,
+			utils.TxLookupLimitFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The reindex command drops the transaction lookup index and rebuilds it for the
+most recent --txlookuplimit blocks (all blocks if the limit is 0). The node must
+not be running while the index is rebuilt.`,
+	}
+)
+
+// reindex wipes and rebuilds the transaction lookup index.
+func reindex(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db, _ := utils.MakeChainDatabase(ctx, stack)
+	defer db.Close()
+
+	headHash := rawdb.ReadHeadBlockHash(db)
+	head := rawdb.ReadHeaderNumber(db, headHash)
+	if head == nil {
+		utils.Fatalf("No head block found in the database")
+	}
+	var (
+		start  = time.Now()
+		limit  = ctx.GlobalUint64(utils.TxLookupLimitFlag.Name)
+		target = core.TxIndexTarget(*head, limit)
+	)
+	log.Info("Dropping transaction lookup index")
+	if err := rawdb.DeleteAllTxLookupEntries(db); err != nil {
+		utils.Fatalf("Failed to drop the transaction index: %v", err)
+	}
+	log.Info("Rebuilding transaction lookup index", "from", target, "head", *head)
+	if err := rawdb.IndexTransactions(db, target, *head+1, nil); err != nil {
+		utils.Fatalf("Failed to rebuild the transaction index: %v", err)
+	}
+	log.Info("Transaction lookup index rebuilt", "tail", target, "head", *head, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
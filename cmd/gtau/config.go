@@ -118,6 +118,11 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	// Register tauservice
 	utils.RegisterTauService(stack, &cfg.Tau)
 
+	// Add the GraphQL server if requested.
+	if endpoint := cfg.Node.GraphQLEndpoint(); endpoint != "" {
+		utils.RegisterGraphQLService(stack, endpoint, cfg.Node.GraphQLCors, cfg.Node.GraphQLVirtualHosts, cfg.Node.HTTPTimeouts)
+	}
+
 	// Add the tau status daemon if requested.
 	if cfg.Taustats.URL != "" {
 		utils.RegisterTauStatsService(stack, cfg.Taustats.URL)
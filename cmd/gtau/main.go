@@ -71,6 +71,7 @@ var (
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
 		utils.GCModeFlag,
+		utils.TxLookupLimitFlag,
 		utils.LightKDFFlag,
 		utils.CacheFlag,
 		utils.CacheDatabaseFlag,
@@ -83,6 +84,7 @@ var (
 		utils.MinerFeeFloorFlag,
 		utils.MinerTauerbaseFlag,
 		utils.MinerRecommitIntervalFlag,
+		utils.FeeOracleBlocksFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV5Flag,
@@ -108,6 +110,19 @@ var (
 		utils.WSPortFlag,
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
+		utils.RPCAuthFlag,
+		utils.RPCConnRateFlag,
+		utils.RPCIPRateFlag,
+		utils.RPCBatchLimitFlag,
+		utils.RPCSubscriptionLimitFlag,
+		utils.RPCResponseLimitFlag,
+		utils.RPCHeavyMethodsFlag,
+		utils.RPCHeavyLimitFlag,
+		utils.GraphQLEnabledFlag,
+		utils.GraphQLListenAddrFlag,
+		utils.GraphQLPortFlag,
+		utils.GraphQLCORSDomainFlag,
+		utils.GraphQLVirtualHostsFlag,
 		utils.InsecureUnlockAllowedFlag,
 	}
 
@@ -128,6 +143,12 @@ func init() {
 	app.Action = gtau
 	app.HideVersion = true // we have a command to print the version
 	app.Copyright = "Copyright 2019-2020 The go-tau Authors"
+	app.Commands = []cli.Command{
+		// See chaincmd.go:
+		reindexCommand,
+		// See tokencmd.go:
+		tokenCommand,
+	}
 
 	app.Flags = append(app.Flags, nodeFlags...)
 	app.Flags = append(app.Flags, rpcFlags...)
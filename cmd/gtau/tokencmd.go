@@ -0,0 +1,206 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of go-tau.
+//
+// go-tau is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-tau is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-tau. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/cmd/utils"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/node"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	tokenNameFlag = cli.StringFlag{
+		Name:  "name",
+		Usage: "Human readable label of the token",
+	}
+	tokenNamespacesFlag = cli.StringFlag{
+		Name:  "namespaces",
+		Usage: "Comma separated list of API namespaces the token may call ('*' for all)",
+	}
+	tokenMethodsFlag = cli.StringFlag{
+		Name:  "methods",
+		Usage: "Comma separated list of individual methods the token may call",
+	}
+	tokenTTLFlag = cli.DurationFlag{
+		Name:  "ttl",
+		Usage: "Lifetime of the token (0 = never expires)",
+	}
+
+	tokenCommand = cli.Command{
+		Name:     "token",
+		Usage:    "Manage the access tokens of the HTTP-RPC and WS-RPC servers",
+		Category: "MISCELLANEOUS COMMANDS",
+		Description: `
+The token commands manage the bearer tokens required by the HTTP-RPC and WS-RPC
+servers when gtau runs with --rpcauth. They talk to the running node over its
+IPC endpoint, so they must be run as the user owning the data directory.
+
+Clients present a token in an "Authorization: Bearer <token>" header and may
+only call the namespaces and methods it was created for.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "create",
+				Usage:  "Create a new access token",
+				Action: utils.MigrateFlags(tokenCreate),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					tokenNameFlag,
+					tokenNamespacesFlag,
+					tokenMethodsFlag,
+					tokenTTLFlag,
+				},
+				Description: `
+    gtau token create --name wallet-ui --namespaces tau,net --methods personal_listAccounts
+
+Creates a token and prints it. The token is shown only once, the node keeps
+just its grant.`,
+			},
+			{
+				Name:   "list",
+				Usage:  "List the issued access tokens",
+				Action: utils.MigrateFlags(tokenList),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+			},
+			{
+				Name:      "revoke",
+				Usage:     "Revoke an access token",
+				ArgsUsage: "<id>",
+				Action:    utils.MigrateFlags(tokenRevoke),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+			},
+			{
+				Name:   "denied",
+				Usage:  "Show the most recent calls refused by the RPC servers",
+				Action: utils.MigrateFlags(tokenDenied),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+			},
+		},
+	}
+)
+
+// dialNodeIPC connects to the IPC endpoint of the node running on the
+// configured data directory.
+func dialNodeIPC(ctx *cli.Context) *rpc.Client {
+	cfg := defaultNodeConfig()
+	utils.SetNodeConfig(ctx, &cfg)
+
+	endpoint := cfg.IPCEndpoint()
+	if endpoint == "" {
+		utils.Fatalf("IPC endpoint unavailable, is the data directory set?")
+	}
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		utils.Fatalf("Unable to attach to gtau at %s: %v", endpoint, err)
+	}
+	return client
+}
+
+func tokenCreate(ctx *cli.Context) error {
+	client := dialNodeIPC(ctx)
+	defer client.Close()
+
+	var (
+		namespaces = splitList(ctx.String(tokenNamespacesFlag.Name))
+		methods    = splitList(ctx.String(tokenMethodsFlag.Name))
+		ttl        = uint64(ctx.Duration(tokenTTLFlag.Name) / time.Second)
+		token      node.IssuedToken
+	)
+	if err := client.Call(&token, "auth_createToken", ctx.String(tokenNameFlag.Name), namespaces, methods, ttl); err != nil {
+		utils.Fatalf("Failed to create token: %v", err)
+	}
+	fmt.Printf("ID:    %s\n", token.ID)
+	if token.Expires != nil {
+		fmt.Printf("Until: %v\n", token.Expires.Local())
+	}
+	fmt.Printf("Token: %s\n", token.Token)
+	return nil
+}
+
+func tokenList(ctx *cli.Context) error {
+	client := dialNodeIPC(ctx)
+	defer client.Close()
+
+	var tokens []*node.TokenInfo
+	if err := client.Call(&tokens, "auth_listTokens"); err != nil {
+		utils.Fatalf("Failed to list tokens: %v", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tNAMESPACES\tMETHODS\tEXPIRES")
+	for _, token := range tokens {
+		expires := "never"
+		if token.Expires != nil {
+			expires = token.Expires.Local().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", token.ID, token.Name, strings.Join(token.Namespaces, ","), strings.Join(token.Methods, ","), expires)
+	}
+	return w.Flush()
+}
+
+func tokenRevoke(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		utils.Fatalf("This command requires the token id as its only argument.")
+	}
+	client := dialNodeIPC(ctx)
+	defer client.Close()
+
+	var ok bool
+	if err := client.Call(&ok, "auth_revokeToken", ctx.Args().First()); err != nil {
+		utils.Fatalf("Failed to revoke token: %v", err)
+	}
+	fmt.Println("Token revoked")
+	return nil
+}
+
+func tokenDenied(ctx *cli.Context) error {
+	client := dialNodeIPC(ctx)
+	defer client.Close()
+
+	var calls []*node.DeniedCall
+	if err := client.Call(&calls, "auth_deniedCalls"); err != nil {
+		utils.Fatalf("Failed to retrieve denied calls: %v", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tREMOTE\tTOKEN\tMETHOD\tREASON")
+	for _, call := range calls {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", call.Time.Local().Format(time.RFC3339), call.Remote, call.Token, call.Method, call.Reason)
+	}
+	return w.Flush()
+}
+
+// splitList splits a comma separated flag value, dropping empty entries.
+func splitList(input string) []string {
+	var list []string
+	for _, item := range strings.Split(input, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/consensus/tauhash"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/graphql"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/metrics"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/metrics/influxdb"
@@ -48,7 +49,9 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/nat"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/netutil"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/feeoracle"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/taustats"
 	"gopkg.in/urfave/cli.v1"
@@ -166,6 +169,11 @@ var (
 		Usage: `Blockchain garbage collection mode ("full", "archive")`,
 		Value: "full",
 	}
+	TxLookupLimitFlag = cli.Uint64Flag{
+		Name:  "txlookuplimit",
+		Usage: "Number of recent blocks to maintain transactions index by-hash for (default = index all blocks)",
+		Value: 0,
+	}
 	LightKDFFlag = cli.BoolFlag{
 		Name:  "lightkdf",
 		Usage: "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -265,6 +273,12 @@ var (
 		Usage: "Time interval to recreate the block being mined",
 		Value: tau.DefaultConfig.Miner.Recommit,
 	}
+	// Fee oracle settings
+	FeeOracleBlocksFlag = cli.IntFlag{
+		Name:  "feeoracle.blocks",
+		Usage: "Number of recent blocks to sample when suggesting fees",
+		Value: tau.DefaultConfig.FeeOracle.Blocks,
+	}
 	// Account settings
 	PasswordFileFlag = cli.StringFlag{
 		Name:  "password",
@@ -343,6 +357,66 @@ var (
 		Usage: "Origins from which to accept websockets requests",
 		Value: "",
 	}
+	RPCAuthFlag = cli.BoolFlag{
+		Name:  "rpcauth",
+		Usage: "Require bearer tokens (see 'gtau token') on the HTTP-RPC and WS-RPC servers",
+	}
+	RPCConnRateFlag = cli.Float64Flag{
+		Name:  "rpc.connrate",
+		Usage: "Requests per second allowed per HTTP-RPC/WS-RPC connection (0 = unlimited)",
+	}
+	RPCIPRateFlag = cli.Float64Flag{
+		Name:  "rpc.iprate",
+		Usage: "Requests per second allowed per remote IP on the HTTP-RPC/WS-RPC servers (0 = unlimited)",
+	}
+	RPCBatchLimitFlag = cli.IntFlag{
+		Name:  "rpc.batchlimit",
+		Usage: "Maximum number of requests in a batch (0 = unlimited)",
+		Value: node.DefaultConfig.RPCLimits.MaxBatchSize,
+	}
+	RPCSubscriptionLimitFlag = cli.IntFlag{
+		Name:  "rpc.sublimit",
+		Usage: "Maximum active subscriptions per WS-RPC connection (0 = unlimited)",
+		Value: node.DefaultConfig.RPCLimits.MaxSubscriptions,
+	}
+	RPCResponseLimitFlag = cli.IntFlag{
+		Name:  "rpc.responselimit",
+		Usage: "Maximum size of a single RPC result in bytes (0 = unlimited)",
+	}
+	RPCHeavyMethodsFlag = cli.StringFlag{
+		Name:  "rpc.heavymethods",
+		Usage: "Comma separated list of expensive methods, or namespaces ending in '_'",
+		Value: strings.Join(node.DefaultConfig.RPCLimits.HeavyMethods, ","),
+	}
+	RPCHeavyLimitFlag = cli.IntFlag{
+		Name:  "rpc.heavylimit",
+		Usage: "Calls of expensive methods allowed per minute per remote IP (0 = unlimited)",
+		Value: rpc.DefaultLimits.HeavyCallsPerMinute,
+	}
+	GraphQLEnabledFlag = cli.BoolFlag{
+		Name:  "graphql",
+		Usage: "Enable the GraphQL server",
+	}
+	GraphQLListenAddrFlag = cli.StringFlag{
+		Name:  "graphql.addr",
+		Usage: "GraphQL server listening interface",
+		Value: node.DefaultGraphQLHost,
+	}
+	GraphQLPortFlag = cli.IntFlag{
+		Name:  "graphql.port",
+		Usage: "GraphQL server listening port",
+		Value: node.DefaultGraphQLPort,
+	}
+	GraphQLCORSDomainFlag = cli.StringFlag{
+		Name:  "graphql.corsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin requests (browser enforced)",
+		Value: "",
+	}
+	GraphQLVirtualHostsFlag = cli.StringFlag{
+		Name:  "graphql.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value: strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
+	}
 	ExecFlag = cli.StringFlag{
 		Name:  "exec",
 		Usage: "Execute JavaScript statement",
@@ -632,6 +706,26 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setGraphQL creates the GraphQL listener interface string from the set
+// command line flags, returning empty if the GraphQL endpoint is disabled.
+func setGraphQL(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalBool(GraphQLEnabledFlag.Name) && cfg.GraphQLHost == "" {
+		cfg.GraphQLHost = "127.0.0.1"
+		if ctx.GlobalIsSet(GraphQLListenAddrFlag.Name) {
+			cfg.GraphQLHost = ctx.GlobalString(GraphQLListenAddrFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(GraphQLPortFlag.Name) {
+		cfg.GraphQLPort = ctx.GlobalInt(GraphQLPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLCORSDomainFlag.Name) {
+		cfg.GraphQLCors = splitAndTrim(ctx.GlobalString(GraphQLCORSDomainFlag.Name))
+	}
+	if ctx.GlobalIsSet(GraphQLVirtualHostsFlag.Name) {
+		cfg.GraphQLVirtualHosts = splitAndTrim(ctx.GlobalString(GraphQLVirtualHostsFlag.Name))
+	}
+}
+
 // makeDatabaseHandles raises out the number of allowed file handles per process
 // for Gtau and returns half of the allowance to assign to the database.
 func makeDatabaseHandles() int {
@@ -752,6 +846,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	SetP2PConfig(ctx, &cfg.P2P)
 	setHTTP(ctx, cfg)
 	setWS(ctx, cfg)
+	setGraphQL(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
 	setDataDir(ctx, cfg)
 
@@ -768,6 +863,36 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.GlobalIsSet(InsecureUnlockAllowedFlag.Name) {
 		cfg.InsecureUnlockAllowed = ctx.GlobalBool(InsecureUnlockAllowedFlag.Name)
 	}
+	if ctx.GlobalIsSet(RPCAuthFlag.Name) {
+		cfg.RPCAuth = ctx.GlobalBool(RPCAuthFlag.Name)
+	}
+	setRPCLimits(ctx, &cfg.RPCLimits)
+}
+
+// setRPCLimits applies the resource limits of the HTTP and WebSocket RPC
+// servers from the command line flags.
+func setRPCLimits(ctx *cli.Context, limits *rpc.Limits) {
+	if ctx.GlobalIsSet(RPCConnRateFlag.Name) {
+		limits.ConnRequestRate = ctx.GlobalFloat64(RPCConnRateFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCIPRateFlag.Name) {
+		limits.IPRequestRate = ctx.GlobalFloat64(RPCIPRateFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCBatchLimitFlag.Name) {
+		limits.MaxBatchSize = ctx.GlobalInt(RPCBatchLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCSubscriptionLimitFlag.Name) {
+		limits.MaxSubscriptions = ctx.GlobalInt(RPCSubscriptionLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCResponseLimitFlag.Name) {
+		limits.MaxResponseSize = ctx.GlobalInt(RPCResponseLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCHeavyMethodsFlag.Name) {
+		limits.HeavyMethods = splitAndTrim(ctx.GlobalString(RPCHeavyMethodsFlag.Name))
+	}
+	if ctx.GlobalIsSet(RPCHeavyLimitFlag.Name) {
+		limits.HeavyCallsPerMinute = ctx.GlobalInt(RPCHeavyLimitFlag.Name)
+	}
 }
 
 func setDataDir(ctx *cli.Context, cfg *node.Config) {
@@ -831,6 +956,12 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	}
 }
 
+func setFeeOracle(ctx *cli.Context, cfg *feeoracle.Config) {
+	if ctx.GlobalIsSet(FeeOracleBlocksFlag.Name) {
+		cfg.Blocks = ctx.GlobalInt(FeeOracleBlocksFlag.Name)
+	}
+}
+
 // CheckExclusive verifies that only a single instance of the provided flags was
 // set by the user. Each flag might optionally be followed by a string type to
 // specialize it further.
@@ -884,6 +1015,7 @@ func SetTauConfig(ctx *cli.Context, stack *node.Node, cfg *tau.Config) {
 	setTauerbase(ctx, ks, cfg)
 	setTxPool(ctx, &cfg.TxPool)
 	setMiner(ctx, &cfg.Miner)
+	setFeeOracle(ctx, &cfg.FeeOracle)
 
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheDatabaseFlag.Name) {
 		cfg.DatabaseCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheDatabaseFlag.Name) / 100
@@ -896,6 +1028,10 @@ func SetTauConfig(ctx *cli.Context, stack *node.Node, cfg *tau.Config) {
 	cfg.NoPruning = ctx.GlobalString(GCModeFlag.Name) == "archive"
 	cfg.NoPrefetch = ctx.GlobalBool(CacheNoPrefetchFlag.Name)
 
+	if ctx.GlobalIsSet(TxLookupLimitFlag.Name) {
+		cfg.TxLookupLimit = ctx.GlobalUint64(TxLookupLimitFlag.Name)
+	}
+
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheTrieFlag.Name) / 100
 	}
@@ -935,6 +1071,19 @@ func RegisterTauStatsService(stack *node.Node, url string) {
 	}
 }
 
+// RegisterGraphQLService is a utility function to construct a new service and register it against a node.
+func RegisterGraphQLService(stack *node.Node, endpoint string, cors, vhosts []string, timeouts rpc.HTTPTimeouts) {
+	if err := stack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
+		var tauServ *tau.Tau
+		if err := ctx.Service(&tauServ); err != nil {
+			return nil, err
+		}
+		return graphql.New(tauServ.APIBackend, endpoint, cors, vhosts, timeouts)
+	}); err != nil {
+		Fatalf("Failed to register the GraphQL service: %v", err)
+	}
+}
+
 func SetupMetrics(ctx *cli.Context) {
 	if metrics.Enabled {
 		log.Info("Enabling metrics collection")
@@ -1026,6 +1175,7 @@ func MakeChain(ctx *cli.Context, stack *node.Node) (chain *core.BlockChain, chai
 		TrieDirtyLimit:      tau.DefaultConfig.TrieDirtyCache,
 		TrieDirtyDisabled:   ctx.GlobalString(GCModeFlag.Name) == "archive",
 		TrieTimeLimit:       tau.DefaultConfig.TrieTimeout,
+		TxLookupLimit:       ctx.GlobalUint64(TxLookupLimitFlag.Name),
 	}
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheTrieFlag.Name) {
 		cache.TrieCleanLimit = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheTrieFlag.Name) / 100
@@ -323,13 +323,37 @@ var (
 	big32 = big.NewInt(32)
 )
 
+// BlockReward returns the amount of coins the block with the given number
+// creates according to the chain's emission schedule, given the amount of
+// coins issued before it. Chains without a configured schedule pay the fixed
+// FrontierBlockReward. The reward never lifts the issuance above MaxSupply.
+func BlockReward(config *params.ChainConfig, number *big.Int, issued *big.Int) *big.Int {
+	reward := config.Tauash.BlockReward(number.Uint64())
+	if reward == nil {
+		reward = new(big.Int).Set(FrontierBlockReward)
+	}
+	if config.Tauash != nil && config.Tauash.MaxSupply != nil {
+		left := new(big.Int).Sub(config.Tauash.MaxSupply, issued)
+		if left.Sign() <= 0 {
+			return new(big.Int)
+		}
+		if reward.Cmp(left) > 0 {
+			reward = left
+		}
+	}
+	return reward
+}
+
 // AccumulateRewards credits the coinbase of the given block with the mining
-// reward. The total reward consists of the static block reward and rewards for
-// included uncles. The coinbase of each uncle block is also rewarded.
+// reward. The reward follows the emission schedule of the chain and, past the
+// supply fork, is tracked in the issued supply of the state.
 func accumulateRewards(config *params.ChainConfig, state *state.StateDB, header *types.Header) {
-	// Select the correct block reward based on chain progression
-	blockReward := FrontierBlockReward
-	// Accumulate the rewards for the miner and any included uncles
-	reward := new(big.Int).Set(blockReward)
+	reward := BlockReward(config, header.Number, state.GetSupply().Issued)
+	if reward.Sign() == 0 {
+		return
+	}
 	state.AddBalance(header.Coinbase, reward)
+	if config.IsSupply(header.Number) {
+		state.AddIssued(reward)
+	}
 }
@@ -0,0 +1,66 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauhash
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+)
+
+// Tests that the block reward follows the emission schedule, is capped by the
+// maximum supply and is only tracked in the supply past the supply fork.
+func TestAccumulateRewards(t *testing.T) {
+	miner := common.HexToAddress("0x01")
+	tests := []struct {
+		supplyBlock *big.Int
+		number      int64
+		issued      int64
+		tauash      *params.TauashConfig
+		wantReward  int64
+		wantIssued  int64
+	}{
+		// Chains without a schedule pay the frontier reward
+		{supplyBlock: big.NewInt(0), number: 1, tauash: new(params.TauashConfig), wantReward: FrontierBlockReward.Int64(), wantIssued: FrontierBlockReward.Int64()},
+		// Scheduled rewards are halved and capped by the maximum supply
+		{supplyBlock: big.NewInt(0), number: 10, tauash: &params.TauashConfig{InitialReward: big.NewInt(1000), HalvingInterval: 10}, wantReward: 500, wantIssued: 500},
+		{supplyBlock: big.NewInt(0), number: 1, issued: 900, tauash: &params.TauashConfig{InitialReward: big.NewInt(1000), MaxSupply: big.NewInt(1500)}, wantReward: 600, wantIssued: 1500},
+		{supplyBlock: big.NewInt(0), number: 1, issued: 1500, tauash: &params.TauashConfig{InitialReward: big.NewInt(1000), MaxSupply: big.NewInt(1500)}, wantReward: 0, wantIssued: 1500},
+		// Blocks before the supply fork pay the reward without tracking it
+		{supplyBlock: big.NewInt(5), number: 4, tauash: &params.TauashConfig{InitialReward: big.NewInt(1000)}, wantReward: 1000, wantIssued: 0},
+		{supplyBlock: nil, number: 4, tauash: &params.TauashConfig{InitialReward: big.NewInt(1000)}, wantReward: 1000, wantIssued: 0},
+	}
+	for i, test := range tests {
+		config := &params.ChainConfig{ChainID: big.NewInt(1), SupplyBlock: test.supplyBlock, Tauash: test.tauash}
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb.AddIssued(big.NewInt(test.issued))
+
+		accumulateRewards(config, statedb, &types.Header{Number: big.NewInt(test.number), Coinbase: miner})
+		if balance := statedb.GetBalance(miner); balance.Cmp(big.NewInt(test.wantReward)) != 0 {
+			t.Errorf("test %d: reward mismatch: have %v, want %d", i, balance, test.wantReward)
+		}
+		if issued := statedb.GetSupply().Issued; issued.Cmp(big.NewInt(test.wantIssued)) != 0 {
+			t.Errorf("test %d: issued mismatch: have %v, want %d", i, issued, test.wantIssued)
+		}
+	}
+}
@@ -69,12 +69,19 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 
 // ValidateState validates the various changes that happen after a state
 // transition, such as amount of used gas, the receipt roots and the state root
-// itself. ValidateState returns a database batch if the validation was a success
+// itself. It also enforces the maximum supply of the chain's emission schedule.
+// ValidateState returns a database batch if the validation was a success
 // otherwise nil and an error is returned.
 func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateDB) error {
 	header := block.Header()
 	if root := statedb.IntermediateRoot(v.config.IsEIP158(header.Number)); header.Root != root {
 		return fmt.Errorf("invalid merkle root (remote: %x local: %x)", header.Root, root)
 	}
+	// Make sure the emission never overshoots the declared maximum supply
+	if tauash := v.config.Tauash; tauash != nil && tauash.MaxSupply != nil {
+		if issued := statedb.GetSupply().Issued; issued.Cmp(tauash.MaxSupply) > 0 {
+			return fmt.Errorf("%v: issued %v, max %v", ErrSupplyExceeded, issued, tauash.MaxSupply)
+		}
+	}
 	return nil
 }
@@ -112,6 +112,7 @@ type CacheConfig struct {
 	TrieDirtyLimit      int           // Memory limit (MB) at which to start flushing dirty trie nodes to disk
 	TrieDirtyDisabled   bool          // Whtauer to disable trie write caching and GC altogtauer (archive node)
 	TrieTimeLimit       time.Duration // Time limit after which to flush the current in-memory trie to disk
+	TxLookupLimit       uint64        // Number of recent blocks to keep transaction indexes for (0 = all blocks)
 }
 
 // BlockChain represents the canonical chain given a database with a genesis
@@ -142,6 +143,8 @@ type BlockChain struct {
 	chainSideFeed event.Feed
 	chainHeadFeed event.Feed
 	blockProcFeed event.Feed
+	profileFeed   event.Feed
+	chainTxsFeed  event.Feed
 	scope         event.SubscriptionScope
 	genesisBlock  *types.Block
 
@@ -281,6 +284,10 @@ func NewBlockChain(db taudb.Database, ipfsDb taudb.IpfsStore, cacheConfig *Cache
 	}
 	// Take ownership of this particular state
 	go bc.update()
+
+	// Keep the transaction lookup index in line with the configured horizon
+	bc.wg.Add(1)
+	go bc.maintainTxIndex()
 	return bc, nil
 }
 
@@ -1031,6 +1038,7 @@ func (bc *BlockChain) CompleteHeaderChainWithBlock(blockChain types.Blocks, anci
 			// Flush data into ancient database.
 			size += rawdb.WriteAncientBlock(bc.db, block, bc.GetTd(block.Hash(), block.NumberU64()))
 			rawdb.WriteTxLookupEntries(batch, block)
+			rawdb.WriteMessageThreads(bc.db, batch, block)
 
 			stats.processed++
 		}
@@ -1105,6 +1113,7 @@ func (bc *BlockChain) CompleteHeaderChainWithBlock(blockChain types.Blocks, anci
 			// Write all the data out into the database
 			rawdb.WriteBody(batch, block.Hash(), block.NumberU64(), block.Body())
 			rawdb.WriteTxLookupEntries(batch, block)
+			rawdb.WriteMessageThreads(bc.db, batch, block)
 
 			stats.processed++
 			if batch.ValueSize() >= taudb.IdealBatchSize {
@@ -1188,6 +1197,7 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 	// Write the positional metadata for transaction lookups.
 	// Preimages here is empty, ignore it.
 	rawdb.WriteTxLookupEntries(bc.db, block)
+	rawdb.WriteMessageThreads(bc.db, bc.db, block)
 
 	bc.insert(block)
 	return nil
@@ -1312,6 +1322,10 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, state *state.State
 		}
 		// Write the positional metadata for transaction lookups and preimages
 		rawdb.WriteTxLookupEntries(batch, block)
+		rawdb.WriteMessageThreads(bc.db, batch, block)
+		if bc.chainConfig.IsProfile(block.Number()) {
+			rawdb.WriteProfileIndexes(batch, block)
+		}
 		rawdb.WritePreimages(batch, state.Preimages())
 
 		status = CanonStatTy
@@ -1591,6 +1605,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, verifySeals bool) (int, []
 				"root", block.Root())
 
 			events = append(events, ChainEvent{block, block.Hash()})
+			events = append(events, bc.profileChangedEvents(block)...)
 			lastCanon = block
 
 			// Only count canonical blocks for GC processing time
@@ -1829,6 +1844,11 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 	} else {
 		log.Error("Impossible reorg, please file an issue", "oldnum", oldBlock.Number(), "oldhash", oldBlock.Hash(), "newnum", newBlock.Number(), "newhash", newBlock.Hash())
 	}
+	// Drop the profile history of the old chain before indexing the new one, as
+	// both chains may carry updates of the same address at the same height
+	for _, block := range oldChain {
+		rawdb.DeleteProfileIndexes(bc.db, block)
+	}
 	// Insert the new chain(except the head block(reverse order)),
 	// taking care of the proper incremental order.
 	for i := len(newChain) - 1; i >= 1; i-- {
@@ -1837,6 +1857,10 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 
 		// Write lookup entries for hash based transaction searches
 		rawdb.WriteTxLookupEntries(bc.db, newChain[i])
+		rawdb.WriteMessageThreads(bc.db, bc.db, newChain[i])
+		if bc.chainConfig.IsProfile(newChain[i].Number()) {
+			rawdb.WriteProfileIndexes(bc.db, newChain[i])
+		}
 		addedTxs = append(addedTxs, newChain[i].Transactions()...)
 	}
 
@@ -1854,20 +1878,19 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 		rawdb.DeleteCanonicalHash(batch, i)
 	}
 	batch.Write()
-	// If any logs need to be fired, do it now. In theory we could avoid creating
-	// this goroutine if there are no events to fire, but realistcally that only
-	// ever happens if we're reorging empty blocks, which will only happen on idle
-	// networks where performance is not an issue either way.
-	//
-	// TODO(karalabe): Can we get rid of the goroutine somehow to guarantee correct
-	// event ordering?
-	go func() {
-		if len(oldChain) > 0 {
-			for _, block := range oldChain {
-				bc.chainSideFeed.Send(ChainSideEvent{Block: block})
-			}
-		}
-	}()
+
+	// Fire the events synchronously to guarantee their ordering: the dropped
+	// blocks are removed from the newest to the oldest, then the re-added ones
+	// are announced from the oldest to the newest. The head of the new chain is
+	// announced by the caller.
+	for _, block := range oldChain {
+		bc.chainTxsFeed.Send(ChainTxsEvent{Block: block, Removed: true})
+		bc.chainSideFeed.Send(ChainSideEvent{Block: block})
+	}
+	for i := len(newChain) - 1; i >= 1; i-- {
+		bc.PostChainEvents([]interface{}{ChainEvent{Block: newChain[i], Hash: newChain[i].Hash()}})
+		bc.PostChainEvents(bc.profileChangedEvents(newChain[i]))
+	}
 	return nil
 }
 
@@ -1879,16 +1902,43 @@ func (bc *BlockChain) PostChainEvents(events []interface{}) {
 		switch ev := event.(type) {
 		case ChainEvent:
 			bc.chainFeed.Send(ev)
+			bc.chainTxsFeed.Send(ChainTxsEvent{Block: ev.Block})
 
 		case ChainHeadEvent:
 			bc.chainHeadFeed.Send(ev)
 
 		case ChainSideEvent:
 			bc.chainSideFeed.Send(ev)
+
+		case ProfileChangedEvent:
+			bc.profileFeed.Send(ev)
 		}
 	}
 }
 
+// profileChangedEvents collects the profile updates carried by a block, if it
+// is past the profile fork.
+func (bc *BlockChain) profileChangedEvents(block *types.Block) []interface{} {
+	if !bc.chainConfig.IsProfile(block.Number()) {
+		return nil
+	}
+	var events []interface{}
+	for _, tx := range block.Transactions() {
+		if pitx, ok := (*tx).(*types.PersonalInfoTx); ok {
+			events = append(events, ProfileChangedEvent{
+				Address:     pitx.Sender(),
+				ContactName: pitx.ContactName(),
+				Name:        pitx.Name(),
+				CID:         pitx.Profile(),
+				BlockHash:   block.Hash(),
+				BlockNumber: block.NumberU64(),
+				TxHash:      pitx.Hash(),
+			})
+		}
+	}
+	return events
+}
+
 func (bc *BlockChain) update() {
 	futureTimer := time.NewTicker(5 * time.Second)
 	defer futureTimer.Stop()
@@ -2054,11 +2104,21 @@ func (bc *BlockChain) SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Su
 	return bc.scope.Track(bc.chainHeadFeed.Subscribe(ch))
 }
 
+// SubscribeChainTxsEvent registers a subscription of ChainTxsEvent.
+func (bc *BlockChain) SubscribeChainTxsEvent(ch chan<- ChainTxsEvent) event.Subscription {
+	return bc.scope.Track(bc.chainTxsFeed.Subscribe(ch))
+}
+
 // SubscribeChainSideEvent registers a subscription of ChainSideEvent.
 func (bc *BlockChain) SubscribeChainSideEvent(ch chan<- ChainSideEvent) event.Subscription {
 	return bc.scope.Track(bc.chainSideFeed.Subscribe(ch))
 }
 
+// SubscribeProfileChangedEvent registers a subscription of ProfileChangedEvent.
+func (bc *BlockChain) SubscribeProfileChangedEvent(ch chan<- ProfileChangedEvent) event.Subscription {
+	return bc.scope.Track(bc.profileFeed.Subscribe(ch))
+}
+
 // SubscribeBlockProcessingEvent registers a subscription of bool where true means
 // block processing has started while false means it has stopped.
 func (bc *BlockChain) SubscribeBlockProcessingEvent(ch chan<- bool) event.Subscription {
@@ -0,0 +1,158 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/consensus/tauhash"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/vm"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+var (
+	profileKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	profileAddress = crypto.PubkeyToAddress(profileKey.PublicKey)
+)
+
+// newProfileTestChain creates a blockchain activating the profile registry at
+// the given block, along with the genesis the test chains are generated from.
+func newProfileTestChain(t *testing.T, profileBlock int64) (*BlockChain, *types.Block, taudb.Database) {
+	config := *params.TestChainConfig
+	config.ProfileBlock = big.NewInt(profileBlock)
+
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &Genesis{
+			Config: &config,
+			Alloc:  GenesisAlloc{profileAddress: {Balance: big.NewInt(1000000)}},
+		}
+		genesis = gspec.MustCommit(db)
+	)
+	chain, err := NewBlockChain(db, nil, gspec.Config, tauhash.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	return chain, genesis, db
+}
+
+// profileTx creates a signed profile update publishing the given name.
+func profileTx(t *testing.T, gen *BlockGen, name string) *types.Transaction {
+	tx := types.Transaction(types.NewPersonalInfoTransaction(types.OneByte{1}, types.OneByte{0}, nil, gen.TxNonce(profileAddress), 0, new(big.Int), profileAddress, types.Byte32s("contact"), types.Byte20s(name), types.Byte32s("cid")))
+	signed, err := types.SignTx(&tx, types.HomesteadSigner{}, profileKey)
+	if err != nil {
+		t.Fatalf("failed to sign profile update: %v", err)
+	}
+	return signed
+}
+
+// profileName returns the name of the profile of the test address in the
+// state of the given block, or "" if it has none.
+func profileName(t *testing.T, chain *BlockChain, block *types.Block) string {
+	statedb, err := chain.StateAt(block.Root())
+	if err != nil {
+		t.Fatalf("failed to open state of block %d: %v", block.NumberU64(), err)
+	}
+	profile := statedb.GetProfile(profileAddress)
+	if profile == nil {
+		return ""
+	}
+	return string(bytes.TrimRight(profile.Name, "\x00"))
+}
+
+// Tests that profile updates are applied to the state and indexed only once
+// the profile fork is active.
+func TestProfileHistory(t *testing.T) {
+	chain, genesis, db := newProfileTestChain(t, 2)
+	defer chain.Stop()
+
+	blocks, _ := GenerateChain(chain.Config(), genesis, tauhash.NewFaker(), db, 3, func(i int, gen *BlockGen) {
+		switch i {
+		case 0:
+			gen.AddTx(profileTx(t, gen, "early"))
+		case 2:
+			gen.AddTx(profileTx(t, gen, "alice"))
+		}
+	})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if name := profileName(t, chain, blocks[0]); name != "" {
+		t.Errorf("pre-fork profile applied: %q", name)
+	}
+	if name := profileName(t, chain, blocks[2]); name != "alice" {
+		t.Errorf("profile name mismatch: have %q, want %q", name, "alice")
+	}
+	history := rawdb.ReadProfileHistory(db, profileAddress)
+	if len(history) != 1 {
+		t.Fatalf("history length mismatch: have %d, want 1", len(history))
+	}
+	if history[0].Number != 3 || history[0].TxHash != (*blocks[2].Transactions()[0]).Hash() {
+		t.Errorf("history entry mismatch: %+v", history[0])
+	}
+	var found []common.Address
+	rawdb.ReadProfileAddressesByName(db, "ali", 10, func(addr common.Address) bool {
+		found = append(found, addr)
+		return true
+	})
+	if len(found) != 1 || found[0] != profileAddress {
+		t.Errorf("name index mismatch: have %v, want [%x]", found, profileAddress)
+	}
+}
+
+// Tests that a reorg replacing a profile update with another one of the same
+// sender at the same height keeps the index entry of the new chain.
+func TestProfileHistoryReorg(t *testing.T) {
+	chain, genesis, db := newProfileTestChain(t, 0)
+	defer chain.Stop()
+
+	oldBlocks, _ := GenerateChain(chain.Config(), genesis, tauhash.NewFaker(), db, 1, func(i int, gen *BlockGen) {
+		gen.AddTx(profileTx(t, gen, "alice"))
+	})
+	newBlocks, _ := GenerateChain(chain.Config(), genesis, tauhash.NewFaker(), db, 2, func(i int, gen *BlockGen) {
+		gen.SetCoinbase(common.Address{0x01})
+		if i == 0 {
+			gen.AddTx(profileTx(t, gen, "bob"))
+		}
+	})
+	if _, err := chain.InsertChain(oldBlocks); err != nil {
+		t.Fatalf("failed to insert old chain: %v", err)
+	}
+	if _, err := chain.InsertChain(newBlocks); err != nil {
+		t.Fatalf("failed to insert new chain: %v", err)
+	}
+	if head := chain.CurrentBlock().Hash(); head != newBlocks[1].Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", head, newBlocks[1].Hash())
+	}
+	if name := profileName(t, chain, chain.CurrentBlock()); name != "bob" {
+		t.Errorf("profile name mismatch: have %q, want %q", name, "bob")
+	}
+	history := rawdb.ReadProfileHistory(db, profileAddress)
+	if len(history) != 1 {
+		t.Fatalf("history length mismatch: have %d, want 1", len(history))
+	}
+	if history[0].Number != 1 || history[0].TxHash != (*newBlocks[0].Transactions()[0]).Hash() {
+		t.Errorf("history entry mismatch: have %+v, want tx %x", history[0], (*newBlocks[0].Transactions()[0]).Hash())
+	}
+}
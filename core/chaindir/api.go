@@ -0,0 +1,174 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package chaindir
+
+import (
+	"fmt"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+)
+
+const (
+	// defaultListLimit is the number of chains listed when no limit is given.
+	defaultListLimit = 50
+
+	// maxListLimit caps the number of chains listed in a single call.
+	maxListLimit = 500
+)
+
+// RPCChain is the RPC representation of a chain directory entry.
+type RPCChain struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Contact     hexutil.Bytes       `json:"contact"`
+	Title       string              `json:"title"`
+	Description hexutil.Bytes       `json:"description"`
+	Creator     common.Address      `json:"creator"`
+	BlockNumber hexutil.Uint64      `json:"blockNumber"`
+	BlockHash   common.Hash         `json:"blockHash"`
+	TxHash      common.Hash         `json:"transactionHash"`
+	Hints       []common.IPLDPeerID `json:"hints"`
+	Duplicates  []string            `json:"duplicates,omitempty"`
+	Following   bool                `json:"following"`
+}
+
+func (d *Directory) newRPCChain(entry *rawdb.ChainDirEntry) *RPCChain {
+	chain := &RPCChain{
+		ID:          string(entry.ID[:]),
+		Name:        string(rawdb.NormaliseChainName(entry.Name)),
+		Contact:     entry.Contact,
+		Title:       string(entry.Title),
+		Description: entry.Description,
+		Creator:     entry.Creator,
+		BlockNumber: hexutil.Uint64(entry.Number),
+		BlockHash:   entry.BlockHash,
+		TxHash:      entry.TxHash,
+		Hints:       entry.Hints,
+	}
+	for _, id := range d.Duplicates(entry) {
+		chain.Duplicates = append(chain.Duplicates, string(id[:]))
+	}
+	if d.udb != nil {
+		chain.Following = d.udb.IsFollowing(entry.ID)
+	}
+	return chain
+}
+
+// parseChainID converts the textual form of a chain id used over RPC.
+func parseChainID(id string) (common.ChainID, error) {
+	var chainID common.ChainID
+	if len(id) != common.ChainIDLength {
+		return chainID, fmt.Errorf("invalid chain id length %d, want %d", len(id), common.ChainIDLength)
+	}
+	copy(chainID[:], id)
+	return chainID, nil
+}
+
+// PublicChainDirectoryAPI exposes the chain directory over RPC.
+type PublicChainDirectoryAPI struct {
+	dir *Directory
+}
+
+// NewPublicChainDirectoryAPI creates a new chain directory API.
+func NewPublicChainDirectoryAPI(dir *Directory) *PublicChainDirectoryAPI {
+	return &PublicChainDirectoryAPI{dir}
+}
+
+// GetChain returns the directory entry of the given chain.
+func (api *PublicChainDirectoryAPI) GetChain(id string) (*RPCChain, error) {
+	chainID, err := parseChainID(id)
+	if err != nil {
+		return nil, err
+	}
+	entry := api.dir.Chain(chainID)
+	if entry == nil {
+		return nil, nil
+	}
+	return api.dir.newRPCChain(entry), nil
+}
+
+// ListChains returns the announced chains in chain id order. Paging is done by
+// passing the last id of the previous page as after.
+func (api *PublicChainDirectoryAPI) ListChains(after *string, limit *hexutil.Uint) ([]*RPCChain, error) {
+	var from *common.ChainID
+	if after != nil {
+		chainID, err := parseChainID(*after)
+		if err != nil {
+			return nil, err
+		}
+		from = &chainID
+	}
+	count := defaultListLimit
+	if limit != nil {
+		count = int(*limit)
+	}
+	if count > maxListLimit {
+		count = maxListLimit
+	}
+	chains := make([]*RPCChain, 0)
+	for _, entry := range api.dir.Chains(from, count) {
+		chains = append(chains, api.dir.newRPCChain(entry))
+	}
+	return chains, nil
+}
+
+// SearchChains returns the chains whose name starts with the given case
+// insensitive prefix.
+func (api *PublicChainDirectoryAPI) SearchChains(prefix string) []*RPCChain {
+	chains := make([]*RPCChain, 0)
+	for _, entry := range api.dir.Search(prefix) {
+		chains = append(chains, api.dir.newRPCChain(entry))
+	}
+	return chains
+}
+
+// PrivateChainDirectoryAPI exposes the chain directory operations that change
+// the local node configuration.
+type PrivateChainDirectoryAPI struct {
+	dir *Directory
+}
+
+// NewPrivateChainDirectoryAPI creates a new private chain directory API.
+func NewPrivateChainDirectoryAPI(dir *Directory) *PrivateChainDirectoryAPI {
+	return &PrivateChainDirectoryAPI{dir}
+}
+
+// FollowChain starts following an announced chain.
+func (api *PrivateChainDirectoryAPI) FollowChain(id string) (bool, error) {
+	chainID, err := parseChainID(id)
+	if err != nil {
+		return false, err
+	}
+	if err := api.dir.Follow(chainID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UnfollowChain stops following an announced chain.
+func (api *PrivateChainDirectoryAPI) UnfollowChain(id string) (bool, error) {
+	chainID, err := parseChainID(id)
+	if err != nil {
+		return false, err
+	}
+	if err := api.dir.Unfollow(chainID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,314 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package chaindir maintains a directory of the community chains announced
+// through NewChainTx transactions.
+package chaindir
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/userdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+const (
+	// chainEventChanSize is the size of channel listening to ChainEvent.
+	chainEventChanSize = 10
+
+	// maxNameMatches caps the number of chains returned by a single name lookup.
+	maxNameMatches = 100
+)
+
+var (
+	// ErrUnknownChain is returned if a chain id is not in the directory.
+	ErrUnknownChain = errors.New("unknown chain")
+)
+
+// blockChain provides the directory with access to the canonical chain.
+type blockChain interface {
+	CurrentBlock() *types.Block
+	GetBlock(hash common.Hash, number uint64) *types.Block
+	GetBlockByNumber(number uint64) *types.Block
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// Directory indexes the NewChainTx announcements of the canonical chain and
+// maps every announced chain id to its metadata.
+//
+// The first announcement of a chain id wins, later announcements colliding
+// with an existing id are rejected from the index. Several chains may share a
+// name, they are reported as duplicates.
+type Directory struct {
+	db    taudb.Database
+	chain blockChain
+	udb   *userdb.Userdb
+	head  *types.Block // Last canonical block processed
+
+	mu   sync.Mutex // Serialises block processing and following
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a chain directory on top of the given database.
+func New(db taudb.Database, chain blockChain, udb *userdb.Userdb) *Directory {
+	return &Directory{
+		db:    db,
+		chain: chain,
+		udb:   udb,
+		quit:  make(chan struct{}),
+	}
+}
+
+// Start catches the directory up with the canonical chain and keeps it in sync
+// with every block imported afterwards.
+func (d *Directory) Start() {
+	chainCh := make(chan core.ChainEvent, chainEventChanSize)
+	chainSub := d.chain.SubscribeChainEvent(chainCh)
+
+	d.catchUp()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer chainSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-chainCh:
+				d.mu.Lock()
+				d.advance(ev.Block)
+				d.mu.Unlock()
+
+			case <-chainSub.Err():
+				return
+			case <-d.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop terminates the directory's event loop.
+func (d *Directory) Stop() {
+	close(d.quit)
+	d.wg.Wait()
+}
+
+// catchUp processes every canonical block between the last processed one and
+// the current head.
+func (d *Directory) catchUp() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var from uint64
+	if head := rawdb.ReadChainDirHead(d.db); head != nil {
+		from = *head + 1
+	}
+	current := d.chain.CurrentBlock().NumberU64()
+	if from <= current {
+		log.Info("Catching up chain directory", "from", from, "to", current)
+	}
+	for number := from; number <= current; number++ {
+		block := d.chain.GetBlockByNumber(number)
+		if block == nil {
+			log.Warn("Chain directory missing canonical block", "number", number)
+			return
+		}
+		d.processBlock(block)
+	}
+	d.head = d.chain.CurrentBlock()
+}
+
+// advance moves the directory to a new canonical head. If the head doesn't
+// extend the last processed block, the blocks of the old chain are reverted
+// down to the common ancestor and every block of the new chain is indexed.
+func (d *Directory) advance(head *types.Block) {
+	if d.head == nil || head.ParentHash() == d.head.Hash() {
+		d.processBlock(head)
+		d.head = head
+		return
+	}
+	if head.Hash() == d.head.Hash() {
+		return
+	}
+	var (
+		oldBlock = d.head
+		newBlock = head
+		newChain []*types.Block
+	)
+	// Reduce the longer chain to the same number as the shorter one
+	for ; oldBlock != nil && oldBlock.NumberU64() > newBlock.NumberU64(); oldBlock = d.chain.GetBlock(oldBlock.ParentHash(), oldBlock.NumberU64()-1) {
+		d.revertBlock(oldBlock)
+	}
+	for ; oldBlock != nil && newBlock != nil && newBlock.NumberU64() > oldBlock.NumberU64(); newBlock = d.chain.GetBlock(newBlock.ParentHash(), newBlock.NumberU64()-1) {
+		newChain = append(newChain, newBlock)
+	}
+	// Step back on both chains until the common ancestor is found
+	for oldBlock != nil && newBlock != nil && oldBlock.Hash() != newBlock.Hash() {
+		d.revertBlock(oldBlock)
+		newChain = append(newChain, newBlock)
+
+		oldBlock = d.chain.GetBlock(oldBlock.ParentHash(), oldBlock.NumberU64()-1)
+		newBlock = d.chain.GetBlock(newBlock.ParentHash(), newBlock.NumberU64()-1)
+	}
+	if oldBlock == nil || newBlock == nil {
+		log.Error("Chain directory reorg ancestor missing", "head", head.NumberU64(), "hash", head.Hash())
+	} else if len(newChain) > 1 {
+		log.Debug("Reorganising chain directory", "ancestor", newBlock.NumberU64(), "blocks", len(newChain))
+	}
+	// Index the new chain from the ancestor upwards
+	for i := len(newChain) - 1; i >= 0; i-- {
+		d.processBlock(newChain[i])
+	}
+	d.head = head
+}
+
+// processBlock indexes the announcements of a canonical block.
+func (d *Directory) processBlock(block *types.Block) {
+	batch := d.db.NewBatch()
+	for _, tx := range block.Transactions() {
+		nctx, ok := (*tx).(*types.NewChainTx)
+		if !ok {
+			continue
+		}
+		id := nctx.NewChainID()
+		if existing := rawdb.ReadChainDirEntry(d.db, id); existing != nil {
+			if existing.TxHash != nctx.Hash() {
+				log.Warn("Rejected colliding chain announcement", "id", string(id[:]), "tx", nctx.Hash(), "existing", existing.TxHash)
+			}
+			continue
+		}
+		entry := &rawdb.ChainDirEntry{
+			ID:          id,
+			Name:        nctx.Name(),
+			Contact:     nctx.Contact(),
+			Title:       nctx.Title(),
+			Description: nctx.Description(),
+			Creator:     nctx.Sender(),
+			Number:      block.NumberU64(),
+			BlockHash:   block.Hash(),
+			TxHash:      nctx.Hash(),
+		}
+		if miner := ipfsPeerID(block.Header().IpfsCoinbase); miner != "" {
+			entry.Hints = append(entry.Hints, miner)
+		}
+		if d.udb != nil {
+			d.udb.AddNewChain(id)
+		}
+		rawdb.WriteChainDirEntry(batch, entry)
+		log.Debug("Indexed chain announcement", "id", string(id[:]), "name", string(rawdb.NormaliseChainName(entry.Name)), "number", entry.Number)
+	}
+	rawdb.WriteChainDirHead(batch, block.NumberU64())
+	if err := batch.Write(); err != nil {
+		log.Error("Failed to write chain directory", "number", block.NumberU64(), "err", err)
+	}
+}
+
+// revertBlock drops the announcements of a block that left the canonical chain.
+func (d *Directory) revertBlock(block *types.Block) {
+	batch := d.db.NewBatch()
+	for _, tx := range block.Transactions() {
+		nctx, ok := (*tx).(*types.NewChainTx)
+		if !ok {
+			continue
+		}
+		if entry := rawdb.ReadChainDirEntry(d.db, nctx.NewChainID()); entry != nil && entry.BlockHash == block.Hash() {
+			rawdb.DeleteChainDirEntry(batch, entry)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Error("Failed to revert chain directory", "number", block.NumberU64(), "err", err)
+	}
+}
+
+// Chain retrieves the directory entry of a chain.
+func (d *Directory) Chain(id common.ChainID) *rawdb.ChainDirEntry {
+	return rawdb.ReadChainDirEntry(d.db, id)
+}
+
+// Chains returns up to limit entries in chain id order, starting after the
+// given chain id.
+func (d *Directory) Chains(after *common.ChainID, limit int) []*rawdb.ChainDirEntry {
+	return rawdb.ReadChainDirEntries(d.db, after, limit)
+}
+
+// Search returns the chains whose name starts with the given case insensitive
+// prefix.
+func (d *Directory) Search(prefix string) []*rawdb.ChainDirEntry {
+	var entries []*rawdb.ChainDirEntry
+	for _, id := range rawdb.ReadChainIDsByName(d.db, []byte(prefix), maxNameMatches) {
+		if entry := rawdb.ReadChainDirEntry(d.db, id); entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Duplicates returns the ids of the other chains announced with exactly the
+// same name as the given chain.
+func (d *Directory) Duplicates(entry *rawdb.ChainDirEntry) []common.ChainID {
+	name := rawdb.NormaliseChainName(entry.Name)
+
+	var ids []common.ChainID
+	for _, id := range rawdb.ReadChainIDsByName(d.db, name, maxNameMatches) {
+		if id == entry.ID {
+			continue
+		}
+		if other := rawdb.ReadChainDirEntry(d.db, id); other != nil && bytes.Equal(rawdb.NormaliseChainName(other.Name), name) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Follow marks an announced chain as followed in the user database.
+func (d *Directory) Follow(id common.ChainID) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if rawdb.ReadChainDirEntry(d.db, id) == nil {
+		return ErrUnknownChain
+	}
+	d.udb.FollowNewChain(id)
+	return nil
+}
+
+// Unfollow marks an announced chain as no longer followed in the user database.
+func (d *Directory) Unfollow(id common.ChainID) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if rawdb.ReadChainDirEntry(d.db, id) == nil {
+		return ErrUnknownChain
+	}
+	d.udb.UnfollowChain(id)
+	return nil
+}
+
+// ipfsPeerID converts the zero padded IPFS miner address of a header into a
+// peer id.
+func ipfsPeerID(addr common.IpfsAddress) common.IPLDPeerID {
+	return common.IPLDPeerID(bytes.TrimRight(addr[:], "\x00"))
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package chaindir
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/userdb"
+)
+
+func newAnnouncement(sender common.Address, nonce uint64, name string) *types.Transaction {
+	var tx types.Transaction = types.NewNewChainTransaction(types.OneByte{1}, types.OneByte{0}, types.Byte32s{}, nonce, 0, types.OneByte{1}, sender, types.Byte20s(name), nil, nil, nil)
+	return &tx
+}
+
+func newTestBlock(number int64, txs ...*types.Transaction) *types.Block {
+	return types.NewBlock(&types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(1)}, txs)
+}
+
+func newChildBlock(parent *types.Block, time uint64, txs ...*types.Transaction) *types.Block {
+	return types.NewBlock(&types.Header{
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		Difficulty: big.NewInt(1),
+		Time:       time,
+		ParentHash: parent.Hash(),
+	}, txs)
+}
+
+// testChain is a block store serving the blocks the directory walks on reorgs.
+type testChain struct {
+	blockChain
+	blocks map[common.Hash]*types.Block
+}
+
+func newTestChain(blocks ...*types.Block) *testChain {
+	chain := &testChain{blocks: make(map[common.Hash]*types.Block)}
+	for _, block := range blocks {
+		chain.blocks[block.Hash()] = block
+	}
+	return chain
+}
+
+func (c *testChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	if block := c.blocks[hash]; block != nil && block.NumberU64() == number {
+		return block
+	}
+	return nil
+}
+
+// Tests that announcements are indexed, duplicate names are reported, and
+// reverted blocks drop their announcements again.
+func TestDirectoryIndexing(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	dir := New(db, nil, userdb.NewUserdb(db))
+
+	var (
+		alice = common.Address{0x01}
+		bob   = common.Address{0x02}
+		first = newAnnouncement(alice, 0, "Tau")
+		dup   = newAnnouncement(bob, 0, "tau")
+	)
+	block1 := newTestBlock(1, first)
+	block2 := newTestBlock(2, dup)
+	dir.processBlock(block1)
+	dir.processBlock(block2)
+
+	entries := dir.Search("TA")
+	if len(entries) != 2 {
+		t.Fatalf("search result mismatch: have %d, want 2", len(entries))
+	}
+	id := (*first).(*types.NewChainTx).NewChainID()
+	entry := dir.Chain(id)
+	if entry == nil || entry.Creator != alice || entry.Number != 1 {
+		t.Fatalf("entry mismatch: %+v", entry)
+	}
+	if dups := dir.Duplicates(entry); len(dups) != 1 || dups[0] != (*dup).(*types.NewChainTx).NewChainID() {
+		t.Errorf("duplicate mismatch: %v", dups)
+	}
+	if head := rawdb.ReadChainDirHead(db); head == nil || *head != 2 {
+		t.Errorf("head mismatch: have %v, want 2", head)
+	}
+	if err := dir.Follow(id); err != nil {
+		t.Fatalf("failed to follow chain: %v", err)
+	}
+	if !dir.udb.IsFollowing(id) {
+		t.Errorf("chain not followed")
+	}
+	dir.revertBlock(block1)
+	if dir.Chain(id) != nil {
+		t.Errorf("reverted announcement still indexed")
+	}
+	if err := dir.Follow(id); err != ErrUnknownChain {
+		t.Errorf("follow error mismatch: have %v, want %v", err, ErrUnknownChain)
+	}
+}
+
+// Tests that a reorg drops the announcements of the old chain and indexes every
+// block of the new chain, not only its head.
+func TestDirectoryReorg(t *testing.T) {
+	var (
+		alice   = common.Address{0x01}
+		bob     = common.Address{0x02}
+		dropped = newAnnouncement(alice, 0, "dropped")
+		below   = newAnnouncement(bob, 0, "below")
+		middle  = newAnnouncement(bob, 1, "middle")
+
+		genesis = newTestBlock(0)
+		old1    = newChildBlock(genesis, 0, dropped)
+		old2    = newChildBlock(old1, 0)
+		new1    = newChildBlock(genesis, 1, below)
+		new2    = newChildBlock(new1, 1, middle)
+		new3    = newChildBlock(new2, 1)
+	)
+	db := rawdb.NewMemoryDatabase()
+	dir := New(db, newTestChain(genesis, old1, old2, new1, new2, new3), userdb.NewUserdb(db))
+
+	for _, block := range []*types.Block{genesis, old1, old2, new3} {
+		dir.advance(block)
+	}
+	if dir.Chain((*dropped).(*types.NewChainTx).NewChainID()) != nil {
+		t.Errorf("announcement of the old chain still indexed")
+	}
+	for _, tx := range []*types.Transaction{below, middle} {
+		if dir.Chain((*tx).(*types.NewChainTx).NewChainID()) == nil {
+			t.Errorf("announcement %x of the new chain not indexed", (*tx).Hash())
+		}
+	}
+	if head := rawdb.ReadChainDirHead(db); head == nil || *head != 3 {
+		t.Errorf("head mismatch: have %v, want 3", head)
+	}
+}
+
+// Tests that the followed chains survive a restart of the user database.
+func TestDirectoryFollowPersistence(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	dir := New(db, nil, userdb.NewUserdb(db))
+
+	var (
+		followed = newAnnouncement(common.Address{0x01}, 0, "followed")
+		known    = newAnnouncement(common.Address{0x02}, 0, "known")
+	)
+	dir.processBlock(newTestBlock(1, followed, known))
+
+	id := (*followed).(*types.NewChainTx).NewChainID()
+	if err := dir.Follow(id); err != nil {
+		t.Fatalf("failed to follow chain: %v", err)
+	}
+	udb := userdb.NewUserdb(db)
+	if chains := udb.FollowedChains(); len(chains) != 1 || chains[0] != id {
+		t.Errorf("followed chains mismatch after restart: %v", chains)
+	}
+	if err := dir.Unfollow(id); err != nil {
+		t.Fatalf("failed to unfollow chain: %v", err)
+	}
+	if userdb.NewUserdb(db).IsFollowing(id) {
+		t.Errorf("unfollowed chain still followed after restart")
+	}
+}
@@ -22,7 +22,11 @@ package contractchain
 	relay = ChooseRelay(H) {
 		1:1:8
 	}
-	peerID = SelectPeerRandomly()
+	peerID = SelectPeerRandomly(chainID) {
+		// IPFS peers recorded from the block miners and libp2p handshakes,
+		// preferring the ones that answered their last liveness check
+		return Userdb.SelectIPLDPeer(chainID)
+	}
 
 4. futureBlock = GraphSyncNPlusOneBlock()
 
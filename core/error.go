@@ -35,4 +35,12 @@ var (
 
 	// ErrNoGenesis is returned when there is no Genesis Block.
 	ErrNoGenesis = errors.New("genesis not found in chain")
+
+	// ErrInvalidProfile is returned if a PersonalInfoTx carries profile fields
+	// longer than the protocol allows.
+	ErrInvalidProfile = errors.New("invalid profile fields")
+
+	// ErrSupplyExceeded is returned if a block issues more coins than the
+	// maximum supply declared in the chain configuration.
+	ErrSupplyExceeded = errors.New("maximum supply exceeded")
 )
@@ -37,3 +37,24 @@ type ChainSideEvent struct {
 }
 
 type ChainHeadEvent struct{ Block *types.Block }
+
+// ChainTxsEvent is posted for every block joining or leaving the canonical
+// chain, in the order of the changes: on a reorg, the dropped blocks are
+// removed from the newest to the oldest before the new ones are added from the
+// oldest to the newest. Removed is set for the dropped blocks.
+type ChainTxsEvent struct {
+	Block   *types.Block
+	Removed bool
+}
+
+// ProfileChangedEvent is posted when a canonical block updates the profile of
+// an address through a PersonalInfoTx.
+type ProfileChangedEvent struct {
+	Address     common.Address
+	ContactName []byte
+	Name        []byte
+	CID         []byte
+	BlockHash   common.Hash
+	BlockNumber uint64
+	TxHash      common.Hash
+}
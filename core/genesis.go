@@ -153,6 +153,11 @@ func SetupGenesisBlockWithOverride(db taudb.Database, ipfsDb taudb.IpfsStore, ge
 	if genesis != nil && genesis.Config == nil {
 		return params.AllTauashProtocolChanges, common.Hash{}, errGenesisNoConfig
 	}
+	if genesis != nil {
+		if err := genesis.Config.Tauash.CheckConfig(); err != nil {
+			return genesis.Config, common.Hash{}, err
+		}
+	}
 	// Just commit the new block if there is no stored genesis block.
 	stored := rawdb.ReadCanonicalHash(db, 0)
 	if (stored == common.Hash{}) {
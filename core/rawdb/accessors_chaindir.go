@@ -0,0 +1,149 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// ChainDirEntry is the metadata of a community chain announced through a
+// NewChainTx.
+type ChainDirEntry struct {
+	ID          common.ChainID
+	Name        []byte
+	Contact     []byte
+	Title       []byte
+	Description []byte
+	Creator     common.Address
+	Number      uint64              // Number of the block carrying the announcement
+	BlockHash   common.Hash         // Hash of the block carrying the announcement
+	TxHash      common.Hash         // Hash of the announcing transaction
+	Hints       []common.IPLDPeerID // IPFS peers known to follow the chain
+}
+
+// NormaliseChainName strips the zero padding of a fixed size chain name and
+// lower cases it, so that name lookups are case insensitive.
+func NormaliseChainName(name []byte) []byte {
+	return bytes.ToLower(bytes.TrimRight(name, "\x00"))
+}
+
+// ReadChainDirEntry retrieves the directory entry of a chain.
+func ReadChainDirEntry(db taudb.KeyValueReader, id common.ChainID) *ChainDirEntry {
+	data, _ := db.Get(chainDirKey(id))
+	if len(data) == 0 {
+		return nil
+	}
+	entry := new(ChainDirEntry)
+	if err := rlp.DecodeBytes(data, entry); err != nil {
+		log.Error("Invalid chain directory entry RLP", "id", string(id[:]), "err", err)
+		return nil
+	}
+	return entry
+}
+
+// WriteChainDirEntry stores a directory entry along with its name index.
+func WriteChainDirEntry(db taudb.KeyValueWriter, entry *ChainDirEntry) {
+	data, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		log.Crit("Failed to encode chain directory entry", "err", err)
+	}
+	if err := db.Put(chainDirKey(entry.ID), data); err != nil {
+		log.Crit("Failed to store chain directory entry", "err", err)
+	}
+	if err := db.Put(chainDirNameKey(NormaliseChainName(entry.Name), entry.ID), []byte{0x01}); err != nil {
+		log.Crit("Failed to store chain directory name entry", "err", err)
+	}
+}
+
+// DeleteChainDirEntry removes a directory entry along with its name index.
+func DeleteChainDirEntry(db taudb.KeyValueWriter, entry *ChainDirEntry) {
+	if err := db.Delete(chainDirKey(entry.ID)); err != nil {
+		log.Crit("Failed to delete chain directory entry", "err", err)
+	}
+	if err := db.Delete(chainDirNameKey(NormaliseChainName(entry.Name), entry.ID)); err != nil {
+		log.Crit("Failed to delete chain directory name entry", "err", err)
+	}
+}
+
+// ReadChainDirEntries iterates over the directory and returns up to limit
+// entries in chain id order, starting after the given chain id.
+func ReadChainDirEntries(db taudb.Iteratee, after *common.ChainID, limit int) []*ChainDirEntry {
+	it := db.NewIteratorWithPrefix(chainDirPrefix)
+	defer it.Release()
+
+	var entries []*ChainDirEntry
+	for it.Next() && len(entries) < limit {
+		key := it.Key()
+		if len(key) != len(chainDirPrefix)+common.ChainIDLength {
+			continue
+		}
+		if after != nil && bytes.Compare(key[len(chainDirPrefix):], after[:]) <= 0 {
+			continue
+		}
+		entry := new(ChainDirEntry)
+		if err := rlp.DecodeBytes(it.Value(), entry); err != nil {
+			log.Error("Invalid chain directory entry RLP", "key", key, "err", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// ReadChainIDsByName retrieves up to limit chain ids whose name starts with the
+// given case insensitive prefix.
+func ReadChainIDsByName(db taudb.Iteratee, prefix []byte, limit int) []common.ChainID {
+	it := db.NewIteratorWithPrefix(append(append([]byte{}, chainDirNamePrefix...), NormaliseChainName(prefix)...))
+	defer it.Release()
+
+	var ids []common.ChainID
+	for it.Next() && len(ids) < limit {
+		key := it.Key()
+		if len(key) < len(chainDirNamePrefix)+common.ChainIDLength {
+			continue
+		}
+		var id common.ChainID
+		copy(id[:], key[len(key)-common.ChainIDLength:])
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ReadChainDirHead retrieves the number of the last block processed by the
+// chain directory.
+func ReadChainDirHead(db taudb.KeyValueReader) *uint64 {
+	data, _ := db.Get(chainDirHeadKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteChainDirHead stores the number of the last block processed by the chain
+// directory.
+func WriteChainDirHead(db taudb.KeyValueWriter, number uint64) {
+	if err := db.Put(chainDirHeadKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store chain directory head", "err", err)
+	}
+}
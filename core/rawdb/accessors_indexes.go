@@ -17,6 +17,7 @@
 package rawdb
 
 import (
+	"encoding/binary"
 	"math/big"
 
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
@@ -67,6 +68,32 @@ func DeleteTxLookupEntry(db taudb.KeyValueWriter, hash common.Hash) {
 	db.Delete(txLookupKey(hash))
 }
 
+// ReadTxIndexTail retrieves the number of the oldest block whose transactions
+// are indexed. A nil result means the indexer never ran on this database.
+func ReadTxIndexTail(db taudb.KeyValueReader) *uint64 {
+	data, _ := db.Get(txIndexTailKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteTxIndexTail stores the number of the oldest block whose transactions
+// are indexed.
+func WriteTxIndexTail(db taudb.KeyValueWriter, number uint64) {
+	if err := db.Put(txIndexTailKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the transaction index tail", "err", err)
+	}
+}
+
+// DeleteTxIndexTail removes the transaction index progress marker.
+func DeleteTxIndexTail(db taudb.KeyValueWriter) {
+	if err := db.Delete(txIndexTailKey); err != nil {
+		log.Crit("Failed to delete the transaction index tail", "err", err)
+	}
+}
+
 // ReadTransaction retrieves a specific transaction from the database, along with
 // its added positional metadata.
 func ReadTransaction(db taudb.Reader, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64) {
@@ -0,0 +1,76 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// maxThreadDepth bounds the number of thread index entries followed to find
+// the root of a thread.
+const maxThreadDepth = 256
+
+// ReadMessageThread retrieves the root of the thread the message with the
+// given hash belongs to. A message that is not indexed is its own root.
+//
+// The index maps every message to the root of its thread as known when it was
+// written, which is an ancestor if the message replied to one indexed in the
+// same batch. Those entries are followed up to the root.
+func ReadMessageThread(db taudb.KeyValueReader, hash common.Hash) common.Hash {
+	for i := 0; i < maxThreadDepth; i++ {
+		data, _ := db.Get(messageThreadKey(hash))
+		if len(data) != common.HashLength {
+			return hash
+		}
+		root := common.BytesToHash(data)
+		if root == hash {
+			return hash
+		}
+		hash = root
+	}
+	return hash
+}
+
+// MessageThread returns the root of the thread a message belongs to, whether
+// it was indexed or not: its own hash if it opens a new thread, otherwise the
+// root of the thread of the message it replies to.
+func MessageThread(db taudb.KeyValueReader, msg *types.NewMessageTx) common.Hash {
+	ref := msg.Referid()
+	if ref == (common.Hash{}) {
+		return msg.Hash()
+	}
+	return ReadMessageThread(db, ref)
+}
+
+// WriteMessageThreads stores the thread root of every NewMessageTx in a block,
+// resolved against the entries already in db. The root of a message only
+// depends on its content, so the entries of blocks dropped by a reorg remain
+// valid and are never deleted.
+func WriteMessageThreads(db taudb.KeyValueReader, w taudb.KeyValueWriter, block *types.Block) {
+	for _, tx := range block.Transactions() {
+		msg, ok := (*tx).(*types.NewMessageTx)
+		if !ok {
+			continue
+		}
+		if err := w.Put(messageThreadKey(msg.Hash()), MessageThread(db, msg).Bytes()); err != nil {
+			log.Crit("Failed to store message thread entry", "err", err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// ProfileHistoryEntry is a single indexed profile update of an address.
+type ProfileHistoryEntry struct {
+	Number uint64      // Block number the update was included in
+	TxHash common.Hash // Hash of the PersonalInfoTx carrying the update
+}
+
+// normaliseProfileName strips the zero padding of a fixed size name and lower
+// cases it, so that name searches are case insensitive.
+func normaliseProfileName(name []byte) []byte {
+	return bytes.ToLower(bytes.TrimRight(name, "\x00"))
+}
+
+// WriteProfileIndexes stores the profile history and name index entries for
+// every PersonalInfoTx in a canonical block.
+func WriteProfileIndexes(db taudb.KeyValueWriter, block *types.Block) {
+	for _, tx := range block.Transactions() {
+		pitx, ok := (*tx).(*types.PersonalInfoTx)
+		if !ok {
+			continue
+		}
+		if err := db.Put(profileHistoryKey(pitx.Sender(), block.NumberU64()), pitx.Hash().Bytes()); err != nil {
+			log.Crit("Failed to store profile history entry", "err", err)
+		}
+		if name := normaliseProfileName(pitx.Name()); len(name) > 0 {
+			if err := db.Put(profileNameKey(name, pitx.Sender()), []byte{0x01}); err != nil {
+				log.Crit("Failed to store profile name entry", "err", err)
+			}
+		}
+	}
+}
+
+// DeleteProfileIndexes removes the profile history entries of a block that
+// dropped out of the canonical chain. Name entries are left in place, readers
+// are expected to verify them against the state.
+func DeleteProfileIndexes(db taudb.KeyValueWriter, block *types.Block) {
+	for _, tx := range block.Transactions() {
+		if pitx, ok := (*tx).(*types.PersonalInfoTx); ok {
+			db.Delete(profileHistoryKey(pitx.Sender(), block.NumberU64()))
+		}
+	}
+}
+
+// ReadProfileHistory retrieves all indexed profile updates of an address in
+// ascending block order.
+func ReadProfileHistory(db taudb.Iteratee, addr common.Address) []ProfileHistoryEntry {
+	prefix := append(append([]byte{}, profileHistoryPrefix...), addr.Bytes()...)
+
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var entries []ProfileHistoryEntry
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		entries = append(entries, ProfileHistoryEntry{
+			Number: binary.BigEndian.Uint64(key[len(prefix):]),
+			TxHash: common.BytesToHash(it.Value()),
+		})
+	}
+	return entries
+}
+
+// ReadProfileAddressesByName retrieves up to limit addresses whose profile name
+// was ever indexed with the given case insensitive prefix. Since the index keeps
+// the old names of renamed profiles, the optional match callback filters the
+// addresses before they count against the limit.
+func ReadProfileAddressesByName(db taudb.Iteratee, prefix string, limit int, match func(common.Address) bool) []common.Address {
+	name := normaliseProfileName([]byte(prefix))
+	it := db.NewIteratorWithPrefix(append(append([]byte{}, profileNamePrefix...), name...))
+	defer it.Release()
+
+	var (
+		addrs []common.Address
+		seen  = make(map[common.Address]struct{})
+	)
+	for it.Next() && len(addrs) < limit {
+		key := it.Key()
+		if len(key) < len(profileNamePrefix)+common.AddressLength {
+			continue
+		}
+		addr := common.BytesToAddress(key[len(key)-common.AddressLength:])
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+		if match != nil && !match(addr) {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
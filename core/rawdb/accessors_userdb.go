@@ -0,0 +1,102 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// ReadChainFollows retrieves the follow status of every chain known to the user
+// database.
+func ReadChainFollows(db taudb.Iteratee) map[common.ChainID]bool {
+	it := db.NewIteratorWithPrefix(chainFollowPrefix)
+	defer it.Release()
+
+	follows := make(map[common.ChainID]bool)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(chainFollowPrefix)+common.ChainIDLength || len(it.Value()) != 1 {
+			continue
+		}
+		var id common.ChainID
+		copy(id[:], key[len(chainFollowPrefix):])
+		follows[id] = it.Value()[0] == 1
+	}
+	return follows
+}
+
+// WriteChainFollow stores the follow status of a chain.
+func WriteChainFollow(db taudb.KeyValueWriter, id common.ChainID, follow bool) {
+	status := []byte{0}
+	if follow {
+		status[0] = 1
+	}
+	if err := db.Put(chainFollowKey(id), status); err != nil {
+		log.Crit("Failed to store chain follow status", "err", err)
+	}
+}
+
+// IPLDPeerEntry is the IPLD peer book entry of an IPFS peer following a chain.
+type IPLDPeerEntry struct {
+	ChainID     common.ChainID
+	Peer        common.IPLDPeerID
+	BlockNumber uint64 // Highest block the peer was seen at
+	LastSeen    uint64 // Unix time the peer was last seen or answered a liveness check
+	Failures    uint64 // Liveness checks failed in a row
+}
+
+// ReadIPLDPeers retrieves the IPLD peer book entries of every chain.
+func ReadIPLDPeers(db taudb.Iteratee) []*IPLDPeerEntry {
+	it := db.NewIteratorWithPrefix(ipldPeerPrefix)
+	defer it.Release()
+
+	var entries []*IPLDPeerEntry
+	for it.Next() {
+		key := it.Key()
+		if len(key) <= len(ipldPeerPrefix)+common.ChainIDLength {
+			continue
+		}
+		entry := new(IPLDPeerEntry)
+		if err := rlp.DecodeBytes(it.Value(), entry); err != nil {
+			log.Error("Invalid IPLD peer entry RLP", "key", key, "err", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WriteIPLDPeer stores the IPLD peer book entry of a peer.
+func WriteIPLDPeer(db taudb.KeyValueWriter, entry *IPLDPeerEntry) {
+	data, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		log.Crit("Failed to encode IPLD peer entry", "err", err)
+	}
+	if err := db.Put(ipldPeerKey(entry.ChainID, entry.Peer), data); err != nil {
+		log.Crit("Failed to store IPLD peer entry", "err", err)
+	}
+}
+
+// DeleteIPLDPeer removes the IPLD peer book entry of a peer on a chain.
+func DeleteIPLDPeer(db taudb.KeyValueWriter, id common.ChainID, peer common.IPLDPeerID) {
+	if err := db.Delete(ipldPeerKey(id, peer)); err != nil {
+		log.Crit("Failed to delete IPLD peer entry", "err", err)
+	}
+}
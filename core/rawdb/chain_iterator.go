@@ -0,0 +1,154 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// errTxIndexInterrupted is returned if a (un)indexing run was aborted.
+var errTxIndexInterrupted = errors.New("transaction indexing interrupted")
+
+// IndexTransactions creates the transaction lookup entries of the canonical
+// blocks in [from, to). Blocks are processed from the newest to the oldest so
+// the tail stored in the database always marks a fully indexed suffix, and an
+// interrupted run can be resumed later on.
+func IndexTransactions(db taudb.Database, from uint64, to uint64, interrupt chan struct{}) error {
+	if from >= to {
+		return nil
+	}
+	var (
+		batch   = db.NewBatch()
+		start   = time.Now()
+		logged  = start
+		indexed int
+	)
+	for number := to; number > from; number-- {
+		select {
+		case <-interrupt:
+			return flushTxIndex(batch, number, errTxIndexInterrupted)
+		default:
+		}
+		block := ReadBlock(db, ReadCanonicalHash(db, number-1), number-1)
+		if block == nil {
+			log.Warn("Missing block while indexing transactions", "number", number-1)
+			return flushTxIndex(batch, number, nil)
+		}
+		WriteTxLookupEntries(batch, block)
+		indexed += len(block.Transactions())
+
+		if batch.ValueSize() > taudb.IdealBatchSize {
+			if err := flushTxIndex(batch, number-1, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Indexing transactions", "blocks", to-number+1, "txs", indexed, "tail", number-1, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := flushTxIndex(batch, from, nil); err != nil {
+		return err
+	}
+	log.Info("Indexed transactions", "blocks", to-from, "txs", indexed, "tail", from, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// UnindexTransactions removes the transaction lookup entries of the canonical
+// blocks in [from, to), moving the stored tail forward as it goes.
+func UnindexTransactions(db taudb.Database, from uint64, to uint64, interrupt chan struct{}) error {
+	if from >= to {
+		return nil
+	}
+	var (
+		batch   = db.NewBatch()
+		start   = time.Now()
+		logged  = start
+		removed int
+	)
+	for number := from; number < to; number++ {
+		select {
+		case <-interrupt:
+			return flushTxIndex(batch, number, errTxIndexInterrupted)
+		default:
+		}
+		if block := ReadBlock(db, ReadCanonicalHash(db, number), number); block != nil {
+			for _, tx := range block.Transactions() {
+				DeleteTxLookupEntry(batch, (*tx).Hash())
+			}
+			removed += len(block.Transactions())
+		}
+		if batch.ValueSize() > taudb.IdealBatchSize {
+			if err := flushTxIndex(batch, number+1, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Unindexing transactions", "blocks", number-from+1, "txs", removed, "tail", number+1, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := flushTxIndex(batch, to, nil); err != nil {
+		return err
+	}
+	log.Info("Unindexed transactions", "blocks", to-from, "txs", removed, "tail", to, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// DeleteAllTxLookupEntries wipes the whole transaction lookup index along with
+// its progress marker.
+func DeleteAllTxLookupEntries(db taudb.Database) error {
+	it := db.NewIteratorWithPrefix(txLookupPrefix)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		if key := it.Key(); len(key) == len(txLookupPrefix)+common.HashLength {
+			if err := batch.Delete(key); err != nil {
+				return err
+			}
+		}
+		if batch.ValueSize() > taudb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	DeleteTxIndexTail(batch)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	return it.Error()
+}
+
+// flushTxIndex records the new index tail in the batch and writes it out,
+// returning err if the write itself succeeded.
+func flushTxIndex(batch taudb.Batch, tail uint64, err error) error {
+	WriteTxIndexTail(batch, tail)
+	if werr := batch.Write(); werr != nil {
+		return werr
+	}
+	return err
+}
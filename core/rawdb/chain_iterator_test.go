@@ -0,0 +1,136 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// newIndexTestChain writes a canonical chain of the given length into a fresh
+// database, every block but the genesis carrying a single transaction.
+func newIndexTestChain(length int) (taudb.Database, []*types.Block) {
+	db := NewMemoryDatabase()
+
+	var (
+		blocks []*types.Block
+		parent common.Hash
+	)
+	for i := 0; i < length; i++ {
+		var txs []*types.Transaction
+		if i > 0 {
+			tx := types.Transaction(types.NewTransferTransaction(nil, nil, nil, uint64(i), 0, big.NewInt(1), common.Address{0x01}, common.Address{0x02}, big.NewInt(1)))
+			txs = append(txs, &tx)
+		}
+		block := types.NewBlock(&types.Header{Number: big.NewInt(int64(i)), Difficulty: big.NewInt(1), ParentHash: parent}, txs)
+		WriteBlock(db, block)
+		WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	return db, blocks
+}
+
+// checkTxIndex verifies that exactly the transactions of the blocks at or above
+// the given tail are indexed, and that the stored tail matches.
+func checkTxIndex(t *testing.T, db taudb.Database, blocks []*types.Block, tail uint64) {
+	t.Helper()
+
+	for _, block := range blocks {
+		for _, tx := range block.Transactions() {
+			number := ReadTxLookupEntry(db, (*tx).Hash())
+			if block.NumberU64() >= tail && (number == nil || *number != block.NumberU64()) {
+				t.Errorf("transaction of block %d not indexed", block.NumberU64())
+			}
+			if block.NumberU64() < tail && number != nil {
+				t.Errorf("transaction of block %d indexed below tail %d", block.NumberU64(), tail)
+			}
+		}
+	}
+	if stored := ReadTxIndexTail(db); stored == nil || *stored != tail {
+		t.Errorf("index tail mismatch: have %v, want %d", stored, tail)
+	}
+}
+
+// Tests that blocks are indexed from the newest to the oldest and the stored
+// tail is extended as the horizon moves backwards.
+func TestIndexTransactions(t *testing.T) {
+	db, blocks := newIndexTestChain(10)
+
+	if err := IndexTransactions(db, 6, 10, nil); err != nil {
+		t.Fatalf("failed to index transactions: %v", err)
+	}
+	checkTxIndex(t, db, blocks, 6)
+
+	if err := IndexTransactions(db, 2, 6, nil); err != nil {
+		t.Fatalf("failed to extend index: %v", err)
+	}
+	checkTxIndex(t, db, blocks, 2)
+}
+
+// Tests that unindexing removes the entries of the blocks below the new tail
+// and moves the horizon forward.
+func TestUnindexTransactions(t *testing.T) {
+	db, blocks := newIndexTestChain(10)
+
+	if err := IndexTransactions(db, 0, 10, nil); err != nil {
+		t.Fatalf("failed to index transactions: %v", err)
+	}
+	if err := UnindexTransactions(db, 0, 4, nil); err != nil {
+		t.Fatalf("failed to unindex transactions: %v", err)
+	}
+	checkTxIndex(t, db, blocks, 4)
+
+	if err := UnindexTransactions(db, 4, 7, nil); err != nil {
+		t.Fatalf("failed to move horizon forward: %v", err)
+	}
+	checkTxIndex(t, db, blocks, 7)
+}
+
+// Tests that interrupted runs persist their progress, and that resuming from
+// the stored tail completes the index.
+func TestTxIndexResume(t *testing.T) {
+	db, blocks := newIndexTestChain(10)
+
+	interrupt := make(chan struct{})
+	close(interrupt)
+
+	if err := IndexTransactions(db, 0, 10, interrupt); err != errTxIndexInterrupted {
+		t.Fatalf("index error mismatch: have %v, want %v", err, errTxIndexInterrupted)
+	}
+	checkTxIndex(t, db, blocks, 10)
+
+	if err := IndexTransactions(db, 0, *ReadTxIndexTail(db), nil); err != nil {
+		t.Fatalf("failed to resume indexing: %v", err)
+	}
+	checkTxIndex(t, db, blocks, 0)
+
+	if err := UnindexTransactions(db, 0, 5, interrupt); err != errTxIndexInterrupted {
+		t.Fatalf("unindex error mismatch: have %v, want %v", err, errTxIndexInterrupted)
+	}
+	checkTxIndex(t, db, blocks, 0)
+
+	if err := UnindexTransactions(db, *ReadTxIndexTail(db), 5, nil); err != nil {
+		t.Fatalf("failed to resume unindexing: %v", err)
+	}
+	checkTxIndex(t, db, blocks, 5)
+}
@@ -41,6 +41,9 @@ var (
 	// fastTrieProgressKey tracks the number of trie entries imported during fast sync.
 	fastTrieProgressKey = []byte("TrieSync")
 
+	// txIndexTailKey tracks the oldest block whose transactions are indexed.
+	txIndexTailKey = []byte("TransactionIndexTail")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -53,6 +56,20 @@ var (
 	txLookupPrefix  = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
 	bloomBitsPrefix = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
 
+	profileHistoryPrefix = []byte("P")   // profileHistoryPrefix + address + num (uint64 big endian) -> profile update tx hash
+	profileNamePrefix    = []byte("pn-") // profileNamePrefix + lowercased name + address -> profile name index marker
+
+	messageThreadPrefix = []byte("mt-") // messageThreadPrefix + message hash -> hash of the thread root, or of an ancestor
+
+	chainDirPrefix     = []byte("cd-")  // chainDirPrefix + chain id -> chain directory entry
+	chainDirNamePrefix = []byte("cdn-") // chainDirNamePrefix + lowercased name + chain id -> chain name index marker
+
+	// chainDirHeadKey tracks the last block processed by the chain directory.
+	chainDirHeadKey = []byte("ChainDirHead")
+
+	chainFollowPrefix = []byte("cf-") // chainFollowPrefix + chain id -> follow status of the chain in the user database
+	ipldPeerPrefix    = []byte("ip-") // ipldPeerPrefix + chain id + peer id -> IPLD peer book entry in the user database
+
 	preimagePrefix = []byte("secure-key-")      // preimagePrefix + hash -> preimage
 	configPrefix   = []byte("tau-config-") // config prefix for the db
 
@@ -155,6 +172,41 @@ func bloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// profileHistoryKey = profileHistoryPrefix + address + num (uint64 big endian)
+func profileHistoryKey(addr common.Address, number uint64) []byte {
+	return append(append(append([]byte{}, profileHistoryPrefix...), addr.Bytes()...), encodeBlockNumber(number)...)
+}
+
+// profileNameKey = profileNamePrefix + lowercased name + address
+func profileNameKey(name []byte, addr common.Address) []byte {
+	return append(append(append([]byte{}, profileNamePrefix...), name...), addr.Bytes()...)
+}
+
+// messageThreadKey = messageThreadPrefix + message hash
+func messageThreadKey(hash common.Hash) []byte {
+	return append(append([]byte{}, messageThreadPrefix...), hash.Bytes()...)
+}
+
+// chainDirKey = chainDirPrefix + chain id
+func chainDirKey(id common.ChainID) []byte {
+	return append(append([]byte{}, chainDirPrefix...), id[:]...)
+}
+
+// chainDirNameKey = chainDirNamePrefix + lowercased name + chain id
+func chainDirNameKey(name []byte, id common.ChainID) []byte {
+	return append(append(append([]byte{}, chainDirNamePrefix...), name...), id[:]...)
+}
+
+// chainFollowKey = chainFollowPrefix + chain id
+func chainFollowKey(id common.ChainID) []byte {
+	return append(append([]byte{}, chainFollowPrefix...), id[:]...)
+}
+
+// ipldPeerKey = ipldPeerPrefix + chain id + peer id
+func ipldPeerKey(id common.ChainID, peer common.IPLDPeerID) []byte {
+	return append(append(append([]byte{}, ipldPeerPrefix...), id[:]...), peer...)
+}
+
 // preimageKey = preimagePrefix + hash
 func preimageKey(hash common.Hash) []byte {
 	return append(preimagePrefix, hash.Bytes()...)
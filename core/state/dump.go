@@ -17,6 +17,7 @@
 package state
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -83,11 +84,24 @@ func (self *StateDB) dump(c collector, excludeCode, excludeStorage, excludeMissi
 	c.onRoot(self.trie.Hash())
 	it := trie.NewIterator(self.trie.NodeIterator(nil))
 	for it.Next() {
+		// Skip the profile records and the supply counters sharing the trie
+		// with the accounts
+		if bytes.Equal(it.Key, supplyHash) {
+			continue
+		}
+		preimage := self.trie.GetKey(it.Key)
+		if preimage != nil && !isAccountKey(preimage) {
+			continue
+		}
 		var data Account
 		if err := rlp.DecodeBytes(it.Value, &data); err != nil {
+			if preimage == nil {
+				// Without a preimage, a record can only be told apart by its encoding
+				continue
+			}
 			panic(err)
 		}
-		addr := common.BytesToAddress(self.trie.GetKey(it.Key))
+		addr := common.BytesToAddress(preimage)
 		account := DumpAccount{
 			Balance:  data.Balance.String(),
 			Nonce:    data.Nonce,
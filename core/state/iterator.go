@@ -17,10 +17,7 @@
 package state
 
 import (
-	"bytes"
-
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
-	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/trie"
 )
 
@@ -95,11 +92,8 @@ func (it *NodeIterator) step() error {
 	if !it.stateIt.Leaf() {
 		return nil
 	}
-	// Otherwise we've reached an account node, initiate data iteration
-	var account Account
-	if err := rlp.Decode(bytes.NewReader(it.stateIt.LeafBlob()), &account); err != nil {
-		return err
-	}
+	// Otherwise we've reached an account or a profile record, neither of which
+	// has data to iterate
 	it.accountHash = it.stateIt.Parent()
 	return nil
 }
@@ -115,6 +115,14 @@ type (
 	addPreimageChange struct {
 		hash common.Hash
 	}
+	profileChange struct {
+		account *common.Address
+		prev    *Profile
+	}
+	supplyChange struct {
+		prev      *Supply
+		prevDirty bool
+	}
 	touchChange struct {
 		account   *common.Address
 		prev      bool
@@ -191,3 +199,20 @@ func (ch addPreimageChange) revert(s *StateDB) {
 func (ch addPreimageChange) dirtied() *common.Address {
 	return nil
 }
+
+func (ch profileChange) revert(s *StateDB) {
+	s.setProfile(*ch.account, ch.prev)
+}
+
+func (ch profileChange) dirtied() *common.Address {
+	return nil
+}
+
+func (ch supplyChange) revert(s *StateDB) {
+	s.supply = ch.prev
+	s.supplyDirty = ch.prevDirty
+}
+
+func (ch supplyChange) dirtied() *common.Address {
+	return nil
+}
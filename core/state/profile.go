@@ -0,0 +1,124 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/metrics"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+)
+
+// profilePrefix is prepended to an address to derive the trie key of its
+// profile record. The resulting 21 byte key can never collide with the 20
+// byte account keys living in the same trie.
+var profilePrefix = []byte("p")
+
+// Profile is the latest personal information an address published through a
+// PersonalInfoTx. It is stored next to the account in the state trie.
+type Profile struct {
+	ContactName []byte // Contact name, up to 32 bytes
+	Name        []byte // Display name, up to 20 bytes
+	CID         []byte // CID of the profile document in IPFS
+	Number      uint64 // Block number the profile was last updated in
+}
+
+// Copy returns a deep copy of the profile.
+func (p *Profile) Copy() *Profile {
+	if p == nil {
+		return nil
+	}
+	return &Profile{
+		ContactName: common.CopyBytes(p.ContactName),
+		Name:        common.CopyBytes(p.Name),
+		CID:         common.CopyBytes(p.CID),
+		Number:      p.Number,
+	}
+}
+
+// isAccountKey reports whether a trie key preimage belongs to an account, as
+// opposed to the records stored next to the accounts.
+func isAccountKey(key []byte) bool {
+	return len(key) == common.AddressLength
+}
+
+// profileKey = profilePrefix + address
+func profileKey(addr common.Address) []byte {
+	return append(append([]byte{}, profilePrefix...), addr.Bytes()...)
+}
+
+// GetProfile retrieves the profile of the given address, or nil if the address
+// never published one.
+func (self *StateDB) GetProfile(addr common.Address) *Profile {
+	if profile, ok := self.profiles[addr]; ok {
+		return profile.Copy()
+	}
+	// Track the amount of time wasted on loading the profile from the database
+	if metrics.EnabledExpensive {
+		defer func(start time.Time) { self.AccountReads += time.Since(start) }(time.Now())
+	}
+	enc, err := self.trie.TryGet(profileKey(addr))
+	if len(enc) == 0 {
+		self.setError(err)
+		return nil
+	}
+	profile := new(Profile)
+	if err := rlp.DecodeBytes(enc, profile); err != nil {
+		log.Error("Failed to decode profile", "addr", addr, "err", err)
+		return nil
+	}
+	self.profiles[addr] = profile
+	return profile.Copy()
+}
+
+// SetProfile replaces the profile of the given address.
+func (self *StateDB) SetProfile(addr common.Address, profile *Profile) {
+	self.journal.append(profileChange{
+		account: &addr,
+		prev:    self.GetProfile(addr),
+	})
+	self.setProfile(addr, profile.Copy())
+}
+
+func (self *StateDB) setProfile(addr common.Address, profile *Profile) {
+	self.profiles[addr] = profile
+	self.profilesDirty[addr] = struct{}{}
+}
+
+// updateProfiles writes all dirty profiles into the trie.
+func (s *StateDB) updateProfiles() {
+	// Track the amount of time wasted on updating the profiles in the trie
+	if metrics.EnabledExpensive {
+		defer func(start time.Time) { s.AccountUpdates += time.Since(start) }(time.Now())
+	}
+	for addr := range s.profilesDirty {
+		profile := s.profiles[addr]
+		if profile == nil {
+			s.setError(s.trie.TryDelete(profileKey(addr)))
+			continue
+		}
+		data, err := rlp.EncodeToBytes(profile)
+		if err != nil {
+			panic(fmt.Errorf("can't encode profile at %x: %v", addr[:], err))
+		}
+		s.setError(s.trie.TryUpdate(profileKey(addr), data))
+	}
+	s.profilesDirty = make(map[common.Address]struct{})
+}
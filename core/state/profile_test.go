@@ -0,0 +1,87 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+)
+
+// Tests that profiles survive a commit and that reverting a snapshot restores
+// the previously published profile.
+func TestProfileCommitAndRevert(t *testing.T) {
+	db := NewDatabase(rawdb.NewMemoryDatabase())
+	state, _ := New(common.Hash{}, db)
+
+	addr := toAddr([]byte("profile"))
+	if profile := state.GetProfile(addr); profile != nil {
+		t.Fatalf("unexpected profile before publishing: %v", profile)
+	}
+	state.SetProfile(addr, &Profile{Name: []byte("alice"), CID: []byte{0x01}, Number: 1})
+
+	snap := state.Snapshot()
+	state.SetProfile(addr, &Profile{Name: []byte("bob"), CID: []byte{0x02}, Number: 2})
+	state.RevertToSnapshot(snap)
+
+	root, err := state.Commit(false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	reloaded, _ := New(root, db)
+	profile := reloaded.GetProfile(addr)
+	if profile == nil {
+		t.Fatalf("profile missing after commit")
+	}
+	if !bytes.Equal(profile.Name, []byte("alice")) || profile.Number != 1 {
+		t.Errorf("profile mismatch: have %s@%d, want alice@1", profile.Name, profile.Number)
+	}
+}
+
+// Tests that state dumps and node iteration skip the profile records sharing the
+// trie with the accounts.
+func TestProfileDump(t *testing.T) {
+	db := NewDatabase(rawdb.NewMemoryDatabase())
+	state, _ := New(common.Hash{}, db)
+
+	addr := toAddr([]byte("profile"))
+	state.AddBalance(addr, big.NewInt(42))
+	state.SetProfile(addr, &Profile{Name: []byte("alice"), CID: []byte{0x01}, Number: 1})
+
+	root, err := state.Commit(false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	state, _ = New(root, db)
+
+	dump := state.RawDump(true, true, false)
+	if len(dump.Accounts) != 1 {
+		t.Fatalf("dumped account count mismatch: have %d, want 1", len(dump.Accounts))
+	}
+	if account, ok := dump.Accounts[addr]; !ok || account.Balance != "42" {
+		t.Fatalf("dumped account mismatch: have %v", dump.Accounts)
+	}
+	it := NewNodeIterator(state)
+	for it.Next() {
+	}
+	if it.Error != nil {
+		t.Fatalf("node iteration failed: %v", it.Error)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/metrics"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
@@ -63,6 +64,14 @@ type StateDB struct {
 	stateObjects      map[common.Address]*stateObject
 	stateObjectsDirty map[common.Address]struct{}
 
+	// Profile records published by PersonalInfoTx, cached the same way.
+	profiles      map[common.Address]*Profile
+	profilesDirty map[common.Address]struct{}
+
+	// Coin supply counters, loaded lazily from the trie.
+	supply      *Supply
+	supplyDirty bool
+
 	// DB error.
 	// State objects are used by the consensus core and VM which are
 	// unable to deal with database-level errors. Any error that occurs
@@ -106,6 +115,8 @@ func New(root common.Hash, db Database) (*StateDB, error) {
 		trie:              tr,
 		stateObjects:      make(map[common.Address]*stateObject),
 		stateObjectsDirty: make(map[common.Address]struct{}),
+		profiles:          make(map[common.Address]*Profile),
+		profilesDirty:     make(map[common.Address]struct{}),
 		preimages:         make(map[common.Hash][]byte),
 		journal:           newJournal(),
 	}, nil
@@ -132,6 +143,10 @@ func (self *StateDB) Reset(root common.Hash) error {
 	self.trie = tr
 	self.stateObjects = make(map[common.Address]*stateObject)
 	self.stateObjectsDirty = make(map[common.Address]struct{})
+	self.profiles = make(map[common.Address]*Profile)
+	self.profilesDirty = make(map[common.Address]struct{})
+	self.supply = nil
+	self.supplyDirty = false
 	self.thash = common.Hash{}
 	self.bhash = common.Hash{}
 	self.txIndex = 0
@@ -202,6 +217,15 @@ func (self *StateDB) GetNonce(addr common.Address) uint64 {
 	return 0
 }
 
+// GetProof returns the Merkle proof of the account at addr in the account
+// trie. The proof consists of the encoded trie nodes on the path from the
+// root to the account and also proves the absence of unknown accounts.
+func (self *StateDB) GetProof(addr common.Address) ([][]byte, error) {
+	var proof proofList
+	err := self.trie.Prove(crypto.Keccak256(addr.Bytes()), 0, &proof)
+	return [][]byte(proof), err
+}
+
 // TxIndex returns the current transaction index set by Prepare.
 func (self *StateDB) TxIndex() int {
 	return self.txIndex
@@ -397,6 +421,10 @@ func (self *StateDB) Copy() *StateDB {
 		trie:              self.db.CopyTrie(self.trie),
 		stateObjects:      make(map[common.Address]*stateObject, len(self.journal.dirties)),
 		stateObjectsDirty: make(map[common.Address]struct{}, len(self.journal.dirties)),
+		profiles:          make(map[common.Address]*Profile, len(self.profiles)),
+		profilesDirty:     make(map[common.Address]struct{}, len(self.profilesDirty)),
+		supply:            self.supply.Copy(),
+		supplyDirty:       self.supplyDirty,
 		refund:            self.refund,
 		preimages:         make(map[common.Hash][]byte, len(self.preimages)),
 		journal:           newJournal(),
@@ -421,6 +449,12 @@ func (self *StateDB) Copy() *StateDB {
 			state.stateObjectsDirty[addr] = struct{}{}
 		}
 	}
+	for addr, profile := range self.profiles {
+		state.profiles[addr] = profile.Copy()
+	}
+	for addr := range self.profilesDirty {
+		state.profilesDirty[addr] = struct{}{}
+	}
 	for hash, preimage := range self.preimages {
 		state.preimages[hash] = preimage
 	}
@@ -478,6 +512,9 @@ func (s *StateDB) Finalise(deleteEmptyObjects bool) {
 		}
 		s.stateObjectsDirty[addr] = struct{}{}
 	}
+	s.updateProfiles()
+	s.updateSupply()
+
 	// Invalidate journal because reverting across transactions is not allowed.
 	s.clearJournalAndRefund()
 }
@@ -527,6 +564,9 @@ func (s *StateDB) Commit(deleteEmptyObjects bool) (root common.Hash, err error)
 		}
 		delete(s.stateObjectsDirty, addr)
 	}
+	s.updateProfiles()
+	s.updateSupply()
+
 	// Write the account trie changes, measuing the amount of wasted time
 	if metrics.EnabledExpensive {
 		defer func(start time.Time) { s.AccountCommits += time.Since(start) }(time.Now())
@@ -0,0 +1,110 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+)
+
+// supplyKey is the trie key of the supply counters. Its length differs from
+// both account keys (20 bytes) and profile keys (21 bytes).
+var supplyKey = []byte("supply")
+
+// supplyHash is the hashed trie key of the supply counters. It tells them apart
+// from the accounts when the key preimage is not available, as the counters
+// decode as an account.
+var supplyHash = crypto.Keccak256(supplyKey)
+
+// Supply tracks the amount of coins created by block rewards and destroyed by
+// fee burning since genesis.
+type Supply struct {
+	Issued *big.Int
+	Burned *big.Int
+}
+
+// Copy returns a deep copy of the supply counters.
+func (s *Supply) Copy() *Supply {
+	if s == nil {
+		return nil
+	}
+	return &Supply{
+		Issued: new(big.Int).Set(s.Issued),
+		Burned: new(big.Int).Set(s.Burned),
+	}
+}
+
+// GetSupply retrieves the supply counters of the current state.
+func (self *StateDB) GetSupply() *Supply {
+	return self.getSupply().Copy()
+}
+
+// AddIssued records newly created coins.
+func (self *StateDB) AddIssued(amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+	supply := self.getSupply()
+	self.journal.append(supplyChange{prev: supply.Copy(), prevDirty: self.supplyDirty})
+	supply.Issued.Add(supply.Issued, amount)
+	self.supplyDirty = true
+}
+
+// AddBurned records destroyed coins.
+func (self *StateDB) AddBurned(amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+	supply := self.getSupply()
+	self.journal.append(supplyChange{prev: supply.Copy(), prevDirty: self.supplyDirty})
+	supply.Burned.Add(supply.Burned, amount)
+	self.supplyDirty = true
+}
+
+func (self *StateDB) getSupply() *Supply {
+	if self.supply != nil {
+		return self.supply
+	}
+	self.supply = &Supply{Issued: new(big.Int), Burned: new(big.Int)}
+
+	enc, err := self.trie.TryGet(supplyKey)
+	if len(enc) == 0 {
+		self.setError(err)
+		return self.supply
+	}
+	if err := rlp.DecodeBytes(enc, self.supply); err != nil {
+		log.Error("Failed to decode supply", "err", err)
+	}
+	return self.supply
+}
+
+// updateSupply writes the supply counters into the trie if they changed.
+func (s *StateDB) updateSupply() {
+	if !s.supplyDirty {
+		return
+	}
+	data, err := rlp.EncodeToBytes(s.supply)
+	if err != nil {
+		panic(fmt.Errorf("can't encode supply: %v", err))
+	}
+	s.setError(s.trie.TryUpdate(supplyKey, data))
+	s.supplyDirty = false
+}
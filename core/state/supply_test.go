@@ -0,0 +1,61 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+)
+
+// Tests that state dumps skip the supply counters, even if their key preimage
+// is missing and they decode as an account.
+func TestSupplyDump(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	db := NewDatabase(diskdb)
+	state, _ := New(common.Hash{}, db)
+
+	addr := toAddr([]byte("miner"))
+	state.AddBalance(addr, big.NewInt(42))
+	state.AddIssued(big.NewInt(42))
+
+	root, err := state.Commit(false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	if err := db.TrieDB().Commit(root, false); err != nil {
+		t.Fatalf("failed to flush state: %v", err)
+	}
+	for _, missing := range []bool{false, true} {
+		if missing {
+			diskdb.Delete(supplyHash)
+		}
+		state, _ = New(root, NewDatabase(diskdb))
+		if issued := state.GetSupply().Issued; issued.Cmp(big.NewInt(42)) != 0 {
+			t.Fatalf("issued supply mismatch: have %v, want 42", issued)
+		}
+		dump := state.RawDump(true, true, false)
+		if len(dump.Accounts) != 1 {
+			t.Fatalf("missing preimage %v: dumped account count mismatch: have %d, want 1", missing, len(dump.Accounts))
+		}
+		if account, ok := dump.Accounts[addr]; !ok || account.Balance != "42" {
+			t.Fatalf("missing preimage %v: dumped account mismatch: have %v", missing, dump.Accounts)
+		}
+	}
+}
@@ -29,8 +29,13 @@ import (
 func NewStateSync(root common.Hash, database taudb.KeyValueReader) *trie.Sync {
 	var syncer *trie.Sync
 	callback := func(leaf []byte, parent common.Hash) error {
+		// Profile records share the trie with the accounts, skip them
 		var obj Account
 		if err := rlp.Decode(bytes.NewReader(leaf), &obj); err != nil {
+			var profile Profile
+			if rlp.DecodeBytes(leaf, &profile) == nil {
+				return nil
+			}
 			return err
 		}
 		//syncer.AddSubTrie(obj.Root, 64, parent, nil)
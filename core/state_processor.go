@@ -53,41 +53,65 @@ func NewStateProcessor(config *params.ChainConfig, bc *BlockChain, engine consen
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
 func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB) error {
+	return p.ProcessTraced(block, statedb, nil)
+}
+
+// ProcessTraced processes the block like Process, reporting every state
+// transition to the given tracer. A nil tracer disables tracing.
+func (p *StateProcessor) ProcessTraced(block *types.Block, statedb *state.StateDB, tracer StateTracer) error {
 	var (
 		header = block.Header()
 	)
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
 		statedb.Prepare((*tx).Hash(), block.Hash(), i)
-		err := ApplyTransaction(p.config, p.bc, nil, statedb, header, tx)
+		err := ApplyTransaction(p.config, p.bc, nil, statedb, header, tx, tracer)
 		if err != nil {
 			return err
 		}
 	}
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
+	if tracer != nil {
+		tracer.CaptureRewardStart(statedb, header)
+	}
 	p.engine.Finalize(p.bc, header, statedb, block.Transactions())
-
+	if tracer != nil {
+		tracer.CaptureRewardEnd(statedb)
+	}
 	return nil
 }
 
 // ApplyTransaction attempts to apply a transaction to the given state database
 // and uses the input parameters for its environment. It returns the receipt
 // for the transaction, gas used and an error if the transaction failed,
-// indicating the block was invalid.
-func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction) error {
+// indicating the block was invalid. If tracer is not nil, it is notified of
+// every state change made by the transaction.
+func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, tracer StateTracer) error {
 	msg, err := (*tx).AsMessage(types.MakeSigner(config, header.Number))
 	if err != nil {
 		return err
 	}
+	if tracer != nil {
+		tracer.CaptureTxStart(statedb, tx, msg.From())
+	}
+	err = applyTransaction(config, bc, author, statedb, header, tx, msg, tracer)
+	if tracer != nil {
+		tracer.CaptureTxEnd(statedb, err)
+	}
+	return err
+}
+
+func applyTransaction(config *params.ChainConfig, bc ChainContext, author *common.Address, statedb *state.StateDB, header *types.Header, tx *types.Transaction, msg types.Message, tracer StateTracer) error {
 	// Create a new context to be used in the EVM environment
 	context := NewEVMContext(msg, header, bc, author)
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
 	vmenv := vm.NewEVM(context, statedb, config)
 	// Apply the transaction to the current state (included in the env)
-	_, _, _, err = ApplyMessage(vmenv, msg)
-	if err != nil {
+	st := NewStateTransition(vmenv, msg)
+	st.tracer = tracer
+	if _, _, _, err := st.TransitionDb(); err != nil {
 		return err
 	}
-	return err
+	return applyTxPayload(config, statedb, header, tx)
 }
@@ -52,6 +52,7 @@ type StateTransition struct {
 	value      *big.Int
 	state      vm.StateDB
 	evm        *vm.EVM
+	tracer     StateTracer
 }
 
 // Message represents a message sent to a contract.
@@ -146,6 +147,9 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 	// ctc modify only call
 	st.state.SetNonce(msg.From(), st.state.GetNonce(sender.Address())+1)
 	ret, vmerr = evm.Call(sender, st.to(), st.getUintFee(), st.value)
+	if vmerr == nil && st.tracer != nil && st.value != nil && st.value.Sign() > 0 {
+		st.tracer.CaptureTransfer(msg.From(), st.to(), st.value)
+	}
 	if vmerr != nil {
 		log.Debug("VM returned with error", "err", vmerr)
 		// The only possible consensus-error would be if there wasn't
@@ -156,11 +160,33 @@ func (st *StateTransition) TransitionDb() (ret []byte, usedGas uint64, failed bo
 		}
 	}
 
-	st.state.AddBalance(st.evm.Coinbase, new(big.Int).SetUint64(st.getUintFee()))
+	st.distributeFee(new(big.Int).SetUint64(st.getUintFee()))
 
 	return ret, st.getUintFee(), vmerr != nil, err
 }
 
+// distributeFee splits the transaction fee according to the chain's reward
+// policy: part of it may be burned, part paid to the relay fund and the rest
+// goes to the miner. Burned coins are tracked in the supply past the supply fork.
+func (st *StateTransition) distributeFee(fee *big.Int) {
+	burn, relay, miner := st.evm.ChainConfig().Tauash.SplitFee(fee)
+	if burn.Sign() > 0 && st.evm.ChainConfig().IsSupply(st.evm.BlockNumber) {
+		st.state.AddBurned(burn)
+	}
+	if relay.Sign() > 0 {
+		st.state.AddBalance(*st.evm.ChainConfig().Tauash.RelayFund, relay)
+	}
+	st.state.AddBalance(st.evm.Coinbase, miner)
+
+	if st.tracer != nil {
+		split := &FeeSplit{Burned: burn, Relay: relay, Miner: miner, Coinbase: st.evm.Coinbase}
+		if relay.Sign() > 0 {
+			split.RelayFund = st.evm.ChainConfig().Tauash.RelayFund
+		}
+		st.tracer.CaptureFee(st.msg.From(), fee, split)
+	}
+}
+
 func (st *StateTransition) getUintFee()  uint64 {
 	return st.fee.Uint64()
 }
@@ -0,0 +1,84 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/vm"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+)
+
+// Tests that transaction fees are split between burning, the relay fund and the
+// miner, and that the burned share is only tracked past the supply fork.
+func TestFeeDistribution(t *testing.T) {
+	var (
+		sender    = common.HexToAddress("0x01")
+		receiver  = common.HexToAddress("0x02")
+		coinbase  = common.HexToAddress("0x03")
+		relayFund = common.HexToAddress("0x04")
+	)
+	tests := []struct {
+		supplyBlock *big.Int
+		tauash      *params.TauashConfig
+		wantMiner   int64
+		wantRelay   int64
+		wantBurned  int64
+	}{
+		{supplyBlock: big.NewInt(0), tauash: new(params.TauashConfig), wantMiner: 1000},
+		{supplyBlock: big.NewInt(0), tauash: &params.TauashConfig{FeeBurnPercent: 30, RelayFeePercent: 20, RelayFund: &relayFund}, wantMiner: 500, wantRelay: 200, wantBurned: 300},
+		{supplyBlock: big.NewInt(2), tauash: &params.TauashConfig{FeeBurnPercent: 30}, wantMiner: 700},
+	}
+	for i, test := range tests {
+		config := &params.ChainConfig{ChainID: big.NewInt(1), SupplyBlock: test.supplyBlock, Tauash: test.tauash}
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb.AddBalance(sender, big.NewInt(10000))
+
+		ctx := vm.Context{
+			CanTransfer: CanTransfer,
+			Transfer:    Transfer,
+			Origin:      sender,
+			Coinbase:    coinbase,
+			BlockNumber: big.NewInt(1),
+		}
+		msg := types.NewMessage(sender, &receiver, 0, big.NewInt(100), big.NewInt(1000), true)
+		if _, _, _, err := ApplyMessage(vm.NewEVM(ctx, statedb, config), msg); err != nil {
+			t.Fatalf("test %d: failed to apply message: %v", i, err)
+		}
+		if balance := statedb.GetBalance(sender); balance.Cmp(big.NewInt(10000-1000-100)) != 0 {
+			t.Errorf("test %d: sender balance mismatch: have %v, want %d", i, balance, 10000-1000-100)
+		}
+		if balance := statedb.GetBalance(receiver); balance.Cmp(big.NewInt(100)) != 0 {
+			t.Errorf("test %d: receiver balance mismatch: have %v, want 100", i, balance)
+		}
+		if balance := statedb.GetBalance(coinbase); balance.Cmp(big.NewInt(test.wantMiner)) != 0 {
+			t.Errorf("test %d: miner fee mismatch: have %v, want %d", i, balance, test.wantMiner)
+		}
+		if balance := statedb.GetBalance(relayFund); balance.Cmp(big.NewInt(test.wantRelay)) != 0 {
+			t.Errorf("test %d: relay fee mismatch: have %v, want %d", i, balance, test.wantRelay)
+		}
+		if burned := statedb.GetSupply().Burned; burned.Cmp(big.NewInt(test.wantBurned)) != 0 {
+			t.Errorf("test %d: burned fee mismatch: have %v, want %d", i, burned, test.wantBurned)
+		}
+	}
+}
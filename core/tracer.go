@@ -0,0 +1,59 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+)
+
+// FeeSplit describes how the fee of a transaction was distributed.
+type FeeSplit struct {
+	Burned    *big.Int        // Amount destroyed
+	Relay     *big.Int        // Amount paid to the relay fund
+	RelayFund *common.Address // Relay fund account, nil if none is configured
+	Miner     *big.Int        // Amount paid to the block's coinbase
+	Coinbase  common.Address  // Coinbase of the block
+}
+
+// StateTracer is notified of the state transitions performed while processing
+// a block: every transaction and the final reward application. Tracers must
+// treat the state databases handed to them as read only.
+type StateTracer interface {
+	// CaptureTxStart is called before tx, sent by from, is applied to statedb.
+	CaptureTxStart(statedb *state.StateDB, tx *types.Transaction, from common.Address)
+
+	// CaptureTransfer is called after value was moved between two accounts.
+	CaptureTransfer(from, to common.Address, value *big.Int)
+
+	// CaptureFee is called after the fee paid by from was distributed.
+	CaptureFee(from common.Address, fee *big.Int, split *FeeSplit)
+
+	// CaptureTxEnd is called after the transaction was applied, or failed
+	// with err.
+	CaptureTxEnd(statedb *state.StateDB, err error)
+
+	// CaptureRewardStart is called before the consensus engine finalizes the
+	// block with the given header.
+	CaptureRewardStart(statedb *state.StateDB, header *types.Header)
+
+	// CaptureRewardEnd is called after the block was finalized.
+	CaptureRewardEnd(statedb *state.StateDB)
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+)
+
+// TxIndexTarget returns the oldest block whose transactions should be indexed
+// for the given chain head and lookup limit (0 = index all blocks).
+func TxIndexTarget(head uint64, limit uint64) uint64 {
+	if limit == 0 || head < limit {
+		return 0
+	}
+	return head - limit + 1
+}
+
+// TxIndexTail returns the oldest block whose transactions are currently
+// indexed, or nil if the indexer has not run yet.
+func (bc *BlockChain) TxIndexTail() *uint64 {
+	return rawdb.ReadTxIndexTail(bc.db)
+}
+
+// indexBlocks moves the transaction index tail towards the target implied by
+// the given head, indexing missing blocks or pruning stale ones. The progress
+// is persisted, so an interrupted run picks up where it left off.
+func (bc *BlockChain) indexBlocks(head uint64, done chan struct{}) {
+	defer close(done)
+
+	var (
+		target = TxIndexTarget(head, bc.cacheConfig.TxLookupLimit)
+		tail   = rawdb.ReadTxIndexTail(bc.db)
+		err    error
+	)
+	switch {
+	case tail == nil:
+		// First run on this database: every block was indexed at import, so
+		// only the blocks beyond the horizon have to go.
+		if target == 0 {
+			rawdb.WriteTxIndexTail(bc.db, 0)
+			return
+		}
+		err = rawdb.UnindexTransactions(bc.db, 0, target, bc.quit)
+
+	case *tail > target:
+		// The horizon was extended (or set to all blocks), fill in the gap
+		err = rawdb.IndexTransactions(bc.db, target, *tail, bc.quit)
+
+	case *tail < target:
+		// The chain progressed beyond the horizon, prune the stale entries
+		err = rawdb.UnindexTransactions(bc.db, *tail, target, bc.quit)
+	}
+	if err != nil {
+		log.Debug("Transaction indexing aborted", "err", err)
+	}
+}
+
+// maintainTxIndex is responsible for the construction and deletion of the
+// transaction index according to the configured lookup limit. It runs in the
+// background, handling one head update at a time.
+func (bc *BlockChain) maintainTxIndex() {
+	defer bc.wg.Done()
+
+	var (
+		headCh = make(chan ChainHeadEvent, 1)
+		done   chan struct{}
+		queued bool
+	)
+	sub := bc.SubscribeChainHeadEvent(headCh)
+	if sub == nil {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	// Launch the initial processing for the current head
+	if head := bc.CurrentBlock(); head != nil {
+		done = make(chan struct{})
+		go bc.indexBlocks(head.NumberU64(), done)
+	}
+	for {
+		select {
+		case <-headCh:
+			if done != nil {
+				// A run is already in progress, remember to re-evaluate afterwards
+				queued = true
+				continue
+			}
+			done = make(chan struct{})
+			go bc.indexBlocks(bc.CurrentBlock().NumberU64(), done)
+
+		case <-done:
+			done = nil
+			if queued {
+				queued = false
+				done = make(chan struct{})
+				go bc.indexBlocks(bc.CurrentBlock().NumberU64(), done)
+			}
+
+		case <-bc.quit:
+			if done != nil {
+				log.Info("Waiting background transaction indexer to exit")
+				<-done
+			}
+			return
+		}
+	}
+}
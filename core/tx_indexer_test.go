@@ -0,0 +1,100 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+)
+
+func TestTxIndexTarget(t *testing.T) {
+	tests := []struct {
+		head, limit, want uint64
+	}{
+		{head: 100, limit: 0, want: 0},
+		{head: 5, limit: 10, want: 0},
+		{head: 9, limit: 10, want: 0},
+		{head: 10, limit: 10, want: 1},
+		{head: 100, limit: 10, want: 91},
+	}
+	for _, test := range tests {
+		if have := TxIndexTarget(test.head, test.limit); have != test.want {
+			t.Errorf("head %d, limit %d: target mismatch: have %d, want %d", test.head, test.limit, have, test.want)
+		}
+	}
+}
+
+// Tests that the indexer prunes the blocks falling out of the lookup horizon as
+// the chain progresses, and fills the index back in when the limit is lifted.
+func TestTxIndexer(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	var (
+		blocks []*types.Block
+		parent common.Hash
+	)
+	for i := 0; i < 20; i++ {
+		var txs []*types.Transaction
+		if i > 0 {
+			tx := types.Transaction(types.NewTransferTransaction(nil, nil, nil, uint64(i), 0, big.NewInt(1), common.Address{0x01}, common.Address{0x02}, big.NewInt(1)))
+			txs = append(txs, &tx)
+		}
+		block := types.NewBlock(&types.Header{Number: big.NewInt(int64(i)), Difficulty: big.NewInt(1), ParentHash: parent}, txs)
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteTxLookupEntries(db, block)
+
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	bc := &BlockChain{
+		db:          db,
+		cacheConfig: &CacheConfig{TxLookupLimit: 5},
+		quit:        make(chan struct{}),
+	}
+	check := func(head uint64, tail uint64) {
+		t.Helper()
+
+		done := make(chan struct{})
+		bc.indexBlocks(head, done)
+		<-done
+
+		if stored := bc.TxIndexTail(); stored == nil || *stored != tail {
+			t.Fatalf("head %d: index tail mismatch: have %v, want %d", head, stored, tail)
+		}
+		for _, block := range blocks[1:] {
+			indexed := rawdb.ReadTxLookupEntry(db, (*block.Transactions()[0]).Hash()) != nil
+			if want := block.NumberU64() >= tail; indexed != want {
+				t.Errorf("head %d: block %d indexed %v, want %v", head, block.NumberU64(), indexed, want)
+			}
+		}
+	}
+	// The first run drops every block below the horizon
+	check(9, 5)
+
+	// The horizon moves forward with the chain head
+	check(12, 8)
+	check(19, 15)
+
+	// Lifting the limit indexes the whole chain again
+	bc.cacheConfig.TxLookupLimit = 0
+	check(19, 0)
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+)
+
+const (
+	maxContactNameLength = 32 // Maximum length of a profile contact name
+	maxProfileNameLength = 20 // Maximum length of a profile display name
+	maxProfileCIDLength  = 32 // Maximum length of a profile document CID
+)
+
+// applyTxPayload applies the kind specific effects of a transaction, on top
+// of the fee and nonce handling done by the state transition. Profiles are only
+// recorded past the profile fork, before it a PersonalInfoTx just pays its fee.
+func applyTxPayload(config *params.ChainConfig, statedb *state.StateDB, header *types.Header, tx *types.Transaction) error {
+	switch payload := (*tx).(type) {
+	case *types.PersonalInfoTx:
+		if !config.IsProfile(header.Number) {
+			return nil
+		}
+		return applyPersonalInfo(statedb, header, payload)
+	}
+	return nil
+}
+
+// validateTxPayload checks the kind specific fields of a transaction, so that
+// the pool rejects transactions the state transition would fail on.
+func validateTxPayload(tx *types.Transaction) error {
+	switch payload := (*tx).(type) {
+	case *types.PersonalInfoTx:
+		return validateProfile(payload)
+	}
+	return nil
+}
+
+// validateProfile checks the profile fields of a PersonalInfoTx against their
+// size limits.
+func validateProfile(tx *types.PersonalInfoTx) error {
+	if len(tx.ContactName()) > maxContactNameLength || len(tx.Name()) > maxProfileNameLength || len(tx.Profile()) > maxProfileCIDLength {
+		return ErrInvalidProfile
+	}
+	return nil
+}
+
+// applyPersonalInfo records the profile carried by a PersonalInfoTx as the
+// latest profile of its sender.
+func applyPersonalInfo(statedb *state.StateDB, header *types.Header, tx *types.PersonalInfoTx) error {
+	if err := validateProfile(tx); err != nil {
+		return err
+	}
+	statedb.SetProfile(tx.Sender(), &state.Profile{
+		ContactName: tx.ContactName(),
+		Name:        tx.Name(),
+		CID:         tx.Profile(),
+		Number:      header.Number.Uint64(),
+	})
+	return nil
+}
@@ -509,6 +509,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if (*tx).Value().Sign() < 0 {
 		return ErrNegativeValue
 	}
+	// Reject payloads the state transition would fail on after charging the fee
+	if err := validateTxPayload(tx); err != nil {
+		return err
+	}
 	// Make sure the transaction is signed properly
 	from, err := types.Sender(pool.signer, tx)
 	if err != nil {
@@ -17,11 +17,17 @@
 package types
 
 import (
+	"encoding/binary"
+	"encoding/hex"
+	"io"
 	"math/big"
 	"sync/atomic"
 
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	"golang.org/x/crypto/sha3"
 )
 
 //go:generate gencodec -type NewChainTxData -field-override NewChainTxDataMarshaling -out new_chain_tx_json.go
@@ -72,6 +78,220 @@ type NewChainTxDataMarshaling struct {
 	Description hexutil.Bytes
 }
 
-func NewNewChainTransaction() {
+func NewNewChainTransaction(version OneByte, option OneByte, chainid Byte32s, nounce uint64, timestamp uint32, fee OneByte, sender common.Address, name Byte20s, contact Byte32s, title Byte144s, description Byte32s) *NewChainTx {
+	return newNewChainTransaction(version, option, chainid, nounce, timestamp, fee, &sender, name, contact, title, description)
+}
+
+func newNewChainTransaction(version OneByte, option OneByte, chainid Byte32s, nounce uint64, timestamp uint32, fee OneByte, sender *common.Address, name Byte20s, contact Byte32s, title Byte144s, description Byte32s) *NewChainTx {
+	d := NewChainTxData{
+		Version:   version,
+		Option:    option,
+		ChainID:   chainid,
+		Nounce:    nounce,
+		TimeStamp: timestamp,
+		Fee:       fee,
+		V:         new(big.Int),
+		R:         new(big.Int),
+		S:         new(big.Int),
+		Sender:    sender,
+
+		Name:        name,
+		Contact:     contact,
+		Title:       title,
+		Description: description,
+	}
+
+	return &NewChainTx{tx: d}
+}
+
+func (nctx *NewChainTx) ChainId() Byte32s {
+	return nctx.tx.ChainID
+}
+
+func (nctx *NewChainTx) Protected() bool {
+	return true
+}
+
+func (nctx *NewChainTx) isProtectedV(V *big.Int) bool {
+	v := V.Uint64()
+	if v == 27 || v == 28 {
+		return false
+	}
+
+	return true
+}
+
+func (nctx *NewChainTx) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, &nctx.tx)
+}
+
+func (nctx *NewChainTx) DecodeRLP(s *rlp.Stream) error {
+	_, size, _ := s.Kind()
+	err := s.Decode(&nctx.tx)
+	if err == nil {
+		nctx.size.Store(common.StorageSize(rlp.ListSize(size)))
+	}
+
+	return err
+}
+
+func (nctx *NewChainTx) MarshalJSON() ([]byte, error) {
+	data := nctx.tx
+	return data.MarshalJSON()
+}
+
+func (nctx *NewChainTx) UnmarshalJSON(input []byte) error {
+	var dec NewChainTxData
+	if err := dec.UnmarshalJSON(input); err != nil {
+		return err
+	}
+
+	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
+	if withSignature {
+		var V byte
+		if nctx.isProtectedV(dec.V) {
+			chainID := deriveChainId(dec.V).Uint64()
+			V = byte(dec.V.Uint64() - 35 - 2*chainID)
+		} else {
+			V = byte(dec.V.Uint64() - 27)
+		}
+		if !crypto.ValidateSignatureValues(V, dec.R, dec.S, false) {
+			return ErrInvalidSig
+		}
+	}
+
+	*nctx = NewChainTx{tx: dec}
+	return nil
+}
+
+// Fee returns the fee of the announcement. Unlike the other transaction kinds
+// it is carried as a big endian byte string.
+func (nctx *NewChainTx) Fee() *big.Int {
+	return new(big.Int).SetBytes(nctx.tx.Fee)
+}
+
+func (nctx *NewChainTx) Value() *big.Int     { return new(big.Int) }
+func (nctx *NewChainTx) Nonce() uint64       { return nctx.tx.Nounce }
+func (nctx *NewChainTx) CheckNonce() bool    { return true }
+func (nctx *NewChainTx) To() *common.Address { return &common.Address{} }
+
+func (nctx *NewChainTx) Hash() (h common.Hash) {
+	if hash := nctx.hash.Load(); hash != nil {
+		return hash.(common.Hash)
+	}
+
+	hw := sha3.NewLegacyKeccak256()
+	rlp.Encode(hw, nctx)
+	hw.Sum(h[:0])
+
+	nctx.hash.Store(h)
+	return h
+}
+
+func (nctx *NewChainTx) Size() common.StorageSize {
+	if size := nctx.size.Load(); size != nil {
+		return size.(common.StorageSize)
+	}
+	c := writeCounter(0)
+	rlp.Encode(&c, &nctx.tx)
+	nctx.size.Store(common.StorageSize(c))
+	return common.StorageSize(c)
+}
+
+func (nctx *NewChainTx) AsMessage(s Signer) (Message, error) {
+	msg := Message{
+		from:       nctx.Sender(),
+		to:         nil,
+		nonce:      nctx.tx.Nounce,
+		amount:     nil,
+		fee:        nctx.Fee(),
+		checkNonce: true,
+	}
+
+	var err error
+	return msg, err
+}
+
+func (nctx *NewChainTx) WithSignature(singer Signer, sig []byte) (bool, error) {
+	V, R, S, err := singer.SignatureValues(sig)
+	if err != nil {
+		return false, err
+	}
+	nctx.tx.V = V
+	nctx.tx.R = R
+	nctx.tx.S = S
+	return true, nil
+}
+
+func (nctx *NewChainTx) Cost() *big.Int {
+	return nctx.Fee()
+}
+
+func (nctx *NewChainTx) RawSignatureValues() (v, r, s *big.Int) {
+	return nctx.tx.V, nctx.tx.R, nctx.tx.S
+}
+
+func (nctx *NewChainTx) GetFrom() atomic.Value {
+	return nctx.from
+}
+
+func (nctx *NewChainTx) GetSigV() *big.Int {
+	return nctx.tx.V
+}
+
+func (nctx *NewChainTx) GetSigR() *big.Int {
+	return nctx.tx.R
+}
+
+func (nctx *NewChainTx) GetSigS() *big.Int {
+	return nctx.tx.S
+}
+
+func (nctx *NewChainTx) GetNounce() uint64 {
+	return nctx.tx.Nounce
+}
+func (nctx *NewChainTx) GetFee() uint64 {
+	return nctx.Fee().Uint64()
+}
+func (nctx *NewChainTx) GetReceiver() common.Address {
+	return common.Address{}
+}
+func (nctx *NewChainTx) GetAmount() big.Int {
+	return big.Int{}
+}
+
+// Sender returns the address that announced the chain.
+func (nctx *NewChainTx) Sender() common.Address {
+	if nctx.tx.Sender == nil {
+		return common.Address{}
+	}
+	return *nctx.tx.Sender
+}
+
+// Name returns the name of the announced chain.
+func (nctx *NewChainTx) Name() Byte20s { return nctx.tx.Name }
+
+// Contact returns the contact information of the chain creator.
+func (nctx *NewChainTx) Contact() Byte32s { return nctx.tx.Contact }
+
+// Title returns the title of the announced chain.
+func (nctx *NewChainTx) Title() Byte144s { return nctx.tx.Title }
+
+// Description returns the CID of the description of the announced chain.
+func (nctx *NewChainTx) Description() Byte32s { return nctx.tx.Description }
+
+// NewChainID derives the identifier of the announced chain. It is the hex
+// encoded keccak256 hash of the creator, nonce, timestamp and name, which
+// exactly fills a common.ChainID.
+func (nctx *NewChainTx) NewChainID() common.ChainID {
+	var enc [12]byte
+	binary.BigEndian.PutUint64(enc[:8], nctx.tx.Nounce)
+	binary.BigEndian.PutUint32(enc[8:], nctx.tx.TimeStamp)
+
+	sender := nctx.Sender()
+	hash := crypto.Keccak256(sender[:], enc[:], nctx.tx.Name)
 
+	var id common.ChainID
+	hex.Encode(id[:], hash)
+	return id
 }
@@ -263,3 +263,20 @@ func (pitx *PersonalInfoTx) GetReceiver() common.Address {
 func (pitx *PersonalInfoTx) GetAmount() big.Int {
 	return big.Int{}
 }
+
+// Sender returns the address that published the profile.
+func (pitx *PersonalInfoTx) Sender() common.Address {
+	if pitx.tx.Sender == nil {
+		return common.Address{}
+	}
+	return *pitx.tx.Sender
+}
+
+// ContactName returns the contact name carried by the transaction.
+func (pitx *PersonalInfoTx) ContactName() Byte32s { return pitx.tx.ContactName }
+
+// Name returns the 20-byte display name carried by the transaction.
+func (pitx *PersonalInfoTx) Name() Byte20s { return pitx.tx.Name }
+
+// Profile returns the CID of the profile document carried by the transaction.
+func (pitx *PersonalInfoTx) Profile() Byte32s { return pitx.tx.Profile }
@@ -271,6 +271,14 @@ func (ttx *TransferTx) GetAmount() big.Int {
 	return *(ttx.tx.Amount)
 }
 
+// Sender returns the address the coins are transferred from.
+func (ttx *TransferTx) Sender() common.Address {
+	if ttx.tx.Sender == nil {
+		return common.Address{}
+	}
+	return *ttx.tx.Sender
+}
+
 type TransferTxs []*TransferTx
 
 func (s TransferTxs) Len() int { return len(s) }
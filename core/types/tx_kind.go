@@ -0,0 +1,123 @@
+// Copyright 2020 The TauCoin Authors
+// This file is part of the TauCoin library.
+//
+// The TauCoin library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The TauCoin library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+// maintained by likeopen
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+)
+
+// TxKind identifies the concrete kind of a transaction.
+type TxKind uint8
+
+const (
+	UnknownTxKind TxKind = iota
+	TransferTxKind
+	PersonalInfoTxKind
+	NewMessageTxKind
+	NewChainTxKind
+)
+
+var txKindNames = map[TxKind]string{
+	UnknownTxKind:      "unknown",
+	TransferTxKind:     "transfer",
+	PersonalInfoTxKind: "profile",
+	NewMessageTxKind:   "message",
+	NewChainTxKind:     "chain",
+}
+
+// String implements fmt.Stringer.
+func (k TxKind) String() string {
+	if name, ok := txKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("kind(%d)", uint8(k))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (k TxKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *TxKind) UnmarshalText(input []byte) error {
+	for kind, name := range txKindNames {
+		if name == string(input) && kind != UnknownTxKind {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown transaction kind %q", input)
+}
+
+// KindOf returns the kind of the given transaction.
+func KindOf(tx Transaction) TxKind {
+	switch tx.(type) {
+	case *TransferTx:
+		return TransferTxKind
+	case *PersonalInfoTx:
+		return PersonalInfoTxKind
+	case *NewMessageTx:
+		return NewMessageTxKind
+	case *NewChainTx:
+		return NewChainTxKind
+	}
+	return UnknownTxKind
+}
+
+// NewTxOfKind returns an empty transaction of the given kind to decode into.
+func NewTxOfKind(kind TxKind) (Transaction, error) {
+	switch kind {
+	case TransferTxKind:
+		return new(TransferTx), nil
+	case PersonalInfoTxKind:
+		return new(PersonalInfoTx), nil
+	case NewMessageTxKind:
+		return new(NewMessageTx), nil
+	case NewChainTxKind:
+		return new(NewChainTx), nil
+	}
+	return nil, fmt.Errorf("unknown transaction kind %v", kind)
+}
+
+// SenderOf returns the address that created the given transaction, as carried
+// in the transaction payload.
+func SenderOf(tx Transaction) common.Address {
+	switch tx := tx.(type) {
+	case *TransferTx:
+		return tx.Sender()
+	case *PersonalInfoTx:
+		return tx.Sender()
+	case *NewMessageTx:
+		return tx.Sender()
+	case *NewChainTx:
+		return tx.Sender()
+	}
+	return common.Address{}
+}
+
+// ReceiverOf returns the receiver of the given transaction, or nil if its kind
+// has no receiver.
+func ReceiverOf(tx Transaction) *common.Address {
+	if tx, ok := tx.(*TransferTx); ok && tx.tx.Receiver != nil {
+		receiver := *tx.tx.Receiver
+		return &receiver
+	}
+	return nil
+}
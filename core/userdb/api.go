@@ -0,0 +1,117 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"sort"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+)
+
+// RPCRelay is the RPC representation of a known relay.
+type RPCRelay struct {
+	Type        common.RelayType     `json:"type"`
+	Addr        common.RelayMultiAdd `json:"addr"`
+	ChainID     string               `json:"chainId"`
+	BlockNumber hexutil.Uint64       `json:"blockNumber"`
+	Time        hexutil.Uint64       `json:"time"`
+}
+
+// PublicUserdbAPI exposes the user preferences of the node over RPC.
+type PublicUserdbAPI struct {
+	udb *Userdb
+}
+
+// NewPublicUserdbAPI creates a new user preferences API.
+func NewPublicUserdbAPI(udb *Userdb) *PublicUserdbAPI {
+	return &PublicUserdbAPI{udb}
+}
+
+// FollowedChains returns the ids of the chains followed by the node.
+func (api *PublicUserdbAPI) FollowedChains() []string {
+	chains := api.udb.FollowedChains()
+
+	ids := make([]string, len(chains))
+	for i, id := range chains {
+		ids[i] = string(id[:])
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Relays returns the relays known to the node.
+func (api *PublicUserdbAPI) Relays() []*RPCRelay {
+	relays := api.udb.Relays()
+
+	result := make([]*RPCRelay, len(relays))
+	for i, relay := range relays {
+		result[i] = &RPCRelay{
+			Type:        relay.Type,
+			Addr:        relay.Addr,
+			ChainID:     string(relay.ChainID[:]),
+			BlockNumber: hexutil.Uint64(relay.BlockNumber),
+			Time:        hexutil.Uint64(relay.Time),
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Addr < result[j].Addr })
+	return result
+}
+
+// RPCIPLDPeer is the RPC representation of an IPFS peer known to follow a chain.
+type RPCIPLDPeer struct {
+	ID          common.IPLDPeerID `json:"id"`
+	ChainID     string            `json:"chainId"`
+	BlockNumber hexutil.Uint64    `json:"blockNumber"`
+	LastSeen    hexutil.Uint64    `json:"lastSeen"`
+	Failures    int               `json:"failures"`
+}
+
+// PrivateUserdbAPI exposes the peer books of the node over the private admin
+// endpoint.
+type PrivateUserdbAPI struct {
+	udb *Userdb
+}
+
+// NewPrivateUserdbAPI creates a new peer book API.
+func NewPrivateUserdbAPI(udb *Userdb) *PrivateUserdbAPI {
+	return &PrivateUserdbAPI{udb}
+}
+
+// IPLDPeers returns the IPFS peers known to follow each chain, with the highest
+// block and the last time they were seen at.
+func (api *PrivateUserdbAPI) IPLDPeers() []*RPCIPLDPeer {
+	peers := api.udb.AllIPLDPeers()
+
+	result := make([]*RPCIPLDPeer, len(peers))
+	for i, peer := range peers {
+		result[i] = &RPCIPLDPeer{
+			ID:          peer.ID,
+			ChainID:     string(peer.ChainID[:]),
+			BlockNumber: hexutil.Uint64(peer.BlockNumber),
+			LastSeen:    hexutil.Uint64(peer.LastSeen.Unix()),
+			Failures:    peer.Failures,
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ChainID != result[j].ChainID {
+			return result[i].ChainID < result[j].ChainID
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
@@ -1,6 +1,7 @@
 package userdb
 
 import (
+	"time"
 
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
@@ -17,7 +18,9 @@ type RangeConfig struct{
 
 type PeerConfig struct{
 	chainid common.ChainID   // Added with chainid
-	blocknum uint64		// Added with blocknum
+	blocknum uint64		// Highest block the peer was seen at
+	seen time.Time		// Last time the peer was seen or answered a liveness check
+	failures int		// Liveness checks failed in a row
 }
 
 type RelayConfig struct{
@@ -1,8 +1,12 @@
 package userdb
 
 import (
+	"math/rand"
+	"sync"
+	"time"
 
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
 
 	cid "github.com/ipfs/go-cid"
@@ -29,12 +33,11 @@ type Userdb struct{
 	immutablePoints			map[common.ChainID]cid.Cid
 	votesCountingPoints		map[common.ChainID]cid.Cid
 
-	//Lock will be added later
+	lock sync.RWMutex // Protects the chain info
 }
 
 func NewUserdb(db taudb.KeyValueStore) *Userdb {
-	//read chainInfo from leveldb
-	return &Userdb{
+	udb := &Userdb{
 		ldb: db,
 		chainInfo: make(map[common.ChainID]ChainConfig),
 		blockRoots: make(map[common.ChainID]cid.Cid),
@@ -42,22 +45,361 @@ func NewUserdb(db taudb.KeyValueStore) *Userdb {
 		mutableRange: make(map[common.ChainID]RangeConfig),
 		pruneRange: make(map[common.ChainID]RangeConfig),
 	}
+	//read chainInfo from leveldb
+	for chainid, follow := range rawdb.ReadChainFollows(db) {
+		udb.chainInfo[chainid] = followConfig(follow)
+	}
+	//read the IPLD peer book from leveldb
+	for _, entry := range rawdb.ReadIPLDPeers(db) {
+		if udb.ipldPeers == nil {
+			udb.ipldPeers = make(map[common.ChainID]map[common.IPLDPeerID]PeerConfig)
+		}
+		if udb.ipldPeers[entry.ChainID] == nil {
+			udb.ipldPeers[entry.ChainID] = make(map[common.IPLDPeerID]PeerConfig)
+		}
+		udb.ipldPeers[entry.ChainID][entry.Peer] = PeerConfig{
+			chainid:  entry.ChainID,
+			blocknum: entry.BlockNumber,
+			seen:     time.Unix(int64(entry.LastSeen), 0),
+			failures: int(entry.Failures),
+		}
+	}
+	return udb
+}
+
+// followConfig returns the chain config of a followed or known chain.
+func followConfig(follow bool) ChainConfig {
+	if follow {
+		return ChainConfig{follow: 1}
+	}
+	return ChainConfig{follow: 0}
 }
 
+// AddNewChain records a known chain without following it. Chains that are
+// already known keep their follow status.
 func (udb *Userdb) AddNewChain(chainid common.ChainID){
-	udb.chainInfo[chainid]= ChainConfig{
-		follow: 0,
+	udb.lock.Lock()
+	defer udb.lock.Unlock()
+
+	if _, ok := udb.chainInfo[chainid]; ok {
+		return
 	}
+	udb.chainInfo[chainid]= followConfig(false)
+	rawdb.WriteChainFollow(udb.ldb, chainid, false)
 }
 
 func (udb *Userdb) FollowNewChain(chainid common.ChainID){
-	udb.chainInfo[chainid] = ChainConfig{
-		follow: 1,
-	}
+	udb.lock.Lock()
+	defer udb.lock.Unlock()
+
+	udb.chainInfo[chainid] = followConfig(true)
+	rawdb.WriteChainFollow(udb.ldb, chainid, true)
 }
 
 func (udb *Userdb) UnfollowChain(chainid common.ChainID){
-	udb.chainInfo[chainid] = ChainConfig{
-		follow: 0,
+	udb.lock.Lock()
+	defer udb.lock.Unlock()
+
+	udb.chainInfo[chainid] = followConfig(false)
+	rawdb.WriteChainFollow(udb.ldb, chainid, false)
+}
+
+// IsFollowing reports whether the given chain is followed.
+func (udb *Userdb) IsFollowing(chainid common.ChainID) bool {
+	udb.lock.RLock()
+	defer udb.lock.RUnlock()
+
+	return udb.chainInfo[chainid].follow == 1
+}
+
+// FollowedChains returns the ids of all followed chains.
+func (udb *Userdb) FollowedChains() []common.ChainID {
+	udb.lock.RLock()
+	defer udb.lock.RUnlock()
+
+	var chains []common.ChainID
+	for chainid, config := range udb.chainInfo {
+		if config.follow == 1 {
+			chains = append(chains, chainid)
+		}
+	}
+	return chains
+}
+
+// maxRelays is the number of relays kept per type, the least recently learnt
+// are evicted.
+const maxRelays = 256
+
+// Relay describes a relay known to the node.
+type Relay struct {
+	Type        common.RelayType
+	Addr        common.RelayMultiAdd
+	ChainID     common.ChainID
+	BlockNumber uint64
+	Time        uint32
+}
+
+// AddRelay records a relay multiaddress of the given type, learnt from the
+// given chain at the given block. If there are too many relays of the type, the
+// least recently learnt one is evicted.
+func (udb *Userdb) AddRelay(typ common.RelayType, addr common.RelayMultiAdd, chainid common.ChainID, blocknum uint64, time uint32) {
+	udb.lock.Lock()
+	defer udb.lock.Unlock()
+
+	if udb.relayList == nil {
+		udb.relayList = make(map[common.RelayType]map[common.RelayMultiAdd]RelayConfig)
+	}
+	relays := udb.relayList[typ]
+	if relays == nil {
+		relays = make(map[common.RelayMultiAdd]RelayConfig)
+		udb.relayList[typ] = relays
+	}
+	if _, known := relays[addr]; !known && len(relays) >= maxRelays {
+		var (
+			oldest common.RelayMultiAdd
+			learnt uint32
+		)
+		for relay, config := range relays {
+			if oldest == "" || config.time < learnt {
+				oldest, learnt = relay, config.time
+			}
+		}
+		delete(relays, oldest)
+	}
+	relays[addr] = RelayConfig{
+		chainid:  chainid,
+		blocknum: blocknum,
+		time:     time,
+	}
+}
+
+// Relays returns the known relays.
+func (udb *Userdb) Relays() []Relay {
+	udb.lock.RLock()
+	defer udb.lock.RUnlock()
+
+	var relays []Relay
+	for typ, addrs := range udb.relayList {
+		for addr, config := range addrs {
+			relays = append(relays, Relay{
+				Type:        typ,
+				Addr:        addr,
+				ChainID:     config.chainid,
+				BlockNumber: config.blocknum,
+				Time:        config.time,
+			})
+		}
+	}
+	return relays
+}
+
+// Limits of the IPLD peer book.
+const (
+	maxIPLDPeers        = 64 // Peers kept per chain, the least recently seen are evicted
+	maxIPLDPeerFailures = 3  // Failed liveness checks in a row before a peer is evicted
+)
+
+// IPLDPeer describes an IPFS peer known to follow a chain.
+type IPLDPeer struct {
+	ID          common.IPLDPeerID
+	ChainID     common.ChainID
+	BlockNumber uint64    // Highest block the peer was seen at
+	LastSeen    time.Time // Last time the peer was seen or answered a liveness check
+	Failures    int       // Liveness checks failed in a row
+}
+
+// AddIPLDPeer records an IPFS peer following the given chain, seen now at the
+// given block. If the chain has too many peers, the least recently seen one is
+// evicted.
+func (udb *Userdb) AddIPLDPeer(chainid common.ChainID, peer common.IPLDPeerID, blocknum uint64) {
+	udb.lock.Lock()
+	defer udb.lock.Unlock()
+
+	if udb.ipldPeers == nil {
+		udb.ipldPeers = make(map[common.ChainID]map[common.IPLDPeerID]PeerConfig)
+	}
+	peers := udb.ipldPeers[chainid]
+	if peers == nil {
+		peers = make(map[common.IPLDPeerID]PeerConfig)
+		udb.ipldPeers[chainid] = peers
+	}
+	config, known := peers[peer]
+	if !known && len(peers) >= maxIPLDPeers {
+		var (
+			stalest common.IPLDPeerID
+			seen    time.Time
+		)
+		for id, config := range peers {
+			if stalest == "" || config.seen.Before(seen) {
+				stalest, seen = id, config.seen
+			}
+		}
+		delete(peers, stalest)
+		rawdb.DeleteIPLDPeer(udb.ldb, chainid, stalest)
+	}
+	if blocknum < config.blocknum {
+		blocknum = config.blocknum
+	}
+	peers[peer] = PeerConfig{
+		chainid:  chainid,
+		blocknum: blocknum,
+		seen:     time.Now(),
+	}
+	udb.writeIPLDPeer(peer, peers[peer])
+}
+
+// ChainIPLDPeers returns the known IPFS peers following the given chain.
+func (udb *Userdb) ChainIPLDPeers(chainid common.ChainID) []IPLDPeer {
+	udb.lock.RLock()
+	defer udb.lock.RUnlock()
+
+	var peers []IPLDPeer
+	for id, config := range udb.ipldPeers[chainid] {
+		peers = append(peers, config.peer(id))
+	}
+	return peers
+}
+
+// AllIPLDPeers returns the known IPFS peers of all chains, once per chain they
+// follow.
+func (udb *Userdb) AllIPLDPeers() []IPLDPeer {
+	udb.lock.RLock()
+	defer udb.lock.RUnlock()
+
+	var peers []IPLDPeer
+	for _, chainPeers := range udb.ipldPeers {
+		for id, config := range chainPeers {
+			peers = append(peers, config.peer(id))
+		}
+	}
+	return peers
+}
+
+// SelectIPLDPeer picks a random IPFS peer following the given chain, preferring
+// the ones that answered their last liveness check.
+func (udb *Userdb) SelectIPLDPeer(chainid common.ChainID) (common.IPLDPeerID, bool) {
+	udb.lock.RLock()
+	defer udb.lock.RUnlock()
+
+	var live, failing []common.IPLDPeerID
+	for id, config := range udb.ipldPeers[chainid] {
+		if config.failures == 0 {
+			live = append(live, id)
+		} else {
+			failing = append(failing, id)
+		}
+	}
+	if len(live) == 0 {
+		live = failing
+	}
+	if len(live) == 0 {
+		return "", false
+	}
+	return live[rand.Intn(len(live))], true
+}
+
+// IPLDPeerAlive records a passed liveness check of an IPFS peer on all the
+// chains it follows.
+func (udb *Userdb) IPLDPeerAlive(peer common.IPLDPeerID) {
+	udb.lock.Lock()
+	defer udb.lock.Unlock()
+
+	now := time.Now()
+	for _, chainPeers := range udb.ipldPeers {
+		if config, ok := chainPeers[peer]; ok {
+			config.seen, config.failures = now, 0
+			chainPeers[peer] = config
+			udb.writeIPLDPeer(peer, config)
+		}
+	}
+}
+
+// IPLDPeerFailed records a failed liveness check of an IPFS peer on all the
+// chains it follows, evicting it after too many failures in a row. It returns
+// whether the peer was evicted.
+func (udb *Userdb) IPLDPeerFailed(peer common.IPLDPeerID) bool {
+	udb.lock.Lock()
+	defer udb.lock.Unlock()
+
+	evicted := false
+	for _, chainPeers := range udb.ipldPeers {
+		config, ok := chainPeers[peer]
+		if !ok {
+			continue
+		}
+		if config.failures++; config.failures >= maxIPLDPeerFailures {
+			delete(chainPeers, peer)
+			rawdb.DeleteIPLDPeer(udb.ldb, config.chainid, peer)
+			evicted = true
+			continue
+		}
+		chainPeers[peer] = config
+		udb.writeIPLDPeer(peer, config)
+	}
+	return evicted
+}
+
+// EvictIPLDPeers drops the IPFS peers not seen since the given time, returning
+// the number of evicted entries.
+func (udb *Userdb) EvictIPLDPeers(before time.Time) int {
+	udb.lock.Lock()
+	defer udb.lock.Unlock()
+
+	evicted := 0
+	for chainid, chainPeers := range udb.ipldPeers {
+		for id, config := range chainPeers {
+			if config.seen.Before(before) {
+				delete(chainPeers, id)
+				rawdb.DeleteIPLDPeer(udb.ldb, chainid, id)
+				evicted++
+			}
+		}
+		if len(chainPeers) == 0 {
+			delete(udb.ipldPeers, chainid)
+		}
+	}
+	return evicted
+}
+
+// writeIPLDPeer persists the book entry of an IPFS peer. The caller must hold
+// the lock.
+func (udb *Userdb) writeIPLDPeer(id common.IPLDPeerID, config PeerConfig) {
+	rawdb.WriteIPLDPeer(udb.ldb, &rawdb.IPLDPeerEntry{
+		ChainID:     config.chainid,
+		Peer:        id,
+		BlockNumber: config.blocknum,
+		LastSeen:    uint64(config.seen.Unix()),
+		Failures:    uint64(config.failures),
+	})
+}
+
+// peer converts the book entry of an IPFS peer to its description.
+func (config PeerConfig) peer(id common.IPLDPeerID) IPLDPeer {
+	return IPLDPeer{
+		ID:          id,
+		ChainID:     config.chainid,
+		BlockNumber: config.blocknum,
+		LastSeen:    config.seen,
+		Failures:    config.failures,
+	}
+}
+
+// IPLDPeers returns the known IPFS peers of all chains, without duplicates.
+func (udb *Userdb) IPLDPeers() []common.IPLDPeerID {
+	udb.lock.RLock()
+	defer udb.lock.RUnlock()
+
+	var (
+		peers []common.IPLDPeerID
+		seen  = make(map[common.IPLDPeerID]bool)
+	)
+	for _, chainPeers := range udb.ipldPeers {
+		for peer := range chainPeers {
+			if !seen[peer] {
+				seen[peer] = true
+				peers = append(peers, peer)
+			}
+		}
 	}
+	return peers
 }
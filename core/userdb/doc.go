@@ -34,13 +34,16 @@ type config struct{
 }
  
 5. dbIPLDPeers              map[ChainID]map[IPLDPeerID]config
-key: []byte("dbipldpeers")
-value: json.Marshal(map[ChainID]map[IPLDPeerID]config)
+key: []byte("ip-") + ChainID + IPLDPeerID, one entry per peer and chain
+value: rlp.Encode(rawdb.IPLDPeerEntry)
 var ChainID string
  
 type IPLDPeerID string
 type config struct{
     NickName [32]byte
+    BlockNum uint64  // highest block the peer was seen at, from mined blocks and handshakes
+    Seen time.Time   // last seen or answered a liveness check; evicted when stale or failing
+    Failures int     // liveness checks failed in a row
 }
  
 6. dbRelays             map[ChainID]map[RelaysMultipleAddr]config;// incllude timestampInRelaySwitchTimeUnit; timestamp is to selelct relays in the mutable ranges. 
@@ -102,4 +105,12 @@ value: string
 key: []byte("dbimmutablepoints")
 value: json.Marshal(map[ChainID]root)
 14. dbVotesCountingPoints   map[ChainID] root 
+
+15. dbTrafficDay / dbTrafficMonth    per protocol ingress and egress bytes of a day or month
+key: []byte("dbtrafficday:2006-01-02:" + protocol), []byte("dbtrafficmonth:2006-01:" + protocol)
+value: uvarint(ingress) ++ uvarint(egress)
+
+16. dbDataCap    per protocol daily or monthly byte limit, missing if uncapped
+key: []byte("dbdatacap:daily:" + protocol), []byte("dbdatacap:monthly:" + protocol)
+value: uvarint(limit)
 */
@@ -2,6 +2,7 @@ package userdb
 
 import (
 	"encoding/binary"
+	"time"
 )
 
 //Set
@@ -55,3 +56,106 @@ func (udb *Userdb) GetTMDownloadSize() (uint64, error) {
 	value, _ := binary.Uvarint(v)
 	return  value, nil
 }
+
+// Keys of the traffic totals, suffixed by the day or month and the protocol.
+const (
+	dbDailyTraffic   = "dbtrafficday:"
+	dbMonthlyTraffic = "dbtrafficmonth:"
+	dbDataCap        = "dbdatacap:"
+)
+
+// trafficKey returns the key of a traffic total of a protocol in a period.
+func trafficKey(prefix, period, protocol string) []byte {
+	return []byte(prefix + period + ":" + protocol)
+}
+
+// dataCapKey returns the key of a data cap of a protocol.
+func dataCapKey(protocol string, monthly bool) []byte {
+	if monthly {
+		return []byte(dbDataCap + "monthly:" + protocol)
+	}
+	return []byte(dbDataCap + "daily:" + protocol)
+}
+
+// getCounters retrieves an ingress and egress counter pair, zero if missing.
+func (udb *Userdb) getCounters(key []byte) (uint64, uint64) {
+	v, err := udb.ldb.Get(key)
+	if err != nil {
+		return 0, 0
+	}
+	ingress, n := binary.Uvarint(v)
+	if n <= 0 {
+		return 0, 0
+	}
+	egress, _ := binary.Uvarint(v[n:])
+	return ingress, egress
+}
+
+// addCounters increments an ingress and egress counter pair.
+func (udb *Userdb) addCounters(key []byte, ingress, egress uint64) error {
+	oldIngress, oldEgress := udb.getCounters(key)
+
+	v := make([]byte, 2*binary.MaxVarintLen64)
+	n := binary.PutUvarint(v, oldIngress+ingress)
+	n += binary.PutUvarint(v[n:], oldEgress+egress)
+	return udb.ldb.Put(key, v[:n])
+}
+
+// AddTraffic adds the bytes exchanged over a protocol to the totals of the day
+// and the month of the given time. Traffic of the tau protocol also counts
+// towards the transaction and mining download total, and IPFS traffic towards
+// the file download and upload totals.
+func (udb *Userdb) AddTraffic(protocol string, at time.Time, ingress, egress uint64) error {
+	if err := udb.addCounters(trafficKey(dbDailyTraffic, at.Format("2006-01-02"), protocol), ingress, egress); err != nil {
+		return err
+	}
+	if err := udb.addCounters(trafficKey(dbMonthlyTraffic, at.Format("2006-01"), protocol), ingress, egress); err != nil {
+		return err
+	}
+	switch protocol {
+	case "tau":
+		total, _ := udb.GetTMDownloadSize()
+		return udb.SetTMDownloadSize(total + ingress)
+	case "ipfs":
+		download, _ := udb.GetFileDownloadSize()
+		if err := udb.SetFileDownloadSize(download + ingress); err != nil {
+			return err
+		}
+		upload, _ := udb.GetFileUploadSize()
+		return udb.SetFileUploadSize(upload + egress)
+	}
+	return nil
+}
+
+// DailyTraffic retrieves the bytes exchanged over a protocol on the day of the
+// given time.
+func (udb *Userdb) DailyTraffic(protocol string, day time.Time) (uint64, uint64) {
+	return udb.getCounters(trafficKey(dbDailyTraffic, day.Format("2006-01-02"), protocol))
+}
+
+// MonthlyTraffic retrieves the bytes exchanged over a protocol in the month of
+// the given time.
+func (udb *Userdb) MonthlyTraffic(protocol string, month time.Time) (uint64, uint64) {
+	return udb.getCounters(trafficKey(dbMonthlyTraffic, month.Format("2006-01"), protocol))
+}
+
+// SetDataCap sets the daily or monthly limit of bytes exchanged over a protocol.
+// A zero limit removes the cap.
+func (udb *Userdb) SetDataCap(protocol string, monthly bool, limit uint64) error {
+	if limit == 0 {
+		return udb.ldb.Delete(dataCapKey(protocol, monthly))
+	}
+	v := make([]byte, binary.MaxVarintLen64)
+	return udb.ldb.Put(dataCapKey(protocol, monthly), v[:binary.PutUvarint(v, limit)])
+}
+
+// DataCap retrieves the daily or monthly limit of bytes exchanged over a
+// protocol, zero if uncapped.
+func (udb *Userdb) DataCap(protocol string, monthly bool) uint64 {
+	v, err := udb.ldb.Get(dataCapKey(protocol, monthly))
+	if err != nil {
+		return 0
+	}
+	limit, _ := binary.Uvarint(v)
+	return limit
+}
@@ -30,6 +30,9 @@ type StateDB interface {
 	AddBalance(common.Address, *big.Int)
 	GetBalance(common.Address) *big.Int
 
+	// AddBurned records fees destroyed instead of being paid out.
+	AddBurned(*big.Int)
+
 	GetNonce(common.Address) uint64
 	SetNonce(common.Address, uint64)
 
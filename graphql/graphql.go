@@ -0,0 +1,1032 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package graphql provides a GraphQL interface to TAU node data.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/chaindir"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/internal/tauapi"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/filters"
+)
+
+var errBlockInvariant = errors.New("block objects must be instantiated with at least one of num or hash")
+
+// Backend is the node access needed by the GraphQL resolvers.
+type Backend interface {
+	tauapi.Backend
+	SubscribeChainTxsEvent(ch chan<- core.ChainTxsEvent) event.Subscription
+	ChainDirectory() *chaindir.Directory
+}
+
+// Long is a 64 bit integer.
+type Long int64
+
+// ImplementsGraphQLType returns true if Long implements the provided GraphQL type.
+func (b Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (b *Long) UnmarshalGraphQL(input interface{}) error {
+	var err error
+	switch input := input.(type) {
+	case string:
+		// Apply leniency and support hex representations of longs
+		if strings.HasPrefix(input, "0x") {
+			value, err := hexutil.DecodeUint64(input)
+			*b = Long(value)
+			return err
+		}
+		value, err := strconv.ParseInt(input, 10, 64)
+		*b = Long(value)
+		return err
+	case int32:
+		*b = Long(input)
+	case int64:
+		*b = Long(input)
+	default:
+		err = fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return err
+}
+
+// blockNumberOrLatest converts an optional block argument to a block number.
+func blockNumberOrLatest(number *Long) rpc.BlockNumber {
+	if number == nil {
+		return rpc.LatestBlockNumber
+	}
+	return rpc.BlockNumber(*number)
+}
+
+// Account represents a TAU account at a particular block.
+type Account struct {
+	backend     Backend
+	address     common.Address
+	blockNumber rpc.BlockNumber
+}
+
+func (a *Account) Address(ctx context.Context) (common.Address, error) {
+	return a.address, nil
+}
+
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	state, _, err := a.backend.StateAndHeaderByNumber(ctx, a.blockNumber)
+	if state == nil || err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*state.GetBalance(a.address)), state.Error()
+}
+
+func (a *Account) Nonce(ctx context.Context) (Long, error) {
+	if a.blockNumber == rpc.PendingBlockNumber {
+		nonce, err := a.backend.GetPoolNonce(ctx, a.address)
+		return Long(nonce), err
+	}
+	state, _, err := a.backend.StateAndHeaderByNumber(ctx, a.blockNumber)
+	if state == nil || err != nil {
+		return 0, err
+	}
+	return Long(state.GetNonce(a.address)), state.Error()
+}
+
+func (a *Account) Profile(ctx context.Context) (*Profile, error) {
+	profile, err := tauapi.NewPublicProfileAPI(a.backend).GetProfile(ctx, a.address, a.blockNumber)
+	if profile == nil || err != nil {
+		return nil, err
+	}
+	return &Profile{a.backend, profile}, nil
+}
+
+func (a *Account) History(ctx context.Context, args struct {
+	FromBlock *Long
+	ToBlock   *Long
+}) ([]*Transaction, error) {
+	from, to := blockNumberOrLatest(args.FromBlock), blockNumberOrLatest(args.ToBlock)
+	crit := filters.FilterCriteria{
+		FromBlock: &from,
+		ToBlock:   &to,
+		Senders:   []common.Address{a.address},
+	}
+	sent, err := filters.HistoricalTxs(ctx, a.backend, crit)
+	if err != nil {
+		return nil, err
+	}
+	crit.Senders, crit.Receivers = nil, []common.Address{a.address}
+	received, err := filters.HistoricalTxs(ctx, a.backend, crit)
+	if err != nil {
+		return nil, err
+	}
+	// Merge both lists, dropping transfers to self reported twice
+	seen := make(map[common.Hash]bool, len(sent))
+	events := append([]*filters.TxEvent{}, sent...)
+	for _, ev := range sent {
+		seen[ev.Hash] = true
+	}
+	for _, ev := range received {
+		if !seen[ev.Hash] {
+			events = append(events, ev)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return *events[i].BlockNumber < *events[j].BlockNumber
+	})
+	return txsFromEvents(a.backend, events), nil
+}
+
+func (a *Account) ProfileHistory(ctx context.Context) []*Transaction {
+	entries := rawdb.ReadProfileHistory(a.backend.ChainDb(), a.address)
+
+	txs := make([]*Transaction, len(entries))
+	for i, entry := range entries {
+		txs[i] = &Transaction{backend: a.backend, hash: entry.TxHash}
+	}
+	return txs
+}
+
+// Profile represents the on-chain profile of an account.
+type Profile struct {
+	backend Backend
+	profile *tauapi.RPCProfile
+}
+
+func (p *Profile) Account(ctx context.Context) *Account {
+	return &Account{
+		backend:     p.backend,
+		address:     p.profile.Address,
+		blockNumber: rpc.LatestBlockNumber,
+	}
+}
+
+func (p *Profile) Name(ctx context.Context) string {
+	return p.profile.Name
+}
+
+func (p *Profile) ContactName(ctx context.Context) hexutil.Bytes {
+	return p.profile.ContactName
+}
+
+func (p *Profile) Cid(ctx context.Context) hexutil.Bytes {
+	return p.profile.CID
+}
+
+func (p *Profile) Block(ctx context.Context) *Block {
+	number := rpc.BlockNumber(p.profile.BlockNumber)
+	return &Block{backend: p.backend, numberOrHash: &number}
+}
+
+// Transaction represents a TAU transaction. It is resolved lazily from its
+// hash, looking into the including block first if one is known.
+type Transaction struct {
+	backend Backend
+	hash    common.Hash
+	tx      types.Transaction
+	block   *Block
+	index   uint64
+}
+
+// resolve returns the internal transaction object, fetching it if needed.
+func (t *Transaction) resolve(ctx context.Context) (types.Transaction, error) {
+	if t.tx != nil {
+		return t.tx, nil
+	}
+	// Look in the known including block first, it may no longer be canonical
+	if t.block != nil {
+		block, err := t.block.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			for i, tx := range block.Transactions() {
+				if (*tx).Hash() == t.hash {
+					t.tx, t.index = *tx, uint64(i)
+					return t.tx, nil
+				}
+			}
+		}
+	}
+	// Fall back to the transaction index and the pool
+	tx, blockHash, _, index, err := t.backend.GetTransaction(ctx, t.hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		t.tx, t.index = *tx, index
+		t.block = &Block{backend: t.backend, hash: blockHash}
+		return t.tx, nil
+	}
+	if tx := t.backend.GetPoolTransaction(t.hash); tx != nil {
+		t.tx, t.block = *tx, nil
+	}
+	return t.tx, nil
+}
+
+func (t *Transaction) Hash(ctx context.Context) common.Hash {
+	return t.hash
+}
+
+func (t *Transaction) Kind(ctx context.Context) (string, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return "", err
+	}
+	return kindToEnum(types.KindOf(tx)), nil
+}
+
+func (t *Transaction) Nonce(ctx context.Context) (Long, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return Long(tx.Nonce()), nil
+}
+
+func (t *Transaction) Fee(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tx.Fee()), nil
+}
+
+func (t *Transaction) ChainID(ctx context.Context) (hexutil.Bytes, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return hexutil.Bytes(bytes.TrimRight(tx.ChainId(), "\x00")), nil
+}
+
+func (t *Transaction) From(ctx context.Context, args struct{ Block *Long }) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return &Account{
+		backend:     t.backend,
+		address:     types.SenderOf(tx),
+		blockNumber: blockNumberOrLatest(args.Block),
+	}, nil
+}
+
+func (t *Transaction) Block(ctx context.Context) (*Block, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return t.block, nil
+}
+
+func (t *Transaction) Index(ctx context.Context) (*int32, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	index := int32(t.index)
+	return &index, nil
+}
+
+func (t *Transaction) Transfer(ctx context.Context) (*Transfer, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transfer, ok := tx.(*types.TransferTx)
+	if !ok {
+		return nil, nil
+	}
+	return &Transfer{t.backend, transfer}, nil
+}
+
+func (t *Transaction) Message(ctx context.Context) (*Message, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	message, ok := tx.(*types.NewMessageTx)
+	if !ok {
+		return nil, nil
+	}
+	return &Message{t.backend, message}, nil
+}
+
+func (t *Transaction) Announcement(ctx context.Context) (*ChainAnnouncement, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	announcement, ok := tx.(*types.NewChainTx)
+	if !ok {
+		return nil, nil
+	}
+	return &ChainAnnouncement{t.backend, announcement}, nil
+}
+
+func (t *Transaction) Profile(ctx context.Context) (*ProfileUpdate, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	update, ok := tx.(*types.PersonalInfoTx)
+	if !ok {
+		return nil, nil
+	}
+	return &ProfileUpdate{update}, nil
+}
+
+// txsFromEvents converts filter notifications into lazily resolved transactions.
+func txsFromEvents(backend Backend, events []*filters.TxEvent) []*Transaction {
+	txs := make([]*Transaction, len(events))
+	for i, ev := range events {
+		txs[i] = txFromEvent(backend, ev)
+	}
+	return txs
+}
+
+func txFromEvent(backend Backend, ev *filters.TxEvent) *Transaction {
+	tx := &Transaction{backend: backend, hash: ev.Hash}
+	if ev.BlockHash != nil {
+		tx.block = &Block{backend: backend, hash: *ev.BlockHash}
+	}
+	return tx
+}
+
+// Transfer is the payload of a transfer transaction.
+type Transfer struct {
+	backend Backend
+	tx      *types.TransferTx
+}
+
+func (t *Transfer) To(ctx context.Context) *Account {
+	var to common.Address
+	if receiver := types.ReceiverOf(t.tx); receiver != nil {
+		to = *receiver
+	}
+	return &Account{backend: t.backend, address: to, blockNumber: rpc.LatestBlockNumber}
+}
+
+func (t *Transfer) Amount(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*t.tx.Value())
+}
+
+// Message is the payload of a message transaction.
+type Message struct {
+	backend Backend
+	tx      *types.NewMessageTx
+}
+
+func (m *Message) ReferID(ctx context.Context) *common.Hash {
+	if refer := m.tx.Referid(); refer != (common.Hash{}) {
+		return &refer
+	}
+	return nil
+}
+
+func (m *Message) Thread(ctx context.Context) common.Hash {
+	return rawdb.MessageThread(m.backend.ChainDb(), m.tx)
+}
+
+func (m *Message) Title(ctx context.Context) string {
+	return trimPadding(m.tx.Title())
+}
+
+func (m *Message) Content(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(m.tx.Content())
+}
+
+func (m *Message) ReplyTo(ctx context.Context) *Transaction {
+	if refer := m.tx.Referid(); refer != (common.Hash{}) {
+		return &Transaction{backend: m.backend, hash: refer}
+	}
+	return nil
+}
+
+// ChainAnnouncement is the payload of a new chain transaction.
+type ChainAnnouncement struct {
+	backend Backend
+	tx      *types.NewChainTx
+}
+
+func (c *ChainAnnouncement) ID(ctx context.Context) string {
+	id := c.tx.NewChainID()
+	return string(id[:])
+}
+
+func (c *ChainAnnouncement) Name(ctx context.Context) string {
+	return trimPadding(c.tx.Name())
+}
+
+func (c *ChainAnnouncement) Contact(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(c.tx.Contact())
+}
+
+func (c *ChainAnnouncement) Title(ctx context.Context) string {
+	return trimPadding(c.tx.Title())
+}
+
+func (c *ChainAnnouncement) Description(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(c.tx.Description())
+}
+
+func (c *ChainAnnouncement) Chain(ctx context.Context) (*Chain, error) {
+	chain, err := chaindir.NewPublicChainDirectoryAPI(c.backend.ChainDirectory()).GetChain(c.ID(ctx))
+	if chain == nil || err != nil {
+		return nil, err
+	}
+	return &Chain{c.backend, chain}, nil
+}
+
+// ProfileUpdate is the payload of a profile transaction.
+type ProfileUpdate struct {
+	tx *types.PersonalInfoTx
+}
+
+func (p *ProfileUpdate) ContactName(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(p.tx.ContactName())
+}
+
+func (p *ProfileUpdate) Name(ctx context.Context) string {
+	return trimPadding(p.tx.Name())
+}
+
+func (p *ProfileUpdate) Profile(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(p.tx.Profile())
+}
+
+// Block represents a TAU block. Exactly one of numberOrHash or hash is used to
+// look it up.
+type Block struct {
+	backend      Backend
+	numberOrHash *rpc.BlockNumber
+	hash         common.Hash
+	header       *types.Header
+	block        *types.Block
+}
+
+// resolve returns the internal Block object representing this block, fetching
+// it if necessary.
+func (b *Block) resolve(ctx context.Context) (*types.Block, error) {
+	if b.block != nil {
+		return b.block, nil
+	}
+	var err error
+	if b.hash != (common.Hash{}) {
+		b.block, err = b.backend.BlockByHash(ctx, b.hash)
+	} else if b.numberOrHash != nil {
+		b.block, err = b.backend.BlockByNumber(ctx, *b.numberOrHash)
+	} else {
+		return nil, errBlockInvariant
+	}
+	if b.block != nil {
+		b.hash = b.block.Hash()
+		b.header = b.block.Header()
+	}
+	return b.block, err
+}
+
+// resolveHeader returns the internal Header object for this block, fetching it
+// if necessary. Call this function instead of `resolve` unless you need the
+// additional data (transactions).
+func (b *Block) resolveHeader(ctx context.Context) (*types.Header, error) {
+	if b.header != nil {
+		return b.header, nil
+	}
+	var err error
+	if b.hash != (common.Hash{}) {
+		b.header, err = b.backend.HeaderByHash(ctx, b.hash)
+	} else if b.numberOrHash != nil {
+		b.header, err = b.backend.HeaderByNumber(ctx, *b.numberOrHash)
+	} else {
+		return nil, errBlockInvariant
+	}
+	if b.header == nil {
+		if err == nil {
+			err = errors.New("block not found")
+		}
+		return nil, err
+	}
+	b.hash = b.header.Hash()
+	return b.header, nil
+}
+
+func (b *Block) Number(ctx context.Context) (Long, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(header.Number.Int64()), nil
+}
+
+func (b *Block) Hash(ctx context.Context) (common.Hash, error) {
+	if _, err := b.resolveHeader(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	return b.hash, nil
+}
+
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if header.Number.Sign() == 0 {
+		return nil, nil
+	}
+	return &Block{backend: b.backend, hash: header.ParentHash}, nil
+}
+
+func (b *Block) Version(ctx context.Context) (int32, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int32(header.Version), nil
+}
+
+func (b *Block) ChainID(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.ChainID, nil
+}
+
+func (b *Block) Miner(ctx context.Context, args struct{ Block *Long }) (*Account, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blockNumber := rpc.BlockNumber(header.Number.Int64())
+	if args.Block != nil {
+		blockNumber = rpc.BlockNumber(*args.Block)
+	}
+	return &Account{backend: b.backend, address: header.Coinbase, blockNumber: blockNumber}, nil
+}
+
+func (b *Block) Timestamp(ctx context.Context) (Long, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(header.Time), nil
+}
+
+func (b *Block) Difficulty(ctx context.Context) (hexutil.Big, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*header.Difficulty), nil
+}
+
+func (b *Block) BaseTarget(ctx context.Context) (hexutil.Big, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*header.BaseTarget), nil
+}
+
+func (b *Block) StateRoot(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Root, nil
+}
+
+func (b *Block) TransactionsRoot(ctx context.Context) (common.Hash, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.TxHash, nil
+}
+
+func (b *Block) TransactionCount(ctx context.Context) (int32, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return int32(len(block.Transactions())), nil
+}
+
+func (b *Block) Transactions(ctx context.Context) ([]*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	txs := make([]*Transaction, 0, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		txs = append(txs, &Transaction{
+			backend: b.backend,
+			hash:    (*tx).Hash(),
+			tx:      *tx,
+			block:   b,
+			index:   uint64(i),
+		})
+	}
+	return txs, nil
+}
+
+func (b *Block) TransactionAt(ctx context.Context, args struct{ Index int32 }) (*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if args.Index < 0 || int(args.Index) >= len(txs) {
+		return nil, nil
+	}
+	tx := txs[args.Index]
+	return &Transaction{
+		backend: b.backend,
+		hash:    (*tx).Hash(),
+		tx:      *tx,
+		block:   b,
+		index:   uint64(args.Index),
+	}, nil
+}
+
+func (b *Block) Account(ctx context.Context, args struct{ Address common.Address }) (*Account, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		backend:     b.backend,
+		address:     args.Address,
+		blockNumber: rpc.BlockNumber(header.Number.Int64()),
+	}, nil
+}
+
+// Chain represents the directory entry of a community chain.
+type Chain struct {
+	backend Backend
+	chain   *chaindir.RPCChain
+}
+
+func (c *Chain) ID(ctx context.Context) string {
+	return c.chain.ID
+}
+
+func (c *Chain) Name(ctx context.Context) string {
+	return c.chain.Name
+}
+
+func (c *Chain) Contact(ctx context.Context) hexutil.Bytes {
+	return c.chain.Contact
+}
+
+func (c *Chain) Title(ctx context.Context) string {
+	return c.chain.Title
+}
+
+func (c *Chain) Description(ctx context.Context) hexutil.Bytes {
+	return c.chain.Description
+}
+
+func (c *Chain) Creator(ctx context.Context) *Account {
+	return &Account{backend: c.backend, address: c.chain.Creator, blockNumber: rpc.LatestBlockNumber}
+}
+
+func (c *Chain) Block(ctx context.Context) *Block {
+	return &Block{backend: c.backend, hash: c.chain.BlockHash}
+}
+
+func (c *Chain) Announcement(ctx context.Context) *Transaction {
+	return &Transaction{
+		backend: c.backend,
+		hash:    c.chain.TxHash,
+		block:   &Block{backend: c.backend, hash: c.chain.BlockHash},
+	}
+}
+
+func (c *Chain) Following(ctx context.Context) bool {
+	return c.chain.Following
+}
+
+func (c *Chain) Duplicates(ctx context.Context) []string {
+	if c.chain.Duplicates == nil {
+		return []string{}
+	}
+	return c.chain.Duplicates
+}
+
+// TxPool represents the transaction pool of the node.
+type TxPool struct {
+	backend Backend
+}
+
+func (p *TxPool) Pending(ctx context.Context) int32 {
+	pending, _ := p.backend.Stats()
+	return int32(pending)
+}
+
+func (p *TxPool) Queued(ctx context.Context) int32 {
+	_, queued := p.backend.Stats()
+	return int32(queued)
+}
+
+func (p *TxPool) Transactions(ctx context.Context) ([]*Transaction, error) {
+	pending, err := p.backend.GetPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*Transaction, len(pending))
+	for i, tx := range pending {
+		txs[i] = &Transaction{backend: p.backend, hash: (*tx).Hash(), tx: *tx}
+	}
+	return txs, nil
+}
+
+// TxFilterInput is the GraphQL representation of filters.FilterCriteria.
+type TxFilterInput struct {
+	FromBlock *Long
+	ToBlock   *Long
+	Kinds     *[]string
+	Senders   *[]common.Address
+	Receivers *[]common.Address
+	ChainIDs  *[]hexutil.Bytes
+	Threads   *[]common.Hash
+}
+
+// criteria converts the filter input into filter system criteria.
+func (input *TxFilterInput) criteria() (filters.FilterCriteria, error) {
+	var crit filters.FilterCriteria
+	if input == nil {
+		return crit, nil
+	}
+	if input.FromBlock != nil {
+		from := rpc.BlockNumber(*input.FromBlock)
+		crit.FromBlock = &from
+	}
+	if input.ToBlock != nil {
+		to := rpc.BlockNumber(*input.ToBlock)
+		crit.ToBlock = &to
+	}
+	if input.Kinds != nil {
+		for _, name := range *input.Kinds {
+			kind, err := enumToKind(name)
+			if err != nil {
+				return crit, err
+			}
+			crit.Kinds = append(crit.Kinds, kind)
+		}
+	}
+	if input.Senders != nil {
+		crit.Senders = *input.Senders
+	}
+	if input.Receivers != nil {
+		crit.Receivers = *input.Receivers
+	}
+	if input.ChainIDs != nil {
+		crit.ChainIDs = *input.ChainIDs
+	}
+	if input.Threads != nil {
+		crit.Threads = *input.Threads
+	}
+	return crit, nil
+}
+
+// maxBlocksRange is the maximum number of blocks returned by a single blocks
+// query.
+const maxBlocksRange = 1024
+
+// Resolver is the root resolver of the GraphQL schema.
+type Resolver struct {
+	backend Backend
+	events  *filters.EventSystem
+}
+
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Number *Long
+	Hash   *common.Hash
+}) (*Block, error) {
+	var block *Block
+	if args.Number != nil {
+		number := rpc.BlockNumber(*args.Number)
+		block = &Block{backend: r.backend, numberOrHash: &number}
+	} else if args.Hash != nil {
+		block = &Block{backend: r.backend, hash: *args.Hash}
+	} else {
+		number := rpc.LatestBlockNumber
+		block = &Block{backend: r.backend, numberOrHash: &number}
+	}
+	// Resolve the header, return nil if it doesn't exist.
+	// Note we don't resolve block directly here since it will require an
+	// additional network request for light client.
+	h, err := block.resolveHeader(ctx)
+	if err != nil || h == nil {
+		return nil, nil
+	}
+	return block, nil
+}
+
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	From Long
+	To   *Long
+}) ([]*Block, error) {
+	head := rpc.BlockNumber(r.backend.CurrentBlock().Number().Int64())
+	to := head
+	if args.To != nil && rpc.BlockNumber(*args.To) < head {
+		to = rpc.BlockNumber(*args.To)
+	}
+	from := rpc.BlockNumber(args.From)
+	if from < 0 {
+		from = 0
+	}
+	if to < from {
+		return []*Block{}, nil
+	}
+	if to-from >= maxBlocksRange {
+		to = from + maxBlocksRange - 1
+	}
+	var ret []*Block
+	for i := from; i <= to; i++ {
+		number := i
+		ret = append(ret, &Block{backend: r.backend, numberOrHash: &number})
+	}
+	return ret, nil
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx := &Transaction{backend: r.backend, hash: args.Hash}
+	// Resolve the transaction; if it doesn't exist, return nil.
+	t, err := tx.resolve(ctx)
+	if err != nil {
+		return nil, err
+	} else if t == nil {
+		return nil, nil
+	}
+	return tx, nil
+}
+
+func (r *Resolver) Transactions(ctx context.Context, args struct{ Filter TxFilterInput }) ([]*Transaction, error) {
+	crit, err := args.Filter.criteria()
+	if err != nil {
+		return nil, err
+	}
+	events, err := filters.HistoricalTxs(ctx, r.backend, crit)
+	if err != nil {
+		return nil, err
+	}
+	return txsFromEvents(r.backend, events), nil
+}
+
+func (r *Resolver) Account(ctx context.Context, args struct {
+	Address common.Address
+	Block   *Long
+}) *Account {
+	return &Account{
+		backend:     r.backend,
+		address:     args.Address,
+		blockNumber: blockNumberOrLatest(args.Block),
+	}
+}
+
+func (r *Resolver) Profiles(ctx context.Context, args struct{ Prefix string }) ([]*Profile, error) {
+	profiles, err := tauapi.NewPublicProfileAPI(r.backend).SearchProfiles(ctx, args.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Profile, len(profiles))
+	for i, profile := range profiles {
+		ret[i] = &Profile{r.backend, profile}
+	}
+	return ret, nil
+}
+
+func (r *Resolver) Thread(ctx context.Context, args struct {
+	Root      common.Hash
+	FromBlock *Long
+	ToBlock   *Long
+}) ([]*Transaction, error) {
+	from, to := blockNumberOrLatest(args.FromBlock), blockNumberOrLatest(args.ToBlock)
+	crit := filters.FilterCriteria{
+		FromBlock: &from,
+		ToBlock:   &to,
+		Kinds:     []types.TxKind{types.NewMessageTxKind},
+		Threads:   []common.Hash{args.Root},
+	}
+	events, err := filters.HistoricalTxs(ctx, r.backend, crit)
+	if err != nil {
+		return nil, err
+	}
+	return txsFromEvents(r.backend, events), nil
+}
+
+func (r *Resolver) Chain(ctx context.Context, args struct{ ID string }) (*Chain, error) {
+	chain, err := chaindir.NewPublicChainDirectoryAPI(r.backend.ChainDirectory()).GetChain(args.ID)
+	if chain == nil || err != nil {
+		return nil, err
+	}
+	return &Chain{r.backend, chain}, nil
+}
+
+func (r *Resolver) Chains(ctx context.Context, args struct {
+	After *string
+	Limit *int32
+}) ([]*Chain, error) {
+	var limit *hexutil.Uint
+	if args.Limit != nil {
+		if *args.Limit < 0 {
+			return nil, fmt.Errorf("negative limit %d", *args.Limit)
+		}
+		l := hexutil.Uint(*args.Limit)
+		limit = &l
+	}
+	chains, err := chaindir.NewPublicChainDirectoryAPI(r.backend.ChainDirectory()).ListChains(args.After, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.wrapChains(chains), nil
+}
+
+func (r *Resolver) SearchChains(ctx context.Context, args struct{ Prefix string }) ([]*Chain, error) {
+	return r.wrapChains(chaindir.NewPublicChainDirectoryAPI(r.backend.ChainDirectory()).SearchChains(args.Prefix)), nil
+}
+
+func (r *Resolver) wrapChains(chains []*chaindir.RPCChain) []*Chain {
+	ret := make([]*Chain, len(chains))
+	for i, chain := range chains {
+		ret[i] = &Chain{r.backend, chain}
+	}
+	return ret
+}
+
+func (r *Resolver) Txpool(ctx context.Context) *TxPool {
+	return &TxPool{r.backend}
+}
+
+func (r *Resolver) SuggestedFee(ctx context.Context) (hexutil.Big, error) {
+	fee, err := r.backend.SuggestPrice(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*fee), nil
+}
+
+func (r *Resolver) SendRawTransaction(ctx context.Context, args struct{ Data hexutil.Bytes }) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(args.Data, tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tauapi.SubmitTransaction(ctx, r.backend, tx)
+}
+
+// kindToEnum returns the GraphQL enum value of a transaction kind.
+func kindToEnum(kind types.TxKind) string {
+	return strings.ToUpper(kind.String())
+}
+
+// enumToKind parses a GraphQL enum value into a transaction kind.
+func enumToKind(name string) (types.TxKind, error) {
+	var kind types.TxKind
+	switch name {
+	case "TRANSFER":
+		kind = types.TransferTxKind
+	case "MESSAGE":
+		kind = types.NewMessageTxKind
+	case "CHAIN":
+		kind = types.NewChainTxKind
+	case "PROFILE":
+		kind = types.PersonalInfoTxKind
+	default:
+		return kind, fmt.Errorf("unknown transaction kind %q", name)
+	}
+	return kind, nil
+}
+
+// trimPadding strips the zero padding of a fixed size text field.
+func trimPadding(field []byte) string {
+	return string(bytes.TrimRight(field, "\x00"))
+}
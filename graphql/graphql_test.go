@@ -0,0 +1,252 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/graph-gophers/graphql-go"
+)
+
+var (
+	testSender   = common.HexToAddress("0x01")
+	testReceiver = common.HexToAddress("0x02")
+)
+
+// testBackend serves a canonical chain with a transfer in every block but the
+// genesis.
+type testBackend struct {
+	Backend
+	blocks []*types.Block
+}
+
+func newTestBackend(n int) *testBackend {
+	b := &testBackend{blocks: []*types.Block{types.NewBlock(&types.Header{Number: big.NewInt(0), Difficulty: big.NewInt(1)}, nil)}}
+	for i := 1; i < n; i++ {
+		var tx types.Transaction = types.NewTransferTransaction(types.OneByte{1}, types.OneByte{1}, types.Byte32s("tau"), uint64(i-1), 0, big.NewInt(1), testSender, testReceiver, big.NewInt(10))
+		parent := b.blocks[i-1]
+		b.blocks = append(b.blocks, types.NewBlock(&types.Header{
+			Number:     big.NewInt(int64(i)),
+			Difficulty: big.NewInt(1),
+			ParentHash: parent.Hash(),
+		}, []*types.Transaction{&tx}))
+	}
+	return b
+}
+
+func (b *testBackend) CurrentBlock() *types.Block {
+	return b.blocks[len(b.blocks)-1]
+}
+
+func (b *testBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	if number < 0 {
+		return b.CurrentBlock(), nil
+	}
+	if int(number) >= len(b.blocks) {
+		return nil, nil
+	}
+	return b.blocks[number], nil
+}
+
+func (b *testBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	for _, block := range b.blocks {
+		if block.Hash() == hash {
+			return block, nil
+		}
+	}
+	return nil, nil
+}
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if block, _ := b.BlockByNumber(ctx, number); block != nil {
+		return block.Header(), nil
+	}
+	return nil, nil
+}
+
+func (b *testBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	if block, _ := b.BlockByHash(ctx, hash); block != nil {
+		return block.Header(), nil
+	}
+	return nil, nil
+}
+
+func (b *testBackend) GetTransaction(ctx context.Context, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
+	for _, block := range b.blocks {
+		for i, tx := range block.Transactions() {
+			if (*tx).Hash() == hash {
+				return tx, block.Hash(), block.NumberU64(), uint64(i), nil
+			}
+		}
+	}
+	return nil, common.Hash{}, 0, 0, nil
+}
+
+func (b *testBackend) GetPoolTransaction(hash common.Hash) *types.Transaction {
+	return nil
+}
+
+// query runs a GraphQL query against the backend and decodes its result.
+func query(t *testing.T, backend Backend, q string, result interface{}) {
+	parsed, err := graphql.ParseSchema(schema, &Resolver{backend: backend})
+	if err != nil {
+		t.Fatalf("could not create schema: %v", err)
+	}
+	res := parsed.Exec(context.Background(), q, "", nil)
+	if len(res.Errors) > 0 {
+		t.Fatalf("query %q failed: %v", q, res.Errors)
+	}
+	if err := json.Unmarshal(res.Data, result); err != nil {
+		t.Fatalf("failed to decode result of %q: %v", q, err)
+	}
+}
+
+// Tests that the schema matches the resolvers, catching any field or argument
+// that is missing on either side.
+func TestBuildSchema(t *testing.T) {
+	if _, err := graphql.ParseSchema(schema, &Resolver{}); err != nil {
+		t.Fatalf("could not create schema: %v", err)
+	}
+}
+
+func TestLongUnmarshal(t *testing.T) {
+	tests := []struct {
+		input interface{}
+		want  Long
+		fail  bool
+	}{
+		{input: int32(7), want: 7},
+		{input: int64(1 << 40), want: 1 << 40},
+		{input: "12", want: 12},
+		{input: "0x10", want: 16},
+		{input: "0xzz", fail: true},
+		{input: 1.5, fail: true},
+	}
+	for i, tt := range tests {
+		var have Long
+		err := have.UnmarshalGraphQL(tt.input)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("test %d: expected error for %v", i, tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if have != tt.want {
+			t.Errorf("test %d: value mismatch: have %d, want %d", i, have, tt.want)
+		}
+	}
+}
+
+func TestKindEnum(t *testing.T) {
+	kinds := []types.TxKind{types.TransferTxKind, types.NewMessageTxKind, types.NewChainTxKind, types.PersonalInfoTxKind}
+	for _, kind := range kinds {
+		have, err := enumToKind(kindToEnum(kind))
+		if err != nil {
+			t.Fatalf("%v: failed to parse enum: %v", kind, err)
+		}
+		if have != kind {
+			t.Errorf("kind mismatch: have %v, want %v", have, kind)
+		}
+	}
+	if _, err := enumToKind("BOGUS"); err == nil {
+		t.Errorf("unknown kind accepted")
+	}
+}
+
+// Tests that block ranges are capped at the chain head and at the maximum
+// number of blocks per query.
+func TestBlocksQuery(t *testing.T) {
+	backend := newTestBackend(maxBlocksRange + 10)
+	head := int64(maxBlocksRange + 9)
+
+	tests := []struct {
+		query       string
+		first, last int64
+		count       int
+	}{
+		{"{ blocks(from: 3, to: 5) { number } }", 3, 5, 3},
+		{fmt.Sprintf("{ blocks(from: %d) { number } }", head-1), head - 1, head, 2},
+		{fmt.Sprintf("{ blocks(from: %d, to: 1000000) { number } }", head-4), head - 4, head, 5},
+		{"{ blocks(from: 0) { number } }", 0, maxBlocksRange - 1, maxBlocksRange},
+		{"{ blocks(from: -5, to: 1) { number } }", 0, 1, 2},
+	}
+	for i, tt := range tests {
+		var result struct {
+			Blocks []struct{ Number int64 }
+		}
+		query(t, backend, tt.query, &result)
+		if len(result.Blocks) != tt.count {
+			t.Errorf("test %d: block count mismatch: have %d, want %d", i, len(result.Blocks), tt.count)
+			continue
+		}
+		if first, last := result.Blocks[0].Number, result.Blocks[len(result.Blocks)-1].Number; first != tt.first || last != tt.last {
+			t.Errorf("test %d: range mismatch: have %d-%d, want %d-%d", i, first, last, tt.first, tt.last)
+		}
+	}
+	var result struct {
+		Blocks []struct{ Number int64 }
+	}
+	query(t, backend, "{ blocks(from: 5, to: 3) { number } }", &result)
+	if len(result.Blocks) != 0 {
+		t.Errorf("inverted range returned %d blocks", len(result.Blocks))
+	}
+}
+
+func TestTransactionQueries(t *testing.T) {
+	backend := newTestBackend(5)
+	want := backend.blocks[3].Transactions()[0]
+
+	var single struct {
+		Transaction struct {
+			Kind  string
+			Nonce int64
+			Block struct{ Number int64 }
+			From  struct{ Address common.Address }
+		}
+	}
+	query(t, backend, fmt.Sprintf(`{ transaction(hash: "%s") { kind nonce block { number } from { address } } }`, (*want).Hash().Hex()), &single)
+	if tx := single.Transaction; tx.Kind != "TRANSFER" || tx.Nonce != 2 || tx.Block.Number != 3 || tx.From.Address != testSender {
+		t.Errorf("transaction mismatch: %+v", tx)
+	}
+
+	var filtered struct {
+		Transactions []struct {
+			Hash  common.Hash
+			Index int32
+		}
+	}
+	query(t, backend, fmt.Sprintf(`{ transactions(filter: {fromBlock: 2, toBlock: 3, senders: ["%s"]}) { hash index } }`, testSender.Hex()), &filtered)
+	if len(filtered.Transactions) != 2 {
+		t.Fatalf("transaction count mismatch: have %d, want 2", len(filtered.Transactions))
+	}
+	if filtered.Transactions[1].Hash != (*want).Hash() || filtered.Transactions[1].Index != 0 {
+		t.Errorf("filtered transaction mismatch: %+v", filtered.Transactions[1])
+	}
+	query(t, backend, fmt.Sprintf(`{ transactions(filter: {fromBlock: 0, senders: ["%s"]}) { hash } }`, testReceiver.Hex()), &filtered)
+	if len(filtered.Transactions) != 0 {
+		t.Errorf("unexpected transactions of receiver: %d", len(filtered.Transactions))
+	}
+}
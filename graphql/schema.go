@@ -0,0 +1,303 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+const schema string = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte TAU address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    # An empty byte string is represented as '0x'. Byte strings must have an even number of hexadecimal nybbles.
+    scalar Bytes
+    # BigInt is a large integer. Input is accepted as either a JSON number or as a string.
+    # Strings may be either decimal or 0x-prefixed hexadecimal. Output values are all
+    # 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit integer.
+    scalar Long
+
+    schema {
+        query: Query
+        mutation: Mutation
+        subscription: Subscription
+    }
+
+    # TransactionKind is the kind of a TAU transaction.
+    enum TransactionKind {
+        # TRANSFER moves coins between two accounts.
+        TRANSFER
+        # MESSAGE posts a message to a community chain.
+        MESSAGE
+        # CHAIN announces a new community chain.
+        CHAIN
+        # PROFILE publishes the profile of the sender.
+        PROFILE
+    }
+
+    # Account is a TAU account at a particular block.
+    type Account {
+        # Address is the address owning the account.
+        address: Address!
+        # Balance is the balance of the account, in tau.
+        balance: BigInt!
+        # Nonce is the number of transactions sent from this account.
+        nonce: Long!
+        # Profile is the latest profile published by the account, if any.
+        profile: Profile
+        # History lists the transactions sent or received by the account in
+        # the given block range, in ascending block order. Missing bounds
+        # select the latest block.
+        history(fromBlock: Long, toBlock: Long): [Transaction!]!
+        # ProfileHistory lists every profile update of the account in
+        # ascending block order.
+        profileHistory: [Transaction!]!
+    }
+
+    # Profile is the on-chain profile of an account.
+    type Profile {
+        # Account is the account owning the profile.
+        account: Account!
+        # Name is the display name of the account.
+        name: String!
+        # ContactName is the contact handle of the account.
+        contactName: Bytes!
+        # CID is the content identifier of the full profile.
+        cid: Bytes!
+        # Block is the block that last updated the profile.
+        block: Block
+    }
+
+    # Transfer is the payload of a TRANSFER transaction.
+    type Transfer {
+        # To is the account receiving the coins.
+        to: Account!
+        # Amount is the value transferred, in tau.
+        amount: BigInt!
+    }
+
+    # Message is the payload of a MESSAGE transaction.
+    type Message {
+        # ReferID is the hash of the message replied to, if any.
+        referID: Bytes32
+        # Thread is the hash of the message starting the thread.
+        thread: Bytes32!
+        # Title is the title of the message.
+        title: String!
+        # Content is the content identifier of the message body.
+        content: Bytes!
+        # ReplyTo is the message replied to, if any.
+        replyTo: Transaction
+    }
+
+    # ChainAnnouncement is the payload of a CHAIN transaction.
+    type ChainAnnouncement {
+        # ID is the id of the announced chain.
+        id: String!
+        # Name is the name of the announced chain.
+        name: String!
+        # Contact is the contact of the chain creator.
+        contact: Bytes!
+        # Title is the title of the chain.
+        title: String!
+        # Description is the content identifier of the chain description.
+        description: Bytes!
+        # Chain is the directory entry of the announced chain.
+        chain: Chain
+    }
+
+    # ProfileUpdate is the payload of a PROFILE transaction.
+    type ProfileUpdate {
+        # ContactName is the published contact handle.
+        contactName: Bytes!
+        # Name is the published display name.
+        name: String!
+        # Profile is the content identifier of the full profile.
+        profile: Bytes!
+    }
+
+    # Transaction is a TAU transaction of any kind.
+    type Transaction {
+        # Hash is the hash of this transaction.
+        hash: Bytes32!
+        # Kind is the kind of this transaction.
+        kind: TransactionKind!
+        # Nonce is the nonce of the account this transaction was generated with.
+        nonce: Long!
+        # Fee is the fee paid by this transaction, in tau.
+        fee: BigInt!
+        # ChainID is the id of the chain this transaction belongs to.
+        chainID: Bytes!
+        # From is the account that sent this transaction, at the block that
+        # included it or at the given block.
+        from(block: Long): Account!
+        # Block is the block this transaction was mined in. This will be null if
+        # the transaction is pending.
+        block: Block
+        # Index is the index of this transaction in the parent block. This will
+        # be null if the transaction has not yet been mined.
+        index: Int
+        # Transfer is set for TRANSFER transactions.
+        transfer: Transfer
+        # Message is set for MESSAGE transactions.
+        message: Message
+        # Announcement is set for CHAIN transactions.
+        announcement: ChainAnnouncement
+        # Profile is set for PROFILE transactions.
+        profile: ProfileUpdate
+    }
+
+    # TransactionEvent is the notification of a transaction being included in,
+    # or removed from, the canonical chain.
+    type TransactionEvent {
+        # Transaction is the transaction concerned.
+        transaction: Transaction!
+        # Removed is true if the transaction was dropped by a reorg.
+        removed: Boolean!
+    }
+
+    # TransactionFilter selects transactions. Every given field must match;
+    # within a field any listed value matches.
+    input TransactionFilter {
+        # FromBlock is the first block to scan; historical queries only.
+        fromBlock: Long
+        # ToBlock is the last block to scan; historical queries only.
+        toBlock: Long
+        kinds: [TransactionKind!]
+        senders: [Address!]
+        receivers: [Address!]
+        chainIDs: [Bytes!]
+        threads: [Bytes32!]
+    }
+
+    # Block is a TAU block.
+    type Block {
+        # Number is the number of this block, starting at 0 for the genesis block.
+        number: Long!
+        # Hash is the block hash of this block.
+        hash: Bytes32!
+        # Parent is the parent block of this block.
+        parent: Block
+        # Version is the block format version.
+        version: Int!
+        # ChainID is the id of the chain this block belongs to.
+        chainID: Bytes32!
+        # Miner is the account that mined this block.
+        miner(block: Long): Account!
+        # Timestamp is the unix timestamp at which this block was mined.
+        timestamp: Long!
+        # Difficulty is the cumulative difficulty of this block.
+        difficulty: BigInt!
+        # BaseTarget is the base target of this block.
+        baseTarget: BigInt!
+        # StateRoot is the hash of the state trie after this block was processed.
+        stateRoot: Bytes32!
+        # TransactionsRoot is the hash of the root of the trie of transactions in this block.
+        transactionsRoot: Bytes32!
+        # TransactionCount is the number of transactions in this block.
+        transactionCount: Int!
+        # Transactions is a list of transactions associated with this block.
+        transactions: [Transaction!]!
+        # TransactionAt returns the transaction at the specified index.
+        transactionAt(index: Int!): Transaction
+        # Account fetches a TAU account at the current block's state.
+        account(address: Address!): Account!
+    }
+
+    # Chain is the directory entry of a community chain.
+    type Chain {
+        # ID is the id of the chain.
+        id: String!
+        # Name is the name of the chain.
+        name: String!
+        # Contact is the contact of the chain creator.
+        contact: Bytes!
+        # Title is the title of the chain.
+        title: String!
+        # Description is the content identifier of the chain description.
+        description: Bytes!
+        # Creator is the account that announced the chain.
+        creator: Account!
+        # Block is the block that included the announcement.
+        block: Block
+        # Announcement is the transaction that announced the chain.
+        announcement: Transaction
+        # Following is true if the node follows the chain.
+        following: Boolean!
+        # Duplicates lists the ids of other chains announced under the same name.
+        duplicates: [String!]!
+    }
+
+    # TxPool is the transaction pool of the node.
+    type TxPool {
+        # Pending is the number of executable transactions.
+        pending: Int!
+        # Queued is the number of transactions waiting for a nonce gap to close.
+        queued: Int!
+        # Transactions lists the executable transactions.
+        transactions: [Transaction!]!
+    }
+
+    type Query {
+        # Block fetches a TAU block by number or by hash. If neither is
+        # supplied, the most recent known block is returned.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns all the blocks between two numbers, inclusive. If
+        # to is not supplied, it defaults to the most recent known block. At
+        # most 1024 blocks are returned, starting at from.
+        blocks(from: Long!, to: Long): [Block!]!
+        # Transaction returns a transaction specified by its hash.
+        transaction(hash: Bytes32!): Transaction
+        # Transactions returns the transactions matching the filter.
+        transactions(filter: TransactionFilter!): [Transaction!]!
+        # Account fetches a TAU account at the given block, or at the most
+        # recent known block if none is given.
+        account(address: Address!, block: Long): Account!
+        # Profiles returns the current profiles whose name starts with prefix.
+        profiles(prefix: String!): [Profile!]!
+        # Thread returns the messages of the thread started by root in the
+        # given block range.
+        thread(root: Bytes32!, fromBlock: Long, toBlock: Long): [Transaction!]!
+        # Chain returns the directory entry of the chain with the given id.
+        chain(id: String!): Chain
+        # Chains lists the directory entries following the given chain id.
+        chains(after: String, limit: Int): [Chain!]!
+        # SearchChains returns the chains whose name starts with prefix.
+        searchChains(prefix: String!): [Chain!]!
+        # TxPool returns the transaction pool of the node.
+        txpool: TxPool!
+        # SuggestedFee returns a transaction fee likely to get a transaction
+        # included in a timely manner.
+        suggestedFee: BigInt!
+    }
+
+    type Mutation {
+        # SendRawTransaction sends an RLP-encoded signed transaction to the network.
+        sendRawTransaction(data: Bytes!): Bytes32!
+    }
+
+    type Subscription {
+        # NewHeads delivers every new head of the canonical chain.
+        newHeads: Block!
+        # TransactionEvents delivers the transactions matching the filter that
+        # are included in, or removed from, the canonical chain.
+        transactionEvents(filter: TransactionFilter): TransactionEvent!
+        # PendingTransactions delivers the transactions matching the filter
+        # that enter the transaction pool.
+        pendingTransactions(filter: TransactionFilter): Transaction!
+    }
+`
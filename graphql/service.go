@@ -0,0 +1,121 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/filters"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+)
+
+// Service encapsulates a GraphQL service.
+type Service struct {
+	endpoint string               // The host:port endpoint for this service.
+	cors     []string             // Allowed CORS domains
+	vhosts   []string             // Recognised vhosts
+	timeouts rpc.HTTPTimeouts     // Timeout settings for HTTP requests.
+	backend  Backend              // The backend that queries will operate on.
+	handler  http.Handler         // The `http.Handler` used to answer queries.
+	events   *filters.EventSystem // The event system feeding the subscriptions.
+	listener net.Listener         // The listening socket.
+}
+
+// New constructs a new GraphQL service instance.
+func New(backend Backend, endpoint string, cors, vhosts []string, timeouts rpc.HTTPTimeouts) (*Service, error) {
+	return &Service{
+		endpoint: endpoint,
+		cors:     cors,
+		vhosts:   vhosts,
+		timeouts: timeouts,
+		backend:  backend,
+	}, nil
+}
+
+// Protocols returns the list of protocols exported by this service.
+func (s *Service) Protocols() []p2p.Protocol { return nil }
+
+// APIs returns the list of APIs exported by this service.
+func (s *Service) APIs() []rpc.API { return nil }
+
+// Start is called after all services have been constructed and the networking
+// layer was also initialized to spawn any goroutines required by the service.
+func (s *Service) Start(server *p2p.Server) error {
+	var err error
+	s.events = filters.NewEventSystem(s.backend)
+	s.handler, err = newHandler(s.backend, s.events, s.cors)
+	if err != nil {
+		s.events.Stop()
+		return err
+	}
+	if s.listener, err = net.Listen("tcp", s.endpoint); err != nil {
+		s.events.Stop()
+		return err
+	}
+	go rpc.NewHTTPServer(s.cors, s.vhosts, s.timeouts, s.handler).Serve(s.listener)
+	log.Info("GraphQL endpoint opened", "url", fmt.Sprintf("http://%s", s.endpoint))
+	return nil
+}
+
+// Stop terminates all goroutines belonging to the service, blocking until they
+// are all terminated.
+func (s *Service) Stop() error {
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+		log.Info("GraphQL endpoint closed", "url", fmt.Sprintf("http://%s", s.endpoint))
+	}
+	if s.events != nil {
+		s.events.Stop()
+		s.events = nil
+	}
+	return nil
+}
+
+// newHandler returns a new `http.Handler` that will answer GraphQL queries
+// over HTTP and subscriptions over websocket, fed by the given event system,
+// originating from the given CORS domains.
+func newHandler(backend Backend, events *filters.EventSystem, cors []string) (http.Handler, error) {
+	q := &Resolver{backend: backend, events: events}
+
+	s, err := graphql.ParseSchema(schema, q)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		queries = &relay.Handler{Schema: s}
+		subs    = newWSHandler(s, cors)
+	)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			subs.ServeHTTP(w, r)
+			return
+		}
+		queries.ServeHTTP(w, r)
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", h)
+	mux.Handle("/graphql/", h)
+	return mux, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/filters"
+)
+
+// TransactionEvent is the notification of a transaction being included in, or
+// removed from, the canonical chain.
+type TransactionEvent struct {
+	tx      *Transaction
+	removed bool
+}
+
+func (ev *TransactionEvent) Transaction(ctx context.Context) *Transaction {
+	return ev.tx
+}
+
+func (ev *TransactionEvent) Removed(ctx context.Context) bool {
+	return ev.removed
+}
+
+// NewHeads delivers every new head of the canonical chain until the context
+// of the subscription is cancelled.
+func (r *Resolver) NewHeads(ctx context.Context) (<-chan *Block, error) {
+	var (
+		headers = make(chan *types.Header)
+		blocks  = make(chan *Block)
+		sub     = r.events.SubscribeNewHeads(headers)
+	)
+	go func() {
+		defer close(blocks)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case header := <-headers:
+				block := &Block{backend: r.backend, hash: header.Hash(), header: header}
+				select {
+				case blocks <- block:
+				case <-ctx.Done():
+					return
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return blocks, nil
+}
+
+// TransactionEvents delivers the transactions matching the filter that are
+// included in, or removed from, the canonical chain.
+func (r *Resolver) TransactionEvents(ctx context.Context, args struct{ Filter *TxFilterInput }) (<-chan *TransactionEvent, error) {
+	crit, err := args.Filter.criteria()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		events = make(chan []*filters.TxEvent)
+		out    = make(chan *TransactionEvent)
+		sub    = r.events.SubscribeChainTxs(&crit, events)
+	)
+	go func() {
+		defer close(out)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case evs := <-events:
+				for _, ev := range evs {
+					select {
+					case out <- &TransactionEvent{tx: txFromEvent(r.backend, ev), removed: ev.Removed}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// PendingTransactions delivers the transactions matching the filter that enter
+// the transaction pool.
+func (r *Resolver) PendingTransactions(ctx context.Context, args struct{ Filter *TxFilterInput }) (<-chan *Transaction, error) {
+	crit, err := args.Filter.criteria()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		events = make(chan []*filters.TxEvent)
+		out    = make(chan *Transaction)
+		sub    = r.events.SubscribePendingTxs(&crit, events)
+	)
+	go func() {
+		defer close(out)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case evs := <-events:
+				for _, ev := range evs {
+					select {
+					case out <- txFromEvent(r.backend, ev):
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
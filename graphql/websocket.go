@@ -0,0 +1,275 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Message types of the graphql-ws subscription protocol.
+const (
+	gqlConnectionInit      = "connection_init"
+	gqlConnectionAck       = "connection_ack"
+	gqlConnectionError     = "connection_error"
+	gqlConnectionKeepAlive = "ka"
+	gqlConnectionTerminate = "connection_terminate"
+	gqlStart               = "start"
+	gqlData                = "data"
+	gqlError               = "error"
+	gqlComplete            = "complete"
+	gqlStop                = "stop"
+)
+
+const (
+	wsProtocol     = "graphql-ws"
+	wsKeepAlive    = 20 * time.Second
+	wsWriteTimeout = 10 * time.Second
+	wsReadLimit    = 128 * 1024
+	wsMaxSubs      = 64 // Maximum number of active subscriptions per connection
+)
+
+var errTooManySubscriptions = errors.New("too many subscriptions")
+
+// wsMessage is the envelope of every graphql-ws protocol message.
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsStartPayload is the payload of a start message.
+type wsStartPayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// wsHandler serves GraphQL subscriptions over websocket connections.
+type wsHandler struct {
+	schema   *graphql.Schema
+	upgrader websocket.Upgrader
+}
+
+func newWSHandler(schema *graphql.Schema, allowedOrigins []string) *wsHandler {
+	return &wsHandler{
+		schema: schema,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{wsProtocol},
+			CheckOrigin:  rpc.WSHandshakeValidator(allowedOrigins),
+		},
+	}
+}
+
+// ServeHTTP upgrades the request and runs the subscription protocol until the
+// client disconnects.
+func (h *wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Debug("GraphQL websocket upgrade failed", "err", err)
+		return
+	}
+	newWSConn(h.schema, conn).run()
+}
+
+// wsConn is a single websocket client with its active subscriptions.
+type wsConn struct {
+	schema *graphql.Schema
+	conn   *websocket.Conn
+
+	writeMu sync.Mutex
+	subsMu  sync.Mutex
+	subs    map[string]*wsSub
+	wg      sync.WaitGroup
+}
+
+func newWSConn(schema *graphql.Schema, conn *websocket.Conn) *wsConn {
+	return &wsConn{schema: schema, conn: conn, subs: make(map[string]*wsSub)}
+}
+
+// wsSub is an active subscription operation of a connection.
+type wsSub struct {
+	cancel context.CancelFunc
+}
+
+// run reads client messages until the connection fails or is terminated,
+// then tears down all subscriptions.
+func (c *wsConn) run() {
+	defer c.conn.Close()
+	defer c.wg.Wait()
+	defer c.stopAll()
+
+	c.conn.SetReadLimit(wsReadLimit)
+	var (
+		done = make(chan struct{})
+		init bool
+	)
+	defer close(done)
+
+	for {
+		var msg wsMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		switch msg.Type {
+		case gqlConnectionInit:
+			c.write(&wsMessage{Type: gqlConnectionAck})
+			c.write(&wsMessage{Type: gqlConnectionKeepAlive})
+			if !init {
+				init = true
+				go c.keepAlive(done)
+			}
+
+		case gqlStart:
+			var payload wsStartPayload
+			if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+				c.writeError(msg.ID, err)
+				continue
+			}
+			c.start(msg.ID, &payload)
+
+		case gqlStop:
+			c.stop(msg.ID)
+
+		case gqlConnectionTerminate:
+			return
+
+		default:
+			c.write(&wsMessage{ID: msg.ID, Type: gqlConnectionError, Payload: errorPayload("unknown message type " + msg.Type)})
+		}
+	}
+}
+
+// start runs the subscription operation in the background, forwarding every
+// response as a data message. Operations beyond the per connection limit are
+// rejected.
+func (c *wsConn) start(id string, payload *wsStartPayload) {
+	c.subsMu.Lock()
+	_, replace := c.subs[id]
+	full := !replace && len(c.subs) >= wsMaxSubs
+	c.subsMu.Unlock()
+	if full {
+		c.writeError(id, errTooManySubscriptions)
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	responses, err := c.schema.Subscribe(ctx, payload.Query, payload.OperationName, payload.Variables)
+	if err != nil {
+		cancel()
+		c.writeError(id, err)
+		return
+	}
+	sub := &wsSub{cancel: cancel}
+	c.subsMu.Lock()
+	if prev, ok := c.subs[id]; ok {
+		prev.cancel()
+	}
+	c.subs[id] = sub
+	c.subsMu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer c.remove(id, sub)
+		for resp := range responses {
+			data, err := json.Marshal(resp)
+			if err != nil {
+				c.writeError(id, err)
+				continue
+			}
+			c.write(&wsMessage{ID: id, Type: gqlData, Payload: data})
+		}
+		c.write(&wsMessage{ID: id, Type: gqlComplete})
+	}()
+}
+
+// stop cancels the subscription with the given id.
+func (c *wsConn) stop(id string) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if sub, ok := c.subs[id]; ok {
+		sub.cancel()
+		delete(c.subs, id)
+	}
+}
+
+// remove releases a subscription that finished on its own, unless the id was
+// meanwhile reused by another operation.
+func (c *wsConn) remove(id string, sub *wsSub) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.subs[id] == sub {
+		sub.cancel()
+		delete(c.subs, id)
+	}
+}
+
+// stopAll cancels every active subscription of the connection.
+func (c *wsConn) stopAll() {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	for id, sub := range c.subs {
+		sub.cancel()
+		delete(c.subs, id)
+	}
+}
+
+// keepAlive periodically pings the client until done is closed.
+func (c *wsConn) keepAlive(done chan struct{}) {
+	ticker := time.NewTicker(wsKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.write(&wsMessage{Type: gqlConnectionKeepAlive}); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (c *wsConn) write(msg *wsMessage) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return c.conn.WriteJSON(msg)
+}
+
+func (c *wsConn) writeError(id string, err error) {
+	c.write(&wsMessage{ID: id, Type: gqlError, Payload: errorPayload(err.Error())})
+}
+
+func errorPayload(message string) json.RawMessage {
+	data, _ := json.Marshal(map[string]string{"message": message})
+	return data
+}
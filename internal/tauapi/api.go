@@ -18,6 +18,7 @@ package tauapi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -32,13 +33,14 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/math"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/consensus/tauhash"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
-	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/vm"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	ipldtau "github.com/Tau-Coin/taucoin-mobile-mining-go/ipld"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/feeoracle"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/tyler-smith/go-bip39"
 )
@@ -64,6 +66,33 @@ func (s *PublicTauAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
 	return (*hexutil.Big)(price), err
 }
 
+// SuggestFeeArgs selects the transaction a fee is suggested for.
+type SuggestFeeArgs struct {
+	Kind    *types.TxKind `json:"kind"`    // Kind of the transaction, transfer if omitted
+	Replace *common.Hash  `json:"replace"` // Pending transaction to be replaced
+}
+
+// SuggestFee returns the fees suggested for a transaction of the given kind at
+// several urgency levels. If args.Replace is set, the suggestion
+// is for the given pending transaction and includes the fee to replace it with.
+func (s *PublicTauAPI) SuggestFee(ctx context.Context, args *SuggestFeeArgs) (*feeoracle.Suggestion, error) {
+	if args == nil {
+		args = new(SuggestFeeArgs)
+	}
+	if args.Replace != nil {
+		tx := s.b.GetPoolTransaction(*args.Replace)
+		if tx == nil {
+			return nil, fmt.Errorf("transaction %#x not pending", *args.Replace)
+		}
+		return s.b.SuggestReplacementFee(ctx, tx)
+	}
+	kind := types.TransferTxKind
+	if args.Kind != nil {
+		kind = *args.Kind
+	}
+	return s.b.SuggestFee(ctx, kind)
+}
+
 // ProtocolVersion returns the current Tau protocol version this node supports
 func (s *PublicTauAPI) ProtocolVersion() hexutil.Uint {
 	return hexutil.Uint(s.b.ProtocolVersion())
@@ -528,19 +557,77 @@ func (s *PublicBlockChainAPI) GetBalance(ctx context.Context, address common.Add
 	return (*hexutil.Big)(state.GetBalance(address)), state.Error()
 }
 
-// Result structs for GetProof
+// RPCSupply reports the coin supply of a chain at a given block.
+type RPCSupply struct {
+	Issued     *hexutil.Big `json:"issued"`
+	Burned     *hexutil.Big `json:"burned"`
+	MaxSupply  *hexutil.Big `json:"maxSupply"`
+	NextReward *hexutil.Big `json:"nextReward"`
+}
+
+// GetSupply returns the amount of coins issued by block rewards and burned
+// through fees up to the given block, together with the reward of the next
+// block according to the chain's emission schedule.
+func (s *PublicBlockChainAPI) GetSupply(ctx context.Context, blockNr rpc.BlockNumber) (*RPCSupply, error) {
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	var (
+		config = s.b.ChainConfig()
+		supply = state.GetSupply()
+		next   = new(big.Int).Add(header.Number, common.Big1)
+	)
+	result := &RPCSupply{
+		Issued:     (*hexutil.Big)(supply.Issued),
+		Burned:     (*hexutil.Big)(supply.Burned),
+		NextReward: (*hexutil.Big)(tauhash.BlockReward(config, next, supply.Issued)),
+	}
+	if config.Tauash != nil && config.Tauash.MaxSupply != nil {
+		result.MaxSupply = (*hexutil.Big)(new(big.Int).Set(config.Tauash.MaxSupply))
+	}
+	return result, state.Error()
+}
+
+// AccountResult is an account together with its Merkle proof in the account
+// trie of a block. The proof nodes are listed from the root down, ProofCIDs
+// holds the IPFS cid of each of them.
 type AccountResult struct {
 	Address      common.Address  `json:"address"`
-	AccountProof []string        `json:"accountProof"`
+	BlockHash    common.Hash     `json:"blockHash"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	ProofCIDs    []string        `json:"proofCids"`
 	Balance      *hexutil.Big    `json:"balance"`
 	Nonce        hexutil.Uint64  `json:"nonce"`
-	StorageHash  common.Hash     `json:"storageHash"`
-	StorageProof []StorageResult `json:"storageProof"`
 }
-type StorageResult struct {
-	Key   string       `json:"key"`
-	Value *hexutil.Big `json:"value"`
-	Proof []string     `json:"proof"`
+
+// GetProof returns the account at the given address in the state of the given
+// block together with the account trie proof, which lets clients check the
+// balance and nonce against the state root of a header they trust.
+func (s *PublicBlockChainAPI) GetProof(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (*AccountResult, error) {
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	proof, err := state.GetProof(address)
+	if err != nil {
+		return nil, err
+	}
+	result := &AccountResult{
+		Address:      address,
+		BlockHash:    header.Hash(),
+		BlockNumber:  hexutil.Uint64(header.Number.Uint64()),
+		AccountProof: make([]hexutil.Bytes, len(proof)),
+		ProofCIDs:    make([]string, len(proof)),
+		Balance:      (*hexutil.Big)(state.GetBalance(address)),
+		Nonce:        hexutil.Uint64(state.GetNonce(address)),
+	}
+	for i, node := range proof {
+		result.AccountProof[i] = node
+		result.ProofCIDs[i] = ipldtau.TrieNodeCid(node).String()
+	}
+	return result, state.Error()
 }
 
 // GetHeaderByNumber returns the requested canonical block header.
@@ -621,47 +708,6 @@ type account struct {
 	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
 }
 
-// ExecutionResult groups all structured logs emitted by the EVM
-// while replaying a transaction in debug mode as well as transaction
-// execution status, the amount of gas used and the return value
-type ExecutionResult struct {
-	Gas         uint64         `json:"gas"`
-	Failed      bool           `json:"failed"`
-	ReturnValue string         `json:"returnValue"`
-	StructLogs  []StructLogRes `json:"structLogs"`
-}
-
-// StructLogRes stores a structured log emitted by the EVM while replaying a
-// transaction in debug mode
-type StructLogRes struct {
-	Pc      uint64             `json:"pc"`
-	Gas     uint64             `json:"gas"`
-	GasCost uint64             `json:"gasCost"`
-	Error   error              `json:"error,omitempty"`
-	Storage *map[string]string `json:"storage,omitempty"`
-}
-
-// FormatLogs formats EVM returned structured logs for json output
-func FormatLogs(logs []vm.StructLog) []StructLogRes {
-	formatted := make([]StructLogRes, len(logs))
-	for index, trace := range logs {
-		formatted[index] = StructLogRes{
-			Pc:      trace.Pc,
-			Gas:     trace.Gas,
-			GasCost: trace.GasCost,
-			Error:   trace.Err,
-		}
-		if trace.Storage != nil {
-			storage := make(map[string]string)
-			for i, storageValue := range trace.Storage {
-				storage[fmt.Sprintf("%x", i)] = fmt.Sprintf("%x", storageValue)
-			}
-			formatted[index].Storage = &storage
-		}
-	}
-	return formatted
-}
-
 // RPCMarshalHeader converts the given header to the RPC output .
 func RPCMarshalHeader(head *types.Header) map[string]interface{} {
 	return map[string]interface{}{
@@ -730,9 +776,10 @@ func (s *PublicBlockChainAPI) rpcMarshalBlock(b *types.Block, inclTx bool, fullT
 
 // RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
 type RPCTransaction struct {
+	Kind             types.TxKind    `json:"kind"`
 	BlockHash        *common.Hash    `json:"blockHash"`
 	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
+	From             *common.Address `json:"from"` // Nil if the signature doesn't recover
 	Fee              *hexutil.Big    `json:"fee"`
 	Hash             common.Hash     `json:"hash"`
 	Nonce            hexutil.Uint64  `json:"nonce"`
@@ -742,6 +789,9 @@ type RPCTransaction struct {
 	V                *hexutil.Big    `json:"v"`
 	R                *hexutil.Big    `json:"r"`
 	S                *hexutil.Big    `json:"s"`
+
+	// Payload is the kind specific JSON encoding of the transaction.
+	Payload json.RawMessage `json:"payload"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -753,11 +803,11 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		signer = types.NewEIP155Signer(tx.ChainId())
 	}
 	*/
-	from, _ := types.Sender(signer, tx)
 	v, r, s := (*tx).RawSignatureValues()
+	payload, _ := (*tx).MarshalJSON()
 
 	result := &RPCTransaction{
-		From:  from,
+		Kind:  types.KindOf(*tx),
 		Fee:   (*hexutil.Big)((*tx).Fee()),
 		Hash:  (*tx).Hash(),
 		Nonce: hexutil.Uint64((*tx).GetNounce()),
@@ -766,6 +816,11 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		V:     (*hexutil.Big)(v),
 		R:     (*hexutil.Big)(r),
 		S:     (*hexutil.Big)(s),
+
+		Payload: payload,
+	}
+	if from, err := types.Sender(signer, tx); err == nil {
+		result.From = &from
 	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = &blockHash
@@ -925,6 +980,45 @@ func (s *PublicTransactionPoolAPI) GetRawTransactionByHash(ctx context.Context,
 	return rlp.EncodeToBytes(tx)
 }
 
+// RPCReceipt describes the inclusion of a transaction in the canonical chain
+// and how its fee was distributed.
+type RPCReceipt struct {
+	TxHash           common.Hash    `json:"transactionHash"`
+	Kind             types.TxKind   `json:"kind"`
+	From             common.Address `json:"from"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	Fee              *hexutil.Big   `json:"fee"`
+	FeeBurned        *hexutil.Big   `json:"feeBurned"`
+	FeeRelay         *hexutil.Big   `json:"feeRelay"`
+	FeeMiner         *hexutil.Big   `json:"feeMiner"`
+}
+
+// GetTransactionReceipt returns the receipt of an included transaction, or nil
+// if the transaction is pending or unknown.
+func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*RPCReceipt, error) {
+	tx, blockHash, blockNumber, index, err := s.b.GetTransaction(ctx, hash)
+	if tx == nil || err != nil {
+		return nil, err
+	}
+	fee := (*tx).Fee()
+	burn, relay, miner := s.b.ChainConfig().Tauash.SplitFee(fee)
+
+	return &RPCReceipt{
+		TxHash:           hash,
+		Kind:             types.KindOf(*tx),
+		From:             types.SenderOf(*tx),
+		BlockHash:        blockHash,
+		BlockNumber:      hexutil.Uint64(blockNumber),
+		TransactionIndex: hexutil.Uint64(index),
+		Fee:              (*hexutil.Big)(fee),
+		FeeBurned:        (*hexutil.Big)(burn),
+		FeeRelay:         (*hexutil.Big)(relay),
+		FeeMiner:         (*hexutil.Big)(miner),
+	}, nil
+}
+
 // sign is a helper function that signs a transaction with the private key of the given address.
 func (s *PublicTransactionPoolAPI) sign(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
 	// Look up the wallet containing the requested signer
@@ -938,39 +1032,27 @@ func (s *PublicTransactionPoolAPI) sign(addr common.Address, tx *types.Transacti
 	return wallet.SignTx(account, tx, s.b.ChainConfig().ChainID)
 }
 
-// SendTxArgs represents the arguments to sumbit a new transaction into the transaction pool.
+// SendTxArgs represents the arguments to sumbit a new transfer into the transaction pool.
 type SendTxArgs struct {
-	From  common.Address  `json:"from"`
+	TxArgs
 	To    *common.Address `json:"to"`
-	Fee   *hexutil.Big    `json:"fee"`
 	Value *hexutil.Big    `json:"value"`
-	Nonce *hexutil.Uint64 `json:"nonce"`
 }
 
 // setDefaults is a helper function that fills in default values for unspecified tx fields.
 func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
-	if args.Fee == nil {
-		price, err := b.SuggestPrice(ctx)
-		if err != nil {
-			return err
-		}
-		args.Fee = (*hexutil.Big)(price)
+	if args.To == nil {
+		return errMissingReceiver
 	}
 	if args.Value == nil {
 		args.Value = new(hexutil.Big)
 	}
-	if args.Nonce == nil {
-		nonce, err := b.GetPoolNonce(ctx, args.From)
-		if err != nil {
-			return err
-		}
-		args.Nonce = (*hexutil.Uint64)(&nonce)
-	}
-	return nil
+	return args.TxArgs.setDefaults(ctx, b)
 }
 
 func (args *SendTxArgs) toTransaction() types.Transaction {
-	return types.NewTransaction(uint64(*args.Nonce), *args.To, (*big.Int)(args.Value), (*big.Int)(args.Fee))
+	version, option, chainid, nonce, timestamp := args.header()
+	return types.NewTransferTransaction(version, option, chainid, nonce, timestamp, (*big.Int)(args.Fee), args.From, *args.To, (*big.Int)(args.Value))
 }
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
@@ -995,36 +1077,10 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 // SendTransaction creates a transaction for the given argument, sign it and submit it to the
 // transaction pool.
 func (s *PublicTransactionPoolAPI) SendTransaction(ctx context.Context, args SendTxArgs) (common.Hash, error) {
-	// Look up the wallet containing the requested signer
-	account := accounts.Account{Address: args.From}
-
-	wallet, err := s.b.AccountManager().Find(account)
-	if err != nil {
-		return common.Hash{}, err
-	}
-
-	if args.Nonce == nil {
-		// Hold the addresse's mutex around signing to prevent concurrent assignment of
-		// the same nonce to multiple accounts.
-		s.nonceLock.LockAddr(args.From)
-		defer s.nonceLock.UnlockAddr(args.From)
-	}
-
-	// Set some sanity defaults and terminate on failure
-	if err := args.setDefaults(ctx, s.b); err != nil {
-		return common.Hash{}, err
-	}
-	// Assemble the transaction and sign with the wallet
-	tx := args.toTransaction()
-
-	signed, err := wallet.SignTx(account, &tx, s.b.ChainConfig().ChainID)
-	if err != nil {
-		return common.Hash{}, err
-	}
-	return SubmitTransaction(ctx, s.b, signed)
+	return s.send(ctx, &args)
 }
 
-// FillTransaction fills the defaults (nonce, gas, gasPrice) on a given unsigned transaction,
+// FillTransaction fills the defaults (nonce, fee, chain id, timestamp) on a given unsigned transaction,
 // and returns it to the caller for further processing (signing + broadcast)
 func (s *PublicTransactionPoolAPI) FillTransaction(ctx context.Context, args SendTxArgs) (*SignTransactionResult, error) {
 	// Set some sanity defaults and terminate on failure
@@ -1086,7 +1142,7 @@ type SignTransactionResult struct {
 // the given from address and it needs to be unlocked.
 func (s *PublicTransactionPoolAPI) SignTransaction(ctx context.Context, args SendTxArgs) (*SignTransactionResult, error) {
 	if args.Fee == nil {
-		return nil, fmt.Errorf("gasPrice not specified")
+		return nil, fmt.Errorf("fee not specified")
 	}
 	if args.Nonce == nil {
 		return nil, fmt.Errorf("nonce not specified")
@@ -1135,48 +1191,44 @@ func (s *PublicTransactionPoolAPI) PendingTransactions() ([]*RPCTransaction, err
 	return transactions, nil
 }
 
-// Resend accepts an existing transaction and a new gas price and limit. It will remove
-// the given transaction from the pool and reinsert it with the new gas price and limit.
-func (s *PublicTransactionPoolAPI) Resend(ctx context.Context, sendArgs SendTxArgs, gasPrice *hexutil.Big, gasLimit *hexutil.Uint64) (common.Hash, error) {
+// Resend accepts an existing transaction and a new fee. It looks up the pending
+// transaction of the sender with the same nonce and replaces it with one paying
+// the new fee.
+func (s *PublicTransactionPoolAPI) Resend(ctx context.Context, sendArgs SendTxArgs, fee *hexutil.Big) (common.Hash, error) {
 	if sendArgs.Nonce == nil {
 		return common.Hash{}, fmt.Errorf("missing transaction nonce in transaction spec")
 	}
-	if err := sendArgs.setDefaults(ctx, s.b); err != nil {
-		return common.Hash{}, err
-	}
-	matchTx := sendArgs.toTransaction()
 	pending, err := s.b.GetPoolTransactions()
 	if err != nil {
 		return common.Hash{}, err
 	}
-
 	for _, p := range pending {
-		var signer types.Signer = types.HomesteadSigner{}
-		/* tx-ctc
-		if p.Protected() {
-			signer = types.NewEIP155Signer(p.ChainId())
+		if types.SenderOf(*p) != sendArgs.From || (*p).Nonce() != uint64(*sendArgs.Nonce) {
+			continue
 		}
-		*/
-		wantSigHash := signer.Hash(&matchTx)
-
-		if pFrom, err := types.Sender(signer, p); err == nil && pFrom == sendArgs.From && signer.Hash(p) == wantSigHash {
-			// Match. Re-sign and send the transaction.
-			if gasPrice != nil && (*big.Int)(gasPrice).Sign() != 0 {
-				sendArgs.Fee = gasPrice
-			}
-			ret := sendArgs.toTransaction()
-			signedTx, err := s.sign(sendArgs.From, &ret)
-			if err != nil {
-				return common.Hash{}, err
-			}
-			if err = s.b.SendTx(ctx, signedTx); err != nil {
-				return common.Hash{}, err
-			}
-			return (*signedTx).Hash(), nil
+		// Only transfers can be rebuilt from the arguments, the other kinds are
+		// replaced through their own methods with the same nonce
+		if kind := types.KindOf(*p); kind != types.TransferTxKind {
+			return common.Hash{}, fmt.Errorf("cannot resend %v transaction %x, send a replacement with nonce %d instead", kind, (*p).Hash(), uint64(*sendArgs.Nonce))
+		}
+		// Match. Re-sign and send the transaction.
+		if fee != nil && (*big.Int)(fee).Sign() != 0 {
+			sendArgs.Fee = fee
 		}
+		if err := sendArgs.setDefaults(ctx, s.b); err != nil {
+			return common.Hash{}, err
+		}
+		ret := sendArgs.toTransaction()
+		signedTx, err := s.sign(sendArgs.From, &ret)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if err = s.b.SendTx(ctx, signedTx); err != nil {
+			return common.Hash{}, err
+		}
+		return (*signedTx).Hash(), nil
 	}
-
-	return common.Hash{}, fmt.Errorf("Transaction %#x not found", matchTx.Hash())
+	return common.Hash{}, fmt.Errorf("transaction from %x with nonce %d not found", sendArgs.From, uint64(*sendArgs.Nonce))
 }
 
 // PublicDebugAPI is the collection of Tau APIs exposed over the public
@@ -0,0 +1,168 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauapi
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+)
+
+// maxProfileSearchResults caps the number of profiles returned by a single
+// name search.
+const maxProfileSearchResults = 100
+
+// PublicProfileAPI exposes the on-chain profile registry built from
+// PersonalInfoTx transactions.
+type PublicProfileAPI struct {
+	b Backend
+}
+
+// NewPublicProfileAPI creates a new profile registry API.
+func NewPublicProfileAPI(b Backend) *PublicProfileAPI {
+	return &PublicProfileAPI{b}
+}
+
+// RPCProfile is the RPC representation of a profile record.
+type RPCProfile struct {
+	Address     common.Address `json:"address"`
+	ContactName hexutil.Bytes  `json:"contactName"`
+	Name        string         `json:"name"`
+	CID         hexutil.Bytes  `json:"cid"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
+
+func newRPCProfile(addr common.Address, profile *state.Profile) *RPCProfile {
+	return &RPCProfile{
+		Address:     addr,
+		ContactName: profile.ContactName,
+		Name:        string(bytes.TrimRight(profile.Name, "\x00")),
+		CID:         profile.CID,
+		BlockNumber: hexutil.Uint64(profile.Number),
+	}
+}
+
+// GetProfile returns the latest profile published by the given address in the
+// state of the given block, or nil if the address never published one.
+func (s *PublicProfileAPI) GetProfile(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (*RPCProfile, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	profile := state.GetProfile(address)
+	if profile == nil {
+		return nil, state.Error()
+	}
+	return newRPCProfile(address, profile), state.Error()
+}
+
+// SearchProfiles returns the current profiles whose name starts with the given
+// case insensitive prefix.
+func (s *PublicProfileAPI) SearchProfiles(ctx context.Context, prefix string) ([]*RPCProfile, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	lower := strings.ToLower(prefix)
+
+	results := make([]*RPCProfile, 0)
+	match := func(addr common.Address) bool {
+		// The name index keeps stale entries of renamed profiles, filter them out
+		profile := state.GetProfile(addr)
+		if profile == nil {
+			return false
+		}
+		rpcProfile := newRPCProfile(addr, profile)
+		if !strings.HasPrefix(strings.ToLower(rpcProfile.Name), lower) {
+			return false
+		}
+		results = append(results, rpcProfile)
+		return true
+	}
+	rawdb.ReadProfileAddressesByName(s.b.ChainDb(), prefix, maxProfileSearchResults, match)
+	return results, state.Error()
+}
+
+// ProfileUpdate is a single entry of the profile history of an address.
+type ProfileUpdate struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxHash      common.Hash    `json:"transactionHash"`
+}
+
+// GetProfileHistory returns every indexed profile update of the given address
+// in ascending block order.
+func (s *PublicProfileAPI) GetProfileHistory(ctx context.Context, address common.Address) []ProfileUpdate {
+	entries := rawdb.ReadProfileHistory(s.b.ChainDb(), address)
+
+	history := make([]ProfileUpdate, len(entries))
+	for i, entry := range entries {
+		history[i] = ProfileUpdate{
+			BlockNumber: hexutil.Uint64(entry.Number),
+			TxHash:      entry.TxHash,
+		}
+	}
+	return history
+}
+
+// ProfileChanges creates a subscription that is triggered each time a canonical
+// block updates a profile. If addresses are given, only changes of those
+// addresses are delivered.
+func (s *PublicProfileAPI) ProfileChanges(ctx context.Context, addresses []common.Address) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	watched := make(map[common.Address]struct{}, len(addresses))
+	for _, addr := range addresses {
+		watched[addr] = struct{}{}
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		changes := make(chan core.ProfileChangedEvent, 16)
+		sub := s.b.SubscribeProfileChangedEvent(changes)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-changes:
+				if _, ok := watched[ev.Address]; len(watched) > 0 && !ok {
+					continue
+				}
+				notifier.Notify(rpcSub.ID, newRPCProfile(ev.Address, &state.Profile{
+					ContactName: ev.ContactName,
+					Name:        ev.Name,
+					CID:         ev.CID,
+					Number:      ev.BlockNumber,
+				}))
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// profileTestBackend serves the chain database and the head state the profile
+// registry is read from.
+type profileTestBackend struct {
+	Backend
+	db    taudb.Database
+	state *state.StateDB
+}
+
+func (b *profileTestBackend) ChainDb() taudb.Database { return b.db }
+
+func (b *profileTestBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
+	return b.state, &types.Header{Number: big.NewInt(2)}, nil
+}
+
+// Tests that the profile registry is served from the head state and the
+// profile indexes of the canonical chain.
+func TestProfileAPI(t *testing.T) {
+	var (
+		sender = common.HexToAddress("0x01")
+		db     = rawdb.NewMemoryDatabase()
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	statedb.SetProfile(sender, &state.Profile{ContactName: []byte("contact"), Name: []byte("Alice"), CID: []byte("cid"), Number: 2})
+
+	var txs []*types.Transaction
+	for _, name := range []string{"alice", "Alice"} {
+		tx := types.Transaction(types.NewPersonalInfoTransaction(types.OneByte{1}, types.OneByte{0}, nil, uint64(len(txs)), 0, new(big.Int), sender, types.Byte32s("contact"), types.Byte20s(name), types.Byte32s("cid")))
+		txs = append(txs, &tx)
+		rawdb.WriteProfileIndexes(db, types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(len(txs)))}).WithBody(types.Transactions{&tx}))
+	}
+	api := NewPublicProfileAPI(&profileTestBackend{db: db, state: statedb})
+
+	profile, err := api.GetProfile(context.Background(), sender, rpc.LatestBlockNumber)
+	if err != nil || profile == nil || profile.Name != "Alice" || profile.BlockNumber != 2 {
+		t.Errorf("profile mismatch: have %+v, err %v", profile, err)
+	}
+	if profile, err := api.GetProfile(context.Background(), common.HexToAddress("0x02"), rpc.LatestBlockNumber); profile != nil || err != nil {
+		t.Errorf("unknown profile returned: %+v, err %v", profile, err)
+	}
+	found, err := api.SearchProfiles(context.Background(), "ALI")
+	if err != nil || len(found) != 1 || found[0].Address != sender {
+		t.Errorf("search mismatch: have %+v, err %v", found, err)
+	}
+	history := api.GetProfileHistory(context.Background(), sender)
+	if len(history) != len(txs) {
+		t.Fatalf("history length mismatch: have %d, want %d", len(history), len(txs))
+	}
+	for i, update := range history {
+		if update.BlockNumber != hexutil.Uint64(i+1) || update.TxHash != (*txs[i]).Hash() {
+			t.Errorf("history entry %d mismatch: %+v", i, update)
+		}
+	}
+}
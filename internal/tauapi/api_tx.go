@@ -0,0 +1,310 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/accounts"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+)
+
+// Length limits of the variable sized transaction fields, as fixed by the
+// transaction encodings.
+const (
+	maxNameLength  = 20  // Byte20s
+	maxFieldLength = 32  // Byte32s
+	maxTitleLength = 144 // Byte144s
+
+	// maxChainFee is the largest fee a chain announcement can carry, its fee
+	// being encoded in a single byte.
+	maxChainFee = 255
+)
+
+var errMissingReceiver = errors.New("transfer without receiver")
+
+// TxArgs holds the fields shared by all kinds of transaction requests. Fields
+// left out are filled in by the node: the fee from the price oracle, the nonce
+// from the transaction pool, the chain id from the current head and the
+// timestamp from the local clock.
+type TxArgs struct {
+	From      common.Address  `json:"from"`
+	Fee       *hexutil.Big    `json:"fee"`
+	Nonce     *hexutil.Uint64 `json:"nonce"`
+	ChainID   *hexutil.Bytes  `json:"chainId"`
+	Timestamp *hexutil.Uint64 `json:"timestamp"`
+
+	version types.OneByte // Transaction version, taken from the current head
+}
+
+// setDefaults fills in default values for the unspecified common fields.
+func (args *TxArgs) setDefaults(ctx context.Context, b Backend) error {
+	head := b.CurrentBlock().Header()
+	args.version = types.OneByte{head.Version}
+
+	if args.Fee == nil {
+		price, err := b.SuggestPrice(ctx)
+		if err != nil {
+			return err
+		}
+		args.Fee = (*hexutil.Big)(price)
+	}
+	if args.Nonce == nil {
+		nonce, err := b.GetPoolNonce(ctx, args.From)
+		if err != nil {
+			return err
+		}
+		args.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+	if args.ChainID == nil {
+		id := hexutil.Bytes(common.CopyBytes(head.ChainID[:]))
+		args.ChainID = &id
+	}
+	if len(*args.ChainID) > maxFieldLength {
+		return fmt.Errorf("chain id too long: %d > %d bytes", len(*args.ChainID), maxFieldLength)
+	}
+	if args.Timestamp == nil {
+		now := hexutil.Uint64(time.Now().Unix())
+		args.Timestamp = &now
+	}
+	return nil
+}
+
+// sender returns the account creating the transaction.
+func (args *TxArgs) sender() common.Address { return args.From }
+
+// hasNonce reports whtauer the caller picked the nonce itself.
+func (args *TxArgs) hasNonce() bool { return args.Nonce != nil }
+
+// header returns the common fields in the form of the transaction constructors.
+func (args *TxArgs) header() (types.OneByte, types.OneByte, types.Byte32s, uint64, uint32) {
+	return args.version, types.OneByte{0}, types.Byte32s(*args.ChainID), uint64(*args.Nonce), uint32(*args.Timestamp)
+}
+
+// txRequest is implemented by the typed transaction arguments.
+type txRequest interface {
+	sender() common.Address
+	hasNonce() bool
+	setDefaults(ctx context.Context, b Backend) error
+	toTransaction() types.Transaction
+}
+
+// MessageArgs represents the arguments to post a message to a community chain.
+type MessageArgs struct {
+	TxArgs
+	ReferID *common.Hash  `json:"referId"` // Message replied to, if any
+	Title   hexutil.Bytes `json:"title"`
+	Content hexutil.Bytes `json:"content"`
+}
+
+func (args *MessageArgs) setDefaults(ctx context.Context, b Backend) error {
+	if err := checkLength("title", args.Title, maxTitleLength); err != nil {
+		return err
+	}
+	if err := checkLength("content", args.Content, maxFieldLength); err != nil {
+		return err
+	}
+	return args.TxArgs.setDefaults(ctx, b)
+}
+
+func (args *MessageArgs) toTransaction() types.Transaction {
+	var referid common.Hash
+	if args.ReferID != nil {
+		referid = *args.ReferID
+	}
+	version, option, chainid, nonce, timestamp := args.header()
+	return types.NewMessageTransaction(version, option, chainid, nonce, timestamp, (*big.Int)(args.Fee), args.From, referid, types.Byte144s(args.Title), types.Byte32s(args.Content))
+}
+
+// ChainArgs represents the arguments to announce a new community chain.
+type ChainArgs struct {
+	TxArgs
+	Name        hexutil.Bytes `json:"name"`
+	Contact     hexutil.Bytes `json:"contact"`
+	Title       hexutil.Bytes `json:"title"`
+	Description hexutil.Bytes `json:"description"`
+}
+
+func (args *ChainArgs) setDefaults(ctx context.Context, b Backend) error {
+	if len(args.Name) == 0 {
+		return errors.New("chain announcement without name")
+	}
+	if err := checkLength("name", args.Name, maxNameLength); err != nil {
+		return err
+	}
+	if err := checkLength("contact", args.Contact, maxFieldLength); err != nil {
+		return err
+	}
+	if err := checkLength("title", args.Title, maxTitleLength); err != nil {
+		return err
+	}
+	if err := checkLength("description", args.Description, maxFieldLength); err != nil {
+		return err
+	}
+	if args.Fee != nil && args.Fee.ToInt().Cmp(big.NewInt(maxChainFee)) > 0 {
+		return fmt.Errorf("chain announcement fee too high: %v > %d", args.Fee.ToInt(), maxChainFee)
+	}
+	suggested := args.Fee == nil
+	if err := args.TxArgs.setDefaults(ctx, b); err != nil {
+		return err
+	}
+	// Cap the suggested fee to what the announcement can carry
+	if suggested && args.Fee.ToInt().Cmp(big.NewInt(maxChainFee)) > 0 {
+		args.Fee = (*hexutil.Big)(big.NewInt(maxChainFee))
+	}
+	return nil
+}
+
+func (args *ChainArgs) toTransaction() types.Transaction {
+	version, option, chainid, nonce, timestamp := args.header()
+	fee := types.OneByte{byte(args.Fee.ToInt().Uint64())}
+	return types.NewNewChainTransaction(version, option, chainid, nonce, timestamp, fee, args.From, types.Byte20s(args.Name), types.Byte32s(args.Contact), types.Byte144s(args.Title), types.Byte32s(args.Description))
+}
+
+// ProfileArgs represents the arguments to publish the profile of an account.
+type ProfileArgs struct {
+	TxArgs
+	ContactName hexutil.Bytes `json:"contactName"`
+	Name        hexutil.Bytes `json:"name"`
+	Profile     hexutil.Bytes `json:"profile"`
+}
+
+func (args *ProfileArgs) setDefaults(ctx context.Context, b Backend) error {
+	if err := checkLength("contact name", args.ContactName, maxFieldLength); err != nil {
+		return err
+	}
+	if err := checkLength("name", args.Name, maxNameLength); err != nil {
+		return err
+	}
+	if err := checkLength("profile", args.Profile, maxFieldLength); err != nil {
+		return err
+	}
+	return args.TxArgs.setDefaults(ctx, b)
+}
+
+func (args *ProfileArgs) toTransaction() types.Transaction {
+	version, option, chainid, nonce, timestamp := args.header()
+	return types.NewPersonalInfoTransaction(version, option, chainid, nonce, timestamp, (*big.Int)(args.Fee), args.From, types.Byte32s(args.ContactName), types.Byte20s(args.Name), types.Byte32s(args.Profile))
+}
+
+// checkLength ensures a variable sized field fits its encoding.
+func checkLength(field string, data []byte, limit int) error {
+	if len(data) > limit {
+		return fmt.Errorf("%s too long: %d > %d bytes", field, len(data), limit)
+	}
+	return nil
+}
+
+// signFn signs a transaction with the given account of a wallet.
+type signFn func(wallet accounts.Wallet, account accounts.Account, tx *types.Transaction) (*types.Transaction, error)
+
+// sendTx fills in the defaults of the request, signs the resulting transaction
+// and submits it to the transaction pool.
+func sendTx(ctx context.Context, b Backend, nonceLock *AddrLocker, req txRequest, sign signFn) (common.Hash, error) {
+	// Look up the wallet containing the requested signer
+	account := accounts.Account{Address: req.sender()}
+
+	wallet, err := b.AccountManager().Find(account)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if !req.hasNonce() {
+		// Hold the addresse's mutex around signing to prevent concurrent assignment of
+		// the same nonce to multiple accounts.
+		nonceLock.LockAddr(account.Address)
+		defer nonceLock.UnlockAddr(account.Address)
+	}
+	// Set some sanity defaults and terminate on failure
+	if err := req.setDefaults(ctx, b); err != nil {
+		return common.Hash{}, err
+	}
+	// Assemble the transaction and sign with the wallet
+	tx := req.toTransaction()
+
+	signed, err := sign(wallet, account, &tx)
+	if err != nil {
+		log.Warn("Failed transaction send attempt", "from", account.Address, "kind", types.KindOf(tx), "err", err)
+		return common.Hash{}, err
+	}
+	return SubmitTransaction(ctx, b, signed)
+}
+
+// send signs the request with an unlocked account and submits it.
+func (s *PublicTransactionPoolAPI) send(ctx context.Context, req txRequest) (common.Hash, error) {
+	return sendTx(ctx, s.b, s.nonceLock, req, func(wallet accounts.Wallet, account accounts.Account, tx *types.Transaction) (*types.Transaction, error) {
+		return wallet.SignTx(account, tx, s.b.ChainConfig().ChainID)
+	})
+}
+
+// Transfer sends value from an unlocked account to the given receiver.
+func (s *PublicTransactionPoolAPI) Transfer(ctx context.Context, args SendTxArgs) (common.Hash, error) {
+	return s.send(ctx, &args)
+}
+
+// SendMessage posts a message to a community chain from an unlocked account.
+func (s *PublicTransactionPoolAPI) SendMessage(ctx context.Context, args MessageArgs) (common.Hash, error) {
+	return s.send(ctx, &args)
+}
+
+// CreateChain announces a new community chain from an unlocked account.
+func (s *PublicTransactionPoolAPI) CreateChain(ctx context.Context, args ChainArgs) (common.Hash, error) {
+	return s.send(ctx, &args)
+}
+
+// UpdateProfile publishes the profile of an unlocked account.
+func (s *PublicTransactionPoolAPI) UpdateProfile(ctx context.Context, args ProfileArgs) (common.Hash, error) {
+	return s.send(ctx, &args)
+}
+
+// send signs the request with the account key, decrypted with the given
+// password, and submits it.
+func (s *PrivateAccountAPI) send(ctx context.Context, req txRequest, passwd string) (common.Hash, error) {
+	return sendTx(ctx, s.b, s.nonceLock, req, func(wallet accounts.Wallet, account accounts.Account, tx *types.Transaction) (*types.Transaction, error) {
+		return wallet.SignTxWithPassphrase(account, passwd, tx, s.b.ChainConfig().ChainID)
+	})
+}
+
+// Transfer sends value to the given receiver, signing with the key decrypted
+// by the given password.
+func (s *PrivateAccountAPI) Transfer(ctx context.Context, args SendTxArgs, passwd string) (common.Hash, error) {
+	return s.send(ctx, &args, passwd)
+}
+
+// SendMessage posts a message to a community chain, signing with the key
+// decrypted by the given password.
+func (s *PrivateAccountAPI) SendMessage(ctx context.Context, args MessageArgs, passwd string) (common.Hash, error) {
+	return s.send(ctx, &args, passwd)
+}
+
+// CreateChain announces a new community chain, signing with the key decrypted
+// by the given password.
+func (s *PrivateAccountAPI) CreateChain(ctx context.Context, args ChainArgs, passwd string) (common.Hash, error) {
+	return s.send(ctx, &args, passwd)
+}
+
+// UpdateProfile publishes the profile of an account, signing with the key
+// decrypted by the given password.
+func (s *PrivateAccountAPI) UpdateProfile(ctx context.Context, args ProfileArgs, passwd string) (common.Hash, error) {
+	return s.send(ctx, &args, passwd)
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauapi
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+)
+
+// txTestBackend serves the chain head, fee suggestion and pool content the
+// transaction arguments are completed from.
+type txTestBackend struct {
+	Backend
+	head  *types.Block
+	price *big.Int
+	nonce uint64
+	pool  types.Transactions
+}
+
+func newTxTestBackend(price int64, nonce uint64, pool ...types.Transaction) *txTestBackend {
+	b := &txTestBackend{
+		head:  types.NewBlockWithHeader(&types.Header{Version: 1, Number: big.NewInt(1), ChainID: common.BytesToHash([]byte("tau"))}),
+		price: big.NewInt(price),
+		nonce: nonce,
+	}
+	for i := range pool {
+		b.pool = append(b.pool, &pool[i])
+	}
+	return b
+}
+
+func (b *txTestBackend) CurrentBlock() *types.Block { return b.head }
+
+func (b *txTestBackend) SuggestPrice(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(b.price), nil
+}
+
+func (b *txTestBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
+	return b.nonce, nil
+}
+
+func (b *txTestBackend) GetPoolTransactions() (types.Transactions, error) {
+	return b.pool, nil
+}
+
+// Tests that the unspecified common fields are filled in from the backend and
+// the requests are turned into transactions of the right kind.
+func TestTxArgsDefaults(t *testing.T) {
+	var (
+		from = common.HexToAddress("0x01")
+		to   = common.HexToAddress("0x02")
+		b    = newTxTestBackend(10, 7)
+	)
+	tests := []struct {
+		req  txRequest
+		kind types.TxKind
+	}{
+		{&SendTxArgs{TxArgs: TxArgs{From: from}, To: &to}, types.TransferTxKind},
+		{&MessageArgs{TxArgs: TxArgs{From: from}, Title: []byte("title")}, types.NewMessageTxKind},
+		{&ChainArgs{TxArgs: TxArgs{From: from}, Name: []byte("tau")}, types.NewChainTxKind},
+		{&ProfileArgs{TxArgs: TxArgs{From: from}, Name: []byte("alice")}, types.PersonalInfoTxKind},
+	}
+	for i, test := range tests {
+		if err := test.req.setDefaults(context.Background(), b); err != nil {
+			t.Fatalf("test %d: failed to set defaults: %v", i, err)
+		}
+		tx := test.req.toTransaction()
+		if kind := types.KindOf(tx); kind != test.kind {
+			t.Errorf("test %d: kind mismatch: have %v, want %v", i, kind, test.kind)
+		}
+		if sender := types.SenderOf(tx); sender != from {
+			t.Errorf("test %d: sender mismatch: have %x, want %x", i, sender, from)
+		}
+		if tx.Nonce() != 7 {
+			t.Errorf("test %d: nonce mismatch: have %d, want 7", i, tx.Nonce())
+		}
+		if tx.Fee().Cmp(big.NewInt(10)) != 0 {
+			t.Errorf("test %d: fee mismatch: have %v, want 10", i, tx.Fee())
+		}
+		if want := b.head.Header().ChainID; !bytes.Equal(tx.ChainId(), want[:]) {
+			t.Errorf("test %d: chain id mismatch: have %x, want %x", i, tx.ChainId(), want)
+		}
+	}
+}
+
+// Tests that requests with missing or oversized fields are rejected.
+func TestTxArgsValidation(t *testing.T) {
+	var (
+		from    = common.HexToAddress("0x01")
+		to      = common.HexToAddress("0x02")
+		long    = make([]byte, maxTitleLength+1)
+		longID  = hexutil.Bytes(make([]byte, maxFieldLength+1))
+		hugeFee = (*hexutil.Big)(big.NewInt(maxChainFee + 1))
+	)
+	tests := []txRequest{
+		&SendTxArgs{TxArgs: TxArgs{From: from}},
+		&SendTxArgs{TxArgs: TxArgs{From: from, ChainID: &longID}, To: &to},
+		&MessageArgs{TxArgs: TxArgs{From: from}, Title: long},
+		&MessageArgs{TxArgs: TxArgs{From: from}, Content: long[:maxFieldLength+1]},
+		&ChainArgs{TxArgs: TxArgs{From: from}},
+		&ChainArgs{TxArgs: TxArgs{From: from}, Name: long[:maxNameLength+1]},
+		&ChainArgs{TxArgs: TxArgs{From: from, Fee: hugeFee}, Name: []byte("tau")},
+		&ProfileArgs{TxArgs: TxArgs{From: from}, Name: long[:maxNameLength+1]},
+		&ProfileArgs{TxArgs: TxArgs{From: from}, Profile: long[:maxFieldLength+1]},
+	}
+	for i, req := range tests {
+		if err := req.setDefaults(context.Background(), newTxTestBackend(10, 0)); err == nil {
+			t.Errorf("test %d: invalid request accepted", i)
+		}
+	}
+}
+
+// Tests that a suggested fee is capped to what a chain announcement can carry.
+func TestChainArgsFeeCap(t *testing.T) {
+	args := &ChainArgs{TxArgs: TxArgs{From: common.HexToAddress("0x01")}, Name: []byte("tau")}
+	if err := args.setDefaults(context.Background(), newTxTestBackend(1000, 0)); err != nil {
+		t.Fatalf("failed to set defaults: %v", err)
+	}
+	if fee := args.Fee.ToInt(); fee.Cmp(big.NewInt(maxChainFee)) != 0 {
+		t.Errorf("fee mismatch: have %v, want %d", fee, maxChainFee)
+	}
+	if fee := args.toTransaction().Fee(); fee.Cmp(big.NewInt(maxChainFee)) != 0 {
+		t.Errorf("transaction fee mismatch: have %v, want %d", fee, maxChainFee)
+	}
+}
+
+// Tests that resending refuses the pending transactions it can't rebuild.
+func TestResendKinds(t *testing.T) {
+	var (
+		from    = common.HexToAddress("0x01")
+		message = types.NewMessageTransaction(nil, nil, nil, 3, 0, big.NewInt(1), from, common.Hash{}, nil, nil)
+		api     = NewPublicTransactionPoolAPI(newTxTestBackend(10, 4, message), new(AddrLocker))
+	)
+	nonce := hexutil.Uint64(3)
+	_, err := api.Resend(context.Background(), SendTxArgs{TxArgs: TxArgs{From: from, Nonce: &nonce}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "cannot resend") {
+		t.Errorf("resend error mismatch: have %v, want refusal", err)
+	}
+	nonce = 5
+	if _, err := api.Resend(context.Background(), SendTxArgs{TxArgs: TxArgs{From: from, Nonce: &nonce}}, nil); err == nil {
+		t.Errorf("resend of a missing transaction succeeded")
+	}
+}
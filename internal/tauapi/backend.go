@@ -30,6 +30,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/downloader"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/feeoracle"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
 )
 
@@ -40,6 +41,8 @@ type Backend interface {
 	Downloader() *downloader.Downloader
 	ProtocolVersion() int
 	SuggestPrice(ctx context.Context) (*big.Int, error)
+	SuggestFee(ctx context.Context, kind types.TxKind) (*feeoracle.Suggestion, error)
+	SuggestReplacementFee(ctx context.Context, tx *types.Transaction) (*feeoracle.Suggestion, error)
 	ChainDb() taudb.Database
 	EventMux() *event.TypeMux
 	AccountManager() *accounts.Manager
@@ -56,6 +59,7 @@ type Backend interface {
 	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
 	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
 	SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) event.Subscription
+	SubscribeProfileChangedEvent(ch chan<- core.ProfileChangedEvent) event.Subscription
 
 	// Transaction pool API
 	SendTx(ctx context.Context, signedTx *types.Transaction) error
@@ -94,6 +98,11 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 			Version:   "1.0",
 			Service:   NewPublicTxPoolAPI(apiBackend),
 			Public:    true,
+		}, {
+			Namespace: "tau",
+			Version:   "1.0",
+			Service:   NewPublicProfileAPI(apiBackend),
+			Public:    true,
 		}, {
 			Namespace: "debug",
 			Version:   "1.0",
@@ -20,6 +20,7 @@ package web3ext
 var Modules = map[string]string{
 	"accounting": AccountingJs,
 	"admin":      AdminJs,
+	"auth":       AuthJs,
 	"chequebook": ChequebookJs,
 	"clique":     CliqueJs,
 	"tauhash":     TauashJs,
@@ -180,6 +181,16 @@ web3._extend({
 			call: 'admin_sleepBlocks',
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'trafficHistory',
+			call: 'admin_trafficHistory',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'setDataCap',
+			call: 'admin_setDataCap',
+			params: 3
+		}),
 		new web3._extend.Method({
 			name: 'startRPC',
 			call: 'admin_startRPC',
@@ -210,6 +221,22 @@ web3._extend({
 			name: 'peers',
 			getter: 'admin_peers'
 		}),
+		new web3._extend.Property({
+			name: 'peerScores',
+			getter: 'admin_peerScores'
+		}),
+		new web3._extend.Property({
+			name: 'traffic',
+			getter: 'admin_traffic'
+		}),
+		new web3._extend.Property({
+			name: 'dataCaps',
+			getter: 'admin_dataCaps'
+		}),
+		new web3._extend.Property({
+			name: 'ipldPeers',
+			getter: 'admin_ipldPeers'
+		}),
 		new web3._extend.Property({
 			name: 'datadir',
 			getter: 'admin_datadir'
@@ -465,11 +492,38 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'suggestFee',
+			call: 'tau_suggestFee',
+			params: 1,
+			inputFormatter: [null]
+		}),
 		new web3._extend.Method({
 			name: 'resend',
 			call: 'tau_resend',
-			params: 3,
-			inputFormatter: [web3._extend.formatters.inputTransactionFormatter, web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputTransactionFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'transfer',
+			call: 'tau_transfer',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'sendMessage',
+			call: 'tau_sendMessage',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'createChain',
+			call: 'tau_createChain',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'updateProfile',
+			call: 'tau_updateProfile',
+			params: 1
 		}),
 		new web3._extend.Method({
 			name: 'signTransaction',
@@ -528,6 +582,102 @@ web3._extend({
 			params: 3,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'newTransactionFilter',
+			call: 'tau_newTransactionFilter',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'newPendingTransactionFilter',
+			call: 'tau_newPendingTransactionFilter',
+			params: 1,
+			inputFormatter: [null]
+		}),
+		new web3._extend.Method({
+			name: 'getFilterChanges',
+			call: 'tau_getFilterChanges',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'uninstallFilter',
+			call: 'tau_uninstallFilter',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getTransactions',
+			call: 'tau_getTransactions',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getTransactionReceipt',
+			call: 'tau_getTransactionReceipt',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'followedChains',
+			call: 'tau_followedChains',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'relays',
+			call: 'tau_relays',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getSupply',
+			call: 'tau_getSupply',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getProof',
+			call: 'tau_getProof',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getProfile',
+			call: 'tau_getProfile',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'searchProfiles',
+			call: 'tau_searchProfiles',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getProfileHistory',
+			call: 'tau_getProfileHistory',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getChain',
+			call: 'tau_getChain',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'listChains',
+			call: 'tau_listChains',
+			params: 2,
+			inputFormatter: [null, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'searchChains',
+			call: 'tau_searchChains',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'followChain',
+			call: 'tau_followChain',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'unfollowChain',
+			call: 'tau_unfollowChain',
+			params: 1
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -586,6 +736,20 @@ web3._extend({
 			name: 'getHashrate',
 			call: 'miner_getHashrate'
 		}),
+		new web3._extend.Method({
+			name: 'reportDeviceStatus',
+			call: 'miner_reportDeviceStatus',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'setPolicy',
+			call: 'miner_setPolicy',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'deviceStatus',
+			call: 'miner_deviceStatus'
+		}),
 	],
 	properties: []
 });
@@ -640,6 +804,27 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'transfer',
+			call: 'personal_transfer',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputTransactionFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'sendMessage',
+			call: 'personal_sendMessage',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'createChain',
+			call: 'personal_createChain',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'updateProfile',
+			call: 'personal_updateProfile',
+			params: 2
+		}),
 		new web3._extend.Method({
 			name: 'unpair',
 			call: 'personal_unpair',
@@ -744,6 +929,35 @@ web3._extend({
 });
 `
 
+const AuthJs = `
+web3._extend({
+	property: 'auth',
+	methods: [
+		new web3._extend.Method({
+			name: 'createToken',
+			call: 'auth_createToken',
+			params: 4,
+			inputFormatter: [null, null, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'revokeToken',
+			call: 'auth_revokeToken',
+			params: 1
+		}),
+	],
+	properties: [
+		new web3._extend.Property({
+			name: 'tokens',
+			getter: 'auth_listTokens'
+		}),
+		new web3._extend.Property({
+			name: 'deniedCalls',
+			getter: 'auth_deniedCalls'
+		}),
+	]
+});
+`
+
 const AccountingJs = `
 web3._extend({
 	property: 'accounting',
@@ -67,6 +67,13 @@ func commonHashToCidString(codec uint64, h common.Hash) string {
 	return cid.NewCidV1(codec, mhash).String()
 }
 
+// TrieNodeCid returns the cid of an encoded trie node. Trie nodes are stored
+// in IPFS as raw blocks keyed by their keccak256 hash, so the cid resolves to
+// the node on any IPFS peer holding the state.
+func TrieNodeCid(rawdata []byte) cid.Cid {
+	return rawdataToCid(RawBinary, rawdata)
+}
+
 // getRLP encodes the given object to RLP returning its bytes.
 func getRLP(object interface{}) []byte {
 	buf := new(bytes.Buffer)
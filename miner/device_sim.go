@@ -0,0 +1,100 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import "sync"
+
+// SimulatedDevice is a device status driver for tests and desktop nodes. Each
+// change of the simulated conditions is emitted as a new DeviceStatus on the
+// channel returned by Statuses, which can be handed to Scheduler.Follow.
+type SimulatedDevice struct {
+	status DeviceStatus
+	out    chan DeviceStatus
+	mu     sync.Mutex
+}
+
+// NewSimulatedDevice creates a simulated device with a fully charged battery,
+// plugged into a charger on an unmetered network.
+func NewSimulatedDevice() *SimulatedDevice {
+	return &SimulatedDevice{
+		status: DeviceStatus{Charging: true, Battery: 100, Thermal: ThermalNominal},
+		out:    make(chan DeviceStatus),
+	}
+}
+
+// Statuses returns the stream of device status reports.
+func (d *SimulatedDevice) Statuses() <-chan DeviceStatus {
+	return d.out
+}
+
+// Status returns the current simulated conditions.
+func (d *SimulatedDevice) Status() DeviceStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.status
+}
+
+// Plug connects the simulated device to a charger.
+func (d *SimulatedDevice) Plug() { d.update(func(s *DeviceStatus) { s.Charging = true }) }
+
+// Unplug disconnects the simulated device from its charger.
+func (d *SimulatedDevice) Unplug() { d.update(func(s *DeviceStatus) { s.Charging = false }) }
+
+// SetBattery sets the battery level in percent.
+func (d *SimulatedDevice) SetBattery(level uint8) {
+	if level > 100 {
+		level = 100
+	}
+	d.update(func(s *DeviceStatus) { s.Battery = level })
+}
+
+// Drain lowers the battery level by the given percentage points.
+func (d *SimulatedDevice) Drain(points uint8) {
+	d.update(func(s *DeviceStatus) {
+		if s.Battery < points {
+			s.Battery = 0
+		} else {
+			s.Battery -= points
+		}
+	})
+}
+
+// SetMetered switches the simulated network between metered and unmetered.
+func (d *SimulatedDevice) SetMetered(metered bool) {
+	d.update(func(s *DeviceStatus) { s.Metered = metered })
+}
+
+// SetThermal sets the simulated thermal state.
+func (d *SimulatedDevice) SetThermal(state ThermalState) {
+	d.update(func(s *DeviceStatus) { s.Thermal = state })
+}
+
+// Close terminates the status stream.
+func (d *SimulatedDevice) Close() {
+	close(d.out)
+}
+
+// update mutates the simulated status and emits it, blocking until the
+// consumer has received the report.
+func (d *SimulatedDevice) update(change func(*DeviceStatus)) {
+	d.mu.Lock()
+	change(&d.status)
+	status := d.status
+	d.mu.Unlock()
+
+	d.out <- status
+}
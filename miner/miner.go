@@ -55,8 +55,11 @@ type Miner struct {
 	engine   consensus.Engine
 	exitCh   chan struct{}
 
+	scheduler *Scheduler // Device aware policy scheduler pausing and throttling the miner
+
 	canStart    int32 // can start indicates whtauer we can start the mining operation
 	shouldStart int32 // should start indicates whtauer we should start after sync
+	paused      int32 // paused indicates whtauer the device policy suspended mining
 }
 
 func New(tau Backend, config *Config, chainConfig *params.ChainConfig, mux *event.TypeMux, engine consensus.Engine, isLocalBlock func(block *types.Block) bool) *Miner {
@@ -68,6 +71,7 @@ func New(tau Backend, config *Config, chainConfig *params.ChainConfig, mux *even
 		worker:   newWorker(config, chainConfig, engine, tau, mux, isLocalBlock),
 		canStart: 1,
 	}
+	miner.scheduler = newScheduler(miner)
 	go miner.update()
 
 	return miner
@@ -120,6 +124,10 @@ func (self *Miner) Start(coinbase common.Address) {
 		log.Info("Network syncing, will start miner afterwards")
 		return
 	}
+	if atomic.LoadInt32(&self.paused) == 1 {
+		log.Info("Mining suspended by device policy, will start miner afterwards")
+		return
+	}
 	self.worker.start()
 }
 
@@ -129,6 +137,7 @@ func (self *Miner) Stop() {
 }
 
 func (self *Miner) Close() {
+	self.scheduler.stop()
 	self.worker.close()
 	close(self.exitCh)
 }
@@ -137,6 +146,32 @@ func (self *Miner) Mining() bool {
 	return self.worker.isRunning()
 }
 
+// Scheduler returns the device aware scheduler governing the miner.
+func (self *Miner) Scheduler() *Scheduler {
+	return self.scheduler
+}
+
+// pauseMining suspends or resumes mining on behalf of the device policy without
+// forgetting whtauer the user asked for mining.
+func (self *Miner) pauseMining(paused bool) {
+	if paused {
+		atomic.StoreInt32(&self.paused, 1)
+		if self.Mining() {
+			self.worker.stop()
+		}
+		return
+	}
+	atomic.StoreInt32(&self.paused, 0)
+	if atomic.LoadInt32(&self.shouldStart) == 1 && atomic.LoadInt32(&self.canStart) == 1 && !self.Mining() {
+		self.worker.start()
+	}
+}
+
+// setThrottle stretches the work recommit interval by the given factor.
+func (self *Miner) setThrottle(factor float64) {
+	self.worker.setThrottle(factor)
+}
+
 // SetRecommitInterval sets the interval for sealing work resubmitting.
 func (self *Miner) SetRecommitInterval(interval time.Duration) {
 	self.worker.setRecommitInterval(interval)
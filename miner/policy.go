@@ -0,0 +1,211 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ThermalState is the coarse thermal condition reported by the device.
+type ThermalState uint8
+
+const (
+	ThermalNominal  ThermalState = iota // Device is cool, no restrictions needed
+	ThermalFair                         // Device is warm, work should be reduced
+	ThermalSerious                      // Device is hot, work must be reduced significantly
+	ThermalCritical                     // Device is about to throttle itself, stop all work
+)
+
+var thermalNames = []string{"nominal", "fair", "serious", "critical"}
+
+// String implements fmt.Stringer.
+func (t ThermalState) String() string {
+	if int(t) < len(thermalNames) {
+		return thermalNames[t]
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ThermalState) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *ThermalState) UnmarshalText(input []byte) error {
+	for i, name := range thermalNames {
+		if strings.EqualFold(name, string(input)) {
+			*t = ThermalState(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown thermal state %q", input)
+}
+
+// DeviceStatus is a snapshot of the device conditions relevant for deciding
+// how much work the node may do. It is reported by the hosting application
+// through RPC or IPC whenever one of the conditions changes.
+type DeviceStatus struct {
+	Charging bool         `json:"charging"` // Whether the device is connected to a charger
+	Battery  uint8        `json:"battery"`  // Battery level in percent
+	Metered  bool         `json:"metered"`  // Whether the active network connection is metered
+	Thermal  ThermalState `json:"thermal"`  // Thermal condition of the device
+}
+
+// Decision is the verdict of a mining policy for a given device status.
+type Decision struct {
+	Mine     bool    `json:"mine"`             // Whether block production may run
+	Sync     bool    `json:"sync"`             // Whether chain synchronisation may run
+	Throttle float64 `json:"throttle"`         // Factor stretching the work recommit interval (1 = full speed)
+	Reason   string  `json:"reason,omitempty"` // Human readable explanation of any restriction
+}
+
+// unrestricted is the decision allowing all activity at full speed.
+var unrestricted = Decision{Mine: true, Sync: true, Throttle: 1}
+
+// MiningPolicy decides how the miner and the chain synchronisation should
+// behave under the given device conditions.
+type MiningPolicy interface {
+	Decide(status DeviceStatus) Decision
+}
+
+// BatteryPolicy stops mining when the battery runs low and slows it down when
+// the battery is half empty. There are no restrictions while charging.
+type BatteryPolicy struct {
+	MinLevel      uint8 // Battery level below which mining stops
+	ThrottleLevel uint8 // Battery level below which mining is slowed down
+}
+
+// Decide implements MiningPolicy.
+func (p *BatteryPolicy) Decide(status DeviceStatus) Decision {
+	decision := unrestricted
+	switch {
+	case status.Charging:
+	case status.Battery < p.MinLevel:
+		decision.Mine = false
+		decision.Reason = fmt.Sprintf("battery at %d%%", status.Battery)
+	case status.Battery < p.ThrottleLevel:
+		decision.Throttle = 2
+		decision.Reason = fmt.Sprintf("battery at %d%%", status.Battery)
+	}
+	return decision
+}
+
+// NetworkPolicy suspends synchronisation on metered connections. Mining is
+// suspended too, since blocks sealed on top of a stale head are wasted work.
+type NetworkPolicy struct {
+	AllowMetered bool // Whether to keep working on metered connections
+}
+
+// Decide implements MiningPolicy.
+func (p *NetworkPolicy) Decide(status DeviceStatus) Decision {
+	decision := unrestricted
+	if status.Metered && !p.AllowMetered {
+		decision.Mine, decision.Sync = false, false
+		decision.Reason = "metered network"
+	}
+	return decision
+}
+
+// ThermalPolicy slows down mining as the device heats up and stops all work
+// once the device reaches a critical temperature.
+type ThermalPolicy struct{}
+
+// Decide implements MiningPolicy.
+func (p *ThermalPolicy) Decide(status DeviceStatus) Decision {
+	decision := unrestricted
+	switch status.Thermal {
+	case ThermalNominal:
+		return decision
+	case ThermalFair:
+		decision.Throttle = 1.5
+	case ThermalSerious:
+		decision.Throttle = 4
+	default:
+		decision.Mine, decision.Sync = false, false
+	}
+	decision.Reason = "thermal state " + status.Thermal.String()
+	return decision
+}
+
+// combinedPolicy applies the most restrictive verdict of all its policies.
+type combinedPolicy []MiningPolicy
+
+// CombinePolicies creates a policy which only allows an activity if every one
+// of the given policies allows it, throttling by the largest requested factor.
+func CombinePolicies(policies ...MiningPolicy) MiningPolicy {
+	return combinedPolicy(policies)
+}
+
+// Decide implements MiningPolicy.
+func (policies combinedPolicy) Decide(status DeviceStatus) Decision {
+	var (
+		decision = unrestricted
+		reasons  []string
+	)
+	for _, policy := range policies {
+		verdict := policy.Decide(status)
+		decision.Mine = decision.Mine && verdict.Mine
+		decision.Sync = decision.Sync && verdict.Sync
+		if verdict.Throttle > decision.Throttle {
+			decision.Throttle = verdict.Throttle
+		}
+		if verdict.Reason != "" {
+			reasons = append(reasons, verdict.Reason)
+		}
+	}
+	decision.Reason = strings.Join(reasons, ", ")
+	return decision
+}
+
+// DefaultPolicy returns the policy used unless the application installs its
+// own: stop below 20% battery, slow down below 50%, pause on metered networks
+// and back off as the device heats up.
+func DefaultPolicy() MiningPolicy {
+	return CombinePolicies(
+		&BatteryPolicy{MinLevel: 20, ThrottleLevel: 50},
+		&NetworkPolicy{},
+		&ThermalPolicy{},
+	)
+}
+
+// unrestrictedPolicy lets the node work at full speed regardless of the device.
+type unrestrictedPolicy struct{}
+
+// Decide implements MiningPolicy.
+func (unrestrictedPolicy) Decide(DeviceStatus) Decision { return unrestricted }
+
+// PolicyByName returns one of the preset policies:
+//   - "default":      DefaultPolicy
+//   - "charging":     only mine while the device is charging
+//   - "unrestricted": ignore the device conditions altogether
+func PolicyByName(name string) (MiningPolicy, error) {
+	switch name {
+	case "default", "":
+		return DefaultPolicy(), nil
+	case "charging":
+		return CombinePolicies(
+			&BatteryPolicy{MinLevel: 101},
+			&NetworkPolicy{},
+			&ThermalPolicy{},
+		), nil
+	case "unrestricted":
+		return unrestrictedPolicy{}, nil
+	}
+	return nil, fmt.Errorf("unknown mining policy %q", name)
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"sync"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+)
+
+// SyncController is implemented by the component running chain synchronisation
+// so the scheduler can suspend it under adverse device conditions.
+type SyncController interface {
+	SetSyncAllowed(allowed bool)
+}
+
+// miningController is the part of the miner the scheduler drives.
+type miningController interface {
+	pauseMining(paused bool)
+	setThrottle(factor float64)
+}
+
+// Scheduler evaluates device status reports against a mining policy and
+// starts, stops or throttles the miner and the chain synchronisation.
+type Scheduler struct {
+	policy   MiningPolicy
+	status   *DeviceStatus // Last reported status, nil until the first report
+	decision Decision      // Decision currently in effect
+	miner    miningController
+	sync     SyncController
+
+	mu   sync.Mutex
+	quit chan struct{}
+}
+
+// newScheduler creates a scheduler driving the given miner with the default
+// policy. Until a device status is reported, nothing is restricted.
+func newScheduler(miner miningController) *Scheduler {
+	return &Scheduler{
+		policy:   DefaultPolicy(),
+		decision: unrestricted,
+		miner:    miner,
+		quit:     make(chan struct{}),
+	}
+}
+
+// SetPolicy replaces the active policy and re-evaluates the last status.
+func (s *Scheduler) SetPolicy(policy MiningPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policy = policy
+	if s.status != nil {
+		s.apply(policy.Decide(*s.status))
+	}
+}
+
+// SetSyncController installs the component whose synchronisation is governed
+// by the scheduler.
+func (s *Scheduler) SetSyncController(sync SyncController) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sync = sync
+	sync.SetSyncAllowed(s.decision.Sync)
+}
+
+// Report feeds a new device status into the scheduler and returns the
+// resulting decision.
+func (s *Scheduler) Report(status DeviceStatus) Decision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status = &status
+	s.apply(s.policy.Decide(status))
+	return s.decision
+}
+
+// Follow feeds every status received on the given channel into the scheduler
+// until the channel is closed or the scheduler stops.
+func (s *Scheduler) Follow(statuses <-chan DeviceStatus) {
+	go func() {
+		for {
+			select {
+			case status, ok := <-statuses:
+				if !ok {
+					return
+				}
+				s.Report(status)
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Status returns the last reported device status (nil if none was reported)
+// along with the decision in effect.
+func (s *Scheduler) Status() (*DeviceStatus, Decision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status == nil {
+		return nil, s.decision
+	}
+	status := *s.status
+	return &status, s.decision
+}
+
+// stop terminates all status streams followed by the scheduler.
+func (s *Scheduler) stop() {
+	close(s.quit)
+}
+
+// apply enacts a decision, only touching the components whose allowance changed.
+// The caller must hold the lock.
+func (s *Scheduler) apply(decision Decision) {
+	if decision.Throttle < 1 {
+		decision.Throttle = 1
+	}
+	prev := s.decision
+	s.decision = decision
+
+	if prev.Mine != decision.Mine {
+		if decision.Mine {
+			log.Info("Device policy allows mining")
+		} else {
+			log.Info("Device policy suspends mining", "reason", decision.Reason)
+		}
+		s.miner.pauseMining(!decision.Mine)
+	}
+	if prev.Throttle != decision.Throttle {
+		log.Info("Device policy throttles mining", "factor", decision.Throttle, "reason", decision.Reason)
+		s.miner.setThrottle(decision.Throttle)
+	}
+	if prev.Sync != decision.Sync {
+		if decision.Sync {
+			log.Info("Device policy allows chain sync")
+		} else {
+			log.Info("Device policy suspends chain sync", "reason", decision.Reason)
+		}
+		if s.sync != nil {
+			s.sync.SetSyncAllowed(decision.Sync)
+		}
+	}
+}
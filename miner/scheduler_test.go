@@ -0,0 +1,124 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// testController records the commands issued by the scheduler.
+type testController struct {
+	mu       sync.Mutex
+	paused   bool
+	throttle float64
+	sync     bool
+}
+
+func (c *testController) pauseMining(paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = paused
+}
+
+func (c *testController) setThrottle(factor float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.throttle = factor
+}
+
+func (c *testController) SetSyncAllowed(allowed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sync = allowed
+}
+
+func (c *testController) state() (bool, float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused, c.throttle, c.sync
+}
+
+// waitState waits until the controller reaches the expected state.
+func waitState(t *testing.T, ctrl *testController, paused bool, throttle float64, sync bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); ; {
+		p, th, s := ctrl.state()
+		if p == paused && th == throttle && s == sync {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("controller state mismatch: have paused=%v throttle=%v sync=%v, want paused=%v throttle=%v sync=%v", p, th, s, paused, throttle, sync)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// Tests that the default policy reacts to a simulated device being unplugged,
+// drained, put on a metered network and overheated.
+func TestSchedulerDefaultPolicy(t *testing.T) {
+	ctrl := &testController{throttle: 1}
+	sched := newScheduler(ctrl)
+	sched.SetSyncController(ctrl)
+	defer sched.stop()
+
+	device := NewSimulatedDevice()
+	sched.Follow(device.Statuses())
+
+	device.Unplug()
+	waitState(t, ctrl, false, 1, true)
+
+	device.SetBattery(40)
+	waitState(t, ctrl, false, 2, true)
+
+	device.Drain(30)
+	waitState(t, ctrl, true, 1, true)
+
+	device.Plug()
+	waitState(t, ctrl, false, 1, true)
+
+	device.SetMetered(true)
+	waitState(t, ctrl, true, 1, false)
+
+	device.SetMetered(false)
+	device.SetThermal(ThermalSerious)
+	waitState(t, ctrl, false, 4, true)
+
+	device.SetThermal(ThermalCritical)
+	waitState(t, ctrl, true, 1, false)
+}
+
+// Tests that switching policies re-evaluates the last reported status.
+func TestSchedulerSetPolicy(t *testing.T) {
+	ctrl := &testController{throttle: 1, sync: true}
+	sched := newScheduler(ctrl)
+	defer sched.stop()
+
+	if decision := sched.Report(DeviceStatus{Battery: 90}); !decision.Mine {
+		t.Fatalf("mining suspended on a full battery: %v", decision.Reason)
+	}
+	policy, err := PolicyByName("charging")
+	if err != nil {
+		t.Fatalf("failed to look up policy: %v", err)
+	}
+	sched.SetPolicy(policy)
+	if _, decision := sched.Status(); decision.Mine {
+		t.Fatalf("charging policy allows mining on battery")
+	}
+	waitState(t, ctrl, true, 1, true)
+}
@@ -158,8 +158,9 @@ type worker struct {
 	snapshotState *state.StateDB
 
 	// atomic status counters
-	running int32 // The indicator whtauer the consensus engine is running or not.
-	newTxs  int32 // New arrival transaction count since last sealing work submitting.
+	running  int32 // The indicator whtauer the consensus engine is running or not.
+	newTxs   int32 // New arrival transaction count since last sealing work submitting.
+	throttle int32 // Percentage the recommit interval is stretched to by the device policy.
 
 	// External functions
 	isLocalBlock func(block *types.Block) bool // Function used to determine whtauer the specified block is mined by local miner.
@@ -194,6 +195,7 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		startCh:            make(chan struct{}, 1),
 		resubmitIntervalCh: make(chan time.Duration),
 		resubmitAdjustCh:   make(chan *intervalAdjust, resubmitAdjustChanSize),
+		throttle:           100,
 	}
 	// Subscribe NewTxsEvent for tx pool
 	worker.txsSub = tau.TxPool().SubscribeNewTxsEvent(worker.txsCh)
@@ -231,6 +233,19 @@ func (w *worker) setRecommitInterval(interval time.Duration) {
 	w.resubmitIntervalCh <- interval
 }
 
+// setThrottle stretches the sealing work recommit interval by the given factor.
+func (w *worker) setThrottle(factor float64) {
+	if factor < 1 {
+		factor = 1
+	}
+	atomic.StoreInt32(&w.throttle, int32(factor*100))
+}
+
+// throttled returns the given interval stretched by the current throttle.
+func (w *worker) throttled(interval time.Duration) time.Duration {
+	return interval * time.Duration(atomic.LoadInt32(&w.throttle)) / 100
+}
+
 // pending returns the pending state and corresponding block.
 func (w *worker) pending() (*types.Block, *state.StateDB) {
 	// return a snapshot to avoid contention on currentMu mutex
@@ -290,7 +305,7 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 		}
 		interrupt = new(int32)
 		w.newWorkCh <- &newWorkReq{interrupt: interrupt, noempty: noempty, timestamp: timestamp}
-		timer.Reset(recommit)
+		timer.Reset(w.throttled(recommit))
 		atomic.StoreInt32(&w.newTxs, 0)
 	}
 	// recalcRecommit recalculates the resubmitting interval upon feedback.
@@ -343,7 +358,7 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 			if w.isRunning() {
 				// Short circuit if no new transaction arrives.
 				if atomic.LoadInt32(&w.newTxs) == 0 {
-					timer.Reset(recommit)
+					timer.Reset(w.throttled(recommit))
 					continue
 				}
 				commit(true, commitInterruptResubmit)
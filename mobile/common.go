@@ -0,0 +1,291 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Contains all the wrappers from the common package.
+
+package gtau
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+)
+
+// Hash represents the 32 byte Keccak256 hash of arbitrary data.
+type Hash struct {
+	hash common.Hash
+}
+
+// NewHashFromBytes converts a slice of bytes to a hash value.
+func NewHashFromBytes(binary []byte) (hash *Hash, _ error) {
+	h := new(Hash)
+	if err := h.SetBytes(common.CopyBytes(binary)); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// NewHashFromHex converts a hex string to a hash value.
+func NewHashFromHex(hex string) (hash *Hash, _ error) {
+	h := new(Hash)
+	if err := h.SetHex(hex); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// SetBytes sets the specified slice of bytes as the hash value.
+func (h *Hash) SetBytes(hash []byte) error {
+	if length := len(hash); length != common.HashLength {
+		return fmt.Errorf("invalid hash length: %v != %v", length, common.HashLength)
+	}
+	copy(h.hash[:], hash)
+	return nil
+}
+
+// GetBytes retrieves the byte representation of the hash.
+func (h *Hash) GetBytes() []byte {
+	return h.hash[:]
+}
+
+// SetHex sets the specified hex string as the hash value.
+func (h *Hash) SetHex(hash string) error {
+	hash = strings.ToLower(hash)
+	if len(hash) >= 2 && hash[:2] == "0x" {
+		hash = hash[2:]
+	}
+	if length := len(hash); length != 2*common.HashLength {
+		return fmt.Errorf("invalid hash hex length: %v != %v", length, 2*common.HashLength)
+	}
+	bin, err := hex.DecodeString(hash)
+	if err != nil {
+		return err
+	}
+	copy(h.hash[:], bin)
+	return nil
+}
+
+// GetHex retrieves the hex string representation of the hash.
+func (h *Hash) GetHex() string {
+	return h.hash.Hex()
+}
+
+// String implements Stringer interface for printable representation of the hash.
+func (h *Hash) String() string {
+	return h.GetHex()
+}
+
+// hashOrEmpty returns the wrapped hash, or the zero hash for a nil wrapper.
+func (h *Hash) hashOrEmpty() common.Hash {
+	if h == nil {
+		return common.Hash{}
+	}
+	return h.hash
+}
+
+// Address represents the 20 byte address of a Tau account.
+type Address struct {
+	address common.Address
+}
+
+// NewAddressFromBytes converts a slice of bytes to an address value.
+func NewAddressFromBytes(binary []byte) (address *Address, _ error) {
+	a := new(Address)
+	if err := a.SetBytes(common.CopyBytes(binary)); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// NewAddressFromHex converts a hex string to an address value.
+func NewAddressFromHex(hex string) (address *Address, _ error) {
+	a := new(Address)
+	if err := a.SetHex(hex); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// SetBytes sets the specified slice of bytes as the address value.
+func (a *Address) SetBytes(address []byte) error {
+	if length := len(address); length != common.AddressLength {
+		return fmt.Errorf("invalid address length: %v != %v", length, common.AddressLength)
+	}
+	copy(a.address[:], address)
+	return nil
+}
+
+// GetBytes retrieves the byte representation of the address.
+func (a *Address) GetBytes() []byte {
+	return a.address[:]
+}
+
+// SetHex sets the specified hex string as the address value.
+func (a *Address) SetHex(address string) error {
+	address = strings.ToLower(address)
+	if len(address) >= 2 && address[:2] == "0x" {
+		address = address[2:]
+	}
+	if length := len(address); length != 2*common.AddressLength {
+		return fmt.Errorf("invalid address hex length: %v != %v", length, 2*common.AddressLength)
+	}
+	bin, err := hex.DecodeString(address)
+	if err != nil {
+		return err
+	}
+	copy(a.address[:], bin)
+	return nil
+}
+
+// GetHex retrieves the hex string representation of the address.
+func (a *Address) GetHex() string {
+	return a.address.Hex()
+}
+
+// String implements Stringer interface for printable representation of the address.
+func (a *Address) String() string {
+	return a.GetHex()
+}
+
+// Addresses represents a slice of addresses.
+type Addresses struct{ addresses []common.Address }
+
+// NewAddresses creates a slice of uninitialized addresses.
+func NewAddresses(size int) *Addresses {
+	return &Addresses{
+		addresses: make([]common.Address, size),
+	}
+}
+
+// NewAddressesEmpty creates an empty slice of Addresses values.
+func NewAddressesEmpty() *Addresses {
+	return NewAddresses(0)
+}
+
+// Size returns the number of addresses in the slice.
+func (a *Addresses) Size() int {
+	return len(a.addresses)
+}
+
+// Get returns the address at the given index from the slice.
+func (a *Addresses) Get(index int) (address *Address, _ error) {
+	if index < 0 || index >= len(a.addresses) {
+		return nil, errors.New("index out of bounds")
+	}
+	return &Address{a.addresses[index]}, nil
+}
+
+// Set sets the address at the given index in the slice.
+func (a *Addresses) Set(index int, address *Address) error {
+	if index < 0 || index >= len(a.addresses) {
+		return errors.New("index out of bounds")
+	}
+	a.addresses[index] = address.address
+	return nil
+}
+
+// Append adds a new address element to the end of the slice.
+func (a *Addresses) Append(address *Address) {
+	a.addresses = append(a.addresses, address.address)
+}
+
+// BigInt represents a signed multi-precision integer.
+type BigInt struct {
+	bigint *big.Int
+}
+
+// NewBigInt allocates and returns a new BigInt set to x.
+func NewBigInt(x int64) *BigInt {
+	return &BigInt{big.NewInt(x)}
+}
+
+// GetBytes returns the absolute value of x as a big-endian byte slice.
+func (bi *BigInt) GetBytes() []byte {
+	return bi.bigint.Bytes()
+}
+
+// String returns the value of x as a formatted decimal string.
+func (bi *BigInt) String() string {
+	return bi.bigint.String()
+}
+
+// GetInt64 returns the int64 representation of x. If x cannot be represented in
+// an int64, the result is undefined.
+func (bi *BigInt) GetInt64() int64 {
+	return bi.bigint.Int64()
+}
+
+// SetBytes interprets buf as the bytes of a big-endian unsigned integer and sets
+// the big int to that value.
+func (bi *BigInt) SetBytes(buf []byte) {
+	bi.bigint.SetBytes(common.CopyBytes(buf))
+}
+
+// SetInt64 sets the big int to x.
+func (bi *BigInt) SetInt64(x int64) {
+	bi.bigint.SetInt64(x)
+}
+
+// SetString sets the big int to x.
+//
+// The string prefix determines the actual conversion base. A prefix of "0x" or
+// "0X" selects base 16; the "0" prefix selects base 8, and a "0b" or "0B" prefix
+// selects base 2. Otherwise the selected base is 10.
+func (bi *BigInt) SetString(x string, base int) {
+	bi.bigint.SetString(x, base)
+}
+
+// Strings represents a slice of strs.
+type Strings struct{ strs []string }
+
+// Size returns the number of strs in the slice.
+func (s *Strings) Size() int {
+	return len(s.strs)
+}
+
+// Get returns the string at the given index from the slice.
+func (s *Strings) Get(index int) (str string, _ error) {
+	if index < 0 || index >= len(s.strs) {
+		return "", errors.New("index out of bounds")
+	}
+	return s.strs[index], nil
+}
+
+// Set sets the string at the given index in the slice.
+func (s *Strings) Set(index int, str string) error {
+	if index < 0 || index >= len(s.strs) {
+		return errors.New("index out of bounds")
+	}
+	s.strs[index] = str
+	return nil
+}
+
+// String implements the Stringer interface.
+func (s *Strings) String() string {
+	return fmt.Sprintf("%v", s.strs)
+}
+
+// bigOrNil returns the wrapped big int, or nil for a nil wrapper.
+func bigOrNil(bi *BigInt) *big.Int {
+	if bi == nil {
+		return nil
+	}
+	return bi.bigint
+}
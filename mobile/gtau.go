@@ -0,0 +1,314 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Contains all the wrappers from the node package to support client side node
+// management on mobile platforms.
+
+package gtau
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/miner"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/node"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tauclient"
+)
+
+// NodeConfig represents the collection of configuration values to fine tune the
+// gtau node embedded into a mobile process. The available values are a subset of
+// the entire API provided by go-tau to reduce the maintenance surface and dev
+// complexity.
+type NodeConfig struct {
+	// Bootstrap nodes used to establish connectivity with the rest of the network.
+	// Nil selects the mainnet bootnodes.
+	BootstrapNodes *Strings
+
+	// MaxPeers is the maximum number of peers that can be connected. If this is
+	// set to zero, then only the configured static and trusted peers can connect.
+	MaxPeers int
+
+	// NoDiscovery disables the peer discovery mechanism.
+	NoDiscovery bool
+
+	// ListenAddr is the network address the node listens on for peers. Mobile
+	// nodes typically leave it at the default of a random port.
+	ListenAddr string
+
+	// TauNetworkID is the network identifier used by the TAU protocol.
+	TauNetworkID int64
+
+	// TauGenesis is the genesis JSON to use to seed the blockchain with. An
+	// empty genesis state is equivalent to using the mainnet's state.
+	TauGenesis string
+
+	// MinerThreads is the number of threads used when mining is started.
+	MinerThreads int
+}
+
+// defaultNodeConfig contains the default node configuration values to use if all
+// or some fields are missing from the user's specified list.
+var defaultNodeConfig = &NodeConfig{
+	MaxPeers:     25,
+	ListenAddr:   ":0",
+	TauNetworkID: int64(tau.DefaultConfig.NetworkId),
+	MinerThreads: 1,
+}
+
+// NewNodeConfig creates a new node option set, initialized to the default values.
+func NewNodeConfig() *NodeConfig {
+	config := *defaultNodeConfig
+	return &config
+}
+
+// Node represents a gtau TAU node instance.
+type Node struct {
+	node    *node.Node
+	threads int
+}
+
+// NewNode creates and configures a new gtau node.
+func NewNode(datadir string, config *NodeConfig) (stack *Node, _ error) {
+	// If no or partial configurations were specified, use defaults
+	if config == nil {
+		config = NewNodeConfig()
+	}
+	if config.MaxPeers == 0 {
+		config.MaxPeers = defaultNodeConfig.MaxPeers
+	}
+	if config.TauNetworkID == 0 {
+		config.TauNetworkID = defaultNodeConfig.TauNetworkID
+	}
+	if config.MinerThreads == 0 {
+		config.MinerThreads = defaultNodeConfig.MinerThreads
+	}
+	bootnodes, err := parseBootnodes(config.BootstrapNodes)
+	if err != nil {
+		return nil, err
+	}
+	// Create the empty networking stack
+	nodeConf := &node.Config{
+		Name:              clientIdentifier,
+		Version:           params.VersionWithMeta,
+		DataDir:           datadir,
+		KeyStoreDir:       filepath.Join(datadir, "keystore"), // Mobile should never use internal keystores!
+		UseLightweightKDF: true,
+		P2P: p2p.Config{
+			NoDiscovery:    config.NoDiscovery,
+			ListenAddr:     config.ListenAddr,
+			MaxPeers:       config.MaxPeers,
+			BootstrapNodes: bootnodes,
+		},
+	}
+	rawStack, err := node.New(nodeConf)
+	if err != nil {
+		return nil, err
+	}
+	// Register the TAU protocol
+	tauConf := tau.DefaultConfig
+	tauConf.NetworkId = uint64(config.TauNetworkID)
+	if config.TauGenesis != "" {
+		genesis := new(core.Genesis)
+		if err := json.Unmarshal([]byte(config.TauGenesis), genesis); err != nil {
+			return nil, fmt.Errorf("invalid genesis spec: %v", err)
+		}
+		tauConf.Genesis = genesis
+	}
+	if err := rawStack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
+		return tau.New(ctx, &tauConf)
+	}); err != nil {
+		return nil, fmt.Errorf("tau init: %v", err)
+	}
+	return &Node{node: rawStack, threads: config.MinerThreads}, nil
+}
+
+// clientIdentifier is the name the embedded node advertises to its peers.
+const clientIdentifier = "gtau-mobile"
+
+// parseBootnodes converts the configured enode URLs, falling back to the
+// mainnet bootnodes if none are given.
+func parseBootnodes(urls *Strings) ([]*enode.Node, error) {
+	list := params.MainnetBootnodes
+	if urls != nil {
+		list = urls.strs
+	}
+	nodes := make([]*enode.Node, 0, len(list))
+	for _, url := range list {
+		n, err := enode.ParseV4(url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bootnode %q: %v", url, err)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// Close terminates a running node along with all it's services, tearing internal
+// state doen too. It's not possible to restart a closed node.
+func (n *Node) Close() error {
+	return n.node.Close()
+}
+
+// Start creates a live P2P node and starts running it.
+func (n *Node) Start() error {
+	return n.node.Start()
+}
+
+// Stop terminates a running node along with all it's services. If the node was
+// not started, an error is returned.
+func (n *Node) Stop() error {
+	return n.node.Stop()
+}
+
+// GetTauClient retrieves a client to access the TAU subsystem.
+func (n *Node) GetTauClient() (client *TauClient, _ error) {
+	rpc, err := n.node.Attach()
+	if err != nil {
+		return nil, err
+	}
+	return &TauClient{tauclient.NewClient(rpc)}, nil
+}
+
+// GetNodeInfo gathers and returns a collection of metadata known about the host.
+func (n *Node) GetNodeInfo() *NodeInfo {
+	return &NodeInfo{n.node.Server().NodeInfo()}
+}
+
+// GetPeersInfo returns an array of metadata objects describing connected peers.
+func (n *Node) GetPeersInfo() *PeerInfos {
+	return &PeerInfos{n.node.Server().PeersInfo()}
+}
+
+// service retrieves the running TAU service of the node.
+func (n *Node) service() (*tau.Tau, error) {
+	var service *tau.Tau
+	if err := n.node.Service(&service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// StartMining starts block production with the configured number of threads.
+func (n *Node) StartMining() error {
+	service, err := n.service()
+	if err != nil {
+		return err
+	}
+	return service.StartMining(n.threads)
+}
+
+// StopMining stops block production.
+func (n *Node) StopMining() error {
+	service, err := n.service()
+	if err != nil {
+		return err
+	}
+	service.StopMining()
+	return nil
+}
+
+// IsMining reports whtauer the node is currently producing blocks.
+func (n *Node) IsMining() bool {
+	service, err := n.service()
+	if err != nil {
+		return false
+	}
+	return service.IsMining()
+}
+
+// ReportDeviceStatus feeds the current device conditions into the mining
+// policy. Battery is the charge level in percent, thermal is one of "nominal",
+// "fair", "serious" or "critical". The returned string explains any restriction
+// the policy imposed, or is empty if the node may run at full speed.
+func (n *Node) ReportDeviceStatus(charging bool, battery int, metered bool, thermal string) (string, error) {
+	status := miner.DeviceStatus{Charging: charging, Metered: metered}
+	switch {
+	case battery < 0:
+		status.Battery = 0
+	case battery > 100:
+		status.Battery = 100
+	default:
+		status.Battery = uint8(battery)
+	}
+	if err := status.Thermal.UnmarshalText([]byte(thermal)); err != nil {
+		return "", err
+	}
+	service, err := n.service()
+	if err != nil {
+		return "", err
+	}
+	return service.Miner().Scheduler().Report(status).Reason, nil
+}
+
+// FollowChain starts following the community chain with the given id.
+func (n *Node) FollowChain(id string) error {
+	service, err := n.service()
+	if err != nil {
+		return err
+	}
+	chain, err := toChainID(id)
+	if err != nil {
+		return err
+	}
+	return service.ChainDirectory().Follow(chain)
+}
+
+// UnfollowChain stops following the community chain with the given id.
+func (n *Node) UnfollowChain(id string) error {
+	service, err := n.service()
+	if err != nil {
+		return err
+	}
+	chain, err := toChainID(id)
+	if err != nil {
+		return err
+	}
+	return service.ChainDirectory().Unfollow(chain)
+}
+
+// GetFollowedChains returns the ids of the community chains followed by the node.
+func (n *Node) GetFollowedChains() (*Strings, error) {
+	service, err := n.service()
+	if err != nil {
+		return nil, err
+	}
+	ids := service.UserDb().FollowedChains()
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = string(id[:])
+	}
+	sort.Strings(strs)
+	return &Strings{strs}, nil
+}
+
+// toChainID converts a chain id passed over the mobile API, rejecting ids of
+// the wrong length instead of truncating or padding them.
+func toChainID(id string) (common.ChainID, error) {
+	var chain common.ChainID
+	if len(id) != common.ChainIDLength {
+		return chain, fmt.Errorf("invalid chain id length %d, want %d", len(id), common.ChainIDLength)
+	}
+	copy(chain[:], id)
+	return chain, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package gtau
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+)
+
+// Tests that the key store wrapper manages accounts end to end.
+func TestKeyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gtau-keystore-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ks := NewKeyStore(dir, LightScryptN, LightScryptP)
+	account, err := ks.NewAccount("Creation password")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if !ks.HasAddress(account.GetAddress()) {
+		t.Fatalf("new account missing from key store")
+	}
+	if accs := ks.GetAccounts(); accs.Size() != 1 {
+		t.Fatalf("account count mismatch: have %d, want 1", accs.Size())
+	}
+	hash := crypto.Keccak256([]byte("gtau"))
+	if _, err := ks.SignHash(account.GetAddress(), hash); err == nil {
+		t.Errorf("locked account signed hash")
+	}
+	if err := ks.Unlock(account, "Creation password"); err != nil {
+		t.Fatalf("failed to unlock account: %v", err)
+	}
+	sig, err := ks.SignHash(account.GetAddress(), hash)
+	if err != nil {
+		t.Fatalf("failed to sign hash: %v", err)
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		t.Fatalf("failed to recover signer: %v", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != account.GetAddress().address {
+		t.Errorf("signer mismatch: have %x, want %x", signer, account.GetAddress().address)
+	}
+	if err := ks.DeleteAccount(account, "Creation password"); err != nil {
+		t.Fatalf("failed to delete account: %v", err)
+	}
+	if ks.HasAddress(account.GetAddress()) {
+		t.Errorf("deleted account still in key store")
+	}
+}
+
+// Tests that transactions of every kind survive a JSON roundtrip through the
+// wrappers.
+func TestTransactionJSON(t *testing.T) {
+	sender, err := NewAddressFromHex("0x0102030405060708090a0b0c0d0e0f1011121314")
+	if err != nil {
+		t.Fatalf("failed to parse sender: %v", err)
+	}
+	receiver, _ := NewAddressFromHex("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	chain, err := NewChainTx([]byte("tau"), 4, 100, 7, sender, "news", "contact", "title", nil)
+	if err != nil {
+		t.Fatalf("failed to create chain transaction: %v", err)
+	}
+	txs := []*Transaction{
+		NewTransferTx([]byte("tau"), 1, 100, NewBigInt(3), sender, receiver, NewBigInt(10)),
+		NewMessageTx([]byte("tau"), 2, 100, NewBigInt(3), sender, nil, "hello", []byte("cid")),
+		NewProfileTx([]byte("tau"), 3, 100, NewBigInt(3), sender, "contact", "alice", []byte("cid")),
+		chain,
+	}
+	for _, tx := range txs {
+		data, err := tx.EncodeJSON()
+		if err != nil {
+			t.Fatalf("%s: failed to encode: %v", tx.GetKind(), err)
+		}
+		dec, err := NewTransactionFromJSON(tx.GetKind(), data)
+		if err != nil {
+			t.Fatalf("%s: failed to decode: %v", tx.GetKind(), err)
+		}
+		if dec.GetHash().GetHex() != tx.GetHash().GetHex() {
+			t.Errorf("%s: hash mismatch: have %v, want %v", tx.GetKind(), dec.GetHash(), tx.GetHash())
+		}
+		if dec.GetSender().GetHex() != sender.GetHex() {
+			t.Errorf("%s: sender mismatch: have %v, want %v", tx.GetKind(), dec.GetSender(), sender)
+		}
+	}
+	if to := txs[0].GetReceiver(); to == nil || to.GetHex() != receiver.GetHex() {
+		t.Errorf("transfer receiver mismatch: have %v, want %v", to, receiver)
+	}
+	if to := txs[1].GetReceiver(); to != nil {
+		t.Errorf("message has receiver %v", to)
+	}
+	if _, err := NewChainTx([]byte("tau"), 5, 100, 256, sender, "news", "", "", nil); err == nil {
+		t.Errorf("chain fee above one byte accepted")
+	}
+	if _, err := NewTransactionFromJSON("bogus", "{}"); err == nil {
+		t.Errorf("unknown kind accepted")
+	}
+}
+
+// Tests that chain ids of the wrong length are rejected instead of truncated or
+// padded.
+func TestToChainID(t *testing.T) {
+	id := strings.Repeat("c", common.ChainIDLength)
+	chain, err := toChainID(id)
+	if err != nil {
+		t.Fatalf("valid chain id rejected: %v", err)
+	}
+	if string(chain[:]) != id {
+		t.Errorf("chain id mismatch: have %q, want %q", chain[:], id)
+	}
+	for _, id := range []string{"", id[1:], id + "c"} {
+		if _, err := toChainID(id); err == nil {
+			t.Errorf("chain id of length %d accepted", len(id))
+		}
+	}
+}
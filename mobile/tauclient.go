@@ -0,0 +1,314 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Contains a wrapper for the TAU client.
+
+package gtau
+
+import (
+	"math/big"
+
+	tau "github.com/Tau-Coin/taucoin-mobile-mining-go"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/filters"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tauclient"
+)
+
+// TauClient provides access to the TAU APIs.
+type TauClient struct {
+	client *tauclient.Client
+}
+
+// NewTauClient connects a client to the given URL.
+func NewTauClient(rawurl string) (client *TauClient, _ error) {
+	rawClient, err := tauclient.Dial(rawurl)
+	return &TauClient{rawClient}, err
+}
+
+// GetHeaderByNumber returns a block header from the current canonical chain. If
+// number is <0, the latest known header is returned.
+func (tc *TauClient) GetHeaderByNumber(ctx *Context, number int64) (header *Header, _ error) {
+	var num *big.Int
+	if number >= 0 {
+		num = big.NewInt(number)
+	}
+	rawHeader, err := tc.client.HeaderByNumber(ctx.context, num)
+	return &Header{rawHeader}, err
+}
+
+// GetTransactionByHash returns the transaction with the given hash.
+func (tc *TauClient) GetTransactionByHash(ctx *Context, hash *Hash) (tx *Transaction, _ error) {
+	rawTx, _, err := tc.client.TransactionByHash(ctx.context, hash.hash)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{*rawTx}, nil
+}
+
+// GetTransactionReceipt returns the receipt of an included transaction.
+func (tc *TauClient) GetTransactionReceipt(ctx *Context, hash *Hash) (receipt *Receipt, _ error) {
+	rawReceipt, err := tc.client.TransactionReceipt(ctx.context, hash.hash)
+	if err != nil {
+		return nil, err
+	}
+	return &Receipt{rawReceipt}, nil
+}
+
+// GetBalanceAt returns the balance of the given account. If number is <0, the
+// balance is taken from the latest known block.
+func (tc *TauClient) GetBalanceAt(ctx *Context, account *Address, number int64) (balance *BigInt, _ error) {
+	var num *big.Int
+	if number >= 0 {
+		num = big.NewInt(number)
+	}
+	rawBalance, err := tc.client.BalanceAt(ctx.context, account.address, num)
+	return &BigInt{rawBalance}, err
+}
+
+// GetPendingBalanceAt returns the balance of the given account in the pending state.
+func (tc *TauClient) GetPendingBalanceAt(ctx *Context, account *Address) (balance *BigInt, _ error) {
+	rawBalance, err := tc.client.PendingBalanceAt(ctx.context, account.address)
+	return &BigInt{rawBalance}, err
+}
+
+// GetPendingNonceAt returns the account nonce of the given account in the
+// pending state. This is the nonce that should be used for the next transaction.
+func (tc *TauClient) GetPendingNonceAt(ctx *Context, account *Address) (nonce int64, _ error) {
+	rawNonce, err := tc.client.PendingNonceAt(ctx.context, account.address)
+	return int64(rawNonce), err
+}
+
+// SuggestFee retrieves the currently suggested transaction fee to allow a
+// timely inclusion of a transaction.
+func (tc *TauClient) SuggestFee(ctx *Context) (fee *BigInt, _ error) {
+	rawFee, err := tc.client.SuggestFee(ctx.context)
+	return &BigInt{rawFee}, err
+}
+
+// SendTransaction injects a locally signed transaction into the pending pool.
+func (tc *TauClient) SendTransaction(ctx *Context, tx *Transaction) error {
+	return tc.client.SendTransaction(ctx.context, &tx.tx)
+}
+
+// FollowedChains returns the ids of the chains followed by the node.
+func (tc *TauClient) FollowedChains(ctx *Context) (chains *Strings, _ error) {
+	ids, err := tc.client.FollowedChains(ctx.context)
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = string(id[:])
+	}
+	return &Strings{strs}, nil
+}
+
+// Receipt represents the outcome of an included transaction.
+type Receipt struct {
+	receipt *tauclient.Receipt
+}
+
+// GetTxHash returns the hash of the transaction.
+func (r *Receipt) GetTxHash() *Hash { return &Hash{r.receipt.TxHash} }
+
+// GetKind returns the kind of the transaction.
+func (r *Receipt) GetKind() string { return r.receipt.Kind.String() }
+
+// GetBlockHash returns the hash of the block including the transaction.
+func (r *Receipt) GetBlockHash() *Hash { return &Hash{r.receipt.BlockHash} }
+
+// GetBlockNumber returns the number of the block including the transaction.
+func (r *Receipt) GetBlockNumber() int64 { return int64(r.receipt.BlockNumber) }
+
+// GetFee returns the fee paid by the transaction.
+func (r *Receipt) GetFee() *BigInt { return &BigInt{r.receipt.Fee} }
+
+// Subscription represents an event subscription where events are
+// delivered on a data channel.
+type Subscription struct {
+	sub tau.Subscription
+}
+
+// Unsubscribe cancels the sending of events to the data channel
+// and closes the error channel.
+func (s *Subscription) Unsubscribe() {
+	s.sub.Unsubscribe()
+}
+
+// NewHeadHandler is a client-side subscription callback to invoke on events and
+// subscription failure.
+type NewHeadHandler interface {
+	OnNewHead(header *Header)
+	OnError(failure string)
+}
+
+// SubscribeNewHead subscribes to notifications about the current blockchain head
+// on the given channel.
+func (tc *TauClient) SubscribeNewHead(ctx *Context, handler NewHeadHandler, buffer int) (sub *Subscription, _ error) {
+	// Subscribe to the event internally
+	ch := make(chan *types.Header, buffer)
+	rawSub, err := tc.client.SubscribeNewHead(ctx.context, ch)
+	if err != nil {
+		return nil, err
+	}
+	// Start up a dispatcher to feed into the callback
+	go func() {
+		for {
+			select {
+			case header := <-ch:
+				handler.OnNewHead(&Header{header})
+
+			case err := <-rawSub.Err():
+				if err != nil {
+					handler.OnError(err.Error())
+				}
+				return
+			}
+		}
+	}()
+	return &Subscription{rawSub}, nil
+}
+
+// TxEvent is the notification of a transaction matching a subscription.
+type TxEvent struct {
+	event *filters.TxEvent
+}
+
+// GetKind returns the kind of the transaction.
+func (ev *TxEvent) GetKind() string { return ev.event.Kind.String() }
+
+// GetHash returns the hash of the transaction.
+func (ev *TxEvent) GetHash() *Hash { return &Hash{ev.event.Hash} }
+
+// GetFrom returns the sender of the transaction.
+func (ev *TxEvent) GetFrom() *Address { return &Address{ev.event.From} }
+
+// GetTo returns the receiver of a transfer, or nil for other kinds.
+func (ev *TxEvent) GetTo() *Address {
+	if ev.event.To == nil {
+		return nil
+	}
+	return &Address{*ev.event.To}
+}
+
+// GetChainID returns the id of the chain the transaction belongs to.
+func (ev *TxEvent) GetChainID() []byte { return common.CopyBytes(ev.event.ChainID) }
+
+// GetBlockNumber returns the number of the including block, or -1 for pending
+// transactions.
+func (ev *TxEvent) GetBlockNumber() int64 {
+	if ev.event.BlockNumber == nil {
+		return -1
+	}
+	return int64(*ev.event.BlockNumber)
+}
+
+// IsRemoved reports whtauer the transaction was dropped by a reorg.
+func (ev *TxEvent) IsRemoved() bool { return ev.event.Removed }
+
+// TxFilter selects the transactions a subscription is notified about. An
+// empty filter matches every transaction.
+type TxFilter struct {
+	crit filters.FilterCriteria
+}
+
+// NewTxFilter creates an empty transaction filter.
+func NewTxFilter() *TxFilter {
+	return new(TxFilter)
+}
+
+// AddKind restricts the filter to transactions of the given kind ("transfer",
+// "message", "chain" or "profile").
+func (f *TxFilter) AddKind(kind string) error {
+	var k types.TxKind
+	if err := k.UnmarshalText([]byte(kind)); err != nil {
+		return err
+	}
+	f.crit.Kinds = append(f.crit.Kinds, k)
+	return nil
+}
+
+// AddSender restricts the filter to transactions sent by the given account.
+func (f *TxFilter) AddSender(sender *Address) {
+	f.crit.Senders = append(f.crit.Senders, sender.address)
+}
+
+// AddReceiver restricts the filter to transfers to the given account.
+func (f *TxFilter) AddReceiver(receiver *Address) {
+	f.crit.Receivers = append(f.crit.Receivers, receiver.address)
+}
+
+// AddChain restricts the filter to transactions of the given chain.
+func (f *TxFilter) AddChain(chainID []byte) {
+	f.crit.ChainIDs = append(f.crit.ChainIDs, common.CopyBytes(chainID))
+}
+
+// TxEventHandler is a client-side subscription callback to invoke on events and
+// subscription failure.
+type TxEventHandler interface {
+	OnTransaction(event *TxEvent)
+	OnError(failure string)
+}
+
+// SubscribeTransactions subscribes to notifications about transactions matching
+// the filter being included in, or removed from, the canonical chain.
+func (tc *TauClient) SubscribeTransactions(ctx *Context, filter *TxFilter, handler TxEventHandler, buffer int) (sub *Subscription, _ error) {
+	return tc.subscribeTxEvents(ctx, filter, handler, buffer, false)
+}
+
+// SubscribePendingTransactions subscribes to notifications about transactions
+// matching the filter entering the transaction pool.
+func (tc *TauClient) SubscribePendingTransactions(ctx *Context, filter *TxFilter, handler TxEventHandler, buffer int) (sub *Subscription, _ error) {
+	return tc.subscribeTxEvents(ctx, filter, handler, buffer, true)
+}
+
+func (tc *TauClient) subscribeTxEvents(ctx *Context, filter *TxFilter, handler TxEventHandler, buffer int, pending bool) (*Subscription, error) {
+	var crit filters.FilterCriteria
+	if filter != nil {
+		crit = filter.crit
+	}
+	// Subscribe to the event internally
+	var (
+		ch     = make(chan *filters.TxEvent, buffer)
+		rawSub tau.Subscription
+		err    error
+	)
+	if pending {
+		rawSub, err = tc.client.SubscribePendingTransactions(ctx.context, crit, ch)
+	} else {
+		rawSub, err = tc.client.SubscribeTransactions(ctx.context, crit, ch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Start up a dispatcher to feed into the callback
+	go func() {
+		for {
+			select {
+			case event := <-ch:
+				handler.OnTransaction(&TxEvent{event})
+
+			case err := <-rawSub.Err():
+				if err != nil {
+					handler.OnError(err.Error())
+				}
+				return
+			}
+		}
+	}()
+	return &Subscription{rawSub}, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Contains all the wrappers from the core/types package.
+
+package gtau
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+)
+
+// Header represents a block header in the TAU blockchain.
+type Header struct {
+	header *types.Header
+}
+
+// GetVersion returns the block format version.
+func (h *Header) GetVersion() int { return int(h.header.Version) }
+
+// GetChainID returns the id of the chain the block belongs to.
+func (h *Header) GetChainID() []byte { return common.CopyBytes(h.header.ChainID[:]) }
+
+// GetNumber returns the height of the block.
+func (h *Header) GetNumber() int64 { return h.header.Number.Int64() }
+
+// GetParentHash returns the hash of the parent block.
+func (h *Header) GetParentHash() *Hash { return &Hash{h.header.ParentHash} }
+
+// GetCoinbase returns the address of the block miner.
+func (h *Header) GetCoinbase() *Address { return &Address{h.header.Coinbase} }
+
+// GetDifficulty returns the cumulative difficulty of the block.
+func (h *Header) GetDifficulty() *BigInt { return &BigInt{h.header.Difficulty} }
+
+// GetBaseTarget returns the base target of the block.
+func (h *Header) GetBaseTarget() *BigInt { return &BigInt{h.header.BaseTarget} }
+
+// GetTime returns the block timestamp in seconds.
+func (h *Header) GetTime() int64 { return int64(h.header.Time) }
+
+// GetRoot returns the state root of the block.
+func (h *Header) GetRoot() *Hash { return &Hash{h.header.Root} }
+
+// GetHash returns the hash of the header.
+func (h *Header) GetHash() *Hash { return &Hash{h.header.Hash()} }
+
+// EncodeJSON encodes a header into a JSON data dump.
+func (h *Header) EncodeJSON() (string, error) {
+	data, err := json.Marshal(h.header)
+	return string(data), err
+}
+
+// txVersion is the transaction format version produced by the constructors.
+var txVersion = types.OneByte{1}
+
+// Transaction represents a single TAU transaction of any kind.
+type Transaction struct {
+	tx types.Transaction
+}
+
+// NewTransferTx creates a transaction moving amount from sender to receiver.
+func NewTransferTx(chainID []byte, nonce int64, timestamp int64, fee *BigInt, sender, receiver *Address, amount *BigInt) *Transaction {
+	return &Transaction{types.NewTransferTransaction(txVersion, types.OneByte{0}, types.Byte32s(chainID), uint64(nonce), uint32(timestamp),
+		bigOrNil(fee), sender.address, receiver.address, bigOrNil(amount))}
+}
+
+// NewMessageTx creates a transaction posting a message to a community chain.
+// The referID may be nil if the message does not reply to another one.
+func NewMessageTx(chainID []byte, nonce int64, timestamp int64, fee *BigInt, sender *Address, referID *Hash, title string, content []byte) *Transaction {
+	tx := types.NewMessageTransaction(txVersion, types.OneByte{0}, types.Byte32s(chainID), uint64(nonce), uint32(timestamp),
+		bigOrNil(fee), sender.address, referID.hashOrEmpty(), types.Byte144s(title), types.Byte32s(content))
+	return &Transaction{tx}
+}
+
+// NewChainTx creates a transaction announcing a new community chain. The fee
+// of a chain announcement must fit in a single byte.
+func NewChainTx(chainID []byte, nonce int64, timestamp int64, fee int, sender *Address, name, contact, title string, description []byte) (*Transaction, error) {
+	if fee < 0 || fee > 255 {
+		return nil, fmt.Errorf("chain fee out of range: %d", fee)
+	}
+	tx := types.NewNewChainTransaction(txVersion, types.OneByte{0}, types.Byte32s(chainID), uint64(nonce), uint32(timestamp),
+		types.OneByte{byte(fee)}, sender.address, types.Byte20s(name), types.Byte32s(contact), types.Byte144s(title), types.Byte32s(description))
+	return &Transaction{tx}, nil
+}
+
+// NewProfileTx creates a transaction publishing the profile of sender.
+func NewProfileTx(chainID []byte, nonce int64, timestamp int64, fee *BigInt, sender *Address, contactName, name string, profile []byte) *Transaction {
+	tx := types.NewPersonalInfoTransaction(txVersion, types.OneByte{0}, types.Byte32s(chainID), uint64(nonce), uint32(timestamp),
+		bigOrNil(fee), sender.address, types.Byte32s(contactName), types.Byte20s(name), types.Byte32s(profile))
+	return &Transaction{tx}
+}
+
+// NewTransactionFromJSON parses a transaction of the given kind ("transfer",
+// "message", "chain" or "profile") from its JSON representation.
+func NewTransactionFromJSON(kind string, data string) (*Transaction, error) {
+	var k types.TxKind
+	if err := k.UnmarshalText([]byte(kind)); err != nil {
+		return nil, err
+	}
+	tx, err := types.NewTxOfKind(k)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(data), tx); err != nil {
+		return nil, err
+	}
+	return &Transaction{tx}, nil
+}
+
+// EncodeJSON encodes a transaction into a JSON data dump.
+func (tx *Transaction) EncodeJSON() (string, error) {
+	data, err := tx.tx.MarshalJSON()
+	return string(data), err
+}
+
+// GetKind returns the kind of the transaction.
+func (tx *Transaction) GetKind() string { return types.KindOf(tx.tx).String() }
+
+// GetHash returns the hash of the transaction.
+func (tx *Transaction) GetHash() *Hash { return &Hash{tx.tx.Hash()} }
+
+// GetNonce returns the account nonce of the transaction.
+func (tx *Transaction) GetNonce() int64 { return int64(tx.tx.Nonce()) }
+
+// GetFee returns the fee paid by the transaction.
+func (tx *Transaction) GetFee() *BigInt { return &BigInt{tx.tx.Fee()} }
+
+// GetValue returns the amount transferred, zero for non-transfer kinds.
+func (tx *Transaction) GetValue() *BigInt {
+	if value := tx.tx.Value(); value != nil {
+		return &BigInt{value}
+	}
+	return NewBigInt(0)
+}
+
+// GetSender returns the account that created the transaction.
+func (tx *Transaction) GetSender() *Address { return &Address{types.SenderOf(tx.tx)} }
+
+// GetReceiver returns the receiver of a transfer, or nil for other kinds.
+func (tx *Transaction) GetReceiver() *Address {
+	if to := types.ReceiverOf(tx.tx); to != nil {
+		return &Address{*to}
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (tx *Transaction) String() string {
+	return fmt.Sprintf("%v transaction %x", types.KindOf(tx.tx), tx.tx.Hash())
+}
+
+// Transactions represents a slice of transactions.
+type Transactions struct{ txs []types.Transaction }
+
+// Size returns the number of transactions in the slice.
+func (txs *Transactions) Size() int {
+	return len(txs.txs)
+}
+
+// Get returns the transaction at the given index from the slice.
+func (txs *Transactions) Get(index int) (tx *Transaction, _ error) {
+	if index < 0 || index >= len(txs.txs) {
+		return nil, errors.New("index out of bounds")
+	}
+	return &Transaction{txs.txs[index]}, nil
+}
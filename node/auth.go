@@ -0,0 +1,516 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+)
+
+const (
+	maxDeniedCalls     = 256              // Recent denials kept in memory for auth_deniedCalls
+	maxAuditEntries    = 1024             // Distinct denials aggregated between two audit log flushes
+	auditFlushInterval = 10 * time.Second // Interval of writing the aggregated denials to the audit log
+	maxAuditLogSize    = 16 * 1024 * 1024 // Size after which the audit log is rotated
+)
+
+var (
+	errMalformedToken = errors.New("malformed token")
+	errInvalidToken   = errors.New("invalid token signature")
+	errExpiredToken   = errors.New("token expired")
+	errUnknownToken   = errors.New("unknown or revoked token")
+	errEmptyGrant     = errors.New("token must grant at least one namespace or method")
+)
+
+// TokenInfo describes an RPC access token. The token itself is not stored,
+// only the grant it was issued with.
+type TokenInfo struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Namespaces []string   `json:"namespaces,omitempty"`
+	Methods    []string   `json:"methods,omitempty"`
+	Created    time.Time  `json:"created"`
+	Expires    *time.Time `json:"expires,omitempty"`
+}
+
+// expired reports whether the token is past its expiry time.
+func (t *TokenInfo) expired(now time.Time) bool {
+	return t.Expires != nil && now.After(*t.Expires)
+}
+
+// DeniedCall is an entry of the RPC audit log.
+type DeniedCall struct {
+	Time   time.Time `json:"time"`
+	Remote string    `json:"remote"`
+	Token  string    `json:"token,omitempty"`  // ID of the token used, empty if unauthenticated
+	Method string    `json:"method,omitempty"` // Method called, empty if refused before decoding
+	Reason string    `json:"reason"`
+	Count  int       `json:"count,omitempty"` // Number of identical denials since Time, audit log only
+}
+
+// auditKey identifies the identical denials aggregated into an audit log entry.
+type auditKey struct {
+	host, token, method, reason string
+}
+
+// jwtHeader is the fixed header of the tokens issued by the node.
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// jwtClaims are the claims of the tokens issued by the node.
+type jwtClaims struct {
+	ID       string `json:"jti"`
+	IssuedAt int64  `json:"iat"`
+	Expires  int64  `json:"exp,omitempty"`
+}
+
+// tokenStore issues and verifies the HMAC signed bearer tokens of the HTTP and
+// WebSocket RPC endpoints, and keeps the audit log of refused calls. Refused
+// calls are aggregated by source and reason and flushed periodically, so that
+// a flood of bad requests can't flood the logs too.
+type tokenStore struct {
+	secret     []byte // HMAC key signing the tokens
+	path       string // File persisting the issued grants, empty to keep them in memory
+	auditPath  string // File the denied calls are appended to, empty to disable
+	auditLimit int64  // Size after which the audit log is rotated
+
+	lock    sync.RWMutex
+	tokens  map[string]*TokenInfo
+	denied  []*DeniedCall
+	pending map[auditKey]*DeniedCall // Denials aggregated since the last flush
+	dropped int                      // Denials not aggregated since the last flush
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newTokenStore creates a token store, loading the signing secret and issued
+// grants from the given files. A missing secret is generated and saved. If all
+// paths are empty, the store lives in memory only. The store must be closed to
+// flush the audit log.
+func newTokenStore(secretPath, tokensPath, auditPath string) (*tokenStore, error) {
+	secret, err := loadSecret(secretPath)
+	if err != nil {
+		return nil, err
+	}
+	s := &tokenStore{
+		secret:     secret,
+		path:       tokensPath,
+		auditPath:  auditPath,
+		auditLimit: maxAuditLogSize,
+		tokens:     make(map[string]*TokenInfo),
+		pending:    make(map[auditKey]*DeniedCall),
+		quit:       make(chan struct{}),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	s.wg.Add(1)
+	go s.auditLoop()
+	return s, nil
+}
+
+// load reads the issued grants from disk.
+func (s *tokenStore) load() error {
+	if s.path == "" {
+		return nil
+	}
+	blob, err := ioutil.ReadFile(s.path)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	var tokens []*TokenInfo
+	if err := json.Unmarshal(blob, &tokens); err != nil {
+		return fmt.Errorf("invalid token file %s: %v", s.path, err)
+	}
+	for _, token := range tokens {
+		s.tokens[token.ID] = token
+	}
+	return nil
+}
+
+// close stops the audit log writer, flushing the pending denials.
+func (s *tokenStore) close() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// loadSecret reads the hex encoded token signing secret from path, generating
+// a new one if the file does not exist yet.
+func loadSecret(path string) ([]byte, error) {
+	if path != "" {
+		blob, err := ioutil.ReadFile(path)
+		if err == nil {
+			secret, err := hex.DecodeString(strings.TrimSpace(string(blob)))
+			if err != nil || len(secret) < 32 {
+				return nil, fmt.Errorf("invalid token secret %s", path)
+			}
+			return secret, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(path, []byte(hex.EncodeToString(secret)), 0600); err != nil {
+			return nil, err
+		}
+	}
+	return secret, nil
+}
+
+// create issues a new token granting the given namespaces and methods. A zero
+// ttl creates a token that never expires.
+func (s *tokenStore) create(name string, namespaces, methods []string, ttl time.Duration) (string, *TokenInfo, error) {
+	if len(namespaces) == 0 && len(methods) == 0 {
+		return "", nil, errEmptyGrant
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, err
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	info := &TokenInfo{
+		ID:         hex.EncodeToString(id),
+		Name:       name,
+		Namespaces: namespaces,
+		Methods:    methods,
+		Created:    now,
+	}
+	claims := jwtClaims{ID: info.ID, IssuedAt: now.Unix()}
+	if ttl > 0 {
+		expires := now.Add(ttl)
+		info.Expires = &expires
+		claims.Expires = expires.Unix()
+	}
+	token, err := s.sign(&claims)
+	if err != nil {
+		return "", nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.tokens[info.ID] = info
+	if err := s.save(); err != nil {
+		delete(s.tokens, info.ID)
+		return "", nil, err
+	}
+	return token, info, nil
+}
+
+// list returns the issued tokens, oldest first.
+func (s *tokenStore) list() []*TokenInfo {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	tokens := make([]*TokenInfo, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if !tokens[i].Created.Equal(tokens[j].Created) {
+			return tokens[i].Created.Before(tokens[j].Created)
+		}
+		return tokens[i].ID < tokens[j].ID
+	})
+	return tokens
+}
+
+// revoke invalidates the token with the given id.
+func (s *tokenStore) revoke(id string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	info, ok := s.tokens[id]
+	if !ok {
+		return errUnknownToken
+	}
+	delete(s.tokens, id)
+	if err := s.save(); err != nil {
+		s.tokens[id] = info
+		return err
+	}
+	return nil
+}
+
+// save persists the issued grants. The caller must hold the write lock.
+func (s *tokenStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	tokens := make([]*TokenInfo, 0, len(s.tokens))
+	for _, token := range s.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].ID < tokens[j].ID })
+
+	blob, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, blob, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// sign encodes and signs the claims into a compact JWT.
+func (s *tokenStore) sign(claims *jwtClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(s.mac(unsigned)), nil
+}
+
+func (s *tokenStore) mac(data string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// Authenticate implements rpc.Authenticator, verifying the signature and
+// expiry of a token and returning the grant it was issued with.
+func (s *tokenStore) Authenticate(token string) (*rpc.Grant, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, errMalformedToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errMalformedToken
+	}
+	if !hmac.Equal(sig, s.mac(parts[0]+"."+parts[1])) {
+		return nil, errInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errMalformedToken
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errMalformedToken
+	}
+	now := time.Now()
+	if claims.Expires != 0 && now.Unix() > claims.Expires {
+		return nil, errExpiredToken
+	}
+	s.lock.RLock()
+	info, ok := s.tokens[claims.ID]
+	s.lock.RUnlock()
+
+	if !ok {
+		return nil, errUnknownToken
+	}
+	if info.expired(now) {
+		return nil, errExpiredToken
+	}
+	return &rpc.Grant{ID: info.ID, Namespaces: info.Namespaces, Methods: info.Methods}, nil
+}
+
+// Denied implements rpc.Authenticator, recording a refused request in the
+// audit log.
+func (s *tokenStore) Denied(remote string, grant *rpc.Grant, method string, reason error) {
+	call := &DeniedCall{
+		Time:   time.Now().UTC(),
+		Remote: remote,
+		Method: method,
+		Reason: reason.Error(),
+	}
+	if grant != nil {
+		call.Token = grant.ID
+	}
+	log.Trace("Denied RPC request", "remote", remote, "token", call.Token, "method", method, "reason", reason)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.denied = append(s.denied, call)
+	if len(s.denied) > maxDeniedCalls {
+		s.denied = s.denied[len(s.denied)-maxDeniedCalls:]
+	}
+	// Aggregate the denials of a host regardless of its source port
+	host := remote
+	if h, _, err := net.SplitHostPort(remote); err == nil {
+		host = h
+	}
+	key := auditKey{host, call.Token, call.Method, call.Reason}
+	switch entry := s.pending[key]; {
+	case entry != nil:
+		entry.Count++
+	case len(s.pending) < maxAuditEntries:
+		entry := *call
+		entry.Remote, entry.Count = host, 1
+		s.pending[key] = &entry
+	default:
+		s.dropped++
+	}
+}
+
+// auditLoop periodically flushes the aggregated denials.
+func (s *tokenStore) auditLoop() {
+	defer s.wg.Done()
+
+	flush := time.NewTicker(auditFlushInterval)
+	defer flush.Stop()
+
+	for {
+		select {
+		case <-flush.C:
+			s.flushAudit()
+		case <-s.quit:
+			s.flushAudit()
+			return
+		}
+	}
+}
+
+// flushAudit reports the denials aggregated since the last flush and appends
+// them to the audit log.
+func (s *tokenStore) flushAudit() {
+	s.lock.Lock()
+	pending, dropped := s.pending, s.dropped
+	s.pending, s.dropped = make(map[auditKey]*DeniedCall), 0
+	s.lock.Unlock()
+
+	if len(pending) == 0 && dropped == 0 {
+		return
+	}
+	entries := make([]*DeniedCall, 0, len(pending))
+	calls := dropped
+	for _, entry := range pending {
+		entries = append(entries, entry)
+		calls += entry.Count
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	log.Warn("Denied RPC requests", "count", calls, "sources", len(entries), "unaudited", dropped)
+
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		log.Debug("Denied RPC requests", "remote", entry.Remote, "token", entry.Token, "method", entry.Method, "reason", entry.Reason, "count", entry.Count)
+		blob, _ := json.Marshal(entry)
+		buf.Write(blob)
+		buf.WriteByte('\n')
+	}
+	if s.auditPath == "" {
+		return
+	}
+	if err := s.appendAudit(buf.Bytes()); err != nil {
+		log.Error("Failed to write RPC audit log", "path", s.auditPath, "err", err)
+	}
+}
+
+// appendAudit appends entries to the audit log, moving the log aside to a
+// single backup first if it would grow past its size limit.
+func (s *tokenStore) appendAudit(blob []byte) error {
+	if info, err := os.Stat(s.auditPath); err == nil && info.Size()+int64(len(blob)) > s.auditLimit {
+		if err := os.Rename(s.auditPath, s.auditPath+".1"); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(s.auditPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(blob)
+	return err
+}
+
+// deniedCalls returns the most recent refused requests, oldest first.
+func (s *tokenStore) deniedCalls() []*DeniedCall {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return append([]*DeniedCall(nil), s.denied...)
+}
+
+// PrivateAuthAPI manages the access tokens of the authenticated HTTP and
+// WebSocket RPC endpoints. It is only exposed over IPC.
+type PrivateAuthAPI struct {
+	store *tokenStore
+}
+
+// IssuedToken is a newly created token along with its grant.
+type IssuedToken struct {
+	Token string `json:"token"`
+	*TokenInfo
+}
+
+// CreateToken issues a token granting every method of the given namespaces
+// plus the individually listed methods. A missing or zero ttl, in seconds,
+// creates a token that never expires.
+func (api *PrivateAuthAPI) CreateToken(name string, namespaces []string, methods []string, ttl *uint64) (*IssuedToken, error) {
+	var lifetime time.Duration
+	if ttl != nil {
+		lifetime = time.Duration(*ttl) * time.Second
+	}
+	token, info, err := api.store.create(name, namespaces, methods, lifetime)
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedToken{Token: token, TokenInfo: info}, nil
+}
+
+// ListTokens returns the grants of the issued tokens.
+func (api *PrivateAuthAPI) ListTokens() []*TokenInfo {
+	return api.store.list()
+}
+
+// RevokeToken invalidates the token with the given id.
+func (api *PrivateAuthAPI) RevokeToken(id string) (bool, error) {
+	if err := api.store.revoke(id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeniedCalls returns the most recent requests refused by the authenticated
+// endpoints.
+func (api *PrivateAuthAPI) DeniedCalls() []*DeniedCall {
+	return api.store.deniedCalls()
+}
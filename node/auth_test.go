@@ -0,0 +1,181 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+)
+
+// Tests that issued tokens authenticate across store reloads until revoked.
+func TestTokenStoreLifecycle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokenstore-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		secret = filepath.Join(dir, datadirRPCSecret)
+		tokens = filepath.Join(dir, datadirRPCTokens)
+		audit  = filepath.Join(dir, datadirRPCAudit)
+	)
+	store, err := newTokenStore(secret, tokens, audit)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	store.close()
+	if _, _, err := store.create("empty", nil, nil, 0); err != errEmptyGrant {
+		t.Errorf("empty grant error mismatch: have %v, want %v", err, errEmptyGrant)
+	}
+	token, info, err := store.create("ui", []string{"tau"}, []string{"admin_peers"}, 0)
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	// Reload the store from disk and check the token still works
+	if store, err = newTokenStore(secret, tokens, audit); err != nil {
+		t.Fatalf("failed to reload store: %v", err)
+	}
+	defer store.close()
+	grant, err := store.Authenticate(token)
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if grant.ID != info.ID || !grant.Allows("tau_blockNumber") || !grant.Allows("admin_peers") || grant.Allows("personal_listAccounts") {
+		t.Errorf("grant mismatch: %+v", grant)
+	}
+	// Tampered tokens must be refused
+	parts := strings.Split(token, ".")
+	forged := parts[0] + "." + parts[1] + "x." + parts[2]
+	if _, err := store.Authenticate(forged); err == nil {
+		t.Errorf("forged token accepted")
+	}
+	// Revoked tokens must be refused
+	if err := store.revoke(info.ID); err != nil {
+		t.Fatalf("failed to revoke token: %v", err)
+	}
+	if _, err := store.Authenticate(token); err != errUnknownToken {
+		t.Errorf("revoked token error mismatch: have %v, want %v", err, errUnknownToken)
+	}
+	if len(store.list()) != 0 {
+		t.Errorf("revoked token still listed")
+	}
+}
+
+// Tests that expired tokens are refused.
+func TestTokenStoreExpiry(t *testing.T) {
+	store, err := newTokenStore("", "", "")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.close()
+	token, info, err := store.create("short", []string{"*"}, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	if _, err := store.Authenticate(token); err != nil {
+		t.Fatalf("fresh token refused: %v", err)
+	}
+	past := time.Now().Add(-time.Minute)
+	info.Expires = &past
+	if _, err := store.Authenticate(token); err != errExpiredToken {
+		t.Errorf("expired token error mismatch: have %v, want %v", err, errExpiredToken)
+	}
+}
+
+// Tests that denied calls are kept in memory and aggregated into the audit log.
+func TestTokenStoreAudit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokenstore-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	audit := filepath.Join(dir, datadirRPCAudit)
+	store, err := newTokenStore("", "", audit)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	for port := 1000; port < 1010; port++ {
+		store.Denied(fmt.Sprintf("127.0.0.1:%d", port), nil, "", errors.New("missing token"))
+	}
+	store.Denied("127.0.0.1:1234", &rpc.Grant{ID: "abcd"}, "personal_unlockAccount", rpc.ErrMethodDenied)
+	store.close()
+
+	calls := store.deniedCalls()
+	if len(calls) != 11 {
+		t.Fatalf("denied call count mismatch: have %d, want 11", len(calls))
+	}
+	if calls[10].Token != "abcd" || calls[10].Method != "personal_unlockAccount" {
+		t.Errorf("denied call mismatch: %+v", calls[10])
+	}
+	blob, err := ioutil.ReadFile(audit)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(blob)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit log line count mismatch: have %d, want 2", len(lines))
+	}
+	var entry DeniedCall
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("invalid audit log entry: %v", err)
+	}
+	if entry.Remote != "127.0.0.1" || entry.Count != 10 {
+		t.Errorf("aggregated entry mismatch: %+v", entry)
+	}
+}
+
+// Tests that the audit log is rotated once it reaches its size limit.
+func TestTokenStoreAuditRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokenstore-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	audit := filepath.Join(dir, datadirRPCAudit)
+	store, err := newTokenStore("", "", audit)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.close()
+	store.auditLimit = 512
+
+	for i := 0; i < 20; i++ {
+		store.Denied("127.0.0.1:1234", nil, fmt.Sprintf("method_%d", i), rpc.ErrMethodDenied)
+		store.flushAudit()
+	}
+	for _, path := range []string{audit, audit + ".1"} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("missing audit log %s: %v", path, err)
+		}
+		if info.Size() > store.auditLimit {
+			t.Errorf("audit log %s exceeds limit: %d bytes", path, info.Size())
+		}
+	}
+}
@@ -43,6 +43,9 @@ const (
 	datadirStaticNodes     = "static-nodes.json"  // Path within the datadir to the static node list
 	datadirTrustedNodes    = "trusted-nodes.json" // Path within the datadir to the trusted node list
 	datadirNodeDatabase    = "nodes"              // Path within the datadir to store the node infos
+	datadirRPCSecret       = "rpc-secret"         // Path within the datadir to the RPC token signing secret
+	datadirRPCTokens       = "rpc-tokens.json"    // Path within the datadir to the issued RPC tokens
+	datadirRPCAudit        = "rpc-audit.log"      // Path within the datadir to the log of denied RPC calls
 )
 
 // Config represents a small collection of configuration values to fine tune the
@@ -154,6 +157,39 @@ type Config struct {
 	// private APIs to untrusted users is a major security risk.
 	WSExposeAll bool `toml:",omitempty"`
 
+	// GraphQLHost is the host interface on which to start the GraphQL server. If this
+	// field is empty, no GraphQL API endpoint will be started.
+	GraphQLHost string `toml:",omitempty"`
+
+	// GraphQLPort is the TCP port number on which to start the GraphQL server. The
+	// default zero value is/ valid and will pick a port number randomly (useful
+	// for ephemeral nodes).
+	GraphQLPort int `toml:",omitempty"`
+
+	// GraphQLCors is the Cross-Origin Resource Sharing header to send to requesting
+	// clients. Please be aware that CORS is a browser enforced security, it's fully
+	// useless for custom HTTP clients.
+	GraphQLCors []string `toml:",omitempty"`
+
+	// GraphQLVirtualHosts is the list of virtual hostnames which are allowed on incoming requests.
+	// This is by default {'localhost'}. Using this prevents attacks like
+	// DNS rebinding, which bypasses SOP by simply masquerading as being within the same
+	// origin. These attacks do not utilize CORS, since they are not cross-domain.
+	// By explicitly checking the Host-header, the server will not allow requests
+	// made against the server with a malicious host domain.
+	// Requests using ip address directly are not affected
+	GraphQLVirtualHosts []string `toml:",omitempty"`
+
+	// RPCAuth requires every request to the HTTP and WebSocket RPC endpoints to
+	// carry a bearer token issued through the IPC auth API. Each token is
+	// restricted to the namespaces and methods it was issued for. The auth API
+	// is only offered while authentication is enabled.
+	RPCAuth bool `toml:",omitempty"`
+
+	// RPCLimits caps the requests, subscriptions and response sizes of every
+	// connection and remote IP of the HTTP and WebSocket RPC endpoints.
+	RPCLimits rpc.Limits
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 
@@ -237,6 +273,15 @@ func DefaultWSEndpoint() string {
 	return config.WSEndpoint()
 }
 
+// GraphQLEndpoint resolves a GraphQL endpoint based on the configured host interface
+// and port parameters.
+func (c *Config) GraphQLEndpoint() string {
+	if c.GraphQLHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.GraphQLHost, c.GraphQLPort)
+}
+
 // ExtRPCEnabled returns the indicator whtauer node enables the external
 // RPC(http, ws).
 func (c *Config) ExtRPCEnabled() bool {
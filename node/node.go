@@ -33,6 +33,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/internal/debug"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/traffic"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
 	"github.com/prometheus/tsdb/fileutil"
 )
@@ -42,6 +43,7 @@ type Node struct {
 	eventmux *event.TypeMux // Event multiplexer used between the services of a stack
 	config   *Config
 	accman   *accounts.Manager
+	traffic  *traffic.Accountant // Traffic accountant shared by the networking layer and the services
 
 	ephemeralKeystore string            // if non-empty, the key directory that will be removed by Stop
 	instanceDirLock   fileutil.Releaser // prevents concurrent use of instance directory
@@ -54,6 +56,7 @@ type Node struct {
 
 	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	tokens        *tokenStore // Access tokens of the authenticated HTTP and WS endpoints
 
 	ipcEndpoint string       // IPC endpoint to listen at (empty = IPC disabled)
 	ipcListener net.Listener // IPC RPC listener socket to serve API requests
@@ -111,6 +114,7 @@ func New(conf *Config) (*Node, error) {
 	// in the data directory or instance directory is delayed until Start.
 	return &Node{
 		accman:            am,
+		traffic:           traffic.NewAccountant(),
 		ephemeralKeystore: ephemeralKeystore,
 		config:            conf,
 		serviceFuncs:      []ServiceConstructor{},
@@ -177,6 +181,7 @@ func (n *Node) Start() error {
 	n.serverConfig.PrivateKey = n.config.NodeKey()
 	n.serverConfig.Name = n.config.NodeName()
 	n.serverConfig.Logger = n.log
+	n.serverConfig.Traffic = n.traffic
 	if n.serverConfig.StaticNodes == nil {
 		n.serverConfig.StaticNodes = n.config.StaticNodes()
 	}
@@ -198,6 +203,7 @@ func (n *Node) Start() error {
 			services:       make(map[reflect.Type]Service),
 			EventMux:       n.eventmux,
 			AccountManager: n.accman,
+			Traffic:        n.traffic,
 		}
 		for kind, s := range services { // copy needed for threaded access
 			ctx.services[kind] = s
@@ -283,23 +289,37 @@ func (n *Node) startRPC(services map[reflect.Type]Service) error {
 	for _, service := range services {
 		apis = append(apis, service.APIs()...)
 	}
+	// Load the access tokens if required, only managed over the local endpoints
+	localAPIs := apis
+	if n.config.RPCAuth {
+		tokens, err := newTokenStore(n.config.ResolvePath(datadirRPCSecret), n.config.ResolvePath(datadirRPCTokens), n.config.ResolvePath(datadirRPCAudit))
+		if err != nil {
+			return err
+		}
+		n.tokens = tokens
+		localAPIs = append(n.authAPIs(), apis...)
+	}
 	// Start the various API endpoints, terminating all in case of errors
-	if err := n.startInProc(apis); err != nil {
+	if err := n.startInProc(localAPIs); err != nil {
+		n.stopTokens()
 		return err
 	}
-	if err := n.startIPC(apis); err != nil {
+	if err := n.startIPC(localAPIs); err != nil {
 		n.stopInProc()
+		n.stopTokens()
 		return err
 	}
 	if err := n.startHTTP(n.httpEndpoint, apis, n.config.HTTPModules, n.config.HTTPCors, n.config.HTTPVirtualHosts, n.config.HTTPTimeouts); err != nil {
 		n.stopIPC()
 		n.stopInProc()
+		n.stopTokens()
 		return err
 	}
 	if err := n.startWS(n.wsEndpoint, apis, n.config.WSModules, n.config.WSOrigins, n.config.WSExposeAll); err != nil {
 		n.stopHTTP()
 		n.stopIPC()
 		n.stopInProc()
+		n.stopTokens()
 		return err
 	}
 	// All API endpoints started successfully
@@ -364,11 +384,11 @@ func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors
 	if endpoint == "" {
 		return nil
 	}
-	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts)
+	listener, handler, err := rpc.StartHTTPEndpoint(endpoint, apis, modules, cors, vhosts, timeouts, n.config.RPCLimits, n.rpcAuthenticator())
 	if err != nil {
 		return err
 	}
-	n.log.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","))
+	n.log.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","), "auth", n.config.RPCAuth)
 	// All listeners booted successfully
 	n.httpEndpoint = endpoint
 	n.httpListener = listener
@@ -397,11 +417,11 @@ func (n *Node) startWS(endpoint string, apis []rpc.API, modules []string, wsOrig
 	if endpoint == "" {
 		return nil
 	}
-	listener, handler, err := rpc.StartWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll)
+	listener, handler, err := rpc.StartWSEndpoint(endpoint, apis, modules, wsOrigins, exposeAll, n.config.RPCLimits, n.rpcAuthenticator())
 	if err != nil {
 		return err
 	}
-	n.log.Info("WebSocket endpoint opened", "url", fmt.Sprintf("ws://%s", listener.Addr()))
+	n.log.Info("WebSocket endpoint opened", "url", fmt.Sprintf("ws://%s", listener.Addr()), "auth", n.config.RPCAuth)
 	// All listeners booted successfully
 	n.wsEndpoint = endpoint
 	n.wsListener = listener
@@ -439,6 +459,7 @@ func (n *Node) Stop() error {
 	n.stopWS()
 	n.stopHTTP()
 	n.stopIPC()
+	n.stopTokens()
 	n.rpcAPIs = nil
 	failure := &StopError{
 		Services: make(map[reflect.Type]error),
@@ -638,6 +659,35 @@ func (n *Node) ResolvePath(x string) string {
 	return n.config.ResolvePath(x)
 }
 
+// rpcAuthenticator returns the token validator of the HTTP and WS endpoints,
+// or nil if they don't require authentication.
+func (n *Node) rpcAuthenticator() rpc.Authenticator {
+	if n.tokens == nil {
+		return nil
+	}
+	return n.tokens
+}
+
+// stopTokens terminates the token store, flushing the audit log.
+func (n *Node) stopTokens() {
+	if n.tokens != nil {
+		n.tokens.close()
+		n.tokens = nil
+	}
+}
+
+// authAPIs returns the token management API, which is only offered over the
+// in-process and IPC endpoints.
+func (n *Node) authAPIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "auth",
+			Version:   "1.0",
+			Service:   &PrivateAuthAPI{store: n.tokens},
+		},
+	}
+}
+
 // apis returns the collection of RPC descriptors this node offers.
 func (n *Node) apis() []rpc.API {
 	return []rpc.API{
@@ -25,6 +25,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/traffic"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
 )
 
@@ -36,6 +37,7 @@ type ServiceContext struct {
 	services       map[reflect.Type]Service // Index of the already constructed services
 	EventMux       *event.TypeMux           // Event multiplexer used for decoupled notifications
 	AccountManager *accounts.Manager        // Account manager created by the node.
+	Traffic        *traffic.Accountant      // Traffic accountant created by the node.
 }
 
 // OpenDatabase opens an existing database with the given name (or creates one
@@ -20,11 +20,13 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/netutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/reputation"
 )
 
 const (
@@ -44,6 +46,9 @@ const (
 	// Endpoint resolution is throttled with bounded backoff.
 	initialResolveDelay = 60 * time.Second
 	maxResolveDelay     = time.Hour
+
+	// Suggested dial candidates are queued up to this limit.
+	maxCandidates = 64
 )
 
 // NodeDialer is used to connect to nodes in the network, typically by using
@@ -74,11 +79,14 @@ type dialstate struct {
 	self        enode.ID
 	bootnodes   []*enode.Node // default dials when there are no peers
 	log         log.Logger
+	reputation  *reputation.Tracker    // ranks dial candidates, nil dials in discovery order
+	filter      func(*enode.Node) bool // rejects dynamic dial candidates, nil dials all
 
 	start         time.Time // time when the dialer was first used
 	lookupRunning bool
 	dialing       map[enode.ID]connFlag
 	lookupBuf     []*enode.Node // current discovery lookup results
+	candidates    []*enode.Node // suggested nodes, dialed before discovery results
 	randomNodes   []*enode.Node // filled from Table
 	static        map[enode.ID]*dialTask
 	hist          expHeap
@@ -150,6 +158,20 @@ func (s *dialstate) removeStatic(n *enode.Node) {
 	delete(s.static, n.ID())
 }
 
+// addCandidate queues a suggested node for the dynamic dials, dropping the
+// oldest suggestion if the queue is full.
+func (s *dialstate) addCandidate(n *enode.Node) {
+	for _, c := range s.candidates {
+		if c.ID() == n.ID() {
+			return
+		}
+	}
+	if len(s.candidates) >= maxCandidates {
+		s.candidates = s.candidates[:copy(s.candidates, s.candidates[1:])]
+	}
+	s.candidates = append(s.candidates, n)
+}
+
 func (s *dialstate) newTasks(nRunning int, peers map[enode.ID]*Peer, now time.Time) []task {
 	if s.start.IsZero() {
 		s.start = now
@@ -157,7 +179,14 @@ func (s *dialstate) newTasks(nRunning int, peers map[enode.ID]*Peer, now time.Ti
 
 	var newtasks []task
 	addDial := func(flag connFlag, n *enode.Node) bool {
-		if err := s.checkDial(n, peers); err != nil {
+		err := s.checkDial(n, peers)
+		if err == nil && s.reputation.Banned(n.ID()) {
+			err = errBanned // Static nodes are dialed regardless of their reputation
+		}
+		if err == nil && s.filter != nil && !s.filter(n) {
+			err = errFiltered
+		}
+		if err != nil {
 			s.log.Trace("Skipping dial candidate", "id", n.ID(), "addr", &net.TCPAddr{IP: n.IP(), Port: n.TCP()}, "err", err)
 			return false
 		}
@@ -206,11 +235,21 @@ func (s *dialstate) newTasks(nRunning int, peers map[enode.ID]*Peer, now time.Ti
 			needDynDials--
 		}
 	}
+	// Dial the suggested candidates first, removing tried items from the queue.
+	s.prioritize(s.candidates)
+	c := 0
+	for ; c < len(s.candidates) && needDynDials > 0; c++ {
+		if addDial(dynDialedConn, s.candidates[c]) {
+			needDynDials--
+		}
+	}
+	s.candidates = s.candidates[:copy(s.candidates, s.candidates[c:])]
 	// Use random nodes from the table for half of the necessary
 	// dynamic dials.
 	randomCandidates := needDynDials / 2
 	if randomCandidates > 0 {
 		n := s.ntab.ReadRandomNodes(s.randomNodes)
+		s.prioritize(s.randomNodes[:n])
 		for i := 0; i < randomCandidates && i < n; i++ {
 			if addDial(dynDialedConn, s.randomNodes[i]) {
 				needDynDials--
@@ -219,6 +258,7 @@ func (s *dialstate) newTasks(nRunning int, peers map[enode.ID]*Peer, now time.Ti
 	}
 	// Create dynamic dials from random lookup results, removing tried
 	// items from the result buffer.
+	s.prioritize(s.lookupBuf)
 	i := 0
 	for ; i < len(s.lookupBuf) && needDynDials > 0; i++ {
 		if addDial(dynDialedConn, s.lookupBuf[i]) {
@@ -249,6 +289,8 @@ var (
 	errAlreadyConnected = errors.New("already connected")
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNotWhitelisted   = errors.New("not contained in netrestrict whitelist")
+	errBanned           = errors.New("banned for misbehaviour")
+	errFiltered         = errors.New("rejected by the protocol dial filters")
 )
 
 func (s *dialstate) checkDial(n *enode.Node, peers map[enode.ID]*Peer) error {
@@ -268,6 +310,21 @@ func (s *dialstate) checkDial(n *enode.Node, peers map[enode.ID]*Peer) error {
 	return nil
 }
 
+// prioritize orders dial candidates by their reputation, best first. Nodes of
+// equal reputation keep their discovery order.
+func (s *dialstate) prioritize(nodes []*enode.Node) {
+	if s.reputation == nil {
+		return
+	}
+	scores := make(map[enode.ID]float64, len(nodes))
+	for _, n := range nodes {
+		scores[n.ID()] = s.reputation.Score(n.ID())
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return scores[nodes[i].ID()] > scores[nodes[j].ID()]
+	})
+}
+
 func (s *dialstate) taskDone(t task, now time.Time) {
 	switch t := t.(type) {
 	case *dialTask:
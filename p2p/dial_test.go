@@ -446,6 +446,59 @@ func TestDialStateNetRestrict(t *testing.T) {
 	})
 }
 
+// This test checks that suggested candidates are dialed ahead of discovery, once.
+func TestDialStateCandidates(t *testing.T) {
+	state := newDialState(enode.ID{}, fakeTable{}, 2, &Config{Logger: testlog.Logger(t, log.LvlTrace)})
+	for _, id := range []uint64{1, 2, 2, 3} {
+		state.addCandidate(newNode(uintID(id), nil))
+	}
+	runDialTest(t, dialtest{
+		init: state,
+		rounds: []round{
+			// The first candidates fill the dynamic slots.
+			{
+				new: []task{
+					&dialTask{flags: dynDialedConn, dest: newNode(uintID(1), nil)},
+					&dialTask{flags: dynDialedConn, dest: newNode(uintID(2), nil)},
+				},
+			},
+			// The remaining candidate is dialed once a slot frees up.
+			{
+				peers: []*Peer{
+					{rw: &conn{flags: dynDialedConn, node: newNode(uintID(2), nil)}},
+				},
+				done: []task{
+					&dialTask{flags: dynDialedConn, dest: newNode(uintID(1), nil)},
+					&dialTask{flags: dynDialedConn, dest: newNode(uintID(2), nil)},
+				},
+				new: []task{
+					&dialTask{flags: dynDialedConn, dest: newNode(uintID(3), nil)},
+				},
+			},
+		},
+	})
+}
+
+// This test checks that candidates rejected by the dial filter are skipped.
+func TestDialStateFilter(t *testing.T) {
+	state := newDialState(enode.ID{}, fakeTable{}, 2, &Config{Logger: testlog.Logger(t, log.LvlTrace)})
+	state.filter = func(n *enode.Node) bool { return n.ID() != uintID(2) }
+	for _, id := range []uint64{1, 2, 3} {
+		state.addCandidate(newNode(uintID(id), nil))
+	}
+	runDialTest(t, dialtest{
+		init: state,
+		rounds: []round{
+			{
+				new: []task{
+					&dialTask{flags: dynDialedConn, dest: newNode(uintID(1), nil)},
+					&dialTask{flags: dynDialedConn, dest: newNode(uintID(3), nil)},
+				},
+			},
+		},
+	})
+}
+
 // This test checks that static dials are launched.
 func TestDialStateStaticDial(t *testing.T) {
 	config := &Config{
@@ -21,6 +21,7 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"net"
 	"os"
 	"sync"
@@ -49,6 +50,11 @@ const (
 	dbNodePong      = "lastpong"
 	dbNodeSeq       = "seq"
 
+	// Reputation fields are stored per ID only, with the zero IP.
+	dbNodeScore       = "score"
+	dbNodeScoreUpdate = "scoreupdate"
+	dbNodeBanned      = "banned"
+
 	// Local information is keyed by ID only, the full key is "local:<ID>:seq".
 	// Use localItemKey to create those keys.
 	dbLocalSeq = "seq"
@@ -378,6 +384,31 @@ func (db *DB) UpdateFindFails(id ID, ip net.IP, fails int) error {
 	return db.storeInt64(nodeItemKey(id, ip, dbNodeFindFails), int64(fails))
 }
 
+// NodeScore retrieves the reputation score of a node and the time it was last
+// updated.
+func (db *DB) NodeScore(id ID) (float64, time.Time) {
+	score := math.Float64frombits(db.fetchUint64(nodeItemKey(id, zeroIP, dbNodeScore)))
+	return score, time.Unix(db.fetchInt64(nodeItemKey(id, zeroIP, dbNodeScoreUpdate)), 0)
+}
+
+// UpdateNodeScore stores the reputation score of a node.
+func (db *DB) UpdateNodeScore(id ID, score float64, updated time.Time) error {
+	if err := db.storeUint64(nodeItemKey(id, zeroIP, dbNodeScore), math.Float64bits(score)); err != nil {
+		return err
+	}
+	return db.storeInt64(nodeItemKey(id, zeroIP, dbNodeScoreUpdate), updated.Unix())
+}
+
+// BannedUntil retrieves the time a node's ban expires.
+func (db *DB) BannedUntil(id ID) time.Time {
+	return time.Unix(db.fetchInt64(nodeItemKey(id, zeroIP, dbNodeBanned)), 0)
+}
+
+// UpdateBannedUntil updates the time a node's ban expires.
+func (db *DB) UpdateBannedUntil(id ID, until time.Time) error {
+	return db.storeInt64(nodeItemKey(id, zeroIP, dbNodeBanned), until.Unix())
+}
+
 // LocalSeq retrieves the local record sequence counter.
 func (db *DB) localSeq(id ID) uint64 {
 	return db.fetchUint64(localItemKey(id, dbLocalSeq))
@@ -154,6 +154,22 @@ func TestDBFetchStore(t *testing.T) {
 	if stored := db.FindFails(node.ID(), node.IP()); stored != num {
 		t.Errorf("find-node fails: value mismatch: have %v, want %v", stored, num)
 	}
+	// Check fetch/store operations on a node reputation object
+	if score, updated := db.NodeScore(node.ID()); score != 0 || updated.Unix() != 0 {
+		t.Errorf("score: non-existing object: %v %v", score, updated)
+	}
+	if err := db.UpdateNodeScore(node.ID(), -12.5, inst); err != nil {
+		t.Errorf("score: failed to update: %v", err)
+	}
+	if score, updated := db.NodeScore(node.ID()); score != -12.5 || updated.Unix() != inst.Unix() {
+		t.Errorf("score: value mismatch: have %v %v, want %v %v", score, updated, -12.5, inst)
+	}
+	if err := db.UpdateBannedUntil(node.ID(), inst); err != nil {
+		t.Errorf("ban: failed to update: %v", err)
+	}
+	if stored := db.BannedUntil(node.ID()); stored.Unix() != inst.Unix() {
+		t.Errorf("ban: value mismatch: have %v, want %v", stored, inst)
+	}
 	// Check fetch/store operations on an actual node object
 	if stored := db.Node(node.ID()); stored != nil {
 		t.Errorf("node: non-existing object: %v", stored)
@@ -56,6 +56,11 @@ type Protocol struct {
 
 	// Attributes contains protocol specific information for the node record.
 	Attributes []enr.Entry
+
+	// DialFilter, if set, reports from the record of a discovered node whether
+	// it's worth dialing for the protocol. Nodes rejected by the filters of all
+	// protocols aren't dialed dynamically, static nodes are dialed regardless.
+	DialFilter func(n *enode.Node) bool
 }
 
 func (p Protocol) cap() Cap {
@@ -0,0 +1,242 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package reputation scores remote nodes by their past behaviour.
+//
+// Protocol handlers report good and bad behaviour as events, each moving the
+// score of the node by a fixed weight. Scores decay towards zero over time so
+// that old offences are eventually forgiven and old merits don't shield a node
+// turning malicious. Nodes dropping below the ban threshold are banned for a
+// while. Scores and bans are persisted in the node database.
+package reputation
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	maxScore       = 100.0            // Upper bound of the score, limiting the credit a node can build up
+	minScore       = -200.0           // Lower bound of the score, limiting how long a node stays shunned
+	banThreshold   = -100.0           // Score at or below which a node is banned
+	banDuration    = time.Hour        // Time a node stays banned after crossing the threshold
+	decayHalfLife  = 30 * time.Minute // Time after which a score decays to half of its value
+	maxCachedNodes = 4096             // Number of node scores kept in memory
+)
+
+// Event is a behaviour of a remote node reported by a protocol handler.
+type Event int
+
+const (
+	UsefulDelivery    Event = iota // Node delivered requested chain data
+	UsefulTxs                      // Node relayed transactions new to the pool
+	UselessAnnounce                // Node announced junk or delivered unrequested data
+	InvalidTx                      // Node relayed transactions rejected by the pool
+	StalledRequest                 // Node failed to answer a request in time
+	BadChain                       // Node fed the downloader an invalid or stalling chain
+	BadBlock                       // Node propagated an invalid block
+	ProtocolViolation              // Node broke the wire protocol
+)
+
+// weights is the score change caused by each event.
+var weights = [...]float64{
+	UsefulDelivery:    1,
+	UsefulTxs:         0.5,
+	UselessAnnounce:   -2,
+	InvalidTx:         -5,
+	StalledRequest:    -10,
+	BadChain:          -40,
+	BadBlock:          -100,
+	ProtocolViolation: -100,
+}
+
+// String implements fmt.Stringer.
+func (e Event) String() string {
+	switch e {
+	case UsefulDelivery:
+		return "useful delivery"
+	case UsefulTxs:
+		return "useful transactions"
+	case UselessAnnounce:
+		return "useless announcement"
+	case InvalidTx:
+		return "invalid transactions"
+	case StalledRequest:
+		return "stalled request"
+	case BadChain:
+		return "bad chain"
+	case BadBlock:
+		return "bad block"
+	case ProtocolViolation:
+		return "protocol violation"
+	default:
+		return "unknown event"
+	}
+}
+
+// Info is the reputation of a node as reported by the admin API.
+type Info struct {
+	ID          string     `json:"id"`
+	Score       float64    `json:"score"`
+	Updated     time.Time  `json:"updated"`
+	BannedUntil *time.Time `json:"bannedUntil,omitempty"`
+}
+
+// entry is the reputation of a single node.
+type entry struct {
+	score   float64   // Score at the time of the last update
+	updated time.Time // Time of the last update, from which the score decays
+	banned  time.Time // Time the current ban expires, zero if never banned
+}
+
+// Tracker keeps the reputation of remote nodes. A nil tracker scores every node
+// neutrally, allowing protocols to run without one.
+type Tracker struct {
+	db      *enode.DB  // Node database to persist the scores in, nil for memory only
+	entries *lru.Cache // Recently used node reputations
+	lock    sync.Mutex
+
+	now func() time.Time // Wall clock, overridden by tests
+}
+
+// New creates a reputation tracker persisting into the given node database.
+func New(db *enode.DB) *Tracker {
+	entries, _ := lru.New(maxCachedNodes)
+	return &Tracker{
+		db:      db,
+		entries: entries,
+		now:     time.Now,
+	}
+}
+
+// Report records an event caused by a node, returning whether the node is
+// banned as a result.
+func (t *Tracker) Report(id enode.ID, event Event) bool {
+	if t == nil || int(event) < 0 || int(event) >= len(weights) {
+		return false
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var (
+		now = t.now()
+		e   = t.entry(id)
+	)
+	e.score = math.Max(minScore, math.Min(maxScore, e.decayed(now)+weights[event]))
+	e.updated = now
+
+	banned := now.Before(e.banned)
+	if !banned && e.score <= banThreshold {
+		log.Debug("Banning misbehaving node", "id", id, "score", e.score, "event", event)
+		e.banned, banned = now.Add(banDuration), true
+	}
+	if t.db != nil {
+		t.db.UpdateNodeScore(id, e.score, e.updated)
+		if !e.banned.IsZero() {
+			t.db.UpdateBannedUntil(id, e.banned)
+		}
+	}
+	return banned
+}
+
+// Score returns the current score of a node.
+func (t *Tracker) Score(id enode.ID) float64 {
+	if t == nil {
+		return 0
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.entry(id).decayed(t.now())
+}
+
+// Banned returns whether a node is currently banned.
+func (t *Tracker) Banned(id enode.ID) bool {
+	if t == nil {
+		return false
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.now().Before(t.entry(id).banned)
+}
+
+// Scores returns the reputation of all nodes known to the tracker with a non
+// zero score or an active ban, best first.
+func (t *Tracker) Scores() []*Info {
+	if t == nil {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var (
+		now   = t.now()
+		infos []*Info
+	)
+	for _, key := range t.entries.Keys() {
+		cached, ok := t.entries.Peek(key)
+		if !ok {
+			continue
+		}
+		var (
+			id    = key.(enode.ID)
+			e     = cached.(*entry)
+			score = e.decayed(now)
+		)
+		info := &Info{ID: id.String(), Score: score, Updated: e.updated}
+		if now.Before(e.banned) {
+			until := e.banned
+			info.BannedUntil = &until
+		}
+		if score != 0 || info.BannedUntil != nil {
+			infos = append(infos, info)
+		}
+	}
+	sort.SliceStable(infos, func(i, j int) bool { return infos[i].Score > infos[j].Score })
+	return infos
+}
+
+// entry returns the reputation of a node, loading it from the database if it's
+// not cached. The caller must hold the lock.
+func (t *Tracker) entry(id enode.ID) *entry {
+	if cached, ok := t.entries.Get(id); ok {
+		return cached.(*entry)
+	}
+	e := new(entry)
+	if t.db != nil {
+		e.score, e.updated = t.db.NodeScore(id)
+		if banned := t.db.BannedUntil(id); banned.Unix() > 0 {
+			e.banned = banned
+		}
+	}
+	t.entries.Add(id, e)
+	return e
+}
+
+// decayed returns the score of the entry at the given time.
+func (e *entry) decayed(now time.Time) float64 {
+	if e.score == 0 || !now.After(e.updated) {
+		return e.score
+	}
+	return e.score * math.Pow(0.5, float64(now.Sub(e.updated))/float64(decayHalfLife))
+}
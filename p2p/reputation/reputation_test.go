@@ -0,0 +1,105 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package reputation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+)
+
+// newTestTracker creates a tracker with a manually advanced clock.
+func newTestTracker(db *enode.DB) (*Tracker, *time.Time) {
+	now := time.Unix(1600000000, 0)
+	tracker := New(db)
+	tracker.now = func() time.Time { return now }
+	return tracker, &now
+}
+
+func TestScoreDecay(t *testing.T) {
+	tracker, now := newTestTracker(nil)
+	id := enode.ID{1}
+
+	tracker.Report(id, StalledRequest)
+	tracker.Report(id, StalledRequest)
+	if score := tracker.Score(id); score != -20 {
+		t.Fatalf("score mismatch: have %v, want -20", score)
+	}
+	*now = now.Add(decayHalfLife)
+	if score := tracker.Score(id); math.Abs(score+10) > 1e-9 {
+		t.Fatalf("decayed score mismatch: have %v, want -10", score)
+	}
+	// Merits are capped and decay the same way
+	for i := 0; i < 1000; i++ {
+		tracker.Report(id, UsefulDelivery)
+	}
+	if score := tracker.Score(id); score != maxScore {
+		t.Fatalf("score not capped: have %v, want %v", score, maxScore)
+	}
+}
+
+func TestBan(t *testing.T) {
+	tracker, now := newTestTracker(nil)
+	good, bad := enode.ID{1}, enode.ID{2}
+
+	tracker.Report(good, UsefulDelivery)
+	if tracker.Report(good, InvalidTx) {
+		t.Fatalf("node banned for a minor offence")
+	}
+	if !tracker.Report(bad, BadBlock) {
+		t.Fatalf("node not banned for a bad block")
+	}
+	if !tracker.Banned(bad) || tracker.Banned(good) {
+		t.Fatalf("ban state mismatch: bad %v, good %v", tracker.Banned(bad), tracker.Banned(good))
+	}
+	scores := tracker.Scores()
+	if len(scores) != 2 || scores[0].ID != good.String() || scores[1].BannedUntil == nil {
+		t.Fatalf("scores mismatch: %+v", scores)
+	}
+	*now = now.Add(banDuration)
+	if tracker.Banned(bad) {
+		t.Fatalf("ban not lifted after %v", banDuration)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	db, _ := enode.OpenDB("")
+	defer db.Close()
+
+	tracker, _ := newTestTracker(db)
+	id := enode.ID{1}
+	tracker.Report(id, BadChain)
+	tracker.Report(id, ProtocolViolation)
+
+	// A new tracker on the same database must restore the score and the ban
+	reloaded, _ := newTestTracker(db)
+	if score := reloaded.Score(id); score != tracker.Score(id) {
+		t.Fatalf("score not restored: have %v, want %v", score, tracker.Score(id))
+	}
+	if !reloaded.Banned(id) {
+		t.Fatalf("ban not restored")
+	}
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	if tracker.Report(enode.ID{1}, BadBlock) || tracker.Banned(enode.ID{1}) || tracker.Score(enode.ID{1}) != 0 {
+		t.Fatalf("nil tracker not neutral")
+	}
+}
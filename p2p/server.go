@@ -40,6 +40,8 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enr"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/nat"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/netutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/reputation"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/traffic"
 )
 
 const (
@@ -50,6 +52,10 @@ const (
 	defaultMaxPendingPeers = 50
 	defaultDialRatio       = 3
 
+	// Reputation limits for evicting peers in favour of better connecting nodes.
+	evictionScore  = -10.0 // Maximum score of a peer to be evicted
+	evictionMargin = 20.0  // Minimum score advantage of the connecting node over the evicted peer
+
 	// This time limits inbound connection attempts per source IP.
 	inboundThrottleTime = 30 * time.Second
 
@@ -142,6 +148,9 @@ type Config struct {
 	// is used to dial outbound peer connections.
 	Dialer NodeDialer `toml:"-"`
 
+	// Traffic, if set, is the accountant recording the discovery traffic.
+	Traffic *traffic.Accountant `toml:"-"`
+
 	// If NoDial is true, the server will not dial any peers.
 	NoDial bool `toml:",omitempty"`
 
@@ -169,6 +178,7 @@ type Server struct {
 
 	nodedb       *enode.DB
 	localnode    *enode.LocalNode
+	reputation   *reputation.Tracker
 	ntab         discoverTable
 	listener     net.Listener
 	ourHandshake *protoHandshake
@@ -181,6 +191,7 @@ type Server struct {
 	quit                    chan struct{}
 	addstatic               chan *enode.Node
 	removestatic            chan *enode.Node
+	addcandidate            chan *enode.Node
 	addtrusted              chan *enode.Node
 	removetrusted           chan *enode.Node
 	peerOp                  chan peerOpFunc
@@ -192,6 +203,7 @@ type Server struct {
 	// State of run loop and listenLoop.
 	lastLookup     time.Time
 	inboundHistory expHeap
+	evicted        map[enode.ID]struct{} // Peers disconnected to make room for better ones
 }
 
 type peerOpFunc func(map[enode.ID]*Peer)
@@ -286,6 +298,12 @@ func (c *conn) set(f connFlag, val bool) {
 	}
 }
 
+// Reputation returns the tracker scoring remote nodes by their behaviour. It
+// is nil until the server is started.
+func (srv *Server) Reputation() *reputation.Tracker {
+	return srv.reputation
+}
+
 // LocalNode returns the local node record.
 func (srv *Server) LocalNode() *enode.LocalNode {
 	return srv.localnode
@@ -338,6 +356,17 @@ func (srv *Server) RemovePeer(node *enode.Node) {
 	}
 }
 
+// AddCandidate suggests a node for the dialer to connect to, ahead of the nodes
+// found by the generic discovery. Unlike AddPeer, the node is dialed only once
+// and counts against the dynamic peer slots, e.g. nodes found by searching the
+// discovery topic of a chain.
+func (srv *Server) AddCandidate(node *enode.Node) {
+	select {
+	case srv.addcandidate <- node:
+	case <-srv.quit:
+	}
+}
+
 // AddTrustedPeer adds the given node to a reserved whitelist which allows the
 // node to always connect, even if the slot are full.
 func (srv *Server) AddTrustedPeer(node *enode.Node) {
@@ -390,10 +419,51 @@ func (srv *Server) Stop() {
 	srv.loopWG.Wait()
 }
 
+// accountedUDPConn records the traffic of the discovery protocols with the traffic
+// accountant of the node. Once the discovery data cap is reached, the outgoing
+// packets are dropped, pausing the lookups and the replies to the remote nodes.
+type accountedUDPConn struct {
+	*net.UDPConn
+	accountant *traffic.Accountant // Accountant to record the traffic with, nil if not accounted
+	capped     uint32              // Flag whether the discovery data cap is reached
+}
+
+// setCapped pauses or resumes sending as the discovery data cap is reached or
+// lifted.
+func (c *accountedUDPConn) setCapped(capped bool) {
+	if capped {
+		atomic.StoreUint32(&c.capped, 1)
+	} else {
+		atomic.StoreUint32(&c.capped, 0)
+	}
+}
+
+// ReadFromUDP implements discover.UDPConn
+func (c *accountedUDPConn) ReadFromUDP(b []byte) (n int, addr *net.UDPAddr, err error) {
+	n, addr, err = c.UDPConn.ReadFromUDP(b)
+	if c.accountant != nil {
+		c.accountant.Record(traffic.Discovery, "", "", uint64(n), 0)
+	}
+	return n, addr, err
+}
+
+// WriteToUDP implements discover.UDPConn
+func (c *accountedUDPConn) WriteToUDP(b []byte, addr *net.UDPAddr) (n int, err error) {
+	// Capped packets are dropped silently, like lost ones
+	if atomic.LoadUint32(&c.capped) == 1 {
+		return len(b), nil
+	}
+	n, err = c.UDPConn.WriteToUDP(b, addr)
+	if c.accountant != nil {
+		c.accountant.Record(traffic.Discovery, "", "", 0, uint64(n))
+	}
+	return n, err
+}
+
 // sharedUDPConn implements a shared connection. Write sends messages to the underlying connection while read returns
 // messages that were found unprocessable and sent to the unhandled channel by the primary listener.
 type sharedUDPConn struct {
-	*net.UDPConn
+	*accountedUDPConn
 	unhandled chan discover.ReadPacket
 }
 
@@ -447,11 +517,13 @@ func (srv *Server) Start() (err error) {
 		srv.Dialer = TCPDialer{&net.Dialer{Timeout: defaultDialTimeout}}
 	}
 	srv.quit = make(chan struct{})
+	srv.evicted = make(map[enode.ID]struct{})
 	srv.delpeer = make(chan peerDrop)
 	srv.checkpointPostHandshake = make(chan *conn)
 	srv.checkpointAddPeer = make(chan *conn)
 	srv.addstatic = make(chan *enode.Node)
 	srv.removestatic = make(chan *enode.Node)
+	srv.addcandidate = make(chan *enode.Node)
 	srv.addtrusted = make(chan *enode.Node)
 	srv.removetrusted = make(chan *enode.Node)
 	srv.peerOp = make(chan peerOpFunc)
@@ -471,11 +543,37 @@ func (srv *Server) Start() (err error) {
 
 	dynPeers := srv.maxDialedConns()
 	dialer := newDialState(srv.localnode.ID(), srv.ntab, dynPeers, &srv.Config)
+	dialer.reputation = srv.reputation
+	dialer.filter = srv.dialFilter()
 	srv.loopWG.Add(1)
 	go srv.run(dialer)
 	return nil
 }
 
+// dialFilter combines the dial filters of the protocols, accepting the nodes
+// worth dialing for any of them. It returns nil if some protocol doesn't filter
+// its candidates.
+func (srv *Server) dialFilter() func(*enode.Node) bool {
+	var filters []func(*enode.Node) bool
+	for _, p := range srv.Protocols {
+		if p.DialFilter == nil {
+			return nil
+		}
+		filters = append(filters, p.DialFilter)
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	return func(n *enode.Node) bool {
+		for _, filter := range filters {
+			if filter(n) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 func (srv *Server) setupLocalNode() error {
 	// Create the devp2p handshake.
 	pubkey := crypto.FromECDSAPub(&srv.PrivateKey.PublicKey)
@@ -491,6 +589,7 @@ func (srv *Server) setupLocalNode() error {
 		return err
 	}
 	srv.nodedb = db
+	srv.reputation = reputation.New(db)
 	srv.localnode = enode.NewLocalNode(db, srv.PrivateKey)
 	srv.localnode.SetFallbackIP(net.IP{127, 0, 0, 1})
 	// TODO: check conflicts
@@ -529,10 +628,14 @@ func (srv *Server) setupDiscovery() error {
 	if err != nil {
 		return err
 	}
-	conn, err := net.ListenUDP("udp", addr)
+	udpconn, err := net.ListenUDP("udp", addr)
 	if err != nil {
 		return err
 	}
+	conn := &accountedUDPConn{UDPConn: udpconn, accountant: srv.Traffic}
+	if srv.Traffic != nil {
+		srv.Traffic.OnCap(traffic.Discovery, conn.setCapped)
+	}
 	realaddr := conn.LocalAddr().(*net.UDPAddr)
 	srv.log.Debug("UDP listener up", "addr", realaddr)
 	if srv.NAT != nil {
@@ -691,6 +794,12 @@ running:
 				p.Disconnect(DiscRequested)
 			}
 
+		case n := <-srv.addcandidate:
+			// This channel is used by AddCandidate to queue a node
+			// for the dynamic dials.
+			srv.log.Trace("Adding dial candidate", "node", n)
+			dialstate.addCandidate(n)
+
 		case n := <-srv.addtrusted:
 			// This channel is used by AddTrustedPeer to add an enode
 			// to the trusted node set.
@@ -765,6 +874,7 @@ running:
 			d := common.PrettyDuration(mclock.Now() - pd.created)
 			pd.log.Debug("Removing p2p peer", "addr", pd.RemoteAddr(), "peers", len(peers)-1, "duration", d, "req", pd.requested, "err", pd.err)
 			delete(peers, pd.ID())
+			delete(srv.evicted, pd.ID())
 			if pd.Inbound() {
 				inboundCount--
 			}
@@ -796,9 +906,9 @@ running:
 
 func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount int, c *conn) error {
 	switch {
-	case !c.is(trustedConn|staticDialedConn) && len(peers) >= srv.MaxPeers:
-		return DiscTooManyPeers
-	case !c.is(trustedConn) && c.is(inboundConn) && inboundCount >= srv.maxInboundConns():
+	case !c.is(trustedConn|staticDialedConn) && srv.reputation.Banned(c.node.ID()):
+		return DiscUselessPeer
+	case srv.overLimit(peers, inboundCount, c) && srv.evictionCandidate(peers, c) == nil:
 		return DiscTooManyPeers
 	case peers[c.node.ID()] != nil:
 		return DiscAlreadyConnected
@@ -809,6 +919,40 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 	}
 }
 
+// overLimit reports whether admitting the connection would exceed the peer
+// limits. Peers already being evicted don't count against the total limit.
+func (srv *Server) overLimit(peers map[enode.ID]*Peer, inboundCount int, c *conn) bool {
+	if !c.is(trustedConn|staticDialedConn) && len(peers)-len(srv.evicted) >= srv.MaxPeers {
+		return true
+	}
+	return !c.is(trustedConn) && c.is(inboundConn) && inboundCount >= srv.maxInboundConns()
+}
+
+// evictionCandidate returns the worst scored peer which may be disconnected to
+// make room for the given connection, or nil if every peer scores better than
+// the connecting node.
+func (srv *Server) evictionCandidate(peers map[enode.ID]*Peer, c *conn) *Peer {
+	var (
+		worst      *Peer
+		worstScore float64
+	)
+	for id, p := range peers {
+		if _, ok := srv.evicted[id]; ok || p.rw.is(trustedConn|staticDialedConn) {
+			continue
+		}
+		if c.is(inboundConn) && !p.Inbound() {
+			continue // Inbound connections only take the slots of inbound peers
+		}
+		if score := srv.reputation.Score(id); worst == nil || score < worstScore {
+			worst, worstScore = p, score
+		}
+	}
+	if worst == nil || worstScore > evictionScore || worstScore+evictionMargin > srv.reputation.Score(c.node.ID()) {
+		return nil
+	}
+	return worst
+}
+
 func (srv *Server) addPeerChecks(peers map[enode.ID]*Peer, inboundCount int, c *conn) error {
 	// Drop connections with no matching protocols.
 	if len(srv.Protocols) > 0 && countMatchingProtocols(srv.Protocols, c.caps) == 0 {
@@ -816,7 +960,17 @@ func (srv *Server) addPeerChecks(peers map[enode.ID]*Peer, inboundCount int, c *
 	}
 	// Repeat the post-handshake checks because the
 	// peer set might have changed since those checks were performed.
-	return srv.postHandshakeChecks(peers, inboundCount, c)
+	if err := srv.postHandshakeChecks(peers, inboundCount, c); err != nil {
+		return err
+	}
+	// Make room for the connection by evicting a worse peer if the server is full
+	if srv.overLimit(peers, inboundCount, c) {
+		victim := srv.evictionCandidate(peers, c)
+		victim.log.Debug("Evicting low reputation peer", "score", srv.reputation.Score(victim.ID()))
+		srv.evicted[victim.ID()] = struct{}{}
+		go victim.Disconnect(DiscUselessPeer)
+	}
+	return nil
 }
 
 func (srv *Server) maxInboundConns() int {
@@ -916,6 +1070,17 @@ func (srv *Server) SetupConn(fd net.Conn, flags connFlag, dialDest *enode.Node)
 	return err
 }
 
+// SetupStreamConn runs the handshakes over a connection established by another
+// transport, e.g. a libp2p stream, and attempts to add it as a peer. Connections
+// with a dial destination are treated as dynamically dialed, others as inbound.
+func (srv *Server) SetupStreamConn(fd net.Conn, dialDest *enode.Node) error {
+	flags := inboundConn
+	if dialDest != nil {
+		flags = dynDialedConn
+	}
+	return srv.SetupConn(fd, flags, dialDest)
+}
+
 func (srv *Server) setupConn(c *conn, flags connFlag, dialDest *enode.Node) error {
 	// Prevent leftover pending conns from entering the handshake.
 	srv.lock.Lock()
@@ -17,6 +17,7 @@
 package adapters
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math"
@@ -34,11 +35,20 @@ import (
 
 // SimAdapter is a NodeAdapter which creates in-memory simulation nodes and
 // connects them using net.Pipe
+//
+// The links between the nodes can be given latency, loss and bandwidth limits,
+// and nodes can be placed behind a simulated NAT, making them reachable only
+// through the relay nodes they are connected to.
 type SimAdapter struct {
 	pipe     func() (net.Conn, net.Conn, error)
 	mtx      sync.RWMutex
 	nodes    map[enode.ID]*SimNode
 	services map[string]ServiceFunc
+
+	link   pipes.LinkConfig                 // Conditions of the links not configured individually
+	links  map[[2]enode.ID]pipes.LinkConfig // Conditions of individual links, keyed by the ordered node pair
+	natted map[enode.ID]bool                // Nodes accepting no inbound connections
+	relays map[enode.ID]bool                // Nodes relaying connections to the natted nodes connected to them
 }
 
 // NewSimAdapter creates a SimAdapter which is capable of running in-memory
@@ -50,6 +60,9 @@ func NewSimAdapter(services map[string]ServiceFunc) *SimAdapter {
 		pipe:     pipes.NetPipe,
 		nodes:    make(map[enode.ID]*SimNode),
 		services: services,
+		links:    make(map[[2]enode.ID]pipes.LinkConfig),
+		natted:   make(map[enode.ID]bool),
+		relays:   make(map[enode.ID]bool),
 	}
 }
 
@@ -58,6 +71,9 @@ func NewTCPAdapter(services map[string]ServiceFunc) *SimAdapter {
 		pipe:     pipes.TCPPipe,
 		nodes:    make(map[enode.ID]*SimNode),
 		services: services,
+		links:    make(map[[2]enode.ID]pipes.LinkConfig),
+		natted:   make(map[enode.ID]bool),
+		relays:   make(map[enode.ID]bool),
 	}
 }
 
@@ -102,7 +118,7 @@ func (s *SimAdapter) NewNode(config *NodeConfig) (Node, error) {
 			PrivateKey:      config.PrivateKey,
 			MaxPeers:        math.MaxInt32,
 			NoDiscovery:     true,
-			Dialer:          s,
+			Dialer:          &simDialer{adapter: s, src: id},
 			EnableMsgEvents: config.EnableMsgEvents,
 		},
 		Logger: log.New("node.id", id.String()),
@@ -125,6 +141,12 @@ func (s *SimAdapter) NewNode(config *NodeConfig) (Node, error) {
 // Dial implements the p2p.NodeDialer interface by connecting to the node using
 // an in-memory net.Pipe
 func (s *SimAdapter) Dial(dest *enode.Node) (conn net.Conn, err error) {
+	return s.dial(enode.ID{}, dest)
+}
+
+// dial connects the source node to the destination, over the link conditions
+// between them.
+func (s *SimAdapter) dial(src enode.ID, dest *enode.Node) (conn net.Conn, err error) {
 	node, ok := s.GetNode(dest.ID())
 	if !ok {
 		return nil, fmt.Errorf("unknown node: %s", dest.ID())
@@ -133,11 +155,18 @@ func (s *SimAdapter) Dial(dest *enode.Node) (conn net.Conn, err error) {
 	if srv == nil {
 		return nil, fmt.Errorf("node not running: %s", dest.ID())
 	}
+	link, err := s.route(src, dest.ID())
+	if err != nil {
+		return nil, err
+	}
 	// SimAdapter.pipe is net.Pipe (NewSimAdapter)
 	pipe1, pipe2, err := s.pipe()
 	if err != nil {
 		return nil, err
 	}
+	if !link.Ideal() {
+		pipe1, pipe2 = pipes.NewShapedConn(pipe1, link), pipes.NewShapedConn(pipe2, link)
+	}
 	// this is simulated 'listening'
 	// asynchronously call the dialed destination node's p2p server
 	// to set up connection on the 'listening' side
@@ -145,6 +174,104 @@ func (s *SimAdapter) Dial(dest *enode.Node) (conn net.Conn, err error) {
 	return pipe2, nil
 }
 
+// SetDefaultLink sets the conditions of the links not configured individually.
+func (s *SimAdapter) SetDefaultLink(config pipes.LinkConfig) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.link = config
+}
+
+// SetLink sets the conditions of the link between two nodes, in both directions.
+// It applies to the connections established afterwards.
+func (s *SimAdapter) SetLink(one, other enode.ID, config pipes.LinkConfig) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.links[linkKey(one, other)] = config
+}
+
+// SetNAT places a node behind a simulated NAT or removes it from there. Natted
+// nodes can dial out, but are only reachable through the relays they are
+// connected to.
+func (s *SimAdapter) SetNAT(id enode.ID, natted bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if natted {
+		s.natted[id] = true
+	} else {
+		delete(s.natted, id)
+	}
+}
+
+// SetRelay makes a node relay connections to the natted nodes connected to it.
+func (s *SimAdapter) SetRelay(id enode.ID, relay bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if relay {
+		s.relays[id] = true
+	} else {
+		delete(s.relays, id)
+	}
+}
+
+// route returns the conditions of the path from the source node to the
+// destination, failing if a natted destination can't be reached over a relay.
+func (s *SimAdapter) route(src, dest enode.ID) (pipes.LinkConfig, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if !s.natted[dest] {
+		return s.linkOf(src, dest), nil
+	}
+	node := s.nodes[dest]
+	srv := node.Server()
+	if srv == nil {
+		return pipes.LinkConfig{}, fmt.Errorf("node not running: %s", dest)
+	}
+	for _, peer := range srv.Peers() {
+		relay := peer.ID()
+		if !s.relays[relay] {
+			continue
+		}
+		if relay == src {
+			return s.linkOf(src, dest), nil
+		}
+		if rnode := s.nodes[relay]; rnode != nil && rnode.Server() != nil {
+			return s.linkOf(src, relay).Combine(s.linkOf(relay, dest)), nil
+		}
+	}
+	return pipes.LinkConfig{}, fmt.Errorf("node behind NAT without relay: %s", dest)
+}
+
+// linkOf returns the conditions of the direct link between two nodes. The lock
+// must be held.
+func (s *SimAdapter) linkOf(one, other enode.ID) pipes.LinkConfig {
+	if config, ok := s.links[linkKey(one, other)]; ok {
+		return config
+	}
+	return s.link
+}
+
+// linkKey returns the key of the link between two nodes, the same in both
+// directions.
+func linkKey(one, other enode.ID) [2]enode.ID {
+	if bytes.Compare(one[:], other[:]) > 0 {
+		one, other = other, one
+	}
+	return [2]enode.ID{one, other}
+}
+
+// simDialer dials on behalf of a simulation node, so that the adapter knows the
+// link a connection goes over.
+type simDialer struct {
+	adapter *SimAdapter
+	src     enode.ID
+}
+
+// Dial implements the p2p.NodeDialer interface.
+func (d *simDialer) Dial(dest *enode.Node) (net.Conn, error) {
+	return d.adapter.dial(d.src, dest)
+}
+
 // DialRPC implements the RPCDialer interface by creating an in-memory RPC
 // client of the given node
 func (s *SimAdapter) DialRPC(id enode.ID) (*rpc.Client, error) {
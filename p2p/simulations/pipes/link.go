@@ -0,0 +1,200 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package pipes
+
+import (
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	segmentSize    = 1460                   // Maximum payload of a simulated segment
+	segmentBuffer  = 256                    // Number of segments in flight per direction
+	minRetransmit  = 200 * time.Millisecond // Minimum retransmission timeout of a lost segment
+	maxRetransmits = 8                      // Maximum number of times a segment is lost in a row
+)
+
+// LinkConfig describes the conditions of a simulated network link. The pipes
+// are reliable streams, so a lost segment isn't dropped but delayed by the
+// retransmission timeout, as it would be over TCP.
+type LinkConfig struct {
+	Latency   time.Duration // One way delay of the link
+	Loss      float64       // Probability of a segment being lost and retransmitted
+	Bandwidth int           // Throughput of the link in bytes per second, 0 for unlimited
+}
+
+// Ideal reports whether the link doesn't impair the traffic at all.
+func (c LinkConfig) Ideal() bool {
+	return c.Latency == 0 && c.Loss == 0 && c.Bandwidth == 0
+}
+
+// Combine returns the conditions of a path going over both links, as through a
+// relay: the latencies add up, the losses compound and the slowest link limits
+// the bandwidth.
+func (c LinkConfig) Combine(other LinkConfig) LinkConfig {
+	bandwidth := c.Bandwidth
+	if bandwidth == 0 || (other.Bandwidth != 0 && other.Bandwidth < bandwidth) {
+		bandwidth = other.Bandwidth
+	}
+	return LinkConfig{
+		Latency:   c.Latency + other.Latency,
+		Loss:      1 - (1-c.Loss)*(1-other.Loss),
+		Bandwidth: bandwidth,
+	}
+}
+
+// ShapedPipe wraps a pipe constructor so that both ends of the created pipes
+// suffer the given link conditions.
+func ShapedPipe(pipe func() (net.Conn, net.Conn, error), config LinkConfig) func() (net.Conn, net.Conn, error) {
+	return func() (net.Conn, net.Conn, error) {
+		c1, c2, err := pipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewShapedConn(c1, config), NewShapedConn(c2, config), nil
+	}
+}
+
+// segment is a chunk of written data and the time it reaches the remote end.
+type segment struct {
+	data []byte
+	at   time.Time
+}
+
+// shapedConn delays the writes to a connection according to the conditions of
+// the link. Writes return once queued, in-order delivery is done by a goroutine.
+type shapedConn struct {
+	net.Conn
+	config LinkConfig
+
+	queue chan segment
+	busy  time.Time  // Time the link finishes transmitting the queued segments
+	last  time.Time  // Delivery time of the last queued segment
+	err   error      // Delivery failure, reported on the next write
+	lock  sync.Mutex // Protects the link state
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewShapedConn wraps a connection, delaying its writes according to the given
+// link conditions.
+func NewShapedConn(conn net.Conn, config LinkConfig) net.Conn {
+	c := &shapedConn{
+		Conn:   conn,
+		config: config,
+		queue:  make(chan segment, segmentBuffer),
+		closed: make(chan struct{}),
+	}
+	go c.deliver()
+	return c
+}
+
+// Write splits the data into segments and queues them for delivery.
+func (c *shapedConn) Write(b []byte) (int, error) {
+	for n := 0; n < len(b); {
+		size := len(b) - n
+		if size > segmentSize {
+			size = segmentSize
+		}
+		seg := segment{data: append([]byte(nil), b[n:n+size]...)}
+
+		c.lock.Lock()
+		if c.err != nil {
+			err := c.err
+			c.lock.Unlock()
+			return n, err
+		}
+		seg.at = c.schedule(size)
+		c.lock.Unlock()
+
+		select {
+		case c.queue <- seg:
+			n += size
+		case <-c.closed:
+			return n, io.ErrClosedPipe
+		}
+	}
+	return len(b), nil
+}
+
+// schedule returns the delivery time of a segment of the given size written
+// now. The lock must be held.
+func (c *shapedConn) schedule(size int) time.Time {
+	now := time.Now()
+	if c.busy.Before(now) {
+		c.busy = now
+	}
+	if c.config.Bandwidth > 0 {
+		c.busy = c.busy.Add(time.Duration(size) * time.Second / time.Duration(c.config.Bandwidth))
+	}
+	at := c.busy.Add(c.config.Latency)
+
+	rto := 4 * c.config.Latency
+	if rto < minRetransmit {
+		rto = minRetransmit
+	}
+	for i := 0; i < maxRetransmits && rand.Float64() < c.config.Loss; i++ {
+		at = at.Add(rto)
+	}
+	// Streams deliver in order, a retransmission holds up the later segments
+	if at.Before(c.last) {
+		at = c.last
+	}
+	c.last = at
+	return at
+}
+
+// deliver writes the queued segments to the connection once they're due.
+func (c *shapedConn) deliver() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	for {
+		select {
+		case seg := <-c.queue:
+			if wait := time.Until(seg.at); wait > 0 {
+				timer.Reset(wait)
+				select {
+				case <-timer.C:
+				case <-c.closed:
+					return
+				}
+			}
+			if _, err := c.Conn.Write(seg.data); err != nil {
+				c.lock.Lock()
+				c.err = err
+				c.lock.Unlock()
+				c.Close()
+				return
+			}
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// Close stops the delivery and closes the connection. Segments still in flight
+// are lost, as if the link went down.
+func (c *shapedConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return c.Conn.Close()
+}
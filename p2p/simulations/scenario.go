@@ -0,0 +1,410 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/simulations/adapters"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/simulations/pipes"
+)
+
+// Scenario describes a simulated network of mobile nodes: a few of them mine,
+// some relay connections to the nodes behind NAT, and the rest come and go on
+// a schedule, over links of limited quality.
+//
+// The nodes are laid out in order: the miners first, then the relays, and the
+// natted nodes last. Miners and relays stay online, the churning nodes are
+// picked among the others.
+type Scenario struct {
+	Nodes  int // Number of nodes in the network
+	Miners int // Number of nodes mining
+	Relays int // Number of publicly reachable nodes relaying to the natted ones
+	NATed  int // Number of nodes behind NAT, reachable only through the relays
+	Peers  int // Number of peers each node dials when coming online
+
+	Services []string         // Services run by every node
+	Link     pipes.LinkConfig // Conditions of the links between the nodes
+	Churn    Churn            // Schedule of the nodes going offline and online
+
+	Duration time.Duration // Duration of the mining and churn phase
+	Settle   time.Duration // Time allowed for the nodes to agree on the head once mining stopped
+	Sample   time.Duration // Interval of sampling the heads of the nodes
+
+	Mine func(id enode.ID, enable bool) error           // Switches mining on a node
+	Head func(id enode.ID) (uint64, common.Hash, error) // Retrieves the head block of a node
+}
+
+// Churn is the schedule of the nodes going offline and online. The online and
+// offline periods are exponentially distributed around their mean.
+type Churn struct {
+	Nodes   int           // Number of churning nodes
+	Online  time.Duration // Mean time a churning node stays online
+	Offline time.Duration // Mean time a churning node stays offline
+}
+
+// ScenarioReport contains the convergence metrics of a scenario run.
+type ScenarioReport struct {
+	Converged   bool          // Whether the nodes agreed on the head within the settle time
+	Convergence time.Duration // Time the nodes took to agree on the head once mining stopped
+
+	Heights  int     // Number of block heights observed on the nodes
+	Forks    int     // Number of heights at which competing blocks were observed
+	ForkRate float64 // Share of the observed heights with competing blocks
+
+	Messages        map[enode.ID]int // Number of messages sent per node
+	MessagesPerNode float64          // Mean number of messages sent per node
+
+	Restarts int // Number of times a churning node came back online
+}
+
+// validate checks that the node roles of the scenario fit in the network.
+func (s *Scenario) validate() error {
+	switch {
+	case s.Nodes < 2:
+		return errors.New("scenario needs at least two nodes")
+	case s.Miners+s.Relays+s.NATed > s.Nodes:
+		return errors.New("more miners, relays and natted nodes than nodes")
+	case s.NATed > 0 && s.Relays == 0:
+		return errors.New("natted nodes need relays")
+	case s.Churn.Nodes > s.Nodes-s.Miners-s.Relays:
+		return errors.New("more churning nodes than nodes neither mining nor relaying")
+	case s.Churn.Nodes > 0 && (s.Churn.Online <= 0 || s.Churn.Offline <= 0):
+		return errors.New("churn periods must be positive")
+	case s.Head == nil:
+		return errors.New("scenario without head retrieval")
+	case s.Miners > 0 && s.Mine == nil:
+		return errors.New("scenario without mining switch")
+	case s.Sample <= 0:
+		return errors.New("sample interval must be positive")
+	}
+	return nil
+}
+
+// scenarioRun is the state of a running scenario.
+type scenarioRun struct {
+	scenario *Scenario
+	net      *Network
+	adapter  *adapters.SimAdapter
+
+	ids    []enode.ID // All nodes of the network, in role order
+	relays []enode.ID // Relay nodes the natted ones connect to
+	natted map[enode.ID]bool
+
+	heights  map[uint64]map[common.Hash]struct{} // Blocks observed per height
+	messages map[enode.ID]int                    // Messages sent per node
+	restarts int
+	lock     sync.Mutex
+}
+
+// RunScenario runs a scenario on a new network of nodes created by the given
+// adapter. The mining and churn phase lasts for the configured duration, after
+// which all nodes are brought online, mining stops and the nodes are given the
+// settle time to agree on the head.
+func RunScenario(ctx context.Context, adapter *adapters.SimAdapter, s *Scenario) (*ScenarioReport, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	net := NewNetwork(adapter, &NetworkConfig{ID: "scenario"})
+	defer net.Shutdown()
+
+	run := &scenarioRun{
+		scenario: s,
+		net:      net,
+		adapter:  adapter,
+		natted:   make(map[enode.ID]bool),
+		heights:  make(map[uint64]map[common.Hash]struct{}),
+		messages: make(map[enode.ID]int),
+	}
+	// Count the messages sent by every node
+	events := make(chan *Event, 1024)
+	sub := net.Events().Subscribe(events)
+	counted := make(chan struct{})
+	go func() {
+		defer close(counted)
+		run.countMessages(events, sub.Err())
+	}()
+	defer func() {
+		sub.Unsubscribe()
+		<-counted
+	}()
+
+	if err := run.setup(); err != nil {
+		return nil, err
+	}
+	for _, id := range run.ids[:s.Miners] {
+		if err := s.Mine(id, true); err != nil {
+			return nil, fmt.Errorf("failed to start mining on %v: %v", id, err)
+		}
+	}
+	// Churn the nodes and sample the heads for the scenario duration
+	var (
+		quit = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	for _, id := range run.ids[s.Miners+s.Relays : s.Miners+s.Relays+s.Churn.Nodes] {
+		wg.Add(1)
+		go func(id enode.ID) {
+			defer wg.Done()
+			run.churn(id, quit)
+		}(id)
+	}
+	sample := time.NewTicker(s.Sample)
+	defer sample.Stop()
+
+	end := time.NewTimer(s.Duration)
+	defer end.Stop()
+
+	err := run.wait(ctx, sample.C, end.C)
+	close(quit)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	// Stop mining and measure how long the nodes take to agree on the head
+	for _, id := range run.ids[:s.Miners] {
+		if err := s.Mine(id, false); err != nil {
+			return nil, fmt.Errorf("failed to stop mining on %v: %v", id, err)
+		}
+	}
+	report := &ScenarioReport{}
+
+	settled := time.Now()
+	settle := time.NewTimer(s.Settle)
+	defer settle.Stop()
+
+	for !report.Converged {
+		if run.sample() {
+			report.Converged = true
+			report.Convergence = time.Since(settled)
+			break
+		}
+		select {
+		case <-sample.C:
+		case <-settle.C:
+			log.Warn("Scenario didn't converge", "settle", s.Settle)
+			return run.report(report), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return run.report(report), nil
+}
+
+// setup creates and starts the nodes, assigns their roles and connects them.
+func (r *scenarioRun) setup() error {
+	s := r.scenario
+
+	r.adapter.SetDefaultLink(s.Link)
+	for i := 0; i < s.Nodes; i++ {
+		config := adapters.RandomNodeConfig()
+		config.Services = s.Services
+		config.EnableMsgEvents = true
+		node, err := r.net.NewNodeWithConfig(config)
+		if err != nil {
+			return fmt.Errorf("failed to create node: %v", err)
+		}
+		r.ids = append(r.ids, node.ID())
+	}
+	r.relays = r.ids[s.Miners : s.Miners+s.Relays]
+	for _, id := range r.relays {
+		r.adapter.SetRelay(id, true)
+	}
+	for _, id := range r.ids[s.Nodes-s.NATed:] {
+		r.natted[id] = true
+		r.adapter.SetNAT(id, true)
+	}
+	for _, id := range r.ids {
+		if err := r.net.Start(id); err != nil {
+			return fmt.Errorf("failed to start node %v: %v", id, err)
+		}
+	}
+	for _, id := range r.ids {
+		r.connect(id)
+	}
+	return nil
+}
+
+// connect makes a node that came online dial its peers: a relay first if it's
+// behind NAT, then random online nodes. The peers are added as static ones, so
+// dials failing for now are retried.
+func (r *scenarioRun) connect(id enode.ID) {
+	if r.natted[id] {
+		r.dial(id, r.relays[rand.Intn(len(r.relays))])
+	}
+	exclude := []enode.ID{id}
+	for i := 0; i < r.scenario.Peers; i++ {
+		node := r.net.GetRandomUpNode(exclude...)
+		if node == nil {
+			return
+		}
+		exclude = append(exclude, node.ID())
+		r.dial(id, node.ID())
+	}
+}
+
+// dial makes a node add another as a static peer. The network's connection
+// bookkeeping is bypassed, since it doesn't track the connections of stopped
+// nodes.
+func (r *scenarioRun) dial(id, peer enode.ID) {
+	node, other := r.net.GetNode(id), r.net.GetNode(peer)
+	client, err := node.Client()
+	if err != nil {
+		log.Debug("Can't dial from stopped node", "id", id, "err", err)
+		return
+	}
+	if err := client.Call(nil, "admin_addPeer", string(other.Addr())); err != nil {
+		log.Debug("Failed to add peer", "id", id, "peer", peer, "err", err)
+	}
+}
+
+// churn takes a node offline and back online on the scenario schedule until
+// quit, leaving it online.
+func (r *scenarioRun) churn(id enode.ID, quit chan struct{}) {
+	period := func(mean time.Duration) <-chan time.Time {
+		return time.After(time.Duration(rand.ExpFloat64() * float64(mean)))
+	}
+	for {
+		select {
+		case <-period(r.scenario.Churn.Online):
+		case <-quit:
+			return
+		}
+		if err := r.net.Stop(id); err != nil {
+			log.Warn("Failed to stop churning node", "id", id, "err", err)
+			return
+		}
+		select {
+		case <-period(r.scenario.Churn.Offline):
+		case <-quit:
+		}
+		if err := r.net.Start(id); err != nil {
+			log.Warn("Failed to restart churning node", "id", id, "err", err)
+			return
+		}
+		r.connect(id)
+
+		r.lock.Lock()
+		r.restarts++
+		r.lock.Unlock()
+
+		select {
+		case <-quit:
+			return
+		default:
+		}
+	}
+}
+
+// wait samples the heads of the nodes until the end of the scenario phase.
+func (r *scenarioRun) wait(ctx context.Context, sample, end <-chan time.Time) error {
+	for {
+		select {
+		case <-sample:
+			r.sample()
+		case <-end:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sample records the heads of the online nodes, reporting whether they all
+// agree on the same block.
+func (r *scenarioRun) sample() bool {
+	var (
+		agreed = true
+		first  *common.Hash
+	)
+	for _, id := range r.ids {
+		if node := r.net.GetNode(id); node == nil || !node.Up() {
+			continue
+		}
+		number, hash, err := r.scenario.Head(id)
+		if err != nil {
+			// The node may have gone offline meanwhile
+			log.Trace("Failed to retrieve head", "id", id, "err", err)
+			agreed = false
+			continue
+		}
+		r.lock.Lock()
+		if r.heights[number] == nil {
+			r.heights[number] = make(map[common.Hash]struct{})
+		}
+		r.heights[number][hash] = struct{}{}
+		r.lock.Unlock()
+
+		if first == nil {
+			first = &hash
+		} else if *first != hash {
+			agreed = false
+		}
+	}
+	return agreed && first != nil
+}
+
+// countMessages counts the messages sent by every node until unsubscribed.
+func (r *scenarioRun) countMessages(events chan *Event, done <-chan error) {
+	for {
+		select {
+		case event := <-events:
+			if event.Type != EventTypeMsg || event.Msg.Received {
+				continue
+			}
+			r.lock.Lock()
+			r.messages[event.Msg.One]++
+			r.lock.Unlock()
+
+		case <-done:
+			return
+		}
+	}
+}
+
+// report fills the metrics gathered during the run into the report.
+func (r *scenarioRun) report(report *ScenarioReport) *ScenarioReport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, blocks := range r.heights {
+		report.Heights++
+		if len(blocks) > 1 {
+			report.Forks++
+		}
+	}
+	if report.Heights > 0 {
+		report.ForkRate = float64(report.Forks) / float64(report.Heights)
+	}
+	report.Messages = make(map[enode.ID]int, len(r.messages))
+	total := 0
+	for id, count := range r.messages {
+		report.Messages[id] = count
+		total += count
+	}
+	report.MessagesPerNode = float64(total) / float64(len(r.ids))
+	report.Restarts = r.restarts
+	return report
+}
@@ -0,0 +1,229 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulations
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/node"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/simulations/adapters"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/simulations/pipes"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+)
+
+// chainHead is the head block announced by the toy chain service.
+type chainHead struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// better reports whether the head is preferred over the other: the higher one
+// wins, ties are broken by the lower hash.
+func (h chainHead) better(other chainHead) bool {
+	if h.Number != other.Number {
+		return h.Number > other.Number
+	}
+	return bytes.Compare(h.Hash[:], other.Hash[:]) < 0
+}
+
+// chainState is the state of a toy chain node, surviving its restarts as a
+// database would.
+type chainState struct {
+	head   chainHead
+	mining bool
+	lock   sync.Mutex
+}
+
+// chainService is a toy blockchain: miners extend their head with random blocks
+// and every node adopts and relays the better heads announced by its peers.
+type chainService struct {
+	state *chainState
+	peers map[*p2p.Peer]p2p.MsgReadWriter
+	lock  sync.Mutex
+	quit  chan struct{}
+}
+
+func (s *chainService) Protocols() []p2p.Protocol {
+	return []p2p.Protocol{{
+		Name:    "chain",
+		Version: 1,
+		Length:  1,
+		Run:     s.run,
+	}}
+}
+
+func (s *chainService) APIs() []rpc.API { return nil }
+
+func (s *chainService) Start(server *p2p.Server) error {
+	go s.mine()
+	return nil
+}
+
+func (s *chainService) Stop() error {
+	close(s.quit)
+	return nil
+}
+
+// run announces the head to a peer and processes its announcements.
+func (s *chainService) run(peer *p2p.Peer, rw p2p.MsgReadWriter) error {
+	s.lock.Lock()
+	s.peers[peer] = rw
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		delete(s.peers, peer)
+		s.lock.Unlock()
+	}()
+	s.state.lock.Lock()
+	head := s.state.head
+	s.state.lock.Unlock()
+
+	go p2p.Send(rw, 0, head)
+	for {
+		msg, err := rw.ReadMsg()
+		if err != nil {
+			return err
+		}
+		var announced chainHead
+		if err := msg.Decode(&announced); err != nil {
+			return err
+		}
+		s.update(announced, peer)
+	}
+}
+
+// mine extends the head with a random block while mining is enabled.
+func (s *chainService) mine() {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.state.lock.Lock()
+			mining, head := s.state.mining, s.state.head
+			s.state.lock.Unlock()
+
+			if mining && rand.Intn(4) == 0 {
+				head.Number++
+				rand.Read(head.Hash[:])
+				s.update(head, nil)
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// update adopts a head if it's better than the current one, relaying it to all
+// peers but its origin.
+func (s *chainService) update(head chainHead, origin *p2p.Peer) {
+	s.state.lock.Lock()
+	if !head.better(s.state.head) {
+		s.state.lock.Unlock()
+		return
+	}
+	s.state.head = head
+	s.state.lock.Unlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for peer, rw := range s.peers {
+		if peer != origin {
+			go p2p.Send(rw, 0, head)
+		}
+	}
+}
+
+// Tests that a network of churning nodes, some of them behind NAT and all of them
+// on lossy links, converges on a single head once mining stops.
+func TestScenarioChurnNAT(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping network scenario in short mode")
+	}
+	var (
+		states = make(map[enode.ID]*chainState)
+		lock   sync.Mutex
+	)
+	state := func(id enode.ID) *chainState {
+		lock.Lock()
+		defer lock.Unlock()
+		if states[id] == nil {
+			states[id] = new(chainState)
+		}
+		return states[id]
+	}
+	adapter := adapters.NewSimAdapter(adapters.Services{
+		"chain": func(ctx *adapters.ServiceContext) (node.Service, error) {
+			return &chainService{
+				state: state(ctx.Config.ID),
+				peers: make(map[*p2p.Peer]p2p.MsgReadWriter),
+				quit:  make(chan struct{}),
+			}, nil
+		},
+	})
+	scenario := &Scenario{
+		Nodes:    8,
+		Miners:   2,
+		Relays:   1,
+		NATed:    2,
+		Peers:    3,
+		Services: []string{"chain"},
+		Link:     pipes.LinkConfig{Latency: 20 * time.Millisecond, Loss: 0.01},
+		Churn:    Churn{Nodes: 3, Online: time.Second, Offline: 500 * time.Millisecond},
+		Duration: 5 * time.Second,
+		Settle:   10 * time.Second,
+		Sample:   100 * time.Millisecond,
+		Mine: func(id enode.ID, enable bool) error {
+			s := state(id)
+			s.lock.Lock()
+			s.mining = enable
+			s.lock.Unlock()
+			return nil
+		},
+		Head: func(id enode.ID) (uint64, common.Hash, error) {
+			s := state(id)
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			return s.head.Number, s.head.Hash, nil
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	report, err := RunScenario(ctx, adapter, scenario)
+	if err != nil {
+		t.Fatalf("failed to run scenario: %v", err)
+	}
+	if !report.Converged {
+		t.Fatalf("network didn't converge within %v", scenario.Settle)
+	}
+	if report.Heights == 0 || report.MessagesPerNode == 0 {
+		t.Fatalf("no activity recorded: %d heights, %v messages per node", report.Heights, report.MessagesPerNode)
+	}
+	t.Logf("converged in %v, fork rate %.2f, %.1f messages per node, %d restarts",
+		report.Convergence, report.ForkRate, report.MessagesPerNode, report.Restarts)
+}
@@ -0,0 +1,264 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package stream runs the devp2p protocols over libp2p streams.
+//
+// Nodes behind NATs that devp2p can't traverse are often reachable through the
+// libp2p host of the embedded IPFS node, which brings circuit relays and hole
+// punching. The transport opens a libp2p stream to the remote peer and hands it
+// to the p2p server as a regular connection: the RLPx and capability handshakes
+// run over it unchanged, so the peers it establishes are indistinguishable from
+// those dialed over TCP for the protocols, the peer limits and the reputation.
+//
+// Since the RLPx initiator has to know the public key of the recipient, every
+// stream starts with the recipient sending its devp2p public key in the clear.
+// A node claiming a foreign key fails the RLPx handshake.
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+// ProtocolID is the libp2p protocol devp2p connections are multiplexed under.
+const ProtocolID = protocol.ID("/tau/devp2p/1.0.0")
+
+const (
+	pubkeyLength     = 65               // Length of an uncompressed secp256k1 public key
+	handshakeTimeout = 10 * time.Second // Time allowed for the key exchange
+	dialTimeout      = 30 * time.Second // Time allowed for connecting and setting up a peer
+	redialInterval   = time.Minute      // Interval of dialing the configured peers not yet connected
+)
+
+var errAlreadyActive = errors.New("already connected over libp2p")
+
+// Addr is the address of a devp2p connection over a libp2p stream.
+type Addr struct {
+	Peer peer.ID
+}
+
+// Network implements net.Addr.
+func (a Addr) Network() string { return "libp2p" }
+
+// String implements net.Addr.
+func (a Addr) String() string { return "/p2p/" + a.Peer.Pretty() }
+
+// conn wraps a libp2p stream into a net.Conn.
+type conn struct {
+	network.Stream
+	local, remote Addr
+	closed        func() // Invoked once the connection is closed
+	once          sync.Once
+}
+
+func (c *conn) LocalAddr() net.Addr  { return c.local }
+func (c *conn) RemoteAddr() net.Addr { return c.remote }
+
+func (c *conn) Close() error {
+	c.once.Do(c.closed)
+	return c.Stream.Close()
+}
+
+// Transport establishes devp2p peers over libp2p streams.
+type Transport struct {
+	host  host.Host
+	srv   *p2p.Server
+	peers func() []peer.ID // Peers to keep connected to, nil for none
+
+	active map[peer.ID]struct{} // Peers with a live devp2p connection
+	lock   sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    log.Logger
+}
+
+// New creates a transport running the protocols of the given server over the
+// streams of a libp2p host. The transport keeps dialing the peers returned by
+// the optional peers callback.
+func New(h host.Host, srv *p2p.Server, peers func() []peer.ID) *Transport {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Transport{
+		host:   h,
+		srv:    srv,
+		peers:  peers,
+		active: make(map[peer.ID]struct{}),
+		ctx:    ctx,
+		cancel: cancel,
+		log:    log.New("host", h.ID().Pretty()),
+	}
+}
+
+// Start accepts inbound streams and starts dialing the configured peers.
+func (t *Transport) Start() {
+	t.host.SetStreamHandler(ProtocolID, t.handle)
+	if t.peers != nil {
+		t.wg.Add(1)
+		go t.loop()
+	}
+}
+
+// Stop stops accepting streams and dialing. Established peers are left to the
+// p2p server to disconnect.
+func (t *Transport) Stop() {
+	t.host.RemoveStreamHandler(ProtocolID)
+	t.cancel()
+	t.wg.Wait()
+}
+
+// Dial connects to a libp2p peer, directly or through a relay as the host sees
+// fit, and sets up a devp2p peer over a new stream.
+func (t *Transport) Dial(ctx context.Context, id peer.ID) error {
+	if !t.activate(id) {
+		return errAlreadyActive
+	}
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	if err := t.host.Connect(ctx, peer.AddrInfo{ID: id}); err != nil {
+		t.deactivate(id)
+		return err
+	}
+	s, err := t.host.NewStream(ctx, id, ProtocolID)
+	if err != nil {
+		t.deactivate(id)
+		return err
+	}
+	fd := t.wrap(s)
+
+	// Learn the devp2p key of the recipient to initiate RLPx with
+	s.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	blob := make([]byte, pubkeyLength)
+	if _, err := io.ReadFull(s, blob); err != nil {
+		fd.Close()
+		return err
+	}
+	s.SetReadDeadline(time.Time{})
+
+	pubkey, err := crypto.UnmarshalPubkey(blob)
+	if err != nil {
+		fd.Close()
+		return fmt.Errorf("invalid devp2p key: %v", err)
+	}
+	return t.srv.SetupStreamConn(fd, enode.NewV4(pubkey, nil, 0, 0))
+}
+
+// handle sets up a devp2p peer over an inbound stream.
+func (t *Transport) handle(s network.Stream) {
+	id := s.Conn().RemotePeer()
+	if !t.activate(id) {
+		t.log.Trace("Rejecting duplicate stream", "peer", id)
+		s.Reset()
+		return
+	}
+	fd := t.wrap(s)
+
+	s.SetWriteDeadline(time.Now().Add(handshakeTimeout))
+	if _, err := s.Write(crypto.FromECDSAPub(&t.srv.PrivateKey.PublicKey)); err != nil {
+		fd.Close()
+		return
+	}
+	s.SetWriteDeadline(time.Time{})
+
+	if err := t.srv.SetupStreamConn(fd, nil); err != nil {
+		t.log.Trace("Failed to set up inbound stream", "peer", id, "err", err)
+	}
+}
+
+// loop periodically dials the configured peers not connected yet.
+func (t *Transport) loop() {
+	defer t.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			for _, id := range t.peers() {
+				if id == t.host.ID() || t.isActive(id) {
+					continue
+				}
+				t.wg.Add(1)
+				go func(id peer.ID) {
+					defer t.wg.Done()
+					if err := t.Dial(t.ctx, id); err != nil {
+						t.log.Trace("Failed to dial over libp2p", "peer", id, "err", err)
+					}
+				}(id)
+			}
+			timer.Reset(redialInterval)
+
+		case <-t.ctx.Done():
+			return
+		}
+	}
+}
+
+// wrap turns a stream into a connection, releasing the peer once it's closed.
+func (t *Transport) wrap(s network.Stream) *conn {
+	remote := s.Conn().RemotePeer()
+	return &conn{
+		Stream: s,
+		local:  Addr{Peer: t.host.ID()},
+		remote: Addr{Peer: remote},
+		closed: func() { t.deactivate(remote) },
+	}
+}
+
+// activate marks a peer as connected, returning false if it already was.
+func (t *Transport) activate(id peer.ID) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.active[id]; ok {
+		return false
+	}
+	t.active[id] = struct{}{}
+	return true
+}
+
+// deactivate marks a peer as disconnected.
+func (t *Transport) deactivate(id peer.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.active, id)
+}
+
+// isActive reports whether a peer is connected.
+func (t *Transport) isActive(id peer.ID) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	_, ok := t.active[id]
+	return ok
+}
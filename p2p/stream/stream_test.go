@@ -0,0 +1,127 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+)
+
+// testNode is an in-process node with a libp2p host and a p2p server running a
+// protocol exchanging a single ping.
+type testNode struct {
+	host      host.Host
+	server    *p2p.Server
+	transport *Transport
+	pinged    chan enode.ID // Ids of the peers that pinged us
+}
+
+func newTestNode(t *testing.T, peers func() []peer.ID) *testNode {
+	h, err := libp2p.New(context.Background(), libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"))
+	if err != nil {
+		t.Fatalf("failed to create libp2p host: %v", err)
+	}
+	key, _ := crypto.GenerateKey()
+	node := &testNode{host: h, pinged: make(chan enode.ID, 1)}
+	node.server = &p2p.Server{Config: p2p.Config{
+		Name:        "test",
+		MaxPeers:    10,
+		PrivateKey:  key,
+		NoDiscovery: true,
+		Protocols: []p2p.Protocol{{
+			Name:    "ping",
+			Version: 1,
+			Length:  1,
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				go p2p.Send(rw, 0, []uint{1})
+				if _, err := rw.ReadMsg(); err != nil {
+					return err
+				}
+				node.pinged <- p.ID()
+				_, err := rw.ReadMsg()
+				return err
+			},
+		}},
+	}}
+	if err := node.server.Start(); err != nil {
+		t.Fatalf("failed to start p2p server: %v", err)
+	}
+	node.transport = New(h, node.server, peers)
+	return node
+}
+
+func (n *testNode) close() {
+	n.transport.Stop()
+	n.server.Stop()
+	n.host.Close()
+}
+
+// expectPing waits for a peer with the given id to complete the protocol.
+func (n *testNode) expectPing(t *testing.T, want enode.ID) {
+	select {
+	case id := <-n.pinged:
+		if id != want {
+			t.Fatalf("peer id mismatch: have %v, want %v", id, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("peer %v never pinged", want)
+	}
+}
+
+// Tests that two hosts can run a devp2p protocol over a libp2p stream, and that
+// duplicate connections are refused.
+func TestDial(t *testing.T) {
+	local, remote := newTestNode(t, nil), newTestNode(t, nil)
+	defer local.close()
+	defer remote.close()
+	local.transport.Start()
+	remote.transport.Start()
+
+	local.host.Peerstore().AddAddrs(remote.host.ID(), remote.host.Addrs(), peerstore.PermanentAddrTTL)
+	if err := local.transport.Dial(context.Background(), remote.host.ID()); err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	local.expectPing(t, remote.server.Self().ID())
+	remote.expectPing(t, local.server.Self().ID())
+
+	if err := local.transport.Dial(context.Background(), remote.host.ID()); err != errAlreadyActive {
+		t.Fatalf("duplicate dial error mismatch: have %v, want %v", err, errAlreadyActive)
+	}
+}
+
+// Tests that the configured peers are dialed automatically.
+func TestConfiguredPeers(t *testing.T) {
+	remote := newTestNode(t, nil)
+	defer remote.close()
+	remote.transport.Start()
+
+	local := newTestNode(t, func() []peer.ID { return []peer.ID{remote.host.ID()} })
+	defer local.close()
+	local.host.Peerstore().AddAddrs(remote.host.ID(), remote.host.Addrs(), peerstore.PermanentAddrTTL)
+	local.transport.Start()
+
+	local.expectPing(t, remote.server.Self().ID())
+}
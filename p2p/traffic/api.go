@@ -0,0 +1,88 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package traffic
+
+import (
+	"fmt"
+	"time"
+)
+
+// protocols is the list of protocols reported by the API.
+var protocols = []string{Tau, Discovery, IPFS}
+
+// PrivateTrafficAPI exposes the traffic accounting and the data caps over RPC.
+type PrivateTrafficAPI struct {
+	accountant *Accountant
+}
+
+// NewPrivateTrafficAPI creates a new API definition for the traffic accounting
+// of the node.
+func NewPrivateTrafficAPI(accountant *Accountant) *PrivateTrafficAPI {
+	return &PrivateTrafficAPI{accountant: accountant}
+}
+
+// Traffic returns the traffic since startup per protocol, message kind and peer.
+func (api *PrivateTrafficAPI) Traffic() *Report {
+	return api.accountant.Report()
+}
+
+// TrafficHistory returns the persisted traffic of a day (formatted 2006-01-02)
+// or a month (formatted 2006-01).
+func (api *PrivateTrafficAPI) TrafficHistory(period string) (map[string]*Counter, error) {
+	if at, err := time.ParseInLocation(dayLayout, period, time.Local); err == nil {
+		return api.accountant.History(protocols, at, false)
+	}
+	if at, err := time.ParseInLocation(monthLayout, period, time.Local); err == nil {
+		return api.accountant.History(protocols, at, true)
+	}
+	return nil, fmt.Errorf("invalid period %q, want %s or %s", period, dayLayout, monthLayout)
+}
+
+// SetDataCap limits the bytes exchanged over a protocol in a "daily" or
+// "monthly" period. A zero limit removes the cap.
+func (api *PrivateTrafficAPI) SetDataCap(protocol string, period string, limit uint64) (bool, error) {
+	if !known(protocol) {
+		return false, fmt.Errorf("unknown protocol %q", protocol)
+	}
+	var monthly bool
+	switch period {
+	case "daily":
+	case "monthly":
+		monthly = true
+	default:
+		return false, fmt.Errorf("invalid period %q, want daily or monthly", period)
+	}
+	if err := api.accountant.SetDataCap(protocol, monthly, limit); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DataCaps returns the data caps of all protocols and whether they are reached.
+func (api *PrivateTrafficAPI) DataCaps() (map[string]*DataCap, error) {
+	return api.accountant.DataCaps(protocols)
+}
+
+// known reports whether a protocol is accounted by the node.
+func known(protocol string) bool {
+	for _, p := range protocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
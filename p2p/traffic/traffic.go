@@ -0,0 +1,497 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package traffic accounts the bytes exchanged by the node over the network.
+//
+// Traffic is split by protocol, by message kind and by peer. Totals are rolled
+// up into daily and monthly counters in a persistent store, against which data
+// caps are enforced: protocols exceeding their cap are reported to the
+// registered hooks, which pause the corresponding activity until the period
+// rolls over or the cap is raised. The caps are kept in memory, the store is
+// only read when the accountant starts.
+package traffic
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// Protocols accounted by the node.
+const (
+	Tau       = "tau"       // Chain synchronisation and propagation
+	Discovery = "discovery" // Node discovery over UDP
+	IPFS      = "ipfs"      // Block and file exchange over IPFS
+)
+
+const (
+	flushInterval   = 30 * time.Second // Interval of persisting the accumulated traffic
+	maxPeerCounters = 1024             // Number of peers whose traffic is individually tracked
+
+	dayLayout   = "2006-01-02" // Format of the daily periods
+	monthLayout = "2006-01"    // Format of the monthly periods
+)
+
+var errNotStarted = errors.New("traffic accountant not started")
+
+// Store persists the traffic totals and the data caps.
+type Store interface {
+	AddTraffic(protocol string, at time.Time, ingress, egress uint64) error
+	DailyTraffic(protocol string, day time.Time) (ingress, egress uint64)
+	MonthlyTraffic(protocol string, month time.Time) (ingress, egress uint64)
+	SetDataCap(protocol string, monthly bool, limit uint64) error
+	DataCap(protocol string, monthly bool) uint64
+}
+
+// Counter is a pair of traffic counters.
+type Counter struct {
+	Ingress uint64 `json:"ingress"`
+	Egress  uint64 `json:"egress"`
+}
+
+// Total returns the sum of the ingress and egress traffic.
+func (c Counter) Total() uint64 {
+	return c.Ingress + c.Egress
+}
+
+// usage is the traffic of a protocol in the current day and month, including
+// the traffic not yet persisted.
+type usage struct {
+	daily   uint64
+	monthly uint64
+}
+
+// limits are the daily and monthly data caps of a protocol, zero meaning
+// unlimited.
+type limits struct {
+	daily   uint64
+	monthly uint64
+}
+
+// capEvent is a cap change to notify to a set of hooks.
+type capEvent struct {
+	hooks  []func(bool)
+	capped bool
+}
+
+// Accountant records the traffic of the node and enforces the data caps.
+type Accountant struct {
+	protocols map[string]*Counter            // Traffic per protocol since startup
+	kinds     map[string]map[string]*Counter // Traffic per protocol and message kind since startup
+	peers     *lru.Cache                     // Traffic per recently active peer since startup
+	pending   map[string]*Counter            // Traffic per protocol not yet persisted
+
+	store  Store                   // Persistent totals and caps, nil if not started
+	usage  map[string]*usage       // Current period usage per protocol
+	limits map[string]*limits      // Data caps per protocol, loaded from the store once
+	day    time.Time               // Day the usage was loaded for
+	capped map[string]bool         // Protocols over their cap
+	hooks  map[string][]func(bool) // Callbacks notified of cap changes per protocol
+	events []capEvent              // Cap changes not yet notified, in order
+	lock   sync.Mutex              // Protects all the fields above
+
+	wake chan struct{}      // Signals the flush loop of queued cap changes
+	quit chan chan struct{} // Termination channel of the flush loop
+	now  func() time.Time   // Wall clock, overridden by tests
+}
+
+// NewAccountant creates an accountant recording traffic in memory only, until
+// started with a persistent store.
+func NewAccountant() *Accountant {
+	peers, _ := lru.New(maxPeerCounters)
+	return &Accountant{
+		protocols: make(map[string]*Counter),
+		kinds:     make(map[string]map[string]*Counter),
+		peers:     peers,
+		pending:   make(map[string]*Counter),
+		usage:     make(map[string]*usage),
+		limits:    make(map[string]*limits),
+		capped:    make(map[string]bool),
+		hooks:     make(map[string][]func(bool)),
+		wake:      make(chan struct{}, 1),
+		now:       time.Now,
+	}
+}
+
+// Start loads the current period totals from the store and starts persisting
+// the recorded traffic into it periodically.
+func (a *Accountant) Start(store Store) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.quit != nil {
+		return
+	}
+	a.store = store
+	a.reload(a.now())
+	a.quit = make(chan chan struct{})
+	go a.loop(a.quit)
+}
+
+// Stop persists the pending traffic and detaches the store and the cap hooks.
+func (a *Accountant) Stop() {
+	a.lock.Lock()
+	quit := a.quit
+	a.quit = nil
+	a.lock.Unlock()
+
+	if quit == nil {
+		return
+	}
+	done := make(chan struct{})
+	quit <- done
+	<-done
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.store = nil
+	a.limits = make(map[string]*limits)
+	a.hooks = make(map[string][]func(bool))
+	a.events = nil
+}
+
+// OnCap registers a callback notified whenever a protocol exceeds its data cap
+// or falls back below it, and right away if the protocol is already capped.
+// Callbacks are invoked one after the other, in the order of the cap changes,
+// from the loop of the accountant: they must not block.
+func (a *Accountant) OnCap(protocol string, hook func(capped bool)) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.hooks[protocol] = append(a.hooks[protocol], hook)
+	if a.capped[protocol] {
+		a.notify([]func(bool){hook}, true)
+	}
+}
+
+// Capped reports whether a protocol exceeded its data cap.
+func (a *Accountant) Capped(protocol string) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.capped[protocol]
+}
+
+// Record accounts traffic exchanged over a protocol. The message kind and the
+// peer are optional, traffic without them is only accounted to the protocol.
+func (a *Accountant) Record(protocol, kind, peer string, ingress, egress uint64) {
+	if ingress == 0 && egress == 0 {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	add(a.protocols, protocol, ingress, egress)
+	add(a.pending, protocol, ingress, egress)
+	if kind != "" {
+		if a.kinds[protocol] == nil {
+			a.kinds[protocol] = make(map[string]*Counter)
+		}
+		add(a.kinds[protocol], kind, ingress, egress)
+	}
+	if peer != "" {
+		counter, ok := a.peers.Get(peer)
+		if !ok {
+			counter = new(Counter)
+			a.peers.Add(peer, counter)
+		}
+		counter.(*Counter).Ingress += ingress
+		counter.(*Counter).Egress += egress
+	}
+	if a.store != nil {
+		u := a.usageOf(protocol)
+		u.daily += ingress + egress
+		u.monthly += ingress + egress
+		a.enforce(protocol)
+	}
+}
+
+// SetDataCap sets the daily or monthly limit of bytes exchanged over a protocol
+// and enforces it right away. A zero limit removes the cap.
+func (a *Accountant) SetDataCap(protocol string, monthly bool, limit uint64) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.store == nil {
+		return errNotStarted
+	}
+	if err := a.store.SetDataCap(protocol, monthly, limit); err != nil {
+		return err
+	}
+	if l := a.limitsOf(protocol); monthly {
+		l.monthly = limit
+	} else {
+		l.daily = limit
+	}
+	a.usageOf(protocol)
+	a.enforce(protocol)
+	return nil
+}
+
+// History returns the persisted traffic of the given protocols in the day or
+// month containing the given time.
+func (a *Accountant) History(protocols []string, at time.Time, monthly bool) (map[string]*Counter, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.store == nil {
+		return nil, errNotStarted
+	}
+	history := make(map[string]*Counter, len(protocols))
+	for _, protocol := range protocols {
+		counter := new(Counter)
+		if monthly {
+			counter.Ingress, counter.Egress = a.store.MonthlyTraffic(protocol, at)
+		} else {
+			counter.Ingress, counter.Egress = a.store.DailyTraffic(protocol, at)
+		}
+		history[protocol] = counter
+	}
+	return history, nil
+}
+
+// DataCap is the daily and monthly limit of bytes exchanged over a protocol,
+// zero meaning unlimited.
+type DataCap struct {
+	Daily   uint64 `json:"daily"`
+	Monthly uint64 `json:"monthly"`
+	Capped  bool   `json:"capped"`
+}
+
+// DataCaps returns the caps of the given protocols.
+func (a *Accountant) DataCaps(protocols []string) (map[string]*DataCap, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.store == nil {
+		return nil, errNotStarted
+	}
+	caps := make(map[string]*DataCap, len(protocols))
+	for _, protocol := range protocols {
+		l := a.limitsOf(protocol)
+		caps[protocol] = &DataCap{
+			Daily:   l.daily,
+			Monthly: l.monthly,
+			Capped:  a.capped[protocol],
+		}
+	}
+	return caps, nil
+}
+
+// Report is a breakdown of the traffic since startup.
+type Report struct {
+	Protocols map[string]*Counter            `json:"protocols"`
+	Kinds     map[string]map[string]*Counter `json:"kinds"`
+	Peers     map[string]*Counter            `json:"peers"`
+	Capped    []string                       `json:"capped"`
+}
+
+// Report returns a copy of the traffic recorded since startup.
+func (a *Accountant) Report() *Report {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	report := &Report{
+		Protocols: copyCounters(a.protocols),
+		Kinds:     make(map[string]map[string]*Counter),
+		Peers:     make(map[string]*Counter),
+		Capped:    []string{},
+	}
+	for protocol, kinds := range a.kinds {
+		report.Kinds[protocol] = copyCounters(kinds)
+	}
+	for _, key := range a.peers.Keys() {
+		if counter, ok := a.peers.Peek(key); ok {
+			c := *counter.(*Counter)
+			report.Peers[key.(string)] = &c
+		}
+	}
+	for protocol, capped := range a.capped {
+		if capped {
+			report.Capped = append(report.Capped, protocol)
+		}
+	}
+	return report
+}
+
+// loop persists the pending traffic periodically and notifies the cap changes
+// until stopped.
+func (a *Accountant) loop(quit chan chan struct{}) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.flush()
+			a.dispatch()
+		case <-a.wake:
+			a.dispatch()
+		case done := <-quit:
+			a.flush()
+			a.dispatch()
+			close(done)
+			return
+		}
+	}
+}
+
+// notify queues a cap change for the given hooks and wakes the loop up to
+// deliver it. The caller must hold the lock.
+func (a *Accountant) notify(hooks []func(bool), capped bool) {
+	if len(hooks) == 0 {
+		return
+	}
+	a.events = append(a.events, capEvent{hooks: append([]func(bool){}, hooks...), capped: capped})
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch delivers the queued cap changes in order, outside of the lock so the
+// hooks may query the accountant.
+func (a *Accountant) dispatch() {
+	a.lock.Lock()
+	events := a.events
+	a.events = nil
+	a.lock.Unlock()
+
+	for _, event := range events {
+		for _, hook := range event.hooks {
+			hook(event.capped)
+		}
+	}
+}
+
+// flush persists the pending traffic, rolling the usage over into a new day or
+// month if needed. Pending traffic is attributed to the day it was accounted in
+// the usage of, which is off by at most a flush interval around midnight.
+func (a *Accountant) flush() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := a.now()
+	for protocol, counter := range a.pending {
+		if err := a.store.AddTraffic(protocol, a.day, counter.Ingress, counter.Egress); err != nil {
+			log.Warn("Failed to persist traffic", "protocol", protocol, "err", err)
+			return
+		}
+		delete(a.pending, protocol)
+	}
+	if a.day.Format(dayLayout) != now.Format(dayLayout) {
+		a.reload(now)
+	}
+}
+
+// reload loads the usage of the current day and month from the store and
+// re-evaluates the caps. The caller must hold the lock.
+func (a *Accountant) reload(now time.Time) {
+	a.day = now
+	for protocol := range a.usage {
+		delete(a.usage, protocol)
+	}
+	for _, protocol := range []string{Tau, Discovery, IPFS} {
+		a.usageOf(protocol)
+	}
+	for protocol := range a.capped {
+		a.usageOf(protocol)
+	}
+	for protocol := range a.usage {
+		a.enforce(protocol)
+	}
+}
+
+// usageOf returns the usage of a protocol in the current period, loading it
+// from the store if needed. The caller must hold the lock.
+func (a *Accountant) usageOf(protocol string) *usage {
+	if u := a.usage[protocol]; u != nil {
+		return u
+	}
+	u := new(usage)
+	if a.store != nil {
+		dayIn, dayOut := a.store.DailyTraffic(protocol, a.day)
+		monthIn, monthOut := a.store.MonthlyTraffic(protocol, a.day)
+		u.daily, u.monthly = dayIn+dayOut, monthIn+monthOut
+	}
+	// Account the traffic recorded since the last flush too
+	if pending := a.pending[protocol]; pending != nil {
+		u.daily += pending.Total()
+		u.monthly += pending.Total()
+	}
+	a.usage[protocol] = u
+	return u
+}
+
+// limitsOf returns the data caps of a protocol, loading them from the store
+// if needed. The caller must hold the lock.
+func (a *Accountant) limitsOf(protocol string) *limits {
+	if l := a.limits[protocol]; l != nil {
+		return l
+	}
+	l := &limits{
+		daily:   a.store.DataCap(protocol, false),
+		monthly: a.store.DataCap(protocol, true),
+	}
+	a.limits[protocol] = l
+	return l
+}
+
+// enforce checks the usage of a protocol against its caps, queueing a
+// notification of the hooks if the protocol became capped or uncapped. The
+// caller must hold the lock.
+func (a *Accountant) enforce(protocol string) {
+	var (
+		u      = a.usage[protocol]
+		l      = a.limitsOf(protocol)
+		capped = (l.daily > 0 && u.daily >= l.daily) || (l.monthly > 0 && u.monthly >= l.monthly)
+	)
+	if capped == a.capped[protocol] {
+		return
+	}
+	if capped {
+		log.Warn("Data cap reached, pausing traffic", "protocol", protocol, "daily", u.daily, "monthly", u.monthly)
+	} else {
+		log.Info("Data cap lifted, resuming traffic", "protocol", protocol)
+	}
+	a.capped[protocol] = capped
+	a.notify(a.hooks[protocol], capped)
+}
+
+// add increments the counter of a key in the given set.
+func add(counters map[string]*Counter, key string, ingress, egress uint64) {
+	counter := counters[key]
+	if counter == nil {
+		counter = new(Counter)
+		counters[key] = counter
+	}
+	counter.Ingress += ingress
+	counter.Egress += egress
+}
+
+// copyCounters deep copies a counter set.
+func copyCounters(counters map[string]*Counter) map[string]*Counter {
+	copied := make(map[string]*Counter, len(counters))
+	for key, counter := range counters {
+		c := *counter
+		copied[key] = &c
+	}
+	return copied
+}
@@ -0,0 +1,226 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package traffic
+
+import (
+	"testing"
+	"time"
+)
+
+// testStore is an in memory traffic store.
+type testStore struct {
+	traffic map[string]*Counter
+	caps    map[string]uint64
+}
+
+func newTestStore() *testStore {
+	return &testStore{
+		traffic: make(map[string]*Counter),
+		caps:    make(map[string]uint64),
+	}
+}
+
+func (s *testStore) AddTraffic(protocol string, at time.Time, ingress, egress uint64) error {
+	add(s.traffic, at.Format(dayLayout)+protocol, ingress, egress)
+	add(s.traffic, at.Format(monthLayout)+protocol, ingress, egress)
+	return nil
+}
+
+func (s *testStore) DailyTraffic(protocol string, day time.Time) (uint64, uint64) {
+	if c := s.traffic[day.Format(dayLayout)+protocol]; c != nil {
+		return c.Ingress, c.Egress
+	}
+	return 0, 0
+}
+
+func (s *testStore) MonthlyTraffic(protocol string, month time.Time) (uint64, uint64) {
+	if c := s.traffic[month.Format(monthLayout)+protocol]; c != nil {
+		return c.Ingress, c.Egress
+	}
+	return 0, 0
+}
+
+func (s *testStore) SetDataCap(protocol string, monthly bool, limit uint64) error {
+	s.caps[capKey(protocol, monthly)] = limit
+	return nil
+}
+
+func (s *testStore) DataCap(protocol string, monthly bool) uint64 {
+	return s.caps[capKey(protocol, monthly)]
+}
+
+func capKey(protocol string, monthly bool) string {
+	if monthly {
+		return "monthly:" + protocol
+	}
+	return "daily:" + protocol
+}
+
+// newTestAccountant creates an accountant with a manually advanced clock.
+func newTestAccountant() (*Accountant, *time.Time) {
+	now := time.Date(2020, 3, 31, 23, 0, 0, 0, time.Local)
+	accountant := NewAccountant()
+	accountant.now = func() time.Time { return now }
+	return accountant, &now
+}
+
+func TestRecord(t *testing.T) {
+	accountant, _ := newTestAccountant()
+
+	accountant.Record(Tau, "headers", "peer", 100, 10)
+	accountant.Record(Tau, "bodies", "peer", 50, 0)
+	accountant.Record(Discovery, "", "", 0, 20)
+
+	report := accountant.Report()
+	if c := report.Protocols[Tau]; c == nil || c.Ingress != 150 || c.Egress != 10 {
+		t.Fatalf("tau traffic mismatch: have %+v, want {150 10}", c)
+	}
+	if c := report.Kinds[Tau]["headers"]; c == nil || c.Ingress != 100 || c.Egress != 10 {
+		t.Fatalf("header traffic mismatch: have %+v, want {100 10}", c)
+	}
+	if c := report.Peers["peer"]; c == nil || c.Total() != 160 {
+		t.Fatalf("peer traffic mismatch: have %+v, want 160 bytes", c)
+	}
+	if len(report.Kinds[Discovery]) != 0 || len(report.Peers) != 1 {
+		t.Fatalf("traffic without kind or peer split: %+v %+v", report.Kinds, report.Peers)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	var (
+		store             = newTestStore()
+		accountant, now   = newTestAccountant()
+		firstDay, lastDay = *now, now.Add(2 * time.Hour)
+	)
+	accountant.Start(store)
+	accountant.Record(IPFS, "block", "", 300, 0)
+	accountant.flush()
+
+	// Traffic recorded after the midnight rollover belongs to the next day and month
+	*now = lastDay
+	accountant.flush()
+	accountant.Record(IPFS, "block", "", 200, 0)
+	accountant.Stop()
+
+	if in, _ := store.DailyTraffic(IPFS, firstDay); in != 300 {
+		t.Fatalf("first day traffic mismatch: have %d, want 300", in)
+	}
+	if in, _ := store.DailyTraffic(IPFS, lastDay); in != 200 {
+		t.Fatalf("last day traffic mismatch: have %d, want 200", in)
+	}
+	if in, _ := store.MonthlyTraffic(IPFS, firstDay); in != 300 {
+		t.Fatalf("monthly traffic mismatch: have %d, want 300", in)
+	}
+	if _, err := accountant.History([]string{IPFS}, firstDay, true); err != errNotStarted {
+		t.Fatalf("history of stopped accountant: have %v, want %v", err, errNotStarted)
+	}
+}
+
+func TestDataCap(t *testing.T) {
+	var (
+		store           = newTestStore()
+		accountant, now = newTestAccountant()
+		hooks           = make(chan bool, 4)
+	)
+	accountant.Start(store)
+	defer accountant.Stop()
+	accountant.OnCap(Tau, func(capped bool) { hooks <- capped })
+
+	expect := func(want bool) {
+		t.Helper()
+		select {
+		case capped := <-hooks:
+			if capped != want || accountant.Capped(Tau) != want {
+				t.Fatalf("cap state mismatch: have %v, want %v", capped, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("cap hook not notified, want %v", want)
+		}
+	}
+	if err := accountant.SetDataCap(Tau, false, 1000); err != nil {
+		t.Fatalf("failed to set data cap: %v", err)
+	}
+	accountant.Record(Tau, "headers", "peer", 600, 0)
+	accountant.Record(Tau, "bodies", "peer", 400, 0)
+	expect(true)
+
+	// The daily cap is lifted as the day rolls over
+	*now = now.Add(2 * time.Hour)
+	accountant.flush()
+	expect(false)
+
+	// A monthly cap covering the past traffic caps right away, and lifts if removed
+	accountant.Record(Tau, "headers", "peer", 100, 0)
+	accountant.SetDataCap(Tau, true, 100)
+	expect(true)
+	accountant.SetDataCap(Tau, true, 0)
+	expect(false)
+}
+
+// countingStore is a test store counting the data cap reads.
+type countingStore struct {
+	*testStore
+	reads int
+}
+
+func (s *countingStore) DataCap(protocol string, monthly bool) uint64 {
+	s.reads++
+	return s.testStore.DataCap(protocol, monthly)
+}
+
+// Tests that the caps are read from the store once, not on every record, and
+// that the cap changes are notified in the order they happened.
+func TestDataCapOrdering(t *testing.T) {
+	var (
+		store         = &countingStore{testStore: newTestStore()}
+		accountant, _ = newTestAccountant()
+		hooks         = make(chan bool, 16)
+	)
+	store.SetDataCap(Tau, false, 100)
+	accountant.Start(store)
+	defer accountant.Stop()
+	accountant.OnCap(Tau, func(capped bool) { hooks <- capped })
+
+	reads := store.reads
+	for i := 0; i < 10; i++ {
+		accountant.Record(Tau, "headers", "peer", 1, 0)
+	}
+	if store.reads != reads {
+		t.Fatalf("data caps read on record: have %d reads, want %d", store.reads, reads)
+	}
+	// Toggle the cap back and forth, the hook must observe every change in order
+	want := []bool{true, false, true, false, true}
+	for i, capped := range want {
+		limit := uint64(1000)
+		if capped {
+			limit = 5
+		}
+		if err := accountant.SetDataCap(Tau, false, limit); err != nil {
+			t.Fatalf("change %d: failed to set data cap: %v", i, err)
+		}
+	}
+	for i, capped := range want {
+		select {
+		case have := <-hooks:
+			if have != capped {
+				t.Fatalf("change %d: cap state mismatch: have %v, want %v", i, have, capped)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("change %d: cap hook not notified", i)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package params
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
@@ -174,16 +175,16 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllTauashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), big.NewInt(0), big.NewInt(0), new(TauashConfig)}
+	AllTauashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), new(TauashConfig)}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Tau core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(0), new(TauashConfig)}
+	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), new(TauashConfig)}
 	TestRules       = TestChainConfig.Rules(new(big.Int))
 )
 
@@ -242,18 +243,121 @@ type ChainConfig struct {
 	EIP155Block *big.Int `json:"eip155Block,omitempty"` // EIP155 HF block
 	EIP158Block *big.Int `json:"eip158Block,omitempty"` // EIP158 HF block
 
+	SupplyBlock  *big.Int `json:"supplyBlock,omitempty"`  // Supply tracking switch block (nil = no fork, 0 = already tracking)
+	ProfileBlock *big.Int `json:"profileBlock,omitempty"` // Profile recording switch block (nil = no fork, 0 = already recording)
+
 	// Various consensus engines
 	Tauash *TauashConfig `json:"tauhash,omitempty"`
 }
 
 // TauashConfig is the consensus engine configs for proof-of-work based sealing.
-type TauashConfig struct{}
+//
+// Besides selecting the engine, it declares the token economics of the chain:
+// how many coins every block creates, how that emission shrinks over time and
+// what happens to the fees paid by transactions. The zero value keeps the
+// original behaviour of a fixed block reward and fees paid fully to the miner.
+type TauashConfig struct {
+	InitialReward   *big.Int `json:"initialReward,omitempty"`   // Reward of the first blocks (nil = engine default)
+	HalvingInterval uint64   `json:"halvingInterval,omitempty"` // Blocks after which the reward is halved (0 = never)
+	DecayInterval   uint64   `json:"decayInterval,omitempty"`   // Blocks after which the reward decays (0 = never)
+	DecayPercent    uint64   `json:"decayPercent,omitempty"`    // Percentage the reward shrinks by at every decay step
+	MaxSupply       *big.Int `json:"maxSupply,omitempty"`       // Upper bound of coins issued past the supply fork (nil = unbounded)
+
+	FeeBurnPercent  uint64          `json:"feeBurnPercent,omitempty"`  // Percentage of every fee that is destroyed
+	RelayFeePercent uint64          `json:"relayFeePercent,omitempty"` // Percentage of every fee paid to the relays
+	RelayFund       *common.Address `json:"relayFund,omitempty"`       // Account collecting the relay share of the fees
+}
 
 // String implements the stringer interface, returning the consensus engine details.
 func (c *TauashConfig) String() string {
 	return "tauhash"
 }
 
+// BlockReward returns the emission of the block with the given number according
+// to the configured schedule, ignoring the supply cap. If the chain does not
+// declare an initial reward, nil is returned and the engine default applies.
+func (c *TauashConfig) BlockReward(number uint64) *big.Int {
+	if c == nil || c.InitialReward == nil {
+		return nil
+	}
+	reward := new(big.Int).Set(c.InitialReward)
+	switch {
+	case c.HalvingInterval > 0:
+		halvings := number / c.HalvingInterval
+		if halvings >= uint64(reward.BitLen()) {
+			return new(big.Int)
+		}
+		reward.Rsh(reward, uint(halvings))
+
+	case c.DecayInterval > 0 && c.DecayPercent > 0:
+		steps := number / c.DecayInterval
+		if c.DecayPercent >= 100 || steps >= decayLimit(reward, c.DecayPercent) {
+			return new(big.Int)
+		}
+		keep := new(big.Int).Exp(big.NewInt(int64(100-c.DecayPercent)), new(big.Int).SetUint64(steps), nil)
+		reward.Mul(reward, keep)
+		reward.Div(reward, new(big.Int).Exp(big.NewInt(100), new(big.Int).SetUint64(steps), nil))
+	}
+	return reward
+}
+
+// decayLimit returns a number of decay steps after which the given reward is
+// certainly worn down to zero, bounding the size of the powers computed by
+// BlockReward.
+func decayLimit(reward *big.Int, percent uint64) uint64 {
+	// reward * (1-p)^n < 1 once n > log(reward) / -log(1-p), add a step of slack
+	// for the float rounding
+	return uint64(float64(reward.BitLen())*math.Ln2/-math.Log1p(-float64(percent)/100)) + 2
+}
+
+// SplitFee divides a transaction fee into the amount destroyed, the amount
+// paid to the relay fund and the amount left for the miner.
+func (c *TauashConfig) SplitFee(fee *big.Int) (burn, relay, miner *big.Int) {
+	burn, relay, miner = new(big.Int), new(big.Int), new(big.Int).Set(fee)
+	if c == nil {
+		return burn, relay, miner
+	}
+	hundred := big.NewInt(100)
+	if c.FeeBurnPercent > 0 {
+		burn.Mul(fee, new(big.Int).SetUint64(c.FeeBurnPercent))
+		burn.Div(burn, hundred)
+	}
+	if c.RelayFund != nil && c.RelayFeePercent > 0 {
+		relay.Mul(fee, new(big.Int).SetUint64(c.RelayFeePercent))
+		relay.Div(relay, hundred)
+	}
+	miner.Sub(miner, burn)
+	miner.Sub(miner, relay)
+	return burn, relay, miner
+}
+
+// CheckConfig verifies that the declared emission schedule and fee distribution
+// are self consistent.
+func (c *TauashConfig) CheckConfig() error {
+	if c == nil {
+		return nil
+	}
+	if c.InitialReward != nil && c.InitialReward.Sign() < 0 {
+		return fmt.Errorf("negative initial block reward %v", c.InitialReward)
+	}
+	if c.MaxSupply != nil && c.MaxSupply.Sign() < 0 {
+		return fmt.Errorf("negative maximum supply %v", c.MaxSupply)
+	}
+	if c.HalvingInterval > 0 && c.DecayInterval > 0 {
+		return fmt.Errorf("both halving (%d) and decay (%d) intervals configured", c.HalvingInterval, c.DecayInterval)
+	}
+	if c.DecayPercent > 100 {
+		return fmt.Errorf("decay percentage %d above 100", c.DecayPercent)
+	}
+	if c.FeeBurnPercent+c.RelayFeePercent > 100 {
+		return fmt.Errorf("fee burn (%d%%) and relay share (%d%%) exceed the whole fee", c.FeeBurnPercent, c.RelayFeePercent)
+	}
+	if c.RelayFeePercent > 0 && c.RelayFund == nil {
+		return fmt.Errorf("relay fee share of %d%% configured without a relay fund", c.RelayFeePercent)
+	}
+	return nil
+}
+
 // String implements the fmt.Stringer interface.
 func (c *ChainConfig) String() string {
 	var engine interface{}
@@ -263,11 +367,13 @@ func (c *ChainConfig) String() string {
 	default:
 		engine = "unknown"
 	}
-	return fmt.Sprintf("{ChainID: %v Homestead: %v EIP155: %v EIP158: %v Engine: %v}",
+	return fmt.Sprintf("{ChainID: %v Homestead: %v EIP155: %v EIP158: %v Supply: %v Profile: %v Engine: %v}",
 		c.ChainID,
 		c.HomesteadBlock,
 		c.EIP155Block,
 		c.EIP158Block,
+		c.SupplyBlock,
+		c.ProfileBlock,
 		engine,
 	)
 }
@@ -287,6 +393,20 @@ func (c *ChainConfig) IsEIP158(num *big.Int) bool {
 	return isForked(c.EIP158Block, num)
 }
 
+// IsSupply returns whether num is either equal to the supply tracking fork block
+// or greater. Only blocks past the fork record the issued and burned coins in
+// the state.
+func (c *ChainConfig) IsSupply(num *big.Int) bool {
+	return isForked(c.SupplyBlock, num)
+}
+
+// IsProfile returns whether num is either equal to the profile fork block or
+// greater. Only blocks past the fork record the profiles of PersonalInfoTxs in
+// the state and index their history.
+func (c *ChainConfig) IsProfile(num *big.Int) bool {
+	return isForked(c.ProfileBlock, num)
+}
+
 // CheckCompatible checks whtauer scheduled fork transitions have been imported
 // with a mismatching chain configuration.
 func (c *ChainConfig) CheckCompatible(newcfg *ChainConfig, height uint64) *ConfigCompatError {
@@ -318,6 +438,12 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if c.IsEIP158(head) && !configNumEqual(c.ChainID, newcfg.ChainID) {
 		return newCompatError("EIP158 chain ID", c.EIP158Block, newcfg.EIP158Block)
 	}
+	if isForkIncompatible(c.SupplyBlock, newcfg.SupplyBlock, head) {
+		return newCompatError("Supply fork block", c.SupplyBlock, newcfg.SupplyBlock)
+	}
+	if isForkIncompatible(c.ProfileBlock, newcfg.ProfileBlock, head) {
+		return newCompatError("Profile fork block", c.ProfileBlock, newcfg.ProfileBlock)
+	}
 	return nil
 }
 
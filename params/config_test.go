@@ -20,6 +20,8 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
 )
 
 func TestCheckCompatible(t *testing.T) {
@@ -79,3 +81,46 @@ func TestCheckCompatible(t *testing.T) {
 		}
 	}
 }
+
+func TestTauashBlockReward(t *testing.T) {
+	tests := []struct {
+		config *TauashConfig
+		number uint64
+		want   *big.Int
+	}{
+		{config: nil, number: 10, want: nil},
+		{config: &TauashConfig{}, number: 10, want: nil},
+		{config: &TauashConfig{InitialReward: big.NewInt(1000)}, number: 1000000, want: big.NewInt(1000)},
+		{config: &TauashConfig{InitialReward: big.NewInt(1000), HalvingInterval: 100}, number: 99, want: big.NewInt(1000)},
+		{config: &TauashConfig{InitialReward: big.NewInt(1000), HalvingInterval: 100}, number: 250, want: big.NewInt(250)},
+		{config: &TauashConfig{InitialReward: big.NewInt(1000), HalvingInterval: 1}, number: 5000, want: big.NewInt(0)},
+		{config: &TauashConfig{InitialReward: big.NewInt(1000), DecayInterval: 10, DecayPercent: 10}, number: 25, want: big.NewInt(810)},
+		{config: &TauashConfig{InitialReward: big.NewInt(1e18), DecayInterval: 1, DecayPercent: 1}, number: 100, want: big.NewInt(366032341273229504)},
+		{config: &TauashConfig{InitialReward: big.NewInt(1e18), DecayInterval: 1, DecayPercent: 1}, number: 1 << 62, want: big.NewInt(0)},
+		{config: &TauashConfig{InitialReward: big.NewInt(1000), DecayInterval: 10, DecayPercent: 100}, number: 10, want: big.NewInt(0)},
+	}
+	for i, test := range tests {
+		have := test.config.BlockReward(test.number)
+		if (have == nil) != (test.want == nil) || (have != nil && have.Cmp(test.want) != 0) {
+			t.Errorf("test %d: reward mismatch: have %v, want %v", i, have, test.want)
+		}
+	}
+}
+
+func TestTauashSplitFee(t *testing.T) {
+	fund := common.HexToAddress("0x01")
+	config := &TauashConfig{FeeBurnPercent: 30, RelayFeePercent: 20, RelayFund: &fund}
+	if err := config.CheckConfig(); err != nil {
+		t.Fatalf("valid config rejected: %v", err)
+	}
+	burn, relay, miner := config.SplitFee(big.NewInt(1000))
+	if burn.Int64() != 300 || relay.Int64() != 200 || miner.Int64() != 500 {
+		t.Errorf("split mismatch: have %v/%v/%v, want 300/200/500", burn, relay, miner)
+	}
+	if err := (&TauashConfig{FeeBurnPercent: 80, RelayFeePercent: 30, RelayFund: &fund}).CheckConfig(); err == nil {
+		t.Errorf("oversized fee split accepted")
+	}
+	if err := (&TauashConfig{RelayFeePercent: 30}).CheckConfig(); err == nil {
+		t.Errorf("relay share without fund accepted")
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrMissingToken is returned when an authenticated endpoint is accessed
+	// without a bearer token.
+	ErrMissingToken = errors.New("missing bearer token")
+
+	// ErrMethodDenied is returned when the token of a caller does not grant
+	// access to the requested method.
+	ErrMethodDenied = errors.New("method not allowed by token")
+)
+
+// Grant is the set of methods an authenticated caller may invoke.
+type Grant struct {
+	ID         string   // Identifier of the token the grant was issued for
+	Namespaces []string // Namespaces whose every method is allowed, "*" allows all
+	Methods    []string // Individual methods allowed, in namespace_method form
+}
+
+// Allows reports whether the grant permits calling the given method. The
+// metadata methods of the rpc namespace are always permitted.
+func (g *Grant) Allows(method string) bool {
+	namespace := method
+	if i := strings.Index(method, serviceMethodSeparator); i >= 0 {
+		namespace = method[:i]
+	}
+	if namespace == MetadataApi {
+		return true
+	}
+	for _, ns := range g.Namespaces {
+		if ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	for _, m := range g.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator validates the bearer tokens presented to an RPC server and is
+// notified of every call it refuses, so that denials can be audited.
+type Authenticator interface {
+	// Authenticate verifies the token and returns the methods it grants.
+	Authenticate(token string) (*Grant, error)
+
+	// Denied records a refused request. The grant is nil if the caller could
+	// not be authenticated, method is empty if no call was decoded yet.
+	Denied(remote string, grant *Grant, method string, reason error)
+}
+
+// authError is returned to callers whose token does not cover a method.
+type authError struct{ method string }
+
+func (e *authError) ErrorCode() int { return -32003 }
+
+func (e *authError) Error() string {
+	return "method " + e.method + " not allowed by token"
+}
+
+// authInfo is the authentication state of a connection.
+type authInfo struct {
+	auth   Authenticator
+	grant  *Grant
+	remote string
+}
+
+type authContextKey struct{}
+
+// withAuth attaches the authentication state of a connection to its context.
+func withAuth(ctx context.Context, info *authInfo) context.Context {
+	return context.WithValue(ctx, authContextKey{}, info)
+}
+
+// authorize checks whether the connection of ctx may call method, reporting
+// refused calls to the authenticator. Connections without authentication
+// state are unrestricted.
+func authorize(ctx context.Context, method string) error {
+	info, ok := ctx.Value(authContextKey{}).(*authInfo)
+	if !ok {
+		return nil
+	}
+	if info.grant.Allows(method) {
+		return nil
+	}
+	info.auth.Denied(info.remote, info.grant, method, ErrMethodDenied)
+	return &authError{method}
+}
+
+// SetAuthenticator requires every connection served over HTTP or websocket to
+// present a bearer token accepted by auth. It must be called before the server
+// starts serving requests.
+func (s *Server) SetAuthenticator(auth Authenticator) {
+	s.auth = auth
+}
+
+// authenticate validates the bearer token of an HTTP request, returning the
+// authentication state to attach to the connection. A nil state is returned
+// if the server does not require authentication.
+func (s *Server) authenticate(r *http.Request) (*authInfo, error) {
+	if s.auth == nil {
+		return nil, nil
+	}
+	token := bearerToken(r)
+	if token == "" {
+		s.auth.Denied(r.RemoteAddr, nil, "", ErrMissingToken)
+		return nil, ErrMissingToken
+	}
+	grant, err := s.auth.Authenticate(token)
+	if err != nil {
+		s.auth.Denied(r.RemoteAddr, nil, "", err)
+		return nil, err
+	}
+	return &authInfo{auth: s.auth, grant: grant, remote: r.RemoteAddr}, nil
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) string {
+	const prefix = "bearer "
+
+	header := r.Header.Get("Authorization")
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefix):])
+}
+
+// unauthorized responds to a request whose token was refused.
+func unauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="gtau"`)
+	http.Error(w, err.Error(), http.StatusUnauthorized)
+}
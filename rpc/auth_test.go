@@ -0,0 +1,121 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// testAuthenticator accepts a fixed set of tokens and records denials.
+type testAuthenticator struct {
+	grants map[string]*Grant
+
+	mu     sync.Mutex
+	denied []string
+}
+
+func (a *testAuthenticator) Authenticate(token string) (*Grant, error) {
+	if grant, ok := a.grants[token]; ok {
+		return grant, nil
+	}
+	return nil, errors.New("unknown token")
+}
+
+func (a *testAuthenticator) Denied(remote string, grant *Grant, method string, reason error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.denied = append(a.denied, method)
+}
+
+// tokenTransport adds a bearer token to every request.
+type tokenTransport struct{ token string }
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestGrantAllows(t *testing.T) {
+	grant := &Grant{Namespaces: []string{"tau"}, Methods: []string{"admin_peers"}}
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"tau_blockNumber", true},
+		{"tau_subscribe", true},
+		{"admin_peers", true},
+		{"admin_addPeer", false},
+		{"personal_unlockAccount", false},
+		{"rpc_modules", true},
+	}
+	for _, tt := range tests {
+		if have := grant.Allows(tt.method); have != tt.want {
+			t.Errorf("%s: have %v, want %v", tt.method, have, tt.want)
+		}
+	}
+	if !(&Grant{Namespaces: []string{"*"}}).Allows("personal_unlockAccount") {
+		t.Errorf("wildcard grant denied call")
+	}
+}
+
+func TestHTTPAuthentication(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+
+	auth := &testAuthenticator{grants: map[string]*Grant{
+		"echo": {ID: "echo", Methods: []string{"test_echo"}},
+	}}
+	server.SetAuthenticator(auth)
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	dial := func(token string) *Client {
+		client, err := DialHTTPWithClient(httpsrv.URL, &http.Client{Transport: &tokenTransport{token}})
+		if err != nil {
+			t.Fatalf("failed to dial: %v", err)
+		}
+		return client
+	}
+	// Valid tokens may call the granted methods only
+	client := dial("echo")
+	defer client.Close()
+
+	var result Result
+	if err := client.Call(&result, "test_echo", "hello", 10, &Args{"world"}); err != nil {
+		t.Fatalf("granted call failed: %v", err)
+	}
+	if err := client.Call(&result, "test_rets"); err == nil {
+		t.Errorf("call outside of grant succeeded")
+	}
+	// Unknown tokens are refused before any call is dispatched
+	bogus := dial("bogus")
+	defer bogus.Close()
+
+	if err := bogus.Call(&result, "test_echo", "hello", 10, &Args{"world"}); err == nil {
+		t.Errorf("call with unknown token succeeded")
+	}
+	auth.mu.Lock()
+	defer auth.mu.Unlock()
+	if len(auth.denied) != 2 || auth.denied[0] != "test_rets" || auth.denied[1] != "" {
+		t.Errorf("denials mismatch: have %q", auth.denied)
+	}
+}
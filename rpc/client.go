@@ -110,7 +110,11 @@ type clientConn struct {
 }
 
 func (c *Client) newClientConn(conn ServerCodec) *clientConn {
-	ctx := context.WithValue(context.Background(), clientContextKey{}, c)
+	ctx := context.Background()
+	if cc, ok := conn.(*connCodec); ok {
+		ctx = cc.ctx
+	}
+	ctx = context.WithValue(ctx, clientContextKey{}, c)
 	handler := newHandler(ctx, conn, c.idgen, c.services)
 	return &clientConn{conn, handler}
 }
@@ -22,8 +22,10 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
 )
 
-// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules
-func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts) (net.Listener, *Server, error) {
+// StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules.
+// Every remote IP is held to the given limits. If auth is non-nil, every request
+// must carry a bearer token accepted by it.
+func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []string, vhosts []string, timeouts HTTPTimeouts, limits Limits, auth Authenticator) (net.Listener, *Server, error) {
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
@@ -39,6 +41,10 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 			log.Debug("HTTP registered", "namespace", api.Namespace)
 		}
 	}
+	handler.SetLimits(limits)
+	if auth != nil {
+		handler.SetAuthenticator(auth)
+	}
 	// All APIs registered, start the HTTP listener
 	var (
 		listener net.Listener
@@ -51,8 +57,10 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, cors []str
 	return listener, handler, err
 }
 
-// StartWSEndpoint starts a websocket endpoint
-func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []string, exposeAll bool) (net.Listener, *Server, error) {
+// StartWSEndpoint starts a websocket endpoint. Every connection and remote IP
+// is held to the given limits. If auth is non-nil, every connection must carry
+// a bearer token accepted by it.
+func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []string, exposeAll bool, limits Limits, auth Authenticator) (net.Listener, *Server, error) {
 
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
@@ -69,6 +77,10 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, wsOrigins []
 			log.Debug("WebSocket registered", "service", api.Service, "namespace", api.Namespace)
 		}
 	}
+	handler.SetLimits(limits)
+	if auth != nil {
+		handler.SetAuthenticator(auth)
+	}
 	// All APIs registered, start the HTTP listener
 	var (
 		listener net.Listener
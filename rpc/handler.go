@@ -61,6 +61,7 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            log.Logger
 	allowSubscribe bool
+	limits         *connLimiter // resource limits of the connection, nil if unlimited
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -84,6 +85,7 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 		allowSubscribe: true,
 		serverSubs:     make(map[ID]*Subscription),
 		log:            log.Root(),
+		limits:         connLimiterFrom(connCtx),
 	}
 	if conn.RemoteAddr() != "" {
 		h.log = h.log.New("conn", conn.RemoteAddr())
@@ -101,6 +103,12 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		})
 		return
 	}
+	if err := h.limits.checkBatch(len(msgs)); err != nil {
+		h.startCallProc(func(cp *callProc) {
+			h.conn.Write(cp.ctx, errorMessage(err))
+		})
+		return
+	}
 
 	// Handle non-call messages first:
 	calls := make([]*jsonrpcMessage, 0, len(msgs))
@@ -296,6 +304,9 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 		return nil
 	case msg.isCall():
 		resp := h.handleCall(ctx, msg)
+		if err := h.limits.checkResponse(len(resp.Result)); err != nil {
+			resp = msg.errorResponse(err)
+		}
 		if resp.Error != nil {
 			h.log.Warn("Served "+msg.Method, "reqid", idForLog{msg.ID}, "t", time.Since(start), "err", resp.Error.Message)
 		} else {
@@ -311,6 +322,9 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if err := h.limits.checkCall(msg.Method); err != nil {
+		return msg.errorResponse(err)
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -318,6 +332,9 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	if msg.isUnsubscribe() {
 		callb = h.unsubscribeCb
 	} else {
+		if err := authorize(cp.ctx, msg.Method); err != nil {
+			return msg.errorResponse(err)
+		}
 		callb = h.reg.callback(msg.Method)
 	}
 	if callb == nil {
@@ -343,6 +360,15 @@ func (h *handler) handleSubscribe(cp *callProc, msg *jsonrpcMessage) *jsonrpcMes
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
 	namespace := msg.namespace()
+	if err := authorize(cp.ctx, msg.Method); err != nil {
+		return msg.errorResponse(err)
+	}
+	h.subLock.Lock()
+	active := len(h.serverSubs)
+	h.subLock.Unlock()
+	if err := h.limits.checkSubscriptions(active + len(cp.notifiers)); err != nil {
+		return msg.errorResponse(err)
+	}
 	callb := h.reg.subscription(namespace, name)
 	if callb == nil {
 		return msg.errorResponse(&subscriptionNotFoundError{namespace, name})
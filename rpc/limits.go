@@ -0,0 +1,289 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/metrics"
+)
+
+// quotaExpiry is the idle time after which the quota of a remote IP or HTTP
+// connection is forgotten.
+const quotaExpiry = 2 * time.Minute
+
+var (
+	rateLimitMeter     = metrics.NewRegisteredMeter("rpc/limits/rate", nil)
+	batchLimitMeter    = metrics.NewRegisteredMeter("rpc/limits/batch", nil)
+	subLimitMeter      = metrics.NewRegisteredMeter("rpc/limits/subscriptions", nil)
+	responseLimitMeter = metrics.NewRegisteredMeter("rpc/limits/response", nil)
+	heavyLimitMeter    = metrics.NewRegisteredMeter("rpc/limits/heavy", nil)
+)
+
+// Limits configures the resources a single connection or remote IP may use.
+// Zero values disable the respective limit.
+type Limits struct {
+	ConnRequestRate     float64  // Requests per second allowed per connection
+	IPRequestRate       float64  // Requests per second allowed per remote IP
+	MaxBatchSize        int      // Maximum number of messages in a batch
+	MaxSubscriptions    int      // Maximum active subscriptions per connection
+	MaxResponseSize     int      // Maximum size of a single result, in bytes
+	HeavyMethods        []string // Expensive methods, whole namespaces given as "name_"
+	HeavyCallsPerMinute int      // Calls of heavy methods allowed per minute per remote IP
+}
+
+// DefaultLimits are the limits applied to the HTTP and WebSocket endpoints
+// unless configured otherwise.
+var DefaultLimits = Limits{
+	MaxBatchSize:        1000,
+	MaxSubscriptions:    1000,
+	HeavyMethods:        []string{"debug_"},
+	HeavyCallsPerMinute: 60,
+}
+
+// limitError is returned to callers exceeding a limit.
+type limitError struct{ message string }
+
+func (e *limitError) ErrorCode() int { return -32005 }
+
+func (e *limitError) Error() string { return e.message }
+
+// bucket is a token bucket refilling at rate tokens per second up to burst.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate, burst float64) bucket {
+	return bucket{rate: rate, burst: burst, tokens: burst}
+}
+
+// take consumes a token, reporting false if the bucket is empty. Buckets with
+// a zero rate never run empty.
+func (b *bucket) take(now time.Time) bool {
+	if b.rate <= 0 {
+		return true
+	}
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// ipQuota is the limit state shared by all connections of a remote IP.
+type ipQuota struct {
+	requests bucket
+	heavy    bucket
+	seen     time.Time
+}
+
+// limiter enforces the limits of a server across all its connections.
+type limiter struct {
+	limits Limits
+
+	lock      sync.Mutex
+	quotas    map[string]*ipQuota
+	httpConns map[string]*connLimiter // Limit state of the HTTP connections, by remote address
+	lastSweep time.Time
+}
+
+func newLimiter(limits Limits) *limiter {
+	return &limiter{
+		limits:    limits,
+		quotas:    make(map[string]*ipQuota),
+		httpConns: make(map[string]*connLimiter),
+	}
+}
+
+// conn creates the limit state of a new connection from the given remote
+// address.
+func (l *limiter) conn(remote string) *connLimiter {
+	ip := remote
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		ip = host
+	}
+	rate := l.limits.ConnRequestRate
+	return &connLimiter{limiter: l, ip: ip, requests: newBucket(rate, math.Max(1, rate))}
+}
+
+// httpConn returns the limit state of the HTTP connection from the given remote
+// address, shared by all the requests sent over the connection.
+func (l *limiter) httpConn(remote string, now time.Time) *connLimiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.sweep(now)
+	conn := l.httpConns[remote]
+	if conn == nil {
+		conn = l.conn(remote)
+		l.httpConns[remote] = conn
+	}
+	conn.seen = now
+	return conn
+}
+
+// sweep forgets the quotas and HTTP connections idle for too long. The caller
+// must hold the lock.
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) <= quotaExpiry {
+		return
+	}
+	for key, quota := range l.quotas {
+		if now.Sub(quota.seen) > quotaExpiry {
+			delete(l.quotas, key)
+		}
+	}
+	for key, conn := range l.httpConns {
+		if now.Sub(conn.seen) > quotaExpiry {
+			delete(l.httpConns, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// takeIP consumes a request, and a heavy call if heavy is set, from the quota
+// of the given IP.
+func (l *limiter) takeIP(ip string, heavy bool, now time.Time) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.sweep(now)
+	quota := l.quotas[ip]
+	if quota == nil {
+		var (
+			rate  = l.limits.IPRequestRate
+			heavy = float64(l.limits.HeavyCallsPerMinute)
+		)
+		quota = &ipQuota{
+			requests: newBucket(rate, math.Max(1, rate)),
+			heavy:    newBucket(heavy/60, heavy),
+		}
+		l.quotas[ip] = quota
+	}
+	quota.seen = now
+
+	if !quota.requests.take(now) {
+		rateLimitMeter.Mark(1)
+		return &limitError{"request rate limit exceeded"}
+	}
+	if heavy && !quota.heavy.take(now) {
+		heavyLimitMeter.Mark(1)
+		return &limitError{"quota of expensive calls exceeded"}
+	}
+	return nil
+}
+
+// isHeavy reports whether method is one of the configured expensive methods.
+func (l *limiter) isHeavy(method string) bool {
+	for _, heavy := range l.limits.HeavyMethods {
+		if method == heavy || (strings.HasSuffix(heavy, serviceMethodSeparator) && strings.HasPrefix(method, heavy)) {
+			return true
+		}
+	}
+	return false
+}
+
+// connLimiter is the limit state of a single connection. All methods accept
+// a nil receiver, which enforces no limits.
+type connLimiter struct {
+	*limiter
+	ip   string
+	seen time.Time // Last request over HTTP, protected by the limiter lock
+
+	lock     sync.Mutex
+	requests bucket
+}
+
+type limiterContextKey struct{}
+
+// withLimiter attaches the limit state of a connection to its context.
+func withLimiter(ctx context.Context, l *connLimiter) context.Context {
+	return context.WithValue(ctx, limiterContextKey{}, l)
+}
+
+// connLimiterFrom retrieves the limit state of a connection, if any.
+func connLimiterFrom(ctx context.Context) *connLimiter {
+	l, _ := ctx.Value(limiterContextKey{}).(*connLimiter)
+	return l
+}
+
+// checkBatch verifies the size of an incoming batch.
+func (l *connLimiter) checkBatch(size int) error {
+	if l == nil || l.limits.MaxBatchSize == 0 || size <= l.limits.MaxBatchSize {
+		return nil
+	}
+	batchLimitMeter.Mark(1)
+	return &limitError{fmt.Sprintf("batch too large (%d > %d)", size, l.limits.MaxBatchSize)}
+}
+
+// checkCall consumes the rate quotas of the connection and its IP for a call
+// of the given method.
+func (l *connLimiter) checkCall(method string) error {
+	if l == nil {
+		return nil
+	}
+	now := time.Now()
+
+	l.lock.Lock()
+	ok := l.requests.take(now)
+	l.lock.Unlock()
+
+	if !ok {
+		rateLimitMeter.Mark(1)
+		return &limitError{"request rate limit exceeded"}
+	}
+	return l.takeIP(l.ip, l.isHeavy(method), now)
+}
+
+// checkSubscriptions verifies that another subscription may be created on a
+// connection with the given number of active ones.
+func (l *connLimiter) checkSubscriptions(active int) error {
+	if l == nil || l.limits.MaxSubscriptions == 0 || active < l.limits.MaxSubscriptions {
+		return nil
+	}
+	subLimitMeter.Mark(1)
+	return &limitError{fmt.Sprintf("too many subscriptions (max %d)", l.limits.MaxSubscriptions)}
+}
+
+// checkResponse verifies the size of an outgoing result.
+func (l *connLimiter) checkResponse(size int) error {
+	if l == nil || l.limits.MaxResponseSize == 0 || size <= l.limits.MaxResponseSize {
+		return nil
+	}
+	responseLimitMeter.Mark(1)
+	return &limitError{fmt.Sprintf("response too large (%d > %d bytes)", size, l.limits.MaxResponseSize)}
+}
+
+// SetLimits applies the given resource limits to the connections served over
+// HTTP or websocket. It must be called before the server starts serving
+// requests.
+func (s *Server) SetLimits(limits Limits) {
+	s.limiter = newLimiter(limits)
+}
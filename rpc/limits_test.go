@@ -0,0 +1,157 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBucket(t *testing.T) {
+	var (
+		now = time.Now()
+		b   = newBucket(2, 2)
+	)
+	if !b.take(now) || !b.take(now) {
+		t.Fatalf("burst not honoured")
+	}
+	if b.take(now) {
+		t.Fatalf("empty bucket allowed request")
+	}
+	if !b.take(now.Add(500 * time.Millisecond)) {
+		t.Fatalf("bucket did not refill")
+	}
+	unlimited := newBucket(0, 0)
+	for i := 0; i < 100; i++ {
+		if !unlimited.take(now) {
+			t.Fatalf("unlimited bucket refused request %d", i)
+		}
+	}
+}
+
+// startLimitedServer starts an HTTP test server enforcing the given limits.
+func startLimitedServer(t *testing.T, limits Limits) (*Client, func()) {
+	server := newTestServer()
+	server.SetLimits(limits)
+	httpsrv := httptest.NewServer(server)
+
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	return client, func() {
+		client.Close()
+		httpsrv.Close()
+		server.Stop()
+	}
+}
+
+// checkLimitError verifies that err is a JSON-RPC limit error.
+func checkLimitError(t *testing.T, err error) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("over-limit call succeeded")
+	}
+	if rpcErr, ok := err.(Error); !ok || rpcErr.ErrorCode() != -32005 {
+		t.Fatalf("error mismatch: have %v, want limit error", err)
+	}
+}
+
+func TestLimitRequestRate(t *testing.T) {
+	client, stop := startLimitedServer(t, Limits{IPRequestRate: 1})
+	defer stop()
+
+	var result Result
+	if err := client.Call(&result, "test_echo", "hello", 10, &Args{"world"}); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	checkLimitError(t, client.Call(&result, "test_echo", "hello", 10, &Args{"world"}))
+}
+
+// Tests that the requests sent over the same HTTP connection share its quota,
+// while other connections get their own.
+func TestLimitHTTPConnRate(t *testing.T) {
+	var (
+		l   = newLimiter(Limits{ConnRequestRate: 1})
+		now = time.Now()
+	)
+	if err := l.httpConn("127.0.0.1:1000", now).checkCall("test_echo"); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	if err := l.httpConn("127.0.0.1:1000", now).checkCall("test_echo"); err == nil {
+		t.Fatalf("second call over the same connection succeeded")
+	}
+	if err := l.httpConn("127.0.0.1:1001", now).checkCall("test_echo"); err != nil {
+		t.Fatalf("call over another connection failed: %v", err)
+	}
+	// Idle connections are forgotten
+	l.httpConn("127.0.0.1:1001", now.Add(2*quotaExpiry))
+	if len(l.httpConns) != 1 {
+		t.Errorf("idle connection not forgotten: %d tracked", len(l.httpConns))
+	}
+}
+
+func TestLimitHeavyMethods(t *testing.T) {
+	client, stop := startLimitedServer(t, Limits{HeavyMethods: []string{"test_echo"}, HeavyCallsPerMinute: 1})
+	defer stop()
+
+	var result Result
+	if err := client.Call(&result, "test_echo", "hello", 10, &Args{"world"}); err != nil {
+		t.Fatalf("first heavy call failed: %v", err)
+	}
+	checkLimitError(t, client.Call(&result, "test_echo", "hello", 10, &Args{"world"}))
+
+	// Light methods are not affected by the heavy quota
+	var rets string
+	if err := client.Call(&rets, "test_rets"); err != nil {
+		t.Fatalf("light call failed: %v", err)
+	}
+}
+
+func TestLimitResponseSize(t *testing.T) {
+	client, stop := startLimitedServer(t, Limits{MaxResponseSize: 16})
+	defer stop()
+
+	var rets string
+	if err := client.Call(&rets, "test_rets"); err != nil {
+		t.Fatalf("small response refused: %v", err)
+	}
+	var result Result
+	checkLimitError(t, client.Call(&result, "test_echo", "hello", 10, &Args{"world"}))
+}
+
+func TestLimitBatchSize(t *testing.T) {
+	client, stop := startLimitedServer(t, Limits{MaxBatchSize: 2})
+	defer stop()
+
+	batch := make([]BatchElem, 3)
+	for i := range batch {
+		batch[i] = BatchElem{Method: "test_rets", Result: new(string)}
+	}
+	if err := client.BatchCall(batch); err == nil {
+		t.Fatalf("oversized batch accepted")
+	}
+	if err := client.BatchCall(batch[:2]); err != nil {
+		t.Fatalf("batch within limit failed: %v", err)
+	}
+	for i, elem := range batch[:2] {
+		if elem.Error != nil {
+			t.Errorf("batch element %d failed: %v", i, elem.Error)
+		}
+	}
+}
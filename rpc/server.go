@@ -20,6 +20,7 @@ import (
 	"context"
 	"io"
 	"sync/atomic"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
@@ -46,6 +47,24 @@ type Server struct {
 	idgen    func() ID
 	run      int32
 	codecs   mapset.Set
+	auth     Authenticator // Bearer token validator, nil if authentication is disabled
+	limiter  *limiter      // Resource limits of remote connections, nil if unlimited
+}
+
+// connCodec wraps the codec of a remote connection with the context carrying
+// its per-connection state, such as authentication and resource limits.
+type connCodec struct {
+	ServerCodec
+	ctx    context.Context
+	remote string
+}
+
+// RemoteAddr returns the peer address of the connection.
+func (c *connCodec) RemoteAddr() string {
+	if c.remote != "" {
+		return c.remote
+	}
+	return c.ServerCodec.RemoteAddr()
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -79,6 +98,10 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		return
 	}
 
+	// Enforce the resource limits on remote connections
+	if cc, ok := codec.(*connCodec); ok && s.limiter != nil {
+		cc.ctx = withLimiter(cc.ctx, s.limiter.conn(cc.RemoteAddr()))
+	}
 	// Add the codec to the set so it can be closed by Stop.
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
@@ -97,6 +120,9 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 		return
 	}
 
+	if s.limiter != nil {
+		ctx = withLimiter(ctx, s.limiter.httpConn(codec.RemoteAddr(), time.Now()))
+	}
 	h := newHandler(ctx, codec, s.idgen, &s.services)
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
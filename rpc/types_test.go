@@ -64,3 +64,19 @@ func TestBlockNumberJSONUnmarshal(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockNumberJSONRoundtrip(t *testing.T) {
+	for _, bn := range []BlockNumber{PendingBlockNumber, LatestBlockNumber, EarliestBlockNumber, 1, 0x3e8} {
+		enc, err := json.Marshal(bn)
+		if err != nil {
+			t.Fatalf("failed to encode %d: %v", bn, err)
+		}
+		var dec BlockNumber
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Fatalf("failed to decode %s: %v", enc, err)
+		}
+		if dec != bn {
+			t.Errorf("roundtrip mismatch: have %d, want %d", dec, bn)
+		}
+	}
+}
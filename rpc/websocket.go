@@ -54,23 +54,32 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 		ReadBufferSize:  wsReadBuffer,
 		WriteBufferSize: wsWriteBuffer,
 		WriteBufferPool: wsBufferPool,
-		CheckOrigin:     wsHandshakeValidator(allowedOrigins),
+		CheckOrigin:     WSHandshakeValidator(allowedOrigins),
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, err := s.authenticate(r)
+		if err != nil {
+			unauthorized(w, err)
+			return
+		}
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			log.Debug("WebSocket upgrade failed", "err", err)
 			return
 		}
-		codec := newWebsocketCodec(conn)
+		ctx := context.Background()
+		if info != nil {
+			ctx = withAuth(ctx, info)
+		}
+		codec := &connCodec{newWebsocketCodec(conn), ctx, r.RemoteAddr}
 		s.ServeCodec(codec, OptionMethodInvocation|OptionSubscriptions)
 	})
 }
 
-// wsHandshakeValidator returns a handler that verifies the origin during the
+// WSHandshakeValidator returns a handler that verifies the origin during the
 // websocket upgrade process. When a '*' is specified as an allowed origins all
 // connections are accepted.
-func wsHandshakeValidator(allowedOrigins []string) func(*http.Request) bool {
+func WSHandshakeValidator(allowedOrigins []string) func(*http.Request) bool {
 	origins := mapset.NewSet()
 	allowAllOrigins := false
 
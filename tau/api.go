@@ -35,6 +35,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/internal/tauapi"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/miner"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/trie"
@@ -136,6 +137,34 @@ func (api *PrivateMinerAPI) SetRecommitInterval(interval int) {
 	api.e.Miner().SetRecommitInterval(time.Duration(interval) * time.Millisecond)
 }
 
+// ReportDeviceStatus feeds the current device conditions (charging, battery
+// level, metered network, thermal state) into the mining scheduler and returns
+// the resulting decision. Mobile hosts call it whenever a condition changes.
+func (api *PrivateMinerAPI) ReportDeviceStatus(status miner.DeviceStatus) miner.Decision {
+	return api.e.Miner().Scheduler().Report(status)
+}
+
+// SetPolicy installs one of the preset mining policies: "default", "charging"
+// or "unrestricted".
+func (api *PrivateMinerAPI) SetPolicy(name string) error {
+	policy, err := miner.PolicyByName(name)
+	if err != nil {
+		return err
+	}
+	api.e.Miner().Scheduler().SetPolicy(policy)
+	return nil
+}
+
+// DeviceStatus returns the last reported device conditions and the decision of
+// the mining policy currently in effect.
+func (api *PrivateMinerAPI) DeviceStatus() map[string]interface{} {
+	status, decision := api.e.Miner().Scheduler().Status()
+	return map[string]interface{}{
+		"status":   status,
+		"decision": decision,
+	}
+}
+
 // PrivateAdminAPI is the collection of Tau full node-related APIs
 // exposed over the private admin endpoint.
 type PrivateAdminAPI struct {
@@ -373,7 +402,7 @@ func (api *PrivateDebugAPI) AccountRange(ctx context.Context, start *common.Hash
 			return AccountRangeResult{}, err
 		}
 	} else {
-		_, _, statedb, err = api.computeTxEnv(block.Hash(), len(block.Transactions())-1, 0)
+		_, statedb, err = api.computeTxState(block.Hash(), len(block.Transactions())-1, 0)
 		if err != nil {
 			return AccountRangeResult{}, err
 		}
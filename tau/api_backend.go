@@ -24,6 +24,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/accounts"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/chaindir"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
@@ -31,6 +32,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/downloader"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/feeoracle"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
 )
 
@@ -38,6 +40,7 @@ import (
 type TauAPIBackend struct {
 	extRPCEnabled bool
 	tau           *Tau
+	oracle        *feeoracle.Oracle
 }
 
 // ChainConfig returns the active chain configuration.
@@ -122,6 +125,14 @@ func (b *TauAPIBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) e
 	return b.tau.BlockChain().SubscribeChainSideEvent(ch)
 }
 
+func (b *TauAPIBackend) SubscribeProfileChangedEvent(ch chan<- core.ProfileChangedEvent) event.Subscription {
+	return b.tau.BlockChain().SubscribeProfileChangedEvent(ch)
+}
+
+func (b *TauAPIBackend) SubscribeChainTxsEvent(ch chan<- core.ChainTxsEvent) event.Subscription {
+	return b.tau.BlockChain().SubscribeChainTxsEvent(ch)
+}
+
 func (b *TauAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	return b.tau.txPool.AddLocal(signedTx)
 }
@@ -172,13 +183,29 @@ func (b *TauAPIBackend) ProtocolVersion() int {
 }
 
 func (b *TauAPIBackend) SuggestPrice(ctx context.Context) (*big.Int, error) {
-	return big.NewInt(1), nil
+	suggestion, err := b.oracle.SuggestFee(ctx, types.TransferTxKind)
+	if err != nil {
+		return nil, err
+	}
+	return suggestion.Normal.ToInt(), nil
+}
+
+func (b *TauAPIBackend) SuggestFee(ctx context.Context, kind types.TxKind) (*feeoracle.Suggestion, error) {
+	return b.oracle.SuggestFee(ctx, kind)
+}
+
+func (b *TauAPIBackend) SuggestReplacementFee(ctx context.Context, tx *types.Transaction) (*feeoracle.Suggestion, error) {
+	return b.oracle.SuggestReplacement(ctx, tx)
 }
 
 func (b *TauAPIBackend) ChainDb() taudb.Database {
 	return b.tau.ChainDb()
 }
 
+func (b *TauAPIBackend) ChainDirectory() *chaindir.Directory {
+	return b.tau.ChainDirectory()
+}
+
 func (b *TauAPIBackend) EventMux() *event.TypeMux {
 	return b.tau.EventMux()
 }
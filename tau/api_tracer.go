@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -34,8 +35,6 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
-	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/vm"
-	"github.com/Tau-Coin/taucoin-mobile-mining-go/internal/tauapi"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
@@ -44,10 +43,6 @@ import (
 )
 
 const (
-	// defaultTraceTimeout is the amount of time a single transaction can execute
-	// by default before being forcefully aborted.
-	defaultTraceTimeout = 5 * time.Second
-
 	// defaultTraceReexec is the number of blocks the tracer is willing to go back
 	// and reexecute to produce missing historical state necessary to run a specific
 	// trace.
@@ -56,51 +51,36 @@ const (
 
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
-	*vm.LogConfig
-	Tracer  *string
-	Timeout *string
-	Reexec  *uint64
+	Reexec *uint64
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
 type StdTraceConfig struct {
-	*vm.LogConfig
 	Reexec *uint64
 	TxHash common.Hash
 }
 
-// txTraceResult is the result of a single transaction trace.
-type txTraceResult struct {
-	Result interface{} `json:"result,omitempty"` // Trace results produced by the tracer
-	Error  string      `json:"error,omitempty"`  // Trace failure produced by the tracer
-}
-
 // blockTraceTask represents a single block trace task when an entire chain is
 // being traced.
 type blockTraceTask struct {
-	statedb *state.StateDB   // Intermediate state prepped for tracing
-	block   *types.Block     // Block to trace the transactions from
-	rootref common.Hash      // Trie root reference held for this task
-	results []*txTraceResult // Trace results procudes by the task
+	statedb *state.StateDB    // Intermediate state prepped for tracing
+	block   *types.Block      // Block to trace the transactions from
+	rootref common.Hash       // Trie root reference held for this task
+	result  *blockTraceResult // Trace result produced by the task
 }
 
-// blockTraceResult represets the results of tracing a single block when an entire
-// chain is being traced.
+// blockTraceResult represets the results of tracing a single block: the state
+// diff of every transaction followed by the application of the block reward.
 type blockTraceResult struct {
-	Block  hexutil.Uint64   `json:"block"`  // Block number corresponding to this trace
-	Hash   common.Hash      `json:"hash"`   // Block hash corresponding to this trace
-	Traces []*txTraceResult `json:"traces"` // Trace results produced by the task
+	Block  hexutil.Uint64       `json:"block"`            // Block number corresponding to this trace
+	Hash   common.Hash          `json:"hash"`             // Block hash corresponding to this trace
+	Traces []*tracers.TxTrace   `json:"traces"`           // Trace results produced by the task
+	Reward *tracers.RewardTrace `json:"reward,omitempty"` // Block reward application
+	Error  string               `json:"error,omitempty"`  // Processing failure of the block
 }
 
-// txTraceTask represents a single transaction trace task when an entire block
-// is being traced.
-type txTraceTask struct {
-	statedb *state.StateDB // Intermediate state prepped for tracing
-	index   int            // Transaction offset in the block
-}
-
-// TraceChain returns the structured logs created during the execution of EVM
-// between two blocks (excluding start) and returns them as a JSON object.
+// TraceChain returns the state diffs created by the transactions and rewards
+// of the blocks between start (excluded) and end as a stream of JSON objects.
 func (api *PrivateDebugAPI) TraceChain(ctx context.Context, start, end rpc.BlockNumber, config *TraceConfig) (*rpc.Subscription, error) {
 	// Fetch the block interval that we want to trace
 	var from, to *types.Block
@@ -134,9 +114,8 @@ func (api *PrivateDebugAPI) TraceChain(ctx context.Context, start, end rpc.Block
 	return api.traceChain(ctx, from, to, config)
 }
 
-// traceChain configures a new tracer according to the provided configuration, and
-// executes all the transactions contained within. The return value will be one item
-// per transaction, dependent on the requested tracer.
+// traceChain traces every block between start (excluded) and end, streaming one
+// result per block to the subscriber.
 func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Block, config *TraceConfig) (*rpc.Subscription, error) {
 	// Tracing a chain is a **long** operation, only do with subscriptions
 	notifier, supported := rpc.NotifierFromContext(ctx)
@@ -201,22 +180,9 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 
 			// Fetch and execute the next block trace tasks
 			for task := range tasks {
-				signer := types.MakeSigner(api.tau.blockchain.Config(), task.block.Number())
-
-				// Trace all the transactions contained within
-				for i, tx := range task.block.Transactions() {
-					msg, _ := (*tx).AsMessage(signer)
-					vmctx := core.NewEVMContext(msg, task.block.Header(), api.tau.blockchain, nil)
-
-					res, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
-					if err != nil {
-						task.results[i] = &txTraceResult{Error: err.Error()}
-						log.Warn("Tracing failed", "hash", (*tx).Hash(), "block", task.block.NumberU64(), "err", err)
-						break
-					}
-					// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
-					task.statedb.Finalise(api.tau.blockchain.Config().IsEIP158(task.block.Number()))
-					task.results[i] = &txTraceResult{Result: res}
+				task.result = api.traceBlockState(task.block, task.statedb)
+				if task.result.Error != "" {
+					log.Warn("Tracing failed", "block", task.block.NumberU64(), "err", task.result.Error)
 				}
 				// Stream the result back to the user or abort on teardown
 				select {
@@ -279,14 +245,12 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 			}
 			// Send the block over to the concurrent tracers (if not in the fast-forward phase)
 			if number > origin {
-				txs := block.Transactions()
-
 				select {
-				case tasks <- &blockTraceTask{statedb: statedb.Copy(), block: block, rootref: proot, results: make([]*txTraceResult, len(txs))}:
+				case tasks <- &blockTraceTask{statedb: statedb.Copy(), block: block, rootref: proot}:
 				case <-notifier.Closed():
 					return
 				}
-				traced += uint64(len(txs))
+				traced += uint64(len(block.Transactions()))
 			}
 			// Generate the next state snapshot fast without tracing
 			err := api.tau.blockchain.Processor().Process(block, statedb)
@@ -327,21 +291,15 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 		)
 		for res := range results {
 			// Queue up next received result
-			result := &blockTraceResult{
-				Block:  hexutil.Uint64(res.block.NumberU64()),
-				Hash:   res.block.Hash(),
-				Traces: res.results,
-			}
-			done[uint64(result.Block)] = result
+			done[uint64(res.result.Block)] = res.result
 
 			// Dereference any paret tries held in memory by this task
 			database.TrieDB().Dereference(res.rootref)
 
-			// Stream completed traces to the user, aborting on the first error
+			// Stream completed traces to the user. Blocks without transactions
+			// are sent too, their reward changes balances as well.
 			for result, ok := done[next]; ok; result, ok = done[next] {
-				if len(result.Traces) > 0 || next == end.NumberU64() {
-					notifier.Notify(sub.ID, result)
-				}
+				notifier.Notify(sub.ID, result)
 				delete(done, next)
 				next++
 			}
@@ -350,9 +308,9 @@ func (api *PrivateDebugAPI) traceChain(ctx context.Context, start, end *types.Bl
 	return sub, nil
 }
 
-// TraceBlockByNumber returns the structured logs created during the execution of
-// EVM and returns them as a JSON object.
-func (api *PrivateDebugAPI) TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) ([]*txTraceResult, error) {
+// TraceBlockByNumber returns the state diffs created by the transactions and the
+// reward of a block as a JSON object.
+func (api *PrivateDebugAPI) TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) (*blockTraceResult, error) {
 	// Fetch the block that we want to trace
 	var block *types.Block
 
@@ -371,9 +329,9 @@ func (api *PrivateDebugAPI) TraceBlockByNumber(ctx context.Context, number rpc.B
 	return api.traceBlock(ctx, block, config)
 }
 
-// TraceBlockByHash returns the structured logs created during the execution of
-// EVM and returns them as a JSON object.
-func (api *PrivateDebugAPI) TraceBlockByHash(ctx context.Context, hash common.Hash, config *TraceConfig) ([]*txTraceResult, error) {
+// TraceBlockByHash returns the state diffs created by the transactions and the
+// reward of a block as a JSON object.
+func (api *PrivateDebugAPI) TraceBlockByHash(ctx context.Context, hash common.Hash, config *TraceConfig) (*blockTraceResult, error) {
 	block := api.tau.blockchain.GetBlockByHash(hash)
 	if block == nil {
 		return nil, fmt.Errorf("block %#x not found", hash)
@@ -381,9 +339,9 @@ func (api *PrivateDebugAPI) TraceBlockByHash(ctx context.Context, hash common.Ha
 	return api.traceBlock(ctx, block, config)
 }
 
-// TraceBlock returns the structured logs created during the execution of EVM
-// and returns them as a JSON object.
-func (api *PrivateDebugAPI) TraceBlock(ctx context.Context, blob []byte, config *TraceConfig) ([]*txTraceResult, error) {
+// TraceBlock returns the state diffs created by the transactions and the reward
+// of an RLP encoded block as a JSON object.
+func (api *PrivateDebugAPI) TraceBlock(ctx context.Context, blob []byte, config *TraceConfig) (*blockTraceResult, error) {
 	block := new(types.Block)
 	if err := rlp.Decode(bytes.NewReader(blob), block); err != nil {
 		return nil, fmt.Errorf("could not decode block: %v", err)
@@ -391,9 +349,9 @@ func (api *PrivateDebugAPI) TraceBlock(ctx context.Context, blob []byte, config
 	return api.traceBlock(ctx, block, config)
 }
 
-// TraceBlockFromFile returns the structured logs created during the execution of
-// EVM and returns them as a JSON object.
-func (api *PrivateDebugAPI) TraceBlockFromFile(ctx context.Context, file string, config *TraceConfig) ([]*txTraceResult, error) {
+// TraceBlockFromFile returns the state diffs created by the transactions and the
+// reward of an RLP encoded block read from a file as a JSON object.
+func (api *PrivateDebugAPI) TraceBlockFromFile(ctx context.Context, file string, config *TraceConfig) (*blockTraceResult, error) {
 	blob, err := ioutil.ReadFile(file)
 	if err != nil {
 		return nil, fmt.Errorf("could not read file: %v", err)
@@ -401,10 +359,9 @@ func (api *PrivateDebugAPI) TraceBlockFromFile(ctx context.Context, file string,
 	return api.TraceBlock(ctx, blob, config)
 }
 
-// TraceBadBlockByHash returns the structured logs created during the execution of
-// EVM against a block pulled from the pool of bad ones and returns them as a JSON
-// object.
-func (api *PrivateDebugAPI) TraceBadBlock(ctx context.Context, hash common.Hash, config *TraceConfig) ([]*txTraceResult, error) {
+// TraceBadBlock returns the state diffs created by a block pulled from the pool
+// of bad ones as a JSON object, up to the transaction that failed.
+func (api *PrivateDebugAPI) TraceBadBlock(ctx context.Context, hash common.Hash, config *TraceConfig) (*blockTraceResult, error) {
 	blocks := api.tau.blockchain.BadBlocks()
 	for _, block := range blocks {
 		if block.Hash() == hash {
@@ -414,9 +371,8 @@ func (api *PrivateDebugAPI) TraceBadBlock(ctx context.Context, hash common.Hash,
 	return nil, fmt.Errorf("bad block %#x not found", hash)
 }
 
-// StandardTraceBlockToFile dumps the structured logs created during the
-// execution of EVM to the local file system and returns a list of files
-// to the caller.
+// StandardTraceBlockToFile dumps the state diffs created by the transactions of
+// a block to the local file system and returns a list of files to the caller.
 func (api *PrivateDebugAPI) StandardTraceBlockToFile(ctx context.Context, hash common.Hash, config *StdTraceConfig) ([]string, error) {
 	block := api.tau.blockchain.GetBlockByHash(hash)
 	if block == nil {
@@ -425,9 +381,9 @@ func (api *PrivateDebugAPI) StandardTraceBlockToFile(ctx context.Context, hash c
 	return api.standardTraceBlockToFile(ctx, block, config)
 }
 
-// StandardTraceBadBlockToFile dumps the structured logs created during the
-// execution of EVM against a block pulled from the pool of bad ones to the
-// local file system and returns a list of files to the caller.
+// StandardTraceBadBlockToFile dumps the state diffs created by the transactions
+// of a block pulled from the pool of bad ones to the local file system and
+// returns a list of files to the caller.
 func (api *PrivateDebugAPI) StandardTraceBadBlockToFile(ctx context.Context, hash common.Hash, config *StdTraceConfig) ([]string, error) {
 	blocks := api.tau.blockchain.BadBlocks()
 	for _, block := range blocks {
@@ -438,10 +394,9 @@ func (api *PrivateDebugAPI) StandardTraceBadBlockToFile(ctx context.Context, has
 	return nil, fmt.Errorf("bad block %#x not found", hash)
 }
 
-// traceBlock configures a new tracer according to the provided configuration, and
-// executes all the transactions contained within. The return value will be one item
-// per transaction, dependent on the requestd tracer.
-func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) ([]*txTraceResult, error) {
+// traceBlock re-executes the given block on top of the state of its parent and
+// returns the state diffs of its transactions and reward.
+func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block, config *TraceConfig) (*blockTraceResult, error) {
 	// Create the parent state database
 	if err := api.tau.engine.VerifyHeader(api.tau.blockchain, block.Header(), true); err != nil {
 		return nil, err
@@ -458,71 +413,37 @@ func (api *PrivateDebugAPI) traceBlock(ctx context.Context, block *types.Block,
 	if err != nil {
 		return nil, err
 	}
-	// Execute all the transaction contained within the block concurrently
-	var (
-		signer = types.MakeSigner(api.tau.blockchain.Config(), block.Number())
-
-		txs     = block.Transactions()
-		results = make([]*txTraceResult, len(txs))
+	return api.traceBlockState(block, statedb), nil
+}
 
-		pend = new(sync.WaitGroup)
-		jobs = make(chan *txTraceTask, len(txs))
+// traceBlockState processes the given block on top of statedb with the native
+// state-diff tracer. Processing failures are reported in the result, which
+// holds the traces up to and including the failing transaction.
+func (api *PrivateDebugAPI) traceBlockState(block *types.Block, statedb *state.StateDB) *blockTraceResult {
+	var (
+		tracer    = tracers.NewStateDiffTracer(api.tau.blockchain.Config(), api.tau.ChainDb())
+		processor = core.NewStateProcessor(api.tau.blockchain.Config(), api.tau.blockchain, api.tau.engine)
 	)
-	threads := runtime.NumCPU()
-	if threads > len(txs) {
-		threads = len(txs)
-	}
-	for th := 0; th < threads; th++ {
-		pend.Add(1)
-		go func() {
-			defer pend.Done()
-
-			// Fetch and execute the next transaction trace tasks
-			for task := range jobs {
-				msg, _ := (*txs[task.index]).AsMessage(signer)
-				vmctx := core.NewEVMContext(msg, block.Header(), api.tau.blockchain, nil)
+	err := processor.ProcessTraced(block, statedb, tracer)
 
-				res, err := api.traceTx(ctx, msg, vmctx, task.statedb, config)
-				if err != nil {
-					results[task.index] = &txTraceResult{Error: err.Error()}
-					continue
-				}
-				results[task.index] = &txTraceResult{Result: res}
-			}
-		}()
+	result := &blockTraceResult{
+		Block:  hexutil.Uint64(block.NumberU64()),
+		Hash:   block.Hash(),
+		Traces: tracer.Transactions(),
+		Reward: tracer.Reward(),
 	}
-	// Feed the transactions into the tracers and return
-	var failed error
-	for i, tx := range txs {
-		// Send the trace task over for execution
-		jobs <- &txTraceTask{statedb: statedb.Copy(), index: i}
-
-		// Generate the next state snapshot fast without tracing
-		msg, _ := (*tx).AsMessage(signer)
-		vmctx := core.NewEVMContext(msg, block.Header(), api.tau.blockchain, nil)
-
-		vmenv := vm.NewEVM(vmctx, statedb, api.tau.blockchain.Config())
-		if _, _, _, err := core.ApplyMessage(vmenv, msg); err != nil {
-			failed = err
-			break
-		}
-		// Finalize the state so any modifications are written to the trie
-		// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
-		statedb.Finalise(vmenv.ChainConfig().IsEIP158(block.Number()))
+	if result.Traces == nil {
+		result.Traces = []*tracers.TxTrace{}
 	}
-	close(jobs)
-	pend.Wait()
-
-	// If execution failed in between, abort
-	if failed != nil {
-		return nil, failed
+	if err != nil {
+		result.Error = err.Error()
 	}
-	return results, nil
+	return result
 }
 
-// standardTraceBlockToFile configures a new tracer which uses standard JSON output,
-// and traces either a full block or an individual transaction. The return value will
-// be one filename per transaction traced.
+// standardTraceBlockToFile traces either a full block or an individual
+// transaction, writing the state diff of each traced transaction as JSON into
+// its own file. The return value will be one filename per transaction traced.
 func (api *PrivateDebugAPI) standardTraceBlockToFile(ctx context.Context, block *types.Block, config *StdTraceConfig) ([]string, error) {
 	// If we're tracing a single transaction, make sure it's present
 	if config != nil && config.TxHash != (common.Hash{}) {
@@ -546,65 +467,49 @@ func (api *PrivateDebugAPI) standardTraceBlockToFile(ctx context.Context, block
 	if err != nil {
 		return nil, err
 	}
-	// Retrieve the tracing configurations, or use default values
-	var (
-		logConfig vm.LogConfig
-		txHash    common.Hash
-	)
+	var txHash common.Hash
 	if config != nil {
-		if config.LogConfig != nil {
-			logConfig = *config.LogConfig
-		}
 		txHash = config.TxHash
 	}
-	logConfig.Debug = true
-
 	// Execute transaction, either tracing all or just the requested one
-	var (
-		signer = types.MakeSigner(api.tau.blockchain.Config(), block.Number())
-		dumps  []string
-	)
+	var dumps []string
 	for i, tx := range block.Transactions() {
-		// Prepare the trasaction for un-traced execution
-		var (
-			msg, _ = (*tx).AsMessage(signer)
-			vmctx  = core.NewEVMContext(msg, block.Header(), api.tau.blockchain, nil)
+		statedb.Prepare((*tx).Hash(), block.Hash(), i)
 
-			dump   *os.File
-			writer *bufio.Writer
-			err    error
-		)
-		// If the transaction needs tracing, swap out the configs
-		if (*tx).Hash() == txHash || txHash == (common.Hash{}) {
-			// Generate a unique temporary file to dump it into
-			prefix := fmt.Sprintf("block_%#x-%d-%#x-", block.Hash().Bytes()[:4], i, (*tx).Hash().Bytes()[:4])
-
-			dump, err = ioutil.TempFile(os.TempDir(), prefix)
-			if err != nil {
-				return nil, err
+		// Execute the transaction untraced unless it was requested
+		if (*tx).Hash() != txHash && txHash != (common.Hash{}) {
+			if err := core.ApplyTransaction(api.tau.blockchain.Config(), api.tau.blockchain, nil, statedb, block.Header(), tx, nil); err != nil {
+				return dumps, err
 			}
-			dumps = append(dumps, dump.Name())
-
-			// Swap out the noop logger to the standard tracer
-			writer = bufio.NewWriter(dump)
+			continue
 		}
-		// Execute the transaction and flush any traces to disk
-		vmenv := vm.NewEVM(vmctx, statedb, api.tau.blockchain.Config())
-		_, _, _, err = core.ApplyMessage(vmenv, msg)
-		if writer != nil {
-			writer.Flush()
+		tracer := tracers.NewStateDiffTracer(api.tau.blockchain.Config(), api.tau.ChainDb())
+		if err := core.ApplyTransaction(api.tau.blockchain.Config(), api.tau.blockchain, nil, statedb, block.Header(), tx, tracer); err != nil {
+			return dumps, err
 		}
-		if dump != nil {
-			dump.Close()
-			log.Info("Wrote standard trace", "file", dump.Name())
+		// Generate a unique temporary file to dump the trace into
+		prefix := fmt.Sprintf("block_%#x-%d-%#x-", block.Hash().Bytes()[:4], i, (*tx).Hash().Bytes()[:4])
+
+		dump, err := ioutil.TempFile(os.TempDir(), prefix)
+		if err != nil {
+			return dumps, err
+		}
+		dumps = append(dumps, dump.Name())
+
+		writer := bufio.NewWriter(dump)
+		err = json.NewEncoder(writer).Encode(tracer.Transactions()[0])
+		if err == nil {
+			err = writer.Flush()
 		}
+		dump.Close()
 		if err != nil {
 			return dumps, err
 		}
-		// Finalize the state so any modifications are written to the trie
-		// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
-		statedb.Finalise(vmenv.ChainConfig().IsEIP158(block.Number()))
+		log.Info("Wrote standard trace", "file", dump.Name())
 
+		if failure := tracer.Transactions()[0].Error; failure != "" {
+			return dumps, errors.New(failure)
+		}
 		// If we've traced the transaction we were looking for, abort
 		if (*tx).Hash() == txHash {
 			break
@@ -693,10 +598,10 @@ func (api *PrivateDebugAPI) computeStateDB(block *types.Block, reexec uint64) (*
 	return statedb, nil
 }
 
-// TraceTransaction returns the structured logs created during the execution of EVM
-// and returns them as a JSON object.
-func (api *PrivateDebugAPI) TraceTransaction(ctx context.Context, hash common.Hash, config *TraceConfig) (interface{}, error) {
-	// Retrieve the transaction and assemble its EVM context
+// TraceTransaction returns the state diff, coin flows and records created by
+// the given transaction as a JSON object.
+func (api *PrivateDebugAPI) TraceTransaction(ctx context.Context, hash common.Hash, config *TraceConfig) (*tracers.TxTrace, error) {
+	// Retrieve the transaction and the state it was executed on
 	tx, blockHash, _, index := rawdb.ReadTransaction(api.tau.ChainDb(), hash)
 	if tx == nil {
 		return nil, fmt.Errorf("transaction %#x not found", hash)
@@ -705,113 +610,58 @@ func (api *PrivateDebugAPI) TraceTransaction(ctx context.Context, hash common.Ha
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
-	msg, vmctx, statedb, err := api.computeTxEnv(blockHash, int(index), reexec)
+	block, statedb, err := api.computeTxState(blockHash, int(index), reexec)
 	if err != nil {
 		return nil, err
 	}
 	// Trace the transaction and return
-	return api.traceTx(ctx, msg, vmctx, statedb, config)
+	return api.traceTx(block, tx, int(index), statedb)
 }
 
-// traceTx configures a new tracer according to the provided configuration, and
-// executes the given message in the provided environment. The return value will
-// be tracer dependent.
-func (api *PrivateDebugAPI) traceTx(ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
-	// Assemble the structured logger or the JavaScript tracer
-	var (
-		tracer vm.Tracer
-		err    error
-	)
-	switch {
-	case config != nil && config.Tracer != nil:
-		// Define a meaningful timeout of a single transaction trace
-		timeout := defaultTraceTimeout
-		if config.Timeout != nil {
-			if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-				return nil, err
-			}
-		}
-		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
-			return nil, err
-		}
-		// Handle timeouts and RPC cancellations
-		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
-		go func() {
-			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
-		}()
-		defer cancel()
-
-	case config == nil:
-		tracer = vm.NewStructLogger(nil)
+// traceTx applies the given transaction of a block on top of statedb with the
+// native state-diff tracer. Execution failures are reported in the trace.
+func (api *PrivateDebugAPI) traceTx(block *types.Block, tx *types.Transaction, index int, statedb *state.StateDB) (*tracers.TxTrace, error) {
+	tracer := tracers.NewStateDiffTracer(api.tau.blockchain.Config(), api.tau.ChainDb())
 
-	default:
-		tracer = vm.NewStructLogger(config.LogConfig)
-	}
-	// Run the transaction with tracing enabled.
-	vmenv := vm.NewEVM(vmctx, statedb, api.tau.blockchain.Config())
-
-	ret, gas, failed, err := core.ApplyMessage(vmenv, message)
-	if err != nil {
-		return nil, fmt.Errorf("tracing failed: %v", err)
-	}
-	// Depending on the tracer type, format and return the output
-	switch tracer := tracer.(type) {
-	case *vm.StructLogger:
-		return &tauapi.ExecutionResult{
-			Gas:         gas,
-			Failed:      failed,
-			ReturnValue: fmt.Sprintf("%x", ret),
-			StructLogs:  tauapi.FormatLogs(tracer.StructLogs()),
-		}, nil
-
-	case *tracers.Tracer:
-		return tracer.GetResult()
-
-	default:
-		panic(fmt.Sprintf("bad tracer type %T", tracer))
+	statedb.Prepare((*tx).Hash(), block.Hash(), index)
+	err := core.ApplyTransaction(api.tau.blockchain.Config(), api.tau.blockchain, nil, statedb, block.Header(), tx, tracer)
+	if traces := tracer.Transactions(); len(traces) > 0 {
+		return traces[0], nil
 	}
+	return nil, fmt.Errorf("tracing failed: %v", err)
 }
 
-// computeTxEnv returns the execution environment of a certain transaction.
-func (api *PrivateDebugAPI) computeTxEnv(blockHash common.Hash, txIndex int, reexec uint64) (core.Message, vm.Context, *state.StateDB, error) {
+// computeTxState returns the block containing a certain transaction and the
+// state right before the transaction is executed.
+func (api *PrivateDebugAPI) computeTxState(blockHash common.Hash, txIndex int, reexec uint64) (*types.Block, *state.StateDB, error) {
 	// Create the parent state database
 	block := api.tau.blockchain.GetBlockByHash(blockHash)
 	if block == nil {
-		return nil, vm.Context{}, nil, fmt.Errorf("block %#x not found", blockHash)
+		return nil, nil, fmt.Errorf("block %#x not found", blockHash)
 	}
 	parent := api.tau.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
 	if parent == nil {
-		return nil, vm.Context{}, nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+		return nil, nil, fmt.Errorf("parent %#x not found", block.ParentHash())
 	}
 	statedb, err := api.computeStateDB(parent, reexec)
 	if err != nil {
-		return nil, vm.Context{}, nil, err
+		return nil, nil, err
 	}
 
 	if txIndex == 0 && len(block.Transactions()) == 0 {
-		return nil, vm.Context{}, statedb, nil
+		return block, statedb, nil
 	}
 
 	// Recompute transactions up to the target index.
-	signer := types.MakeSigner(api.tau.blockchain.Config(), block.Number())
-
 	for idx, tx := range block.Transactions() {
-		// Assemble the transaction call message and return if the requested offset
-		msg, _ := (*tx).AsMessage(signer)
-		context := core.NewEVMContext(msg, block.Header(), api.tau.blockchain, nil)
 		if idx == txIndex {
-			return msg, context, statedb, nil
+			return block, statedb, nil
 		}
 		// Not yet the searched for transaction, execute on top of the current state
-		vmenv := vm.NewEVM(context, statedb, api.tau.blockchain.Config())
-		if _, _, _, err := core.ApplyMessage(vmenv, msg); err != nil {
-			return nil, vm.Context{}, nil, fmt.Errorf("transaction %#x failed: %v", (*tx).Hash(), err)
+		statedb.Prepare((*tx).Hash(), block.Hash(), idx)
+		if err := core.ApplyTransaction(api.tau.blockchain.Config(), api.tau.blockchain, nil, statedb, block.Header(), tx, nil); err != nil {
+			return nil, nil, fmt.Errorf("transaction %#x failed: %v", (*tx).Hash(), err)
 		}
-		// Ensure any modifications are committed to the state
-		// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
-		statedb.Finalise(vmenv.ChainConfig().IsEIP158(block.Number()))
 	}
-	return nil, vm.Context{}, nil, fmt.Errorf("transaction index %d out of range for block %#x", txIndex, blockHash)
+	return nil, nil, fmt.Errorf("transaction index %d out of range for block %#x", txIndex, blockHash)
 }
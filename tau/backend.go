@@ -18,6 +18,7 @@
 package tau
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -28,8 +29,10 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/consensus"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/consensus/tauhash"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/chaindir"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/userdb"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/internal/tauapi"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
@@ -37,10 +40,19 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/node"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enr"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/stream"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/traffic"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/downloader"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/feeoracle"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/filters"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+	coreiface "github.com/ipfs/interface-go-ipfs-core"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	libp2ppeer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 )
 
 // Tau implements the Tau full node service.
@@ -50,7 +62,10 @@ type Tau struct {
 	// Channel for shutting down the service
 	shutdownChan chan bool
 
-	server *p2p.Server
+	server     *p2p.Server
+	streams    *stream.Transport // Tau over the libp2p host of the embedded IPFS node, if attached
+	gossip     *gossip           // Chain data gossip over the pubsub of the embedded IPFS node, if attached
+	ipfsCapped bool              // Whether the IPFS data cap is reached, pausing the gossip
 
 	// Handlers
 	txPool          *core.TxPool
@@ -60,10 +75,17 @@ type Tau struct {
 	// DB interfaces
 	chainDb taudb.Database  // Block chain database
 	ipfsDb  taudb.IpfsStore // Block chain IPFS database
+	userDb  *userdb.Userdb  // User preferences, e.g. the followed chains
+
+	chainDir  *chaindir.Directory // Directory of the announced community chains
+	chainDisc *chainDiscovery     // Discovery of the peers of the followed chains
+	ipldPeers *ipldTracker        // Tracker of the IPFS peers following the chains
 
 	eventMux       *event.TypeMux
 	engine         consensus.Engine
 	accountManager *accounts.Manager
+	traffic        *traffic.Accountant // Traffic accountant of the node, backing the data caps
+	ipfsTraffic    *ipfsTraffic        // Accounting of the embedded IPFS node traffic, if attached
 
 	APIBackend *TauAPIBackend
 
@@ -114,8 +136,10 @@ func New(ctx *node.ServiceContext, config *Config) (*Tau, error) {
 		config:         config,
 		chainDb:        chainDb,
 		ipfsDb:         ipfsDb,
+		userDb:         userdb.NewUserdb(chainDb),
 		eventMux:       ctx.EventMux,
 		accountManager: ctx.AccountManager,
+		traffic:        ctx.Traffic,
 		engine:         CreateConsensusEngine(ctx, chainConfig, &config.Tauash),
 		shutdownChan:   make(chan bool),
 		networkID:      config.NetworkId,
@@ -137,6 +161,7 @@ func New(ctx *node.ServiceContext, config *Config) (*Tau, error) {
 			TrieDirtyLimit:      config.TrieDirtyCache,
 			TrieDirtyDisabled:   config.NoPruning,
 			TrieTimeLimit:       config.TrieTimeout,
+			TxLookupLimit:       config.TxLookupLimit,
 		}
 	)
 
@@ -152,6 +177,8 @@ func New(ctx *node.ServiceContext, config *Config) (*Tau, error) {
 		rawdb.WriteChainConfig(chainDb, genesisHash, chainConfig)
 	}
 
+	tau.chainDir = chaindir.New(chainDb, tau.blockchain, tau.userDb)
+
 	log.Info("New Tx Pool")
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = ctx.ResolvePath(config.TxPool.Journal)
@@ -164,12 +191,22 @@ func New(ctx *node.ServiceContext, config *Config) (*Tau, error) {
 	if checkpoint == nil {
 		checkpoint = params.TrustedCheckpoints[genesisHash]
 	}
-	if tau.protocolManager, err = NewProtocolManager(chainConfig, checkpoint, config.SyncMode, config.NetworkId, tau.eventMux, tau.txPool, tau.engine, tau.blockchain, ipfsDb, cacheLimit, config.Whitelist); err != nil {
+	if tau.protocolManager, err = NewProtocolManager(chainConfig, checkpoint, config.SyncMode, config.NetworkId, tau.eventMux, tau.txPool, tau.engine, tau.blockchain, ipfsDb, cacheLimit, tau.traffic, config.Whitelist); err != nil {
 		return nil, err
 	}
+	tau.ipldPeers = newIPLDTracker(tau.userDb, tau.blockchain, tau.protocolManager.chainID)
+	tau.protocolManager.ipldPeers = tau.ipldPeers
+	tau.protocolManager.chains = tau.userDb
+
 	tau.miner = miner.New(tau, &config.Miner, chainConfig, tau.EventMux(), tau.engine, tau.isLocalBlock)
+	tau.miner.Scheduler().SetSyncController(tau.protocolManager)
 
-	tau.APIBackend = &TauAPIBackend{ctx.ExtRPCEnabled(), tau}
+	tau.APIBackend = &TauAPIBackend{ctx.ExtRPCEnabled(), tau, nil}
+	oracleParams := config.FeeOracle
+	if oracleParams.Default == nil {
+		oracleParams.Default = config.Miner.FeeFloor
+	}
+	tau.APIBackend.oracle = feeoracle.NewOracle(tau.APIBackend, oracleParams)
 
 	return tau, nil
 }
@@ -213,6 +250,25 @@ func (s *Tau) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   downloader.NewPublicDownloaderAPI(s.protocolManager.downloader, s.eventMux),
 			Public:    true,
+		}, {
+			Namespace: "tau",
+			Version:   "1.0",
+			Service:   filters.NewPublicFilterAPI(s.APIBackend),
+			Public:    true,
+		}, {
+			Namespace: "tau",
+			Version:   "1.0",
+			Service:   chaindir.NewPublicChainDirectoryAPI(s.chainDir),
+			Public:    true,
+		}, {
+			Namespace: "tau",
+			Version:   "1.0",
+			Service:   chaindir.NewPrivateChainDirectoryAPI(s.chainDir),
+		}, {
+			Namespace: "tau",
+			Version:   "1.0",
+			Service:   userdb.NewPublicUserdbAPI(s.userDb),
+			Public:    true,
 		}, {
 			Namespace: "miner",
 			Version:   "1.0",
@@ -222,6 +278,14 @@ func (s *Tau) APIs() []rpc.API {
 			Namespace: "admin",
 			Version:   "1.0",
 			Service:   NewPrivateAdminAPI(s),
+		}, {
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   traffic.NewPrivateTrafficAPI(s.traffic),
+		}, {
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   userdb.NewPrivateUserdbAPI(s.userDb),
 		}, {
 			Namespace: "debug",
 			Version:   "1.0",
@@ -380,6 +444,9 @@ func (s *Tau) Downloader() *downloader.Downloader { return s.protocolManager.dow
 func (s *Tau) Synced() bool                       { return atomic.LoadUint32(&s.protocolManager.acceptTxs) == 1 }
 func (s *Tau) ArchiveMode() bool                  { return s.config.NoPruning }
 
+func (s *Tau) UserDb() *userdb.Userdb              { return s.userDb }
+func (s *Tau) ChainDirectory() *chaindir.Directory { return s.chainDir }
+
 // Protocols implements node.Service, returning all the currently configured
 // network protocols to start.
 func (s *Tau) Protocols() []p2p.Protocol {
@@ -387,6 +454,7 @@ func (s *Tau) Protocols() []p2p.Protocol {
 	for i, vsn := range ProtocolVersions {
 		protos[i] = s.protocolManager.makeProtocol(vsn)
 		protos[i].Attributes = []enr.Entry{s.currentTauEntry()}
+		protos[i].DialFilter = chainDialFilter(s.followedChains)
 	}
 	return protos
 }
@@ -394,6 +462,10 @@ func (s *Tau) Protocols() []p2p.Protocol {
 // Start implements node.Service, starting all internal goroutines needed by the
 // Tau protocol implementation.
 func (s *Tau) Start(srvr *p2p.Server) error {
+	s.lock.Lock()
+	s.server = srvr
+	s.lock.Unlock()
+
 	s.startTauEntryUpdate(srvr.LocalNode())
 
 	// Start the RPC service
@@ -402,14 +474,184 @@ func (s *Tau) Start(srvr *p2p.Server) error {
 	// Figure out a max peers count based on the server limits
 	maxPeers := srvr.MaxPeers
 
+	// Score peers with the server's reputation tracker, shared with the dialer
+	s.protocolManager.peers.SetReputation(srvr.Reputation())
+
+	// Persist the traffic totals and pause the chain traffic and the gossip once
+	// the data caps are reached
+	s.traffic.Start(s.userDb)
+	s.traffic.OnCap(traffic.Tau, s.protocolManager.SetCapped)
+	s.traffic.OnCap(traffic.IPFS, s.setIPFSCapped)
+
 	// Start the networking layer and the light server if requested
 	s.protocolManager.Start(maxPeers)
+
+	// Start indexing the community chain announcements
+	s.chainDir.Start()
+
+	// Record the IPFS peers following the chains from the blocks and handshakes
+	s.ipldPeers.start()
+
+	// Advertise the followed chains and search their peers over discv5, if enabled
+	var topics topicNetwork
+	if srvr.DiscV5 != nil {
+		topics = srvr.DiscV5
+	}
+	s.chainDisc = newChainDiscovery(topics, srvr, srvr.LocalNode(), s.followedChains, s.protocolManager.peers.ChainLen)
+	s.chainDisc.start()
+	return nil
+}
+
+// followedChains returns the chain served by the local blockchain followed by
+// the community chains followed by the user.
+func (s *Tau) followedChains() []common.ChainID {
+	chains := []common.ChainID{s.protocolManager.chainID}
+	for _, chain := range s.userDb.FollowedChains() {
+		if chain != s.protocolManager.chainID {
+			chains = append(chains, chain)
+		}
+	}
+	return chains
+}
+
+// AttachLibp2pHost runs the tau protocol over the streams of a libp2p host,
+// typically the one of the embedded IPFS node, in addition to devp2p. Peers are
+// reached through the relays and hole punching of the host, and the known IPLD
+// peers of the followed chains are dialed by their IPFS peer id.
+func (s *Tau) AttachLibp2pHost(h host.Host) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.server == nil {
+		return errors.New("tau service not started")
+	}
+	if s.streams != nil {
+		return errors.New("libp2p host already attached")
+	}
+	s.streams = stream.New(h, s.server, s.dialIPLDPeers)
+	s.streams.Start()
+	s.ipldPeers.setHost(h)
+	return nil
+}
+
+// AttachPubSub gossips new blocks, transactions and relays on the per-chain
+// topics of the pubsub of the embedded IPFS node, a fallback propagation path
+// for nodes devp2p can't reach. Gossiped blocks are shared through the given
+// IPFS block API and announced by CID.
+func (s *Tau) AttachPubSub(h host.Host, ps *pubsub.PubSub, blocks coreiface.BlockAPI) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.server == nil {
+		return errors.New("tau service not started")
+	}
+	if s.gossip != nil {
+		return errors.New("pubsub already attached")
+	}
+	addrs := func() []string {
+		var addrs []string
+		for _, addr := range h.Addrs() {
+			addrs = append(addrs, addr.String())
+		}
+		return addrs
+	}
+	g := newGossip(s.protocolManager, ps, ipfsGossipStore{blocks}, accountSigner{s}, h.ID(), s.userDb, s.followedChains, addrs)
+	g.setCapped(s.ipfsCapped)
+	if err := g.start(); err != nil {
+		g.stop()
+		return err
+	}
+	s.gossip = g
+	return nil
+}
+
+// accountSigner signs the gossip messages with the keys of the unlocked local
+// accounts, publishing the blocks and relays with the tauerbase.
+type accountSigner struct {
+	tau *Tau
+}
+
+// Account returns the tauerbase.
+func (s accountSigner) Account() (common.Address, error) {
+	return s.tau.Tauerbase()
+}
+
+// SignData signs the hash of the data with the wallet holding the given account.
+func (s accountSigner) SignData(account common.Address, data []byte) ([]byte, error) {
+	acc := accounts.Account{Address: account}
+	wallet, err := s.tau.accountManager.Find(acc)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.SignData(acc, accounts.MimetypeTextPlain, data)
+}
+
+// AttachBandwidthReporter accounts the traffic of the embedded IPFS node from the
+// bandwidth counters of its libp2p host. The tau streams multiplexed over the
+// host are left out, as they are accounted with the tau protocol already.
+func (s *Tau) AttachBandwidthReporter(reporter metrics.Reporter) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.server == nil {
+		return errors.New("tau service not started")
+	}
+	if s.ipfsTraffic != nil {
+		return errors.New("bandwidth reporter already attached")
+	}
+	s.ipfsTraffic = newIPFSTraffic(reporter, s.traffic)
+	s.ipfsTraffic.start()
 	return nil
 }
 
+// setIPFSCapped pauses or resumes the traffic the node causes over the embedded
+// IPFS node, the gossip and its block transfers, as the IPFS data cap is
+// reached or lifted.
+func (s *Tau) setIPFSCapped(capped bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ipfsCapped = capped
+	if s.gossip != nil {
+		s.gossip.setCapped(capped)
+	}
+}
+
+// dialIPLDPeers returns the decodable IPFS peer ids of the known IPLD peers.
+func (s *Tau) dialIPLDPeers() []libp2ppeer.ID {
+	var ids []libp2ppeer.ID
+	for _, id := range s.userDb.IPLDPeers() {
+		decoded, err := libp2ppeer.IDB58Decode(string(id))
+		if err != nil {
+			log.Debug("Skipping invalid IPLD peer", "id", id, "err", err)
+			continue
+		}
+		ids = append(ids, decoded)
+	}
+	return ids
+}
+
 // Stop implements node.Service, terminating all internal goroutines used by the
 // Tau protocol.
 func (s *Tau) Stop() error {
+	s.lock.Lock()
+	if s.streams != nil {
+		s.streams.Stop()
+		s.streams = nil
+	}
+	if s.gossip != nil {
+		s.gossip.stop()
+		s.gossip = nil
+	}
+	if s.ipfsTraffic != nil {
+		s.ipfsTraffic.stop()
+		s.ipfsTraffic = nil
+	}
+	s.lock.Unlock()
+
+	s.chainDisc.stop()
+	s.chainDir.Stop()
+	s.ipldPeers.stop()
 	s.blockchain.Stop()
 	s.engine.Close()
 	s.protocolManager.Stop()
@@ -417,6 +659,7 @@ func (s *Tau) Stop() error {
 	s.miner.Stop()
 	s.eventMux.Stop()
 
+	s.traffic.Stop()
 	s.chainDb.Close()
 	close(s.shutdownChan)
 	return nil
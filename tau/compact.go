@@ -0,0 +1,236 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/reputation"
+)
+
+const (
+	compactTimeout    = 3 * time.Second // Time allowed for the missing transactions of a compact block to arrive
+	maxCompactPending = 16              // Number of compact blocks awaiting missing transactions at once
+	maxCompactSent    = 32              // Number of propagated blocks kept to serve missing transactions from
+	maxCompactTxs     = 16384           // Maximum number of transactions in a compact block
+)
+
+var (
+	errCompactCount = errors.New("missing transaction count mismatch")
+	errCompactRoot  = errors.New("transaction root mismatch")
+)
+
+// shortTxID returns the short id of a transaction within a block. The ids are
+// salted with the block hash so that colliding transactions can't be crafted
+// in advance.
+func shortTxID(block common.Hash, tx common.Hash) uint64 {
+	return binary.BigEndian.Uint64(crypto.Keccak256(block[:], tx[:])[:8])
+}
+
+// newCompactBlock creates the compact form of a block, prefilling the
+// transactions the receiver isn't known to have.
+func newCompactBlock(block *types.Block, td *big.Int, known func(common.Hash) bool) *compactBlockData {
+	var (
+		hash = block.Hash()
+		txs  = block.Transactions()
+		data = &compactBlockData{
+			Header:   block.Header(),
+			TD:       td,
+			ShortIDs: make([]uint64, len(txs)),
+		}
+	)
+	for i, tx := range txs {
+		txhash := (*tx).Hash()
+		data.ShortIDs[i] = shortTxID(hash, txhash)
+		if !known(txhash) {
+			data.Prefilled = append(data.Prefilled, prefilledTx{Index: uint64(i), Tx: tx})
+		}
+	}
+	return data
+}
+
+// compactBlock is a block being reconstructed from its compact form.
+type compactBlock struct {
+	peer    *peer                // Peer that propagated the block
+	header  *types.Header        // Header of the block
+	txs     []*types.Transaction // Transactions of the block, nil where missing
+	missing []uint64             // Indexes of the missing transactions
+	timer   *time.Timer          // Timer falling back to a full retrieval
+}
+
+// newCompactReconstruction fills a compact block with the prefilled transactions
+// and the ones found by their short id in the given pool index.
+func newCompactReconstruction(p *peer, request *compactBlockData, pool map[uint64]*types.Transaction) *compactBlock {
+	c := &compactBlock{
+		peer:   p,
+		header: request.Header,
+		txs:    make([]*types.Transaction, len(request.ShortIDs)),
+	}
+	for _, prefilled := range request.Prefilled {
+		c.txs[prefilled.Index] = prefilled.Tx
+	}
+	for i, id := range request.ShortIDs {
+		if c.txs[i] != nil {
+			continue
+		}
+		if tx := pool[id]; tx != nil {
+			c.txs[i] = tx
+		} else {
+			c.missing = append(c.missing, uint64(i))
+		}
+	}
+	return c
+}
+
+// fill completes the block with the retrieved missing transactions.
+func (c *compactBlock) fill(txs []*types.Transaction) error {
+	if len(txs) != len(c.missing) {
+		return errCompactCount
+	}
+	for i, index := range c.missing {
+		if txs[i] == nil {
+			return errCompactCount
+		}
+		c.txs[index] = txs[i]
+	}
+	c.missing = nil
+	return nil
+}
+
+// assemble builds the block, verifying that the transactions match the header,
+// which they may not on short id collisions.
+func (c *compactBlock) assemble() (*types.Block, error) {
+	if types.DeriveSha(types.Transactions(c.txs)) != c.header.TxHash {
+		return nil, errCompactRoot
+	}
+	return types.NewBlockWithHeader(c.header).WithBody(c.txs), nil
+}
+
+// poolIndex indexes the pending transactions of the pool by their short id
+// within the given block.
+func (pm *ProtocolManager) poolIndex(block common.Hash) map[uint64]*types.Transaction {
+	index := make(map[uint64]*types.Transaction)
+	pending, _ := pm.txpool.Pending()
+	for _, txs := range pending {
+		for _, tx := range txs {
+			index[shortTxID(block, (*tx).Hash())] = tx
+		}
+	}
+	return index
+}
+
+// handleCompactBlock reconstructs a propagated compact block from the local pool,
+// requesting the missing transactions from the propagating peer.
+func (pm *ProtocolManager) handleCompactBlock(p *peer, request *compactBlockData) error {
+	hash := request.Header.Hash()
+	if pm.blockchain.HasBlock(hash, request.Header.Number.Uint64()) {
+		return nil
+	}
+	c := newCompactReconstruction(p, request, pm.poolIndex(hash))
+	if len(c.missing) == 0 {
+		pm.deliverCompact(c)
+		return nil
+	}
+	pm.compactLock.Lock()
+	if _, ok := pm.compactPending[hash]; ok {
+		// Already reconstructing the block from another peer
+		pm.compactLock.Unlock()
+		return nil
+	}
+	if len(pm.compactPending) >= maxCompactPending {
+		pm.compactLock.Unlock()
+		pm.fallbackCompact(c)
+		return nil
+	}
+	pm.compactPending[hash] = c
+	c.timer = time.AfterFunc(compactTimeout, func() { pm.expireCompact(hash) })
+	pm.compactLock.Unlock()
+
+	p.Log().Trace("Requesting missing block transactions", "hash", hash, "missing", len(c.missing), "total", len(c.txs))
+	return p.RequestBlockTxs(hash, c.missing)
+}
+
+// handleBlockTxs completes a compact block with the retrieved transactions.
+func (pm *ProtocolManager) handleBlockTxs(p *peer, response *blockTxsData) {
+	pm.compactLock.Lock()
+	c := pm.compactPending[response.Hash]
+	if c == nil || c.peer != p {
+		pm.compactLock.Unlock()
+		pm.reportPeer(p.id, reputation.UselessAnnounce)
+		return
+	}
+	delete(pm.compactPending, response.Hash)
+	c.timer.Stop()
+	pm.compactLock.Unlock()
+
+	if err := c.fill(response.Txs); err != nil {
+		p.Log().Debug("Invalid block transactions", "hash", response.Hash, "err", err)
+		pm.reportPeer(p.id, reputation.UselessAnnounce)
+		pm.fallbackCompact(c)
+		return
+	}
+	pm.deliverCompact(c)
+}
+
+// expireCompact falls back to a full retrieval of a compact block whose missing
+// transactions didn't arrive in time.
+func (pm *ProtocolManager) expireCompact(hash common.Hash) {
+	pm.compactLock.Lock()
+	c := pm.compactPending[hash]
+	delete(pm.compactPending, hash)
+	pm.compactLock.Unlock()
+
+	if c != nil {
+		pm.reportPeer(c.peer.id, reputation.StalledRequest)
+		pm.fallbackCompact(c)
+	}
+}
+
+// deliverCompact schedules a reconstructed block for import, or falls back to a
+// full retrieval if the reconstruction is wrong.
+func (pm *ProtocolManager) deliverCompact(c *compactBlock) {
+	block, err := c.assemble()
+	if err != nil {
+		c.peer.Log().Debug("Failed to reconstruct compact block", "hash", c.header.Hash(), "err", err)
+		pm.fallbackCompact(c)
+		return
+	}
+	block.ReceivedAt = time.Now()
+	block.ReceivedFrom = c.peer
+	pm.fetcher.Enqueue(c.peer.id, block)
+}
+
+// fallbackCompact schedules the retrieval of the full body of a compact block
+// through the announcement path of the fetcher.
+func (pm *ProtocolManager) fallbackCompact(c *compactBlock) {
+	pm.fetcher.Notify(c.peer.id, c.header.Hash(), c.header.Number.Uint64(), time.Now(), c.peer.RequestOneHeader, c.peer.RequestBodies)
+}
+
+// compactSource returns a block recently propagated or known locally to serve
+// the missing transactions of its compact form from.
+func (pm *ProtocolManager) compactSource(hash common.Hash) *types.Block {
+	if block, ok := pm.compactSent.Get(hash); ok {
+		return block.(*types.Block)
+	}
+	return pm.blockchain.GetBlockByHash(hash)
+}
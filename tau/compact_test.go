@@ -0,0 +1,125 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+)
+
+// newCompactTestBlock creates a block with the given number of transactions.
+func newCompactTestBlock(n int) *types.Block {
+	txs := make([]*types.Transaction, n)
+	for i := range txs {
+		txs[i] = newTestTransaction(testBankKey, uint64(i), 100)
+	}
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		Difficulty: big.NewInt(1),
+		TxHash:     types.DeriveSha(types.Transactions(txs)),
+	}
+	return types.NewBlockWithHeader(header).WithBody(txs)
+}
+
+// Tests that a compact block is rebuilt from the pool and the prefilled
+// transactions, requesting only the ones found in neither.
+func TestCompactBlockReconstruction(t *testing.T) {
+	block := newCompactTestBlock(8)
+	txs := block.Transactions()
+
+	// The receiver is known to have the first half of the transactions, and has
+	// all but the last of them in its pool
+	known := make(map[common.Hash]bool)
+	for _, tx := range txs[:4] {
+		known[(*tx).Hash()] = true
+	}
+	compact := newCompactBlock(block, big.NewInt(1), func(hash common.Hash) bool { return known[hash] })
+	if len(compact.Prefilled) != 4 {
+		t.Fatalf("prefilled transaction count mismatch: have %d, want %d", len(compact.Prefilled), 4)
+	}
+	// Check that the packet survives the network
+	blob, err := rlp.EncodeToBytes(compact)
+	if err != nil {
+		t.Fatalf("failed to encode compact block: %v", err)
+	}
+	var request compactBlockData
+	if err := rlp.DecodeBytes(blob, &request); err != nil {
+		t.Fatalf("failed to decode compact block: %v", err)
+	}
+	if err := request.sanityCheck(); err != nil {
+		t.Fatalf("compact block failed sanity check: %v", err)
+	}
+	pool := make(map[uint64]*types.Transaction)
+	for _, tx := range txs[:2] {
+		pool[shortTxID(block.Hash(), (*tx).Hash())] = tx
+	}
+	c := newCompactReconstruction(nil, &request, pool)
+	if len(c.missing) != 2 || c.missing[0] != 2 || c.missing[1] != 3 {
+		t.Fatalf("missing transactions mismatch: have %v, want [2 3]", c.missing)
+	}
+	if err := c.fill(txs[2:3]); err != errCompactCount {
+		t.Fatalf("short delivery error mismatch: have %v, want %v", err, errCompactCount)
+	}
+	if err := c.fill(txs[2:4]); err != nil {
+		t.Fatalf("failed to fill missing transactions: %v", err)
+	}
+	rebuilt, err := c.assemble()
+	if err != nil {
+		t.Fatalf("failed to assemble block: %v", err)
+	}
+	if rebuilt.Hash() != block.Hash() || len(rebuilt.Transactions()) != len(txs) {
+		t.Fatalf("rebuilt block mismatch")
+	}
+}
+
+// Tests that a block rebuilt with wrong transactions, as on a short id collision,
+// is rejected to be retrieved in full.
+func TestCompactBlockMismatch(t *testing.T) {
+	block := newCompactTestBlock(2)
+	compact := newCompactBlock(block, big.NewInt(1), func(common.Hash) bool { return true })
+
+	other := newTestTransaction(testBankKey, 100, 100)
+	c := newCompactReconstruction(nil, compact, nil)
+	if err := c.fill([]*types.Transaction{block.Transactions()[0], other}); err != nil {
+		t.Fatalf("failed to fill missing transactions: %v", err)
+	}
+	if _, err := c.assemble(); err != errCompactRoot {
+		t.Fatalf("assembly error mismatch: have %v, want %v", err, errCompactRoot)
+	}
+}
+
+// Tests that malformed compact blocks are rejected.
+func TestCompactBlockSanity(t *testing.T) {
+	block := newCompactTestBlock(3)
+	tests := []func(*compactBlockData){
+		func(c *compactBlockData) { c.Prefilled[0].Index = 3 },
+		func(c *compactBlockData) { c.Prefilled[1].Index = 0 },
+		func(c *compactBlockData) { c.Prefilled[0].Tx = nil },
+		func(c *compactBlockData) { c.TD = new(big.Int).Lsh(common.Big1, 101) },
+	}
+	for i, corrupt := range tests {
+		compact := newCompactBlock(block, big.NewInt(1), func(common.Hash) bool { return false })
+		corrupt(compact)
+		if err := compact.sanityCheck(); err == nil {
+			t.Errorf("test %d: malformed compact block accepted", i)
+		}
+	}
+}
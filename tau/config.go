@@ -30,6 +30,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/miner"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/downloader"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/feeoracle"
 )
 
 // DefaultConfig contains default settings for use on the Tau main net.
@@ -51,7 +52,8 @@ var DefaultConfig = Config{
 		FeeFloor: big.NewInt(params.GWei),
 		Recommit: 3 * time.Second,
 	},
-	TxPool: core.DefaultTxPoolConfig,
+	TxPool:    core.DefaultTxPoolConfig,
+	FeeOracle: feeoracle.DefaultConfig,
 }
 
 func init() {
@@ -89,6 +91,8 @@ type Config struct {
 	NoPruning  bool // Whtauer to disable pruning and flush everything to disk
 	NoPrefetch bool // Whtauer to disable prefetching and only load state on demand
 
+	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
+
 	// Whitelist of required block number -> hash values to accept
 	Whitelist map[uint64]common.Hash `toml:"-"`
 
@@ -111,6 +115,9 @@ type Config struct {
 	// Transaction pool options
 	TxPool core.TxPoolConfig
 
+	// Fee oracle options
+	FeeOracle feeoracle.Config
+
 	// Miscellaneous options
 	DocRoot string `toml:"-"`
 
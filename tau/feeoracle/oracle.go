@@ -0,0 +1,259 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package feeoracle suggests transaction fees from the fees recently paid on
+// chain and the backlog of the transaction pool.
+package feeoracle
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+)
+
+// maxChainFee is the largest fee a chain announcement can pay, as its fee is
+// encoded in a single byte.
+var maxChainFee = big.NewInt(255)
+
+// Config are the configuration parameters of the fee oracle.
+type Config struct {
+	Blocks  int      // Number of recent blocks to sample
+	Default *big.Int `toml:",omitempty"` // Minimum fee ever suggested
+}
+
+// DefaultConfig samples the last 20 blocks.
+var DefaultConfig = Config{
+	Blocks: 20,
+}
+
+// urgency is a suggestion level: the percentile of the recently included fees
+// to match and the number of blocks the transaction should be included
+// within given the current pool backlog.
+type urgency struct {
+	percentile int
+	blocks     int
+}
+
+var (
+	slow   = urgency{percentile: 30, blocks: 10}
+	normal = urgency{percentile: 60, blocks: 3}
+	fast   = urgency{percentile: 90, blocks: 1}
+)
+
+// Backend is the chain and pool access needed by the oracle.
+type Backend interface {
+	HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error)
+	GetPoolTransactions() (types.Transactions, error)
+}
+
+// Suggestion is the fee suggested for a transaction of a given kind at several
+// urgency levels. Miners include transactions by their absolute fee, regardless
+// of their size, so the suggestions are absolute fees too.
+type Suggestion struct {
+	Kind   types.TxKind `json:"kind"`
+	Slow   *hexutil.Big `json:"slow"`   // Included within about 10 blocks
+	Normal *hexutil.Big `json:"normal"` // Included within about 3 blocks
+	Fast   *hexutil.Big `json:"fast"`   // Included in the next block
+
+	// Replacement is the fee to resubmit a stuck pending transaction with,
+	// only set for replacement suggestions.
+	Replacement *hexutil.Big `json:"replacement,omitempty"`
+}
+
+// sample is the fee paid by an included transaction.
+type sample struct {
+	kind types.TxKind
+	fee  *big.Int
+}
+
+// Oracle suggests fees based on the transactions included in recent blocks
+// and the ones waiting in the pool.
+type Oracle struct {
+	backend Backend
+	blocks  int
+	floor   *big.Int
+
+	lock     sync.Mutex
+	head     common.Hash // Head the cached samples were collected at
+	samples  []sample    // Samples of the recent blocks, sorted by fee
+	capacity int         // Most transactions seen in a sampled block
+}
+
+// NewOracle creates a fee oracle sampling the chain and pool of the backend.
+func NewOracle(backend Backend, config Config) *Oracle {
+	blocks := config.Blocks
+	if blocks < 1 {
+		blocks = 1
+	}
+	floor := new(big.Int)
+	if config.Default != nil {
+		floor.Set(config.Default)
+	}
+	return &Oracle{backend: backend, blocks: blocks, floor: floor}
+}
+
+// SuggestFee returns the suggested fees of a transaction of the given kind.
+func (o *Oracle) SuggestFee(ctx context.Context, kind types.TxKind) (*Suggestion, error) {
+	samples, capacity, err := o.recent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := o.backend.GetPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+	// Prefer the samples of the same kind, fall back to all of them
+	if own := filterKind(samples, kind); len(own) > 0 {
+		samples = own
+	}
+	backlog := poolFees(pending)
+
+	suggest := func(level urgency) *hexutil.Big {
+		fee := new(big.Int)
+		if len(samples) > 0 {
+			fee.Set(samples[(len(samples)-1)*level.percentile/100].fee)
+		}
+		// If the pool holds more transactions than fit in the blocks until
+		// the target, outbid the last one that would still be included
+		if ahead := capacity * level.blocks; capacity > 0 && ahead <= len(backlog) && backlog[ahead-1].Cmp(fee) >= 0 {
+			fee.Add(backlog[ahead-1], common.Big1)
+		}
+		if fee.Cmp(o.floor) < 0 {
+			fee.Set(o.floor)
+		}
+		return (*hexutil.Big)(capFee(kind, fee))
+	}
+	suggestion := &Suggestion{
+		Kind:   kind,
+		Slow:   suggest(slow),
+		Normal: suggest(normal),
+		Fast:   suggest(fast),
+	}
+	// Keep the levels monotonic
+	if suggestion.Normal.ToInt().Cmp(suggestion.Slow.ToInt()) < 0 {
+		suggestion.Normal = suggestion.Slow
+	}
+	if suggestion.Fast.ToInt().Cmp(suggestion.Normal.ToInt()) < 0 {
+		suggestion.Fast = suggestion.Normal
+	}
+	return suggestion, nil
+}
+
+// SuggestReplacement returns the suggested fees for resubmitting the given
+// pending transaction. The replacement pays at least the fast fee and always
+// more than the original, which the pool requires to accept it, unless the
+// original already pays the highest fee its kind can encode.
+func (o *Oracle) SuggestReplacement(ctx context.Context, tx *types.Transaction) (*Suggestion, error) {
+	kind := types.KindOf(*tx)
+	suggestion, err := o.SuggestFee(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	replacement := new(big.Int).Add((*tx).Fee(), common.Big1)
+	if fast := suggestion.Fast.ToInt(); fast.Cmp(replacement) > 0 {
+		replacement.Set(fast)
+	}
+	suggestion.Replacement = (*hexutil.Big)(capFee(kind, replacement))
+	return suggestion, nil
+}
+
+// capFee limits a fee to the highest one a transaction of the given kind can
+// pay.
+func capFee(kind types.TxKind, fee *big.Int) *big.Int {
+	if kind == types.NewChainTxKind && fee.Cmp(maxChainFee) > 0 {
+		fee.Set(maxChainFee)
+	}
+	return fee
+}
+
+// recent returns the fee samples of the recent blocks, sorted by fee, and the
+// largest number of transactions a sampled block contained. The samples are
+// cached until the head changes.
+func (o *Oracle) recent(ctx context.Context) ([]sample, int, error) {
+	head, err := o.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+	hash := head.Hash()
+
+	o.lock.Lock()
+	if o.head == hash {
+		samples, capacity := o.samples, o.capacity
+		o.lock.Unlock()
+		return samples, capacity, nil
+	}
+	o.lock.Unlock()
+
+	var (
+		samples  []sample
+		capacity int
+		number   = head.Number.Uint64()
+	)
+	for i := 0; i < o.blocks && uint64(i) <= number; i++ {
+		block, err := o.backend.BlockByNumber(ctx, rpc.BlockNumber(number-uint64(i)))
+		if err != nil {
+			return nil, 0, err
+		}
+		if block == nil {
+			break
+		}
+		txs := block.Transactions()
+		if len(txs) > capacity {
+			capacity = len(txs)
+		}
+		for _, tx := range txs {
+			samples = append(samples, sample{
+				kind: types.KindOf(*tx),
+				fee:  (*tx).Fee(),
+			})
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].fee.Cmp(samples[j].fee) < 0 })
+
+	o.lock.Lock()
+	o.head, o.samples, o.capacity = hash, samples, capacity
+	o.lock.Unlock()
+	return samples, capacity, nil
+}
+
+// filterKind returns the samples of the given kind, preserving their order.
+func filterKind(samples []sample, kind types.TxKind) []sample {
+	var own []sample
+	for _, s := range samples {
+		if s.kind == kind {
+			own = append(own, s)
+		}
+	}
+	return own
+}
+
+// poolFees returns the fees of the pending transactions, highest first, in
+// the order miners include them.
+func poolFees(txs types.Transactions) []*big.Int {
+	fees := make([]*big.Int, len(txs))
+	for i, tx := range txs {
+		fees[i] = (*tx).Fee()
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i].Cmp(fees[j]) > 0 })
+	return fees
+}
@@ -0,0 +1,184 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package feeoracle
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+)
+
+// testBackend serves a fixed chain and pool.
+type testBackend struct {
+	blocks  []*types.Block
+	pending types.Transactions
+}
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	block, _ := b.BlockByNumber(ctx, number)
+	return block.Header(), nil
+}
+
+func (b *testBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	if number == rpc.LatestBlockNumber {
+		return b.blocks[len(b.blocks)-1], nil
+	}
+	if int(number) >= len(b.blocks) {
+		return nil, nil
+	}
+	return b.blocks[number], nil
+}
+
+func (b *testBackend) GetPoolTransactions() (types.Transactions, error) {
+	return b.pending, nil
+}
+
+func newTransfer(nonce uint64, fee int64) *types.Transaction {
+	var (
+		from = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		to   = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	)
+	tx := types.Transaction(types.NewTransferTransaction(types.OneByte{1}, types.OneByte{0}, nil, nonce, 0, big.NewInt(fee), from, to, big.NewInt(1)))
+	return &tx
+}
+
+// newTestBackend creates a chain of blocks each including transfers paying the
+// given fees.
+func newTestBackend(blocks int, fees ...int64) *testBackend {
+	backend := new(testBackend)
+	for i := 0; i < blocks; i++ {
+		var txs []*types.Transaction
+		for j, fee := range fees {
+			txs = append(txs, newTransfer(uint64(i*len(fees)+j), fee))
+		}
+		header := &types.Header{Number: big.NewInt(int64(i)), Time: uint64(i)}
+		backend.blocks = append(backend.blocks, types.NewBlock(header, txs))
+	}
+	return backend
+}
+
+func TestSuggestFee(t *testing.T) {
+	backend := newTestBackend(5, 1000, 2000, 3000, 4000, 5000)
+	oracle := NewOracle(backend, Config{Blocks: 3, Default: big.NewInt(100)})
+
+	suggestion, err := oracle.SuggestFee(context.Background(), types.TransferTxKind)
+	if err != nil {
+		t.Fatalf("failed to suggest fee: %v", err)
+	}
+	var (
+		slow   = suggestion.Slow.ToInt()
+		normal = suggestion.Normal.ToInt()
+		fast   = suggestion.Fast.ToInt()
+	)
+	if slow.Cmp(normal) > 0 || normal.Cmp(fast) > 0 {
+		t.Errorf("levels not monotonic: slow %v, normal %v, fast %v", slow, normal, fast)
+	}
+	// Suggestions are absolute fees picked from the paid ones
+	for _, fee := range []*big.Int{slow, normal, fast} {
+		if fee.Int64()%1000 != 0 || fee.Cmp(big.NewInt(1000)) < 0 || fee.Cmp(big.NewInt(5000)) > 0 {
+			t.Errorf("suggestion %v not one of the paid fees", fee)
+		}
+	}
+}
+
+// Tests that chain announcements are never suggested more than their single
+// byte fee can encode.
+func TestSuggestFeeChainCap(t *testing.T) {
+	backend := newTestBackend(3, 1000, 2000)
+	oracle := NewOracle(backend, Config{Blocks: 3})
+
+	suggestion, err := oracle.SuggestFee(context.Background(), types.NewChainTxKind)
+	if err != nil {
+		t.Fatalf("failed to suggest fee: %v", err)
+	}
+	for _, fee := range []*big.Int{suggestion.Slow.ToInt(), suggestion.Normal.ToInt(), suggestion.Fast.ToInt()} {
+		if fee.Cmp(maxChainFee) != 0 {
+			t.Errorf("chain fee mismatch: have %v, want %v", fee, maxChainFee)
+		}
+	}
+	var tx types.Transaction = types.NewNewChainTransaction(types.OneByte{1}, types.OneByte{0}, nil, 0, 0, types.OneByte{255}, common.Address{}, nil, nil, nil, nil)
+	suggestion, err = oracle.SuggestReplacement(context.Background(), &tx)
+	if err != nil {
+		t.Fatalf("failed to suggest replacement: %v", err)
+	}
+	if suggestion.Replacement.ToInt().Cmp(maxChainFee) != 0 {
+		t.Errorf("chain replacement mismatch: have %v, want %v", suggestion.Replacement, maxChainFee)
+	}
+}
+
+func TestSuggestFeeFloor(t *testing.T) {
+	backend := newTestBackend(3)
+	oracle := NewOracle(backend, Config{Blocks: 3, Default: big.NewInt(100)})
+
+	suggestion, err := oracle.SuggestFee(context.Background(), types.NewMessageTxKind)
+	if err != nil {
+		t.Fatalf("failed to suggest fee: %v", err)
+	}
+	for _, fee := range []*big.Int{suggestion.Slow.ToInt(), suggestion.Normal.ToInt(), suggestion.Fast.ToInt()} {
+		if fee.Cmp(big.NewInt(100)) != 0 {
+			t.Errorf("empty chain suggestion mismatch: have %v, want 100", fee)
+		}
+	}
+}
+
+func TestSuggestFeeBacklog(t *testing.T) {
+	backend := newTestBackend(3, 1000, 1000)
+	oracle := NewOracle(backend, Config{Blocks: 3})
+
+	quiet, err := oracle.SuggestFee(context.Background(), types.TransferTxKind)
+	if err != nil {
+		t.Fatalf("failed to suggest fee: %v", err)
+	}
+	// Fill the pool with more high paying transactions than fit in a block
+	for i := 0; i < 3; i++ {
+		backend.pending = append(backend.pending, newTransfer(uint64(100+i), 9000))
+	}
+	busy, err := oracle.SuggestFee(context.Background(), types.TransferTxKind)
+	if err != nil {
+		t.Fatalf("failed to suggest fee: %v", err)
+	}
+	if busy.Fast.ToInt().Cmp(big.NewInt(9001)) != 0 {
+		t.Errorf("fast fee does not outbid the backlog: have %v", busy.Fast)
+	}
+	if busy.Slow.ToInt().Cmp(quiet.Slow.ToInt()) != 0 {
+		t.Errorf("slow fee affected by short backlog: have %v, want %v", busy.Slow, quiet.Slow)
+	}
+}
+
+func TestSuggestReplacement(t *testing.T) {
+	backend := newTestBackend(3, 1000, 2000)
+	oracle := NewOracle(backend, Config{Blocks: 3})
+
+	for _, fee := range []int64{10, 100000} {
+		stuck := newTransfer(100, fee)
+		suggestion, err := oracle.SuggestReplacement(context.Background(), stuck)
+		if err != nil {
+			t.Fatalf("failed to suggest replacement: %v", err)
+		}
+		replacement := suggestion.Replacement.ToInt()
+		if replacement.Cmp((*stuck).Fee()) <= 0 {
+			t.Errorf("replacement %v does not exceed original fee %d", replacement, fee)
+		}
+		if replacement.Cmp(suggestion.Fast.ToInt()) < 0 {
+			t.Errorf("replacement %v below fast fee %v", replacement, suggestion.Fast)
+		}
+	}
+}
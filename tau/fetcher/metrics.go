@@ -40,4 +40,15 @@ var (
 	headerFilterOutMeter = metrics.NewRegisteredMeter("tau/fetcher/filter/headers/out", nil)
 	bodyFilterInMeter    = metrics.NewRegisteredMeter("tau/fetcher/filter/bodies/in", nil)
 	bodyFilterOutMeter   = metrics.NewRegisteredMeter("tau/fetcher/filter/bodies/out", nil)
+
+	txAnnounceInMeter    = metrics.NewRegisteredMeter("tau/fetcher/tx/announces/in", nil)
+	txAnnounceKnownMeter = metrics.NewRegisteredMeter("tau/fetcher/tx/announces/known", nil)
+	txAnnounceDOSMeter   = metrics.NewRegisteredMeter("tau/fetcher/tx/announces/dos", nil)
+
+	txRequestOutMeter     = metrics.NewRegisteredMeter("tau/fetcher/tx/requests/out", nil)
+	txRequestTimeoutMeter = metrics.NewRegisteredMeter("tau/fetcher/tx/requests/timeout", nil)
+
+	txReplyInMeter     = metrics.NewRegisteredMeter("tau/fetcher/tx/replies/in", nil)
+	txReplyDropMeter   = metrics.NewRegisteredMeter("tau/fetcher/tx/replies/drop", nil)
+	txBroadcastInMeter = metrics.NewRegisteredMeter("tau/fetcher/tx/broadcasts/in", nil)
 )
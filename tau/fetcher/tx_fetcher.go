@@ -0,0 +1,476 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+)
+
+const (
+	txArriveTimeout    = 500 * time.Millisecond // Time allowance before an announced transaction is explicitly requested
+	txGatherSlack      = 100 * time.Millisecond // Interval used to collate almost-expired announces with fetches
+	txFetchTimeout     = 5 * time.Second        // Maximum allotted time to return explicitly requested transactions
+	maxTxAnnounces     = 4096                   // Maximum number of unique transactions a peer may have announced
+	maxTxRetrievals    = 256                    // Maximum number of transactions requested from a peer at once
+	maxTxRetrievalSize = 128 * 1024             // Maximum number of transaction bytes fetched from a peer at once
+	maxTxAnnounceSize  = 128 * 1024             // Maximum size a transaction may be announced with
+)
+
+// TxAnnounce is the announcement of a transaction available at a peer.
+type TxAnnounce struct {
+	Hash common.Hash  // Hash of the announced transaction
+	Kind types.TxKind // Kind of the announced transaction
+	Size uint32       // Encoded size of the announced transaction
+}
+
+// txHasFn is a callback type for checking whether a transaction is already
+// known locally.
+type txHasFn func(common.Hash) bool
+
+// txAddFn is a callback type for handing the transactions received from a peer
+// to the pool.
+type txAddFn func(peer string, txs []*types.Transaction) []error
+
+// txRequesterFn is a callback type for requesting transactions from a peer.
+type txRequesterFn func(peer string, hashes []common.Hash) error
+
+// txPenaltyFn is a callback type for reporting a peer misbehaving in the
+// transaction exchange.
+type txPenaltyFn func(peer string, offence TxOffence)
+
+// TxOffence is a misbehaviour of a peer detected by the transaction fetcher.
+type TxOffence int
+
+const (
+	TxAnnounceInvalid     TxOffence = iota // Peer announced an invalid transaction or exceeded its allowance
+	TxDeliveryUnrequested                  // Peer delivered transactions that weren't requested from it
+	TxRequestStalled                       // Peer failed to deliver requested transactions in time
+)
+
+// txAnnounceBatch is a batch of transaction announcements from a peer.
+type txAnnounceBatch struct {
+	origin    string       // Identifier of the peer originating the notification
+	announces []TxAnnounce // Transactions announced by the peer
+	time      time.Time    // Timestamp of the announcement
+}
+
+// txFilterTask is a batch of transactions delivered by a peer, waiting to be
+// matched against the fetcher's requests.
+type txFilterTask struct {
+	peer   string                    // Peer delivering the transactions
+	txs    []*types.Transaction      // Delivered transactions
+	direct bool                      // Whether the transactions are a reply to a request
+	done   chan []*types.Transaction // Channel receiving the transactions to import
+}
+
+// txState is an announced transaction waiting to be retrieved.
+type txState struct {
+	size     uint32              // Announced size of the transaction
+	time     time.Time           // Timestamp of the first announcement
+	peers    map[string]struct{} // Peers that announced the transaction
+	fetching string              // Peer the transaction is requested from, empty if none
+}
+
+// txRequest is a transaction retrieval in flight.
+type txRequest struct {
+	hashes []common.Hash // Transactions requested
+	time   time.Time     // Timestamp of the request
+}
+
+// TxFetcher is responsible for retrieving the transactions announced by peers.
+// Announcements are given some time for a full broadcast of the transaction to
+// arrive, after which the transaction is requested from one of the announcing
+// peers. Every peer has at most one request in flight, capped both in count and
+// in bytes.
+type TxFetcher struct {
+	notify chan *txAnnounceBatch
+	filter chan *txFilterTask
+	drop   chan string
+	quit   chan struct{}
+
+	announced map[common.Hash]*txState            // Announced transactions not yet delivered
+	announces map[string]map[common.Hash]struct{} // Per peer announced transactions, to prevent memory exhaustion
+	requests  map[string]*txRequest               // Per peer retrievals in flight
+
+	// Callbacks
+	hasTx    txHasFn       // Checks whether a transaction is already in the pool
+	addTxs   txAddFn       // Adds a batch of transactions to the pool
+	fetchTxs txRequesterFn // Requests a batch of transactions from a peer
+	penalise txPenaltyFn   // Reports a peer misbehaving in the transaction exchange
+
+	// Testing hooks
+	fetchingHook func(string, []common.Hash) // Method to call upon starting a transaction retrieval
+}
+
+// NewTxFetcher creates a transaction fetcher to retrieve transactions based on
+// hash announcements.
+func NewTxFetcher(hasTx txHasFn, addTxs txAddFn, fetchTxs txRequesterFn, penalise txPenaltyFn) *TxFetcher {
+	return &TxFetcher{
+		notify:    make(chan *txAnnounceBatch),
+		filter:    make(chan *txFilterTask),
+		drop:      make(chan string),
+		quit:      make(chan struct{}),
+		announced: make(map[common.Hash]*txState),
+		announces: make(map[string]map[common.Hash]struct{}),
+		requests:  make(map[string]*txRequest),
+		hasTx:     hasTx,
+		addTxs:    addTxs,
+		fetchTxs:  fetchTxs,
+		penalise:  penalise,
+	}
+}
+
+// Start boots up the transaction fetcher.
+func (f *TxFetcher) Start() {
+	go f.loop()
+}
+
+// Stop terminates the transaction fetcher, canceling all pending operations.
+func (f *TxFetcher) Stop() {
+	close(f.quit)
+}
+
+// Notify announces the fetcher of the availability of transactions at a peer.
+func (f *TxFetcher) Notify(peer string, announces []TxAnnounce, time time.Time) error {
+	select {
+	case f.notify <- &txAnnounceBatch{origin: peer, announces: announces, time: time}:
+		return nil
+	case <-f.quit:
+		return errTerminated
+	}
+}
+
+// Enqueue imports a batch of transactions received from a peer into the pool.
+// Direct deliveries are replies to the fetcher's requests, of which only the
+// requested transactions within the byte cap are imported. Broadcasts are
+// imported as is, satisfying any pending announcements.
+func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool) error {
+	task := &txFilterTask{peer: peer, txs: txs, direct: direct, done: make(chan []*types.Transaction, 1)}
+	select {
+	case f.filter <- task:
+	case <-f.quit:
+		return errTerminated
+	}
+	if accepted := <-task.done; len(accepted) > 0 {
+		f.addTxs(peer, accepted)
+	}
+	return nil
+}
+
+// Drop forgets all announcements and requests of a disconnected peer.
+func (f *TxFetcher) Drop(peer string) error {
+	select {
+	case f.drop <- peer:
+		return nil
+	case <-f.quit:
+		return errTerminated
+	}
+}
+
+// loop is the main fetcher loop, processing announcements and deliveries and
+// scheduling retrievals.
+func (f *TxFetcher) loop() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-f.quit:
+			return
+
+		case batch := <-f.notify:
+			f.announce(batch)
+
+		case task := <-f.filter:
+			task.done <- f.deliver(task)
+
+		case peer := <-f.drop:
+			if request := f.requests[peer]; request != nil {
+				delete(f.requests, peer)
+				f.release(peer, request.hashes)
+			}
+			for hash := range f.announces[peer] {
+				f.release(peer, []common.Hash{hash})
+			}
+
+		case <-timer.C:
+		}
+		now := time.Now()
+		f.expire(now)
+		f.schedule(now)
+
+		// Wake up again when the next announcement or request is due
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if next, ok := f.nextDue(); ok {
+			timer.Reset(next.Sub(now))
+		}
+	}
+}
+
+// announce records the transactions announced by a peer, skipping the ones
+// already known and the ones over the peer's announcement allowance.
+func (f *TxFetcher) announce(batch *txAnnounceBatch) {
+	txAnnounceInMeter.Mark(int64(len(batch.announces)))
+
+	invalid := false
+	defer func() {
+		if invalid {
+			f.report(batch.origin, TxAnnounceInvalid)
+		}
+	}()
+	for _, ann := range batch.announces {
+		if ann.Kind == types.UnknownTxKind || ann.Kind > types.NewChainTxKind || ann.Size == 0 || ann.Size > maxTxAnnounceSize {
+			log.Debug("Peer announced invalid transaction", "peer", batch.origin, "hash", ann.Hash, "kind", ann.Kind, "size", ann.Size)
+			txAnnounceDOSMeter.Mark(1)
+			invalid = true
+			continue
+		}
+		if _, ok := f.announces[batch.origin][ann.Hash]; ok {
+			continue
+		}
+		if len(f.announces[batch.origin]) >= maxTxAnnounces {
+			log.Debug("Peer exceeded outstanding transaction announces", "peer", batch.origin, "limit", maxTxAnnounces)
+			txAnnounceDOSMeter.Mark(1)
+			invalid = true
+			return
+		}
+		state := f.announced[ann.Hash]
+		if state == nil {
+			if f.hasTx(ann.Hash) {
+				txAnnounceKnownMeter.Mark(1)
+				continue
+			}
+			state = &txState{size: ann.Size, time: batch.time, peers: make(map[string]struct{})}
+			f.announced[ann.Hash] = state
+		}
+		state.peers[batch.origin] = struct{}{}
+		if f.announces[batch.origin] == nil {
+			f.announces[batch.origin] = make(map[common.Hash]struct{})
+		}
+		f.announces[batch.origin][ann.Hash] = struct{}{}
+	}
+}
+
+// deliver matches a batch of delivered transactions against the requests,
+// returning the ones to import and forgetting their announcements.
+func (f *TxFetcher) deliver(task *txFilterTask) []*types.Transaction {
+	if !task.direct {
+		txBroadcastInMeter.Mark(int64(len(task.txs)))
+		for _, tx := range task.txs {
+			f.forget((*tx).Hash())
+		}
+		return task.txs
+	}
+	txReplyInMeter.Mark(int64(len(task.txs)))
+
+	request := f.requests[task.peer]
+	if request == nil {
+		log.Debug("Peer delivered unrequested transactions", "peer", task.peer, "count", len(task.txs))
+		txReplyDropMeter.Mark(int64(len(task.txs)))
+		f.report(task.peer, TxDeliveryUnrequested)
+		return nil
+	}
+	delete(f.requests, task.peer)
+
+	pending := make(map[common.Hash]bool, len(request.hashes))
+	for _, hash := range request.hashes {
+		pending[hash] = true
+	}
+	var (
+		accepted []*types.Transaction
+		bytes    common.StorageSize
+	)
+	for _, tx := range task.txs {
+		hash := (*tx).Hash()
+		if !pending[hash] {
+			txReplyDropMeter.Mark(1)
+			continue
+		}
+		if bytes += (*tx).Size(); len(accepted) > 0 && bytes > maxTxRetrievalSize {
+			log.Debug("Peer exceeded transaction retrieval size", "peer", task.peer, "limit", maxTxRetrievalSize)
+			txReplyDropMeter.Mark(1)
+			break
+		}
+		delete(pending, hash)
+		accepted = append(accepted, tx)
+		f.forget(hash)
+	}
+	// The transactions the peer failed to deliver may be fetched from others
+	var missing []common.Hash
+	for hash := range pending {
+		missing = append(missing, hash)
+	}
+	f.release(task.peer, missing)
+
+	return accepted
+}
+
+// expire releases the transactions of the requests not answered in time.
+func (f *TxFetcher) expire(now time.Time) {
+	for peer, request := range f.requests {
+		if now.Sub(request.time) < txFetchTimeout {
+			continue
+		}
+		log.Debug("Transaction retrieval timed out", "peer", peer, "count", len(request.hashes))
+		txRequestTimeoutMeter.Mark(int64(len(request.hashes)))
+		f.report(peer, TxRequestStalled)
+
+		delete(f.requests, peer)
+		f.release(peer, request.hashes)
+	}
+}
+
+// schedule requests the announced transactions past their arrival allowance
+// from idle peers announcing them.
+func (f *TxFetcher) schedule(now time.Time) {
+	for peer, hashes := range f.announces {
+		if f.requests[peer] != nil {
+			continue
+		}
+		var (
+			request []common.Hash
+			bytes   uint64
+		)
+		for hash := range hashes {
+			state := f.announced[hash]
+			if state.fetching != "" || now.Sub(state.time) < txArriveTimeout-txGatherSlack {
+				continue
+			}
+			if f.hasTx(hash) {
+				f.forget(hash)
+				continue
+			}
+			if len(request) > 0 && bytes+uint64(state.size) > maxTxRetrievalSize {
+				continue
+			}
+			request = append(request, hash)
+			bytes += uint64(state.size)
+			state.fetching = peer
+
+			if len(request) >= maxTxRetrievals {
+				break
+			}
+		}
+		if len(request) == 0 {
+			continue
+		}
+		f.requests[peer] = &txRequest{hashes: request, time: now}
+		txRequestOutMeter.Mark(int64(len(request)))
+
+		if f.fetchingHook != nil {
+			f.fetchingHook(peer, request)
+		}
+		go func(peer string, hashes []common.Hash) {
+			if err := f.fetchTxs(peer, hashes); err != nil {
+				log.Debug("Failed to request transactions", "peer", peer, "count", len(hashes), "err", err)
+			}
+		}(peer, request)
+	}
+}
+
+// nextDue returns the time the next announcement becomes fetchable or the next
+// request times out, if any. Announcements whose announcers all have a request
+// in flight only become fetchable once one of those requests concludes.
+func (f *TxFetcher) nextDue() (time.Time, bool) {
+	var (
+		next  time.Time
+		found bool
+	)
+	for _, state := range f.announced {
+		if state.fetching != "" || !f.idleAnnouncer(state) {
+			continue
+		}
+		if due := state.time.Add(txArriveTimeout); !found || due.Before(next) {
+			next, found = due, true
+		}
+	}
+	for _, request := range f.requests {
+		if due := request.time.Add(txFetchTimeout); !found || due.Before(next) {
+			next, found = due, true
+		}
+	}
+	return next, found
+}
+
+// idleAnnouncer reports whether any peer announcing a transaction has no request
+// in flight.
+func (f *TxFetcher) idleAnnouncer(state *txState) bool {
+	for peer := range state.peers {
+		if f.requests[peer] == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// release forgets the announcements of the given transactions by a peer, which
+// failed to deliver them, leaving them to be fetched from other announcers.
+func (f *TxFetcher) release(peer string, hashes []common.Hash) {
+	for _, hash := range hashes {
+		if announces := f.announces[peer]; announces != nil {
+			delete(announces, hash)
+			if len(announces) == 0 {
+				delete(f.announces, peer)
+			}
+		}
+		state := f.announced[hash]
+		if state == nil {
+			continue
+		}
+		if state.fetching == peer {
+			state.fetching = ""
+		}
+		delete(state.peers, peer)
+		if len(state.peers) == 0 {
+			delete(f.announced, hash)
+		}
+	}
+}
+
+// report notifies the penalty callback of a misbehaving peer. The callback runs
+// on its own goroutine as it may drop the peer, which blocks on the fetcher.
+func (f *TxFetcher) report(peer string, offence TxOffence) {
+	if f.penalise != nil {
+		go f.penalise(peer, offence)
+	}
+}
+
+// forget removes all traces of an announced transaction.
+func (f *TxFetcher) forget(hash common.Hash) {
+	state := f.announced[hash]
+	if state == nil {
+		return
+	}
+	for peer := range state.peers {
+		if announces := f.announces[peer]; announces != nil {
+			delete(announces, hash)
+			if len(announces) == 0 {
+				delete(f.announces, peer)
+			}
+		}
+	}
+	delete(f.announced, hash)
+}
@@ -0,0 +1,259 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package fetcher
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+)
+
+// txFetcherTester is a test simulator for mocking out the transaction pool and
+// the peers serving transactions.
+type txFetcherTester struct {
+	fetcher *TxFetcher
+
+	pool      map[common.Hash]*types.Transaction // Transactions imported into the pool
+	requests  chan txFetchRequest                // Retrievals started by the fetcher
+	penalties chan TxOffence                     // Misbehaviours reported by the fetcher
+	lock      sync.RWMutex
+}
+
+// txFetchRequest is a transaction retrieval started by the fetcher.
+type txFetchRequest struct {
+	peer   string
+	hashes []common.Hash
+}
+
+func newTxFetcherTester() *txFetcherTester {
+	tester := &txFetcherTester{
+		pool:      make(map[common.Hash]*types.Transaction),
+		requests:  make(chan txFetchRequest, 16),
+		penalties: make(chan TxOffence, 16),
+	}
+	tester.fetcher = NewTxFetcher(tester.hasTx, tester.addTxs, tester.fetchTxs, tester.penalise)
+	tester.fetcher.Start()
+	return tester
+}
+
+func (f *txFetcherTester) hasTx(hash common.Hash) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.pool[hash] != nil
+}
+
+func (f *txFetcherTester) addTxs(peer string, txs []*types.Transaction) []error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, tx := range txs {
+		f.pool[(*tx).Hash()] = tx
+	}
+	return make([]error, len(txs))
+}
+
+func (f *txFetcherTester) fetchTxs(peer string, hashes []common.Hash) error {
+	f.requests <- txFetchRequest{peer: peer, hashes: hashes}
+	return nil
+}
+
+func (f *txFetcherTester) penalise(peer string, offence TxOffence) {
+	f.penalties <- offence
+}
+
+// expectPenalty waits for the fetcher to report a misbehaving peer.
+func (f *txFetcherTester) expectPenalty(t *testing.T, want TxOffence) {
+	select {
+	case offence := <-f.penalties:
+		if offence != want {
+			t.Fatalf("penalty mismatch: have %d, want %d", offence, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("no penalty reported, want %d", want)
+	}
+}
+
+// expectRequest waits for the fetcher to request transactions from a peer.
+func (f *txFetcherTester) expectRequest(t *testing.T) txFetchRequest {
+	select {
+	case request := <-f.requests:
+		return request
+	case <-time.After(txArriveTimeout + time.Second):
+		t.Fatalf("no transaction retrieval started")
+	}
+	return txFetchRequest{}
+}
+
+// expectNoRequest checks that the fetcher stays idle.
+func (f *txFetcherTester) expectNoRequest(t *testing.T) {
+	select {
+	case request := <-f.requests:
+		t.Fatalf("unexpected retrieval from %s: %x", request.peer, request.hashes)
+	case <-time.After(txArriveTimeout + 200*time.Millisecond):
+	}
+}
+
+// newTestTransfer creates a transfer with the given nonce.
+func newTestTransfer(nonce uint64) *types.Transaction {
+	var (
+		from = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		to   = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	)
+	tx := types.Transaction(types.NewTransferTransaction(types.OneByte{1}, types.OneByte{0}, nil, nonce, 0, big.NewInt(1), from, to, big.NewInt(1)))
+	return &tx
+}
+
+// announcesOf creates the announcements of the given transactions.
+func announcesOf(txs ...*types.Transaction) []TxAnnounce {
+	announces := make([]TxAnnounce, len(txs))
+	for i, tx := range txs {
+		announces[i] = TxAnnounce{Hash: (*tx).Hash(), Kind: types.KindOf(*tx), Size: uint32((*tx).Size())}
+	}
+	return announces
+}
+
+// Tests that announced transactions are retrieved once, and that known ones are
+// never requested.
+func TestTxFetcherAnnounce(t *testing.T) {
+	tester := newTxFetcherTester()
+	defer tester.fetcher.Stop()
+
+	known, unknown := newTestTransfer(0), newTestTransfer(1)
+	tester.addTxs("", []*types.Transaction{known})
+
+	tester.fetcher.Notify("peer", announcesOf(known, unknown), time.Now())
+	tester.fetcher.Notify("other", announcesOf(unknown), time.Now())
+
+	request := tester.expectRequest(t)
+	if len(request.hashes) != 1 || request.hashes[0] != (*unknown).Hash() {
+		t.Fatalf("requested hashes mismatch: have %x, want %x", request.hashes, (*unknown).Hash())
+	}
+	tester.fetcher.Enqueue(request.peer, []*types.Transaction{unknown}, true)
+	if !tester.hasTx((*unknown).Hash()) {
+		t.Fatalf("retrieved transaction not imported")
+	}
+	tester.expectNoRequest(t)
+}
+
+// Tests that transactions a peer failed to deliver are requested from another
+// peer announcing them, and that unrequested deliveries are ignored.
+func TestTxFetcherAlternates(t *testing.T) {
+	tester := newTxFetcherTester()
+	defer tester.fetcher.Stop()
+
+	tx := newTestTransfer(0)
+	tester.fetcher.Notify("first", announcesOf(tx), time.Now())
+	tester.fetcher.Notify("second", announcesOf(tx), time.Now())
+
+	request := tester.expectRequest(t)
+	other := "second"
+	if request.peer == "second" {
+		other = "first"
+	}
+	// Push the transaction from the peer not asked, it must be ignored
+	tester.fetcher.Enqueue(other, []*types.Transaction{tx}, true)
+	if tester.hasTx((*tx).Hash()) {
+		t.Fatalf("unrequested transaction imported")
+	}
+	tester.expectPenalty(t, TxDeliveryUnrequested)
+	// Reply without the transaction, it must be requested from the other peer
+	tester.fetcher.Enqueue(request.peer, nil, true)
+	if retry := tester.expectRequest(t); retry.peer != other || len(retry.hashes) != 1 || retry.hashes[0] != (*tx).Hash() {
+		t.Fatalf("retry mismatch: have %s %x, want %s %x", retry.peer, retry.hashes, other, (*tx).Hash())
+	}
+}
+
+// Tests that dropped peers are not requested from, and that broadcasts satisfy
+// pending announcements.
+func TestTxFetcherDropAndBroadcast(t *testing.T) {
+	tester := newTxFetcherTester()
+	defer tester.fetcher.Stop()
+
+	first, second := newTestTransfer(0), newTestTransfer(1)
+	tester.fetcher.Notify("dropped", announcesOf(first), time.Now())
+	tester.fetcher.Notify("broadcaster", announcesOf(second), time.Now())
+	tester.fetcher.Drop("dropped")
+	tester.fetcher.Enqueue("broadcaster", []*types.Transaction{second}, false)
+
+	if !tester.hasTx((*second).Hash()) {
+		t.Fatalf("broadcast transaction not imported")
+	}
+	tester.expectNoRequest(t)
+}
+
+// Tests that announcements over the size limits are rejected and that requests
+// are capped in bytes.
+func TestTxFetcherSizeCaps(t *testing.T) {
+	tester := newTxFetcherTester()
+	defer tester.fetcher.Stop()
+
+	var announces []TxAnnounce
+	for i := 0; i < 3; i++ {
+		announces = append(announces, TxAnnounce{Hash: common.Hash{byte(i + 1)}, Kind: types.TransferTxKind, Size: maxTxRetrievalSize / 2})
+	}
+	announces = append(announces, TxAnnounce{Hash: common.Hash{0xff}, Kind: types.TransferTxKind, Size: maxTxAnnounceSize + 1})
+	tester.fetcher.Notify("peer", announces, time.Now())
+
+	tester.expectPenalty(t, TxAnnounceInvalid)
+
+	request := tester.expectRequest(t)
+	if len(request.hashes) != 2 {
+		t.Fatalf("request not capped in bytes: have %d hashes, want 2", len(request.hashes))
+	}
+	for _, hash := range request.hashes {
+		if hash == (common.Hash{0xff}) {
+			t.Fatalf("oversized announcement requested")
+		}
+	}
+}
+
+// Tests that announcements of busy peers are not due until the requests in
+// flight conclude, so the fetcher does not spin on them.
+func TestTxFetcherBusyAnnouncer(t *testing.T) {
+	fetcher := NewTxFetcher(
+		func(common.Hash) bool { return false },
+		func(string, []*types.Transaction) []error { return nil },
+		func(string, []common.Hash) error { return nil },
+		nil,
+	)
+	var (
+		now           = time.Now()
+		first, second = newTestTransfer(0), newTestTransfer(1)
+	)
+	fetcher.announce(&txAnnounceBatch{origin: "peer", announces: announcesOf(first), time: now.Add(-time.Second)})
+	fetcher.schedule(now)
+	if fetcher.requests["peer"] == nil {
+		t.Fatalf("announced transaction not requested")
+	}
+	fetcher.announce(&txAnnounceBatch{origin: "peer", announces: announcesOf(second), time: now.Add(-time.Second)})
+	fetcher.schedule(now)
+
+	next, ok := fetcher.nextDue()
+	if want := now.Add(txFetchTimeout); !ok || !next.Equal(want) {
+		t.Fatalf("next due time mismatch: have %v, want %v", next, want)
+	}
+	// Once the request concludes, the pending announcement is due right away
+	fetcher.deliver(&txFilterTask{peer: "peer", txs: []*types.Transaction{first}, direct: true})
+	if next, ok := fetcher.nextDue(); !ok || next.After(now) {
+		t.Fatalf("next due time mismatch: have %v, want past %v", next, now)
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+)
+
+var (
+	deadline = 5 * time.Minute // consider a filter inactive if it has not been polled for within deadline
+
+	errFilterNotFound = errors.New("filter not found")
+)
+
+// filter is a helper struct that holds meta information over the filter type
+// and associated subscription in the event system.
+type filter struct {
+	typ      Type
+	deadline *time.Timer // filter is inactive when deadline triggers
+	hashes   []common.Hash
+	txs      []*TxEvent
+	s        *Subscription // associated subscription in event system
+}
+
+// PublicFilterAPI offers support to create and manage filters. This will allow
+// external clients to retrieve chain and transaction pool events.
+type PublicFilterAPI struct {
+	backend   Backend
+	events    *EventSystem
+	filtersMu sync.Mutex
+	filters   map[rpc.ID]*filter
+}
+
+// NewPublicFilterAPI returns a new PublicFilterAPI instance.
+func NewPublicFilterAPI(backend Backend) *PublicFilterAPI {
+	api := &PublicFilterAPI{
+		backend: backend,
+		events:  NewEventSystem(backend),
+		filters: make(map[rpc.ID]*filter),
+	}
+	go api.timeoutLoop()
+
+	return api
+}
+
+// timeoutLoop runs every 5 minutes and deletes filters that have not been recently used.
+// Tt is started when the api is created.
+func (api *PublicFilterAPI) timeoutLoop() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+	for {
+		<-ticker.C
+		api.filtersMu.Lock()
+		for id, f := range api.filters {
+			select {
+			case <-f.deadline.C:
+				delete(api.filters, id)
+				f.s.Unsubscribe()
+			default:
+				continue
+			}
+		}
+		api.filtersMu.Unlock()
+	}
+}
+
+// NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
+// It is part of the filter package since polling goes with tau_getFilterChanges.
+func (api *PublicFilterAPI) NewBlockFilter() rpc.ID {
+	var (
+		headers   = make(chan *types.Header)
+		headerSub = api.events.SubscribeNewHeads(headers)
+	)
+	api.filtersMu.Lock()
+	api.filters[headerSub.ID] = &filter{typ: BlocksSubscription, deadline: time.NewTimer(deadline), hashes: make([]common.Hash, 0), s: headerSub}
+	api.filtersMu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case h := <-headers:
+				api.filtersMu.Lock()
+				if f, found := api.filters[headerSub.ID]; found {
+					f.hashes = append(f.hashes, h.Hash())
+				}
+				api.filtersMu.Unlock()
+			case <-headerSub.Err():
+				api.filtersMu.Lock()
+				delete(api.filters, headerSub.ID)
+				api.filtersMu.Unlock()
+				return
+			}
+		}
+	}()
+
+	return headerSub.ID
+}
+
+// NewPendingTransactionFilter creates a filter that fetches the pending
+// transactions matching the optional criteria, as transactions enter the pool.
+func (api *PublicFilterAPI) NewPendingTransactionFilter(crit *FilterCriteria) rpc.ID {
+	return api.newTxFilter(PendingTransactionsSubscription, crit)
+}
+
+// NewTransactionFilter creates a filter that fetches the transactions matching
+// the criteria as they get included in the canonical chain. Transactions
+// dropped by a reorg are reported again with the removed flag set.
+func (api *PublicFilterAPI) NewTransactionFilter(crit FilterCriteria) rpc.ID {
+	return api.newTxFilter(TransactionsSubscription, &crit)
+}
+
+// newTxFilter installs a polling filter for transaction events.
+func (api *PublicFilterAPI) newTxFilter(typ Type, crit *FilterCriteria) rpc.ID {
+	var (
+		txs   = make(chan []*TxEvent)
+		txSub *Subscription
+	)
+	if typ == PendingTransactionsSubscription {
+		txSub = api.events.SubscribePendingTxs(crit, txs)
+	} else {
+		txSub = api.events.SubscribeChainTxs(crit, txs)
+	}
+	api.filtersMu.Lock()
+	api.filters[txSub.ID] = &filter{typ: typ, deadline: time.NewTimer(deadline), txs: make([]*TxEvent, 0), s: txSub}
+	api.filtersMu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case evs := <-txs:
+				api.filtersMu.Lock()
+				if f, found := api.filters[txSub.ID]; found {
+					f.txs = append(f.txs, evs...)
+				}
+				api.filtersMu.Unlock()
+			case <-txSub.Err():
+				api.filtersMu.Lock()
+				delete(api.filters, txSub.ID)
+				api.filtersMu.Unlock()
+				return
+			}
+		}
+	}()
+
+	return txSub.ID
+}
+
+// GetFilterChanges returns the events for the filter with the given id since
+// last time it was called. This can be used for polling.
+//
+// For block filters the result is []common.Hash, for transaction filters
+// []*TxEvent.
+func (api *PublicFilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
+	api.filtersMu.Lock()
+	defer api.filtersMu.Unlock()
+
+	f, found := api.filters[id]
+	if !found {
+		return []interface{}{}, errFilterNotFound
+	}
+	if !f.deadline.Stop() {
+		// timer expired but filter is not yet removed in timeout loop
+		// receive timer value and reset timer
+		<-f.deadline.C
+	}
+	f.deadline.Reset(deadline)
+
+	switch f.typ {
+	case BlocksSubscription:
+		hashes := f.hashes
+		f.hashes = nil
+		return returnHashes(hashes), nil
+	case PendingTransactionsSubscription, TransactionsSubscription:
+		txs := f.txs
+		f.txs = nil
+		return returnTxs(txs), nil
+	}
+	return []interface{}{}, errFilterNotFound
+}
+
+// UninstallFilter removes the filter with the given filter id.
+func (api *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
+	api.filtersMu.Lock()
+	f, found := api.filters[id]
+	if found {
+		delete(api.filters, id)
+	}
+	api.filtersMu.Unlock()
+	if found {
+		f.s.Unsubscribe()
+	}
+	return found
+}
+
+// GetTransactions returns the transactions of the canonical blocks in the
+// criteria's block range (the head block by default) matching the criteria.
+func (api *PublicFilterAPI) GetTransactions(ctx context.Context, crit FilterCriteria) ([]*TxEvent, error) {
+	return HistoricalTxs(ctx, api.backend, crit)
+}
+
+// NewHeads send a notification each time a new (header) block is appended to the chain.
+func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		headers := make(chan *types.Header)
+		headersSub := api.events.SubscribeNewHeads(headers)
+
+		for {
+			select {
+			case h := <-headers:
+				notifier.Notify(rpcSub.ID, h)
+			case <-rpcSub.Err():
+				headersSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				headersSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// NewPendingTransactions creates a subscription that is triggered each time a
+// transaction matching the optional criteria enters the transaction pool.
+func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context, crit *FilterCriteria) (*rpc.Subscription, error) {
+	return api.subscribeTxs(ctx, PendingTransactionsSubscription, crit)
+}
+
+// Transactions creates a subscription that is triggered each time a transaction
+// matching the criteria is included in the canonical chain, or removed from it
+// by a reorg.
+func (api *PublicFilterAPI) Transactions(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+	return api.subscribeTxs(ctx, TransactionsSubscription, &crit)
+}
+
+// subscribeTxs streams transaction events to an RPC subscription.
+func (api *PublicFilterAPI) subscribeTxs(ctx context.Context, typ Type, crit *FilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		var (
+			txs   = make(chan []*TxEvent, 128)
+			txSub *Subscription
+		)
+		if typ == PendingTransactionsSubscription {
+			txSub = api.events.SubscribePendingTxs(crit, txs)
+		} else {
+			txSub = api.events.SubscribeChainTxs(crit, txs)
+		}
+		for {
+			select {
+			case evs := <-txs:
+				for _, ev := range evs {
+					notifier.Notify(rpcSub.ID, ev)
+				}
+			case <-rpcSub.Err():
+				txSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				txSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// returnHashes is a helper that will return an empty hash array case the given hash array is nil,
+// otherwise the given hashes array is returned.
+func returnHashes(hashes []common.Hash) []common.Hash {
+	if hashes == nil {
+		return []common.Hash{}
+	}
+	return hashes
+}
+
+// returnTxs is a helper that will return an empty event array in case the given
+// events array is nil, otherwise the given events array is returned.
+func returnTxs(txs []*TxEvent) []*TxEvent {
+	if txs == nil {
+		return []*TxEvent{}
+	}
+	return txs
+}
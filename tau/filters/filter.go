@@ -0,0 +1,235 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package filters implements a TAU filtering system for block and
+// transaction events, served as polling filters and RPC subscriptions.
+package filters
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// maxFilterRange is the maximum number of blocks a single historical query
+// may scan.
+const maxFilterRange = 10000
+
+var errFilterRange = errors.New("requested block range too large")
+
+// Backend is the chain access needed by the filter system.
+type Backend interface {
+	ChainDb() taudb.Database
+	HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Header, error)
+	BlockByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Block, error)
+
+	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+	SubscribeChainTxsEvent(ch chan<- core.ChainTxsEvent) event.Subscription
+}
+
+// FilterCriteria selects transactions by their kind and participants. Every
+// non-empty field must match; within a field any listed value matches.
+type FilterCriteria struct {
+	FromBlock *rpc.BlockNumber `json:"fromBlock,omitempty"` // Only used by historical queries
+	ToBlock   *rpc.BlockNumber `json:"toBlock,omitempty"`   // Only used by historical queries
+
+	Kinds        []types.TxKind   `json:"kinds,omitempty"`        // Transaction kinds
+	Senders      []common.Address `json:"senders,omitempty"`      // Transaction senders
+	Receivers    []common.Address `json:"receivers,omitempty"`    // Transfer receivers
+	Participants []common.Address `json:"participants,omitempty"` // Transaction senders or transfer receivers
+	ChainIDs     []hexutil.Bytes  `json:"chainIds,omitempty"`     // Chains the transactions belong to
+	Threads      []common.Hash    `json:"threads,omitempty"`      // Roots of message threads
+}
+
+// Matches reports whtauer the given transaction satisfies the criteria. The
+// threads of messages are resolved to their root through the thread index in
+// db.
+func (crit *FilterCriteria) Matches(db taudb.KeyValueReader, tx types.Transaction) bool {
+	if crit == nil {
+		return true
+	}
+	kind := types.KindOf(tx)
+	if len(crit.Kinds) > 0 && !includesKind(crit.Kinds, kind) {
+		return false
+	}
+	if len(crit.Senders) > 0 && !includesAddress(crit.Senders, types.SenderOf(tx)) {
+		return false
+	}
+	if len(crit.Receivers) > 0 {
+		receiver := types.ReceiverOf(tx)
+		if receiver == nil || !includesAddress(crit.Receivers, *receiver) {
+			return false
+		}
+	}
+	if len(crit.Participants) > 0 && !includesAddress(crit.Participants, types.SenderOf(tx)) {
+		receiver := types.ReceiverOf(tx)
+		if receiver == nil || !includesAddress(crit.Participants, *receiver) {
+			return false
+		}
+	}
+	if len(crit.ChainIDs) > 0 && !includesChain(crit.ChainIDs, tx.ChainId()) {
+		return false
+	}
+	if len(crit.Threads) > 0 {
+		msg, ok := tx.(*types.NewMessageTx)
+		if !ok || !includesHash(crit.Threads, rawdb.MessageThread(db, msg)) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTxs returns the transactions matching the criteria.
+func filterTxs(db taudb.KeyValueReader, txs []*types.Transaction, crit *FilterCriteria) []*types.Transaction {
+	var matched []*types.Transaction
+	for _, tx := range txs {
+		if crit.Matches(db, *tx) {
+			matched = append(matched, tx)
+		}
+	}
+	return matched
+}
+
+// TxEvent is the notification sent for a matching transaction.
+type TxEvent struct {
+	Kind        types.TxKind    `json:"kind"`
+	Hash        common.Hash     `json:"hash"`
+	From        common.Address  `json:"from"`
+	To          *common.Address `json:"to,omitempty"`
+	ChainID     hexutil.Bytes   `json:"chainId"`
+	Thread      *common.Hash    `json:"thread,omitempty"` // Root of the thread of a message
+	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
+	BlockNumber *hexutil.Uint64 `json:"blockNumber,omitempty"`
+
+	// Removed is true if the transaction was dropped from the canonical chain
+	// by a reorg. Transactions of pending filters are never removed.
+	Removed bool `json:"removed"`
+}
+
+// newTxEvent creates the notification of a transaction, optionally included in
+// the given block.
+func newTxEvent(db taudb.KeyValueReader, tx types.Transaction, block *types.Block, removed bool) *TxEvent {
+	ev := &TxEvent{
+		Kind:    types.KindOf(tx),
+		Hash:    tx.Hash(),
+		From:    types.SenderOf(tx),
+		To:      types.ReceiverOf(tx),
+		ChainID: hexutil.Bytes(tx.ChainId()),
+		Removed: removed,
+	}
+	if msg, ok := tx.(*types.NewMessageTx); ok {
+		thread := rawdb.MessageThread(db, msg)
+		ev.Thread = &thread
+	}
+	if block != nil {
+		hash, number := block.Hash(), hexutil.Uint64(block.NumberU64())
+		ev.BlockHash, ev.BlockNumber = &hash, &number
+	}
+	return ev
+}
+
+// blockTxEvents returns the notifications of all transactions in the block
+// matching the criteria.
+func blockTxEvents(db taudb.KeyValueReader, block *types.Block, txs []*types.Transaction, crit *FilterCriteria, removed bool) []*TxEvent {
+	var events []*TxEvent
+	for _, tx := range filterTxs(db, txs, crit) {
+		events = append(events, newTxEvent(db, *tx, block, removed))
+	}
+	return events
+}
+
+// HistoricalTxs scans the canonical blocks selected by the criteria range and
+// returns the matching transactions.
+func HistoricalTxs(ctx context.Context, backend Backend, crit FilterCriteria) ([]*TxEvent, error) {
+	head, err := backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if head == nil {
+		return nil, err
+	}
+	begin, end := head.Number.Uint64(), head.Number.Uint64()
+	if crit.FromBlock != nil && *crit.FromBlock >= 0 {
+		begin = uint64(*crit.FromBlock)
+	}
+	if crit.ToBlock != nil && *crit.ToBlock >= 0 {
+		end = uint64(*crit.ToBlock)
+	}
+	if begin > end {
+		return []*TxEvent{}, nil
+	}
+	if end-begin >= maxFilterRange {
+		return nil, errFilterRange
+	}
+	events := []*TxEvent{}
+	for number := begin; number <= end; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := backend.BlockByNumber(ctx, rpc.BlockNumber(number))
+		if block == nil {
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+		events = append(events, blockTxEvents(backend.ChainDb(), block, block.Transactions(), &crit, false)...)
+	}
+	return events, nil
+}
+
+func includesKind(kinds []types.TxKind, kind types.TxKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func includesAddress(addresses []common.Address, a common.Address) bool {
+	for _, addr := range addresses {
+		if addr == a {
+			return true
+		}
+	}
+	return false
+}
+
+func includesChain(chains []hexutil.Bytes, id []byte) bool {
+	for _, chain := range chains {
+		if bytes.Equal(chain, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func includesHash(hashes []common.Hash, h common.Hash) bool {
+	for _, hash := range hashes {
+		if hash == h {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,303 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+)
+
+// Type determines the kind of filter and is used to put the filter in to
+// the correct bucket when added.
+type Type byte
+
+const (
+	// UnknownSubscription indicates an unknown subscription type
+	UnknownSubscription Type = iota
+	// BlocksSubscription queries hashes for blocks that are imported
+	BlocksSubscription
+	// PendingTransactionsSubscription queries transactions entering the pending state
+	PendingTransactionsSubscription
+	// TransactionsSubscription queries transactions included in (or removed
+	// from) the canonical chain
+	TransactionsSubscription
+	// LastIndexSubscription keeps track of the last index
+	LastIndexSubscription
+)
+
+const (
+	// txChanSize is the size of channel listening to NewTxsEvent.
+	// The number is referenced from the size of tx pool.
+	txChanSize = 4096
+	// chainTxsChanSize is the size of channel listening to ChainTxsEvent.
+	chainTxsChanSize = 10
+	// chainEvChanSize is the size of channel listening to ChainEvent.
+	chainEvChanSize = 10
+)
+
+type subscription struct {
+	id        rpc.ID
+	typ       Type
+	created   time.Time
+	crit      *FilterCriteria
+	txs       chan []*TxEvent
+	headers   chan *types.Header
+	installed chan struct{} // closed when the filter is installed
+	err       chan error    // closed when the filter is uninstalled
+}
+
+// EventSystem creates subscriptions, processes events and broadcasts them to the
+// subscription which match the subscription criteria.
+type EventSystem struct {
+	backend Backend
+
+	// Subscriptions
+	txsSub      event.Subscription // Subscription for new transaction event
+	chainTxsSub event.Subscription // Subscription for canonical chain transactions event
+	chainSub    event.Subscription // Subscription for new chain event
+
+	// Channels
+	install    chan *subscription      // install filter for event notification
+	uninstall  chan *subscription      // remove filter for event notification
+	txsCh      chan core.NewTxsEvent   // Channel to receive new transactions event
+	chainTxsCh chan core.ChainTxsEvent // Channel to receive the added and removed chain transactions, in order
+	chainCh    chan core.ChainEvent    // Channel to receive new chain event
+
+	quit     chan struct{} // closed to terminate the event loop
+	done     chan struct{} // closed when the event loop terminated
+	stopOnce sync.Once
+}
+
+// NewEventSystem creates a new manager that listens for chain and transaction
+// pool events of the given backend and forwards them to the installed filters.
+//
+// The event loop terminates once the backend closes its event subscriptions
+// or the event system is stopped.
+func NewEventSystem(backend Backend) *EventSystem {
+	m := &EventSystem{
+		backend:    backend,
+		install:    make(chan *subscription),
+		uninstall:  make(chan *subscription),
+		txsCh:      make(chan core.NewTxsEvent, txChanSize),
+		chainTxsCh: make(chan core.ChainTxsEvent, chainTxsChanSize),
+		chainCh:    make(chan core.ChainEvent, chainEvChanSize),
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	// Subscribe events
+	m.txsSub = m.backend.SubscribeNewTxsEvent(m.txsCh)
+	m.chainTxsSub = m.backend.SubscribeChainTxsEvent(m.chainTxsCh)
+	m.chainSub = m.backend.SubscribeChainEvent(m.chainCh)
+
+	// Make sure none of the subscriptions are empty
+	if m.txsSub == nil || m.chainTxsSub == nil || m.chainSub == nil {
+		log.Crit("Subscribe for event system failed")
+	}
+	go m.eventLoop()
+	return m
+}
+
+// Subscription is created when the client registers itself for a particular event.
+type Subscription struct {
+	ID        rpc.ID
+	f         *subscription
+	es        *EventSystem
+	unsubOnce sync.Once
+}
+
+// Err returns a channel that is closed when unsubscribed.
+func (sub *Subscription) Err() <-chan error {
+	return sub.f.err
+}
+
+// Unsubscribe uninstalls the subscription from the event broadcast loop.
+func (sub *Subscription) Unsubscribe() {
+	sub.unsubOnce.Do(func() {
+	uninstallLoop:
+		for {
+			// write uninstall request and consume logs/hashes. This prevents
+			// the eventLoop broadcast method to deadlock when writing to the
+			// filter event channel while the subscription loop is waiting for
+			// this method to return (and thus not reading these events).
+			select {
+			case sub.es.uninstall <- sub.f:
+				break uninstallLoop
+			case <-sub.f.txs:
+			case <-sub.f.headers:
+			case <-sub.es.done:
+				break uninstallLoop
+			}
+		}
+		// wait for filter to be uninstalled in work loop before returning
+		// this ensures that the manager won't use the event channel which
+		// will probably be closed by the client asap after this method returns.
+		<-sub.Err()
+	})
+}
+
+// subscribe installs the subscription in the event broadcast loop.
+func (es *EventSystem) subscribe(sub *subscription) *Subscription {
+	select {
+	case es.install <- sub:
+		<-sub.installed
+	case <-es.done:
+		// The event system is stopped, hand out a terminated subscription
+		close(sub.installed)
+		close(sub.err)
+	}
+	return &Subscription{ID: sub.id, f: sub, es: es}
+}
+
+// Stop terminates the event loop, closing the error channel of every installed
+// subscription, and waits until it returned.
+func (es *EventSystem) Stop() {
+	es.stopOnce.Do(func() { close(es.quit) })
+	<-es.done
+}
+
+// SubscribeNewHeads creates a subscription that writes the header of a block
+// that is imported in the chain.
+func (es *EventSystem) SubscribeNewHeads(headers chan *types.Header) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       BlocksSubscription,
+		created:   time.Now(),
+		txs:       make(chan []*TxEvent),
+		headers:   headers,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
+// SubscribePendingTxs creates a subscription that writes the transactions
+// matching the given criteria that enter the transaction pool.
+func (es *EventSystem) SubscribePendingTxs(crit *FilterCriteria, txs chan []*TxEvent) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       PendingTransactionsSubscription,
+		created:   time.Now(),
+		crit:      crit,
+		txs:       txs,
+		headers:   make(chan *types.Header),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
+// SubscribeChainTxs creates a subscription that writes the transactions
+// matching the given criteria that get included in the canonical chain, and
+// again with the removed flag set if a reorg drops them.
+func (es *EventSystem) SubscribeChainTxs(crit *FilterCriteria, txs chan []*TxEvent) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       TransactionsSubscription,
+		created:   time.Now(),
+		crit:      crit,
+		txs:       txs,
+		headers:   make(chan *types.Header),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+
+type filterIndex map[Type]map[rpc.ID]*subscription
+
+// broadcast event to filters that match criteria.
+func (es *EventSystem) broadcast(filters filterIndex, ev interface{}) {
+	if ev == nil {
+		return
+	}
+	db := es.backend.ChainDb()
+	switch e := ev.(type) {
+	case core.NewTxsEvent:
+		for _, f := range filters[PendingTransactionsSubscription] {
+			if matched := blockTxEvents(db, nil, e.Txs, f.crit, false); len(matched) > 0 {
+				f.txs <- matched
+			}
+		}
+	case core.ChainTxsEvent:
+		// Removals and additions share a channel, keeping the reorg order
+		for _, f := range filters[TransactionsSubscription] {
+			if matched := blockTxEvents(db, e.Block, e.Block.Transactions(), f.crit, e.Removed); len(matched) > 0 {
+				f.txs <- matched
+			}
+		}
+	case core.ChainEvent:
+		for _, f := range filters[BlocksSubscription] {
+			f.headers <- e.Block.Header()
+		}
+	}
+}
+
+// eventLoop (un)installs filters and processes mux events.
+func (es *EventSystem) eventLoop() {
+	index := make(filterIndex)
+	for i := UnknownSubscription; i < LastIndexSubscription; i++ {
+		index[i] = make(map[rpc.ID]*subscription)
+	}
+	// Ensure all subscriptions get cleaned up
+	defer func() {
+		es.txsSub.Unsubscribe()
+		es.chainTxsSub.Unsubscribe()
+		es.chainSub.Unsubscribe()
+
+		for _, filters := range index {
+			for _, f := range filters {
+				close(f.err)
+			}
+		}
+		close(es.done)
+	}()
+
+	for {
+		select {
+		case ev := <-es.txsCh:
+			es.broadcast(index, ev)
+		case ev := <-es.chainTxsCh:
+			es.broadcast(index, ev)
+		case ev := <-es.chainCh:
+			es.broadcast(index, ev)
+
+		case f := <-es.install:
+			index[f.typ][f.id] = f
+			close(f.installed)
+
+		case f := <-es.uninstall:
+			delete(index[f.typ], f.id)
+			close(f.err)
+
+		// System stopped
+		case <-es.quit:
+			return
+		case <-es.txsSub.Err():
+			return
+		case <-es.chainTxsSub.Err():
+			return
+		case <-es.chainSub.Err():
+			return
+		}
+	}
+}
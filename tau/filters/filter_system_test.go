@@ -0,0 +1,210 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// testBackend feeds the event system with manually posted events.
+type testBackend struct {
+	db           taudb.Database
+	txsFeed      event.Feed
+	chainFeed    event.Feed
+	chainTxsFeed event.Feed
+}
+
+func (b *testBackend) ChainDb() taudb.Database { return b.db }
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Header, error) {
+	return nil, nil
+}
+
+func (b *testBackend) BlockByNumber(ctx context.Context, blockNr rpc.BlockNumber) (*types.Block, error) {
+	return nil, nil
+}
+
+func (b *testBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
+	return b.txsFeed.Subscribe(ch)
+}
+
+func (b *testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return b.chainFeed.Subscribe(ch)
+}
+
+func (b *testBackend) SubscribeChainTxsEvent(ch chan<- core.ChainTxsEvent) event.Subscription {
+	return b.chainTxsFeed.Subscribe(ch)
+}
+
+// expectTxEvents waits for the next notification of a subscription and checks
+// the hashes and removal flags it carries.
+func expectTxEvents(t *testing.T, ch chan []*TxEvent, hashes []common.Hash, removed bool) {
+	t.Helper()
+
+	select {
+	case events := <-ch:
+		if len(events) != len(hashes) {
+			t.Fatalf("event count mismatch: have %d, want %d", len(events), len(hashes))
+		}
+		for i, ev := range events {
+			if ev.Hash != hashes[i] || ev.Removed != removed {
+				t.Fatalf("event %d mismatch: have %x removed %v, want %x removed %v", i, ev.Hash, ev.Removed, hashes[i], removed)
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("no notification received")
+	}
+}
+
+// Tests that pending transactions are delivered to the subscriptions whose
+// criteria they match.
+func TestPendingTxsSubscription(t *testing.T) {
+	var (
+		backend  = &testBackend{db: rawdb.NewMemoryDatabase()}
+		es       = NewEventSystem(backend)
+		transfer = types.Transaction(types.NewTransferTransaction(types.OneByte{1}, types.OneByte{1}, types.Byte32s("tau-chain"), 0, 0, big.NewInt(1), common.HexToAddress("0x01"), common.HexToAddress("0x02"), big.NewInt(10)))
+		message  = types.Transaction(newTestMessage(0, common.Hash{}))
+		all      = make(chan []*TxEvent)
+		messages = make(chan []*TxEvent)
+	)
+	allSub := es.SubscribePendingTxs(nil, all)
+	defer allSub.Unsubscribe()
+	msgSub := es.SubscribePendingTxs(&FilterCriteria{Kinds: []types.TxKind{types.NewMessageTxKind}}, messages)
+	defer msgSub.Unsubscribe()
+
+	go backend.txsFeed.Send(core.NewTxsEvent{Txs: []*types.Transaction{&transfer, &message}})
+
+	expectTxEvents(t, all, []common.Hash{transfer.Hash(), message.Hash()}, false)
+	expectTxEvents(t, messages, []common.Hash{message.Hash()}, false)
+}
+
+// Tests that a reorg delivers the transactions of the dropped blocks with the
+// removed flag before the ones of the new blocks, in the order of the chain
+// changes, and that block subscriptions only see the added headers.
+func TestChainTxsSubscriptionReorg(t *testing.T) {
+	var (
+		backend = &testBackend{db: rawdb.NewMemoryDatabase()}
+		es      = NewEventSystem(backend)
+		oldMsg  = newTestMessage(0, common.Hash{})
+		newMsg  = newTestMessage(1, common.Hash{})
+		headMsg = newTestMessage(2, common.Hash{})
+		oldHead = newTestBlock(1, oldMsg)
+		newHead = newTestBlock(1, newMsg)
+		next    = newTestBlock(2, headMsg)
+		txs     = make(chan []*TxEvent)
+		headers = make(chan *types.Header, 4)
+	)
+	txSub := es.SubscribeChainTxs(nil, txs)
+	defer txSub.Unsubscribe()
+	headSub := es.SubscribeNewHeads(headers)
+	defer headSub.Unsubscribe()
+
+	// Post the events like the blockchain does, import and reorg of the head
+	go func() {
+		backend.chainFeed.Send(core.ChainEvent{Block: oldHead, Hash: oldHead.Hash()})
+		backend.chainTxsFeed.Send(core.ChainTxsEvent{Block: oldHead})
+		backend.chainTxsFeed.Send(core.ChainTxsEvent{Block: oldHead, Removed: true})
+		backend.chainFeed.Send(core.ChainEvent{Block: newHead, Hash: newHead.Hash()})
+		backend.chainTxsFeed.Send(core.ChainTxsEvent{Block: newHead})
+		backend.chainFeed.Send(core.ChainEvent{Block: next, Hash: next.Hash()})
+		backend.chainTxsFeed.Send(core.ChainTxsEvent{Block: next})
+	}()
+	expectTxEvents(t, txs, []common.Hash{oldMsg.Hash()}, false)
+	expectTxEvents(t, txs, []common.Hash{oldMsg.Hash()}, true)
+	expectTxEvents(t, txs, []common.Hash{newMsg.Hash()}, false)
+	expectTxEvents(t, txs, []common.Hash{headMsg.Hash()}, false)
+
+	for i, want := range []*types.Block{oldHead, newHead, next} {
+		select {
+		case header := <-headers:
+			if header.Hash() != want.Hash() {
+				t.Fatalf("header %d mismatch: have %x, want %x", i, header.Hash(), want.Hash())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("header %d not delivered", i)
+		}
+	}
+}
+
+// Tests that unsubscribed filters stop receiving events and that the event
+// loop keeps serving the others.
+func TestUnsubscribe(t *testing.T) {
+	var (
+		backend = &testBackend{db: rawdb.NewMemoryDatabase()}
+		es      = NewEventSystem(backend)
+		msg     = types.Transaction(newTestMessage(0, common.Hash{}))
+		first   = make(chan []*TxEvent)
+		second  = make(chan []*TxEvent)
+	)
+	firstSub := es.SubscribePendingTxs(nil, first)
+	secondSub := es.SubscribePendingTxs(nil, second)
+	defer secondSub.Unsubscribe()
+
+	firstSub.Unsubscribe()
+	select {
+	case <-firstSub.Err():
+	default:
+		t.Fatalf("unsubscribed filter still installed")
+	}
+	go backend.txsFeed.Send(core.NewTxsEvent{Txs: []*types.Transaction{&msg}})
+	expectTxEvents(t, second, []common.Hash{msg.Hash()}, false)
+
+	select {
+	case events := <-first:
+		t.Fatalf("unsubscribed filter notified: %v", events)
+	default:
+	}
+}
+
+// Tests that stopping the event system terminates the installed subscriptions
+// and the ones created afterwards.
+func TestEventSystemStop(t *testing.T) {
+	var (
+		backend = &testBackend{db: rawdb.NewMemoryDatabase()}
+		es      = NewEventSystem(backend)
+		headers = make(chan *types.Header)
+	)
+	sub := es.SubscribeNewHeads(headers)
+	es.Stop()
+
+	select {
+	case <-sub.Err():
+	case <-time.After(time.Second):
+		t.Fatalf("installed subscription not terminated")
+	}
+	sub.Unsubscribe()
+
+	late := es.SubscribePendingTxs(nil, make(chan []*TxEvent))
+	select {
+	case <-late.Err():
+	default:
+		t.Fatalf("subscription created after stop not terminated")
+	}
+	late.Unsubscribe()
+	es.Stop()
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package filters
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+)
+
+func TestFilterCriteriaMatches(t *testing.T) {
+	var (
+		chain    = types.Byte32s("tau-chain")
+		other    = types.Byte32s("other-chain")
+		alice    = common.HexToAddress("0x01")
+		bob      = common.HexToAddress("0x02")
+		transfer = types.NewTransferTransaction(types.OneByte{1}, types.OneByte{1}, chain, 0, 0, big.NewInt(1), alice, bob, big.NewInt(10))
+		message  = types.NewMessageTransaction(types.OneByte{1}, types.OneByte{1}, other, 0, 0, big.NewInt(1), bob, common.Hash{}, types.Byte144s("title"), types.Byte32s("content"))
+		db       = rawdb.NewMemoryDatabase()
+	)
+	tests := []struct {
+		crit     *FilterCriteria
+		transfer bool
+		message  bool
+	}{
+		{nil, true, true},
+		{&FilterCriteria{}, true, true},
+		{&FilterCriteria{Kinds: []types.TxKind{types.TransferTxKind}}, true, false},
+		{&FilterCriteria{Kinds: []types.TxKind{types.TransferTxKind, types.NewMessageTxKind}}, true, true},
+		{&FilterCriteria{Senders: []common.Address{bob}}, false, true},
+		{&FilterCriteria{Receivers: []common.Address{bob}}, true, false},
+		{&FilterCriteria{Participants: []common.Address{bob}}, true, true},
+		{&FilterCriteria{Participants: []common.Address{alice}}, true, false},
+		{&FilterCriteria{ChainIDs: []hexutil.Bytes{hexutil.Bytes(chain)}}, true, false},
+		{&FilterCriteria{Threads: []common.Hash{message.Hash()}}, false, true},
+		{&FilterCriteria{Kinds: []types.TxKind{types.TransferTxKind}, Senders: []common.Address{bob}}, false, false},
+	}
+	for i, tt := range tests {
+		if have := tt.crit.Matches(db, transfer); have != tt.transfer {
+			t.Errorf("test %d: transfer match mismatch: have %v, want %v", i, have, tt.transfer)
+		}
+		if have := tt.crit.Matches(db, message); have != tt.message {
+			t.Errorf("test %d: message match mismatch: have %v, want %v", i, have, tt.message)
+		}
+	}
+}
+
+// newTestMessage creates a message of bob replying to the given one.
+func newTestMessage(nonce uint64, referid common.Hash) *types.NewMessageTx {
+	return types.NewMessageTransaction(types.OneByte{1}, types.OneByte{1}, types.Byte32s("tau-chain"), nonce, 0, big.NewInt(1), common.HexToAddress("0x02"), referid, types.Byte144s("title"), types.Byte32s("content"))
+}
+
+// newTestBlock creates a block of the given number holding the given messages.
+func newTestBlock(number int64, msgs ...*types.NewMessageTx) *types.Block {
+	var txs []*types.Transaction
+	for _, msg := range msgs {
+		tx := types.Transaction(msg)
+		txs = append(txs, &tx)
+	}
+	return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(number)}).WithBody(txs)
+}
+
+// Tests that replies are matched by the root of their thread, not only by the
+// message they directly reply to, whether they are indexed or still pending.
+func TestFilterThreadRoot(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		root  = newTestMessage(0, common.Hash{})
+		reply = newTestMessage(1, root.Hash())
+		deep  = newTestMessage(2, reply.Hash())
+		crit  = &FilterCriteria{Threads: []common.Hash{root.Hash()}}
+	)
+	// Index the whole thread in a single batch, the deepest reply only knows
+	// its direct parent at that time
+	batch := db.NewBatch()
+	rawdb.WriteMessageThreads(db, batch, newTestBlock(1, root, reply, deep))
+	if err := batch.Write(); err != nil {
+		t.Fatalf("failed to write thread index: %v", err)
+	}
+	for i, msg := range []*types.NewMessageTx{root, reply, deep} {
+		if have := rawdb.ReadMessageThread(db, msg.Hash()); have != root.Hash() {
+			t.Errorf("message %d: indexed thread mismatch: have %x, want %x", i, have, root.Hash())
+		}
+		if !crit.Matches(db, msg) {
+			t.Errorf("message %d: not matched by its thread root", i)
+		}
+	}
+	// A pending reply to the deepest message belongs to the same thread
+	pending := newTestMessage(3, deep.Hash())
+	if !crit.Matches(db, pending) {
+		t.Errorf("pending reply not matched by its thread root")
+	}
+	if ev := newTxEvent(db, pending, nil, false); ev.Thread == nil || *ev.Thread != root.Hash() {
+		t.Errorf("pending reply event thread mismatch: have %v, want %x", ev.Thread, root.Hash())
+	}
+	if (&FilterCriteria{Threads: []common.Hash{reply.Hash()}}).Matches(db, deep) {
+		t.Errorf("reply matched by an intermediate message of its thread")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/miner"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/downloader"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/feeoracle"
 )
 
 // MarshalTOML marshals as TOML.
@@ -21,6 +22,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		SyncMode        downloader.SyncMode
 		NoPruning       bool
 		NoPrefetch      bool
+		TxLookupLimit   uint64                 `toml:",omitempty"`
 		Whitelist       map[uint64]common.Hash `toml:"-"`
 		DatabaseHandles int                    `toml:"-"`
 		DatabaseCache   int
@@ -31,6 +33,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner           miner.Config
 		Tauash          tauhash.Config
 		TxPool          core.TxPoolConfig
+		FeeOracle       feeoracle.Config
 		DocRoot         string                    `toml:"-"`
 		Checkpoint      *params.TrustedCheckpoint `toml:",omitempty"`
 	}
@@ -40,6 +43,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.SyncMode = c.SyncMode
 	enc.NoPruning = c.NoPruning
 	enc.NoPrefetch = c.NoPrefetch
+	enc.TxLookupLimit = c.TxLookupLimit
 	enc.Whitelist = c.Whitelist
 	enc.DatabaseHandles = c.DatabaseHandles
 	enc.DatabaseCache = c.DatabaseCache
@@ -50,6 +54,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.Tauash = c.Tauash
 	enc.TxPool = c.TxPool
+	enc.FeeOracle = c.FeeOracle
 	enc.DocRoot = c.DocRoot
 	enc.Checkpoint = c.Checkpoint
 	return &enc, nil
@@ -63,6 +68,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		SyncMode        *downloader.SyncMode
 		NoPruning       *bool
 		NoPrefetch      *bool
+		TxLookupLimit   *uint64                `toml:",omitempty"`
 		Whitelist       map[uint64]common.Hash `toml:"-"`
 		DatabaseHandles *int                   `toml:"-"`
 		DatabaseCache   *int
@@ -73,6 +79,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner           *miner.Config
 		Tauash          *tauhash.Config
 		TxPool          *core.TxPoolConfig
+		FeeOracle       *feeoracle.Config
 		DocRoot         *string                   `toml:"-"`
 		Checkpoint      *params.TrustedCheckpoint `toml:",omitempty"`
 	}
@@ -95,6 +102,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.NoPrefetch != nil {
 		c.NoPrefetch = *dec.NoPrefetch
 	}
+	if dec.TxLookupLimit != nil {
+		c.TxLookupLimit = *dec.TxLookupLimit
+	}
 	if dec.Whitelist != nil {
 		c.Whitelist = dec.Whitelist
 	}
@@ -125,6 +135,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TxPool != nil {
 		c.TxPool = *dec.TxPool
 	}
+	if dec.FeeOracle != nil {
+		c.FeeOracle = *dec.FeeOracle
+	}
 	if dec.DocRoot != nil {
 		c.DocRoot = *dec.DocRoot
 	}
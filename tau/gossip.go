@@ -0,0 +1,720 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/consensus"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	lru "github.com/hashicorp/golang-lru"
+	cid "github.com/ipfs/go-cid"
+	coreiface "github.com/ipfs/interface-go-ipfs-core"
+	caopts "github.com/ipfs/interface-go-ipfs-core/options"
+	"github.com/ipfs/interface-go-ipfs-core/path"
+	libp2ppeer "github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	mh "github.com/multiformats/go-multihash"
+)
+
+// Kinds of the per-chain gossip topics.
+const (
+	gossipBlocks = "blocks" // CIDs of newly mined blocks
+	gossipTxs    = "txs"    // Transactions entering the pool
+	gossipRelays = "relays" // Relays the sender is reachable through
+)
+
+const (
+	gossipMaxAge        = 5 * time.Minute  // Maximum age of an accepted message, bounding replays
+	gossipMaxSkew       = 30 * time.Second // Maximum clock skew of an accepted message
+	gossipRateWindow    = 10 * time.Second // Window of the per peer and per signer rate limits
+	gossipRateLimit     = 64               // Messages accepted per propagating peer and per signer within a window
+	gossipSeenCache     = 4096             // Number of message and transaction hashes remembered for deduplication
+	gossipFetchTimeout  = 10 * time.Second // Time allowed for retrieving a gossiped block from IPFS
+	gossipRelayInterval = 10 * time.Minute // Interval of republishing the local relays and syncing the relay topics
+	gossipMaxTxs        = 256              // Maximum number of transactions in a single message
+	gossipMaxRelays     = 8                // Maximum number of relays in a single message
+
+	// gossipCircuitRelay is the relay type of the libp2p circuit relays gossiped
+	// by the nodes reachable through them.
+	gossipCircuitRelay = common.RelayType("libp2p-circuit")
+)
+
+var (
+	errGossipSignature = errors.New("invalid signature")
+	errGossipStale     = errors.New("stale or future message")
+	errGossipDuplicate = errors.New("duplicate message")
+	errGossipRateLimit = errors.New("rate limit exceeded")
+	errGossipNotSynced = errors.New("transactions not accepted before sync")
+	errGossipUseless   = errors.New("no transaction accepted by the pool")
+	errGossipKnown     = errors.New("known block")
+	errGossipMismatch  = errors.New("block content mismatch")
+	errGossipCapped    = errors.New("data cap reached")
+	errGossipUnfunded  = errors.New("signer holds no funds")
+	errGossipSender    = errors.New("transaction not sent by the signer")
+)
+
+// gossipTopic returns the name of the pubsub topic of the given kind of chain data.
+func gossipTopic(chain common.ChainID, kind string) string {
+	return "/tau/" + string(chain[:]) + "/" + kind
+}
+
+// gossipPacket is the envelope of every gossip message, signed by the account
+// of its author. The signature covers the topic, so a message can't be replayed
+// on another chain or kind.
+type gossipPacket struct {
+	Payload rlp.RawValue
+	Time    uint64 // Unix time of signing, bounding replays
+	Sig     []byte
+}
+
+// gossipBlock is the payload announcing a new block stored in IPFS.
+type gossipBlock struct {
+	Hash   common.Hash
+	Number uint64
+	CID    []byte // CID of the RLP encoded block
+}
+
+// gossipRelay is a relay the author of a message is reachable through.
+type gossipRelay struct {
+	Type   common.RelayType
+	Addr   common.RelayMultiAdd
+	Number uint64 // Head of the chain when the relay was published
+}
+
+// gossipSigData returns the data whose keccak256 hash is signed by the author
+// of a gossip message.
+func gossipSigData(topic string, payload []byte, time uint64) []byte {
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], time)
+
+	data := make([]byte, 0, len(topic)+len(payload)+len(enc))
+	data = append(data, topic...)
+	data = append(data, payload...)
+	return append(data, enc[:]...)
+}
+
+// gossipSigner signs the published messages with the keys of the local accounts,
+// implemented by the account manager.
+type gossipSigner interface {
+	// Account returns the account publishing the blocks and relays.
+	Account() (common.Address, error)
+
+	// SignData signs the keccak256 hash of the data with the key of the given
+	// local account.
+	SignData(account common.Address, data []byte) ([]byte, error)
+}
+
+// signer recovers the address of the author of the packet on the given topic.
+func (p *gossipPacket) signer(topic string) (common.Address, error) {
+	pubkey, err := crypto.SigToPub(crypto.Keccak256(gossipSigData(topic, p.Payload, p.Time)), p.Sig)
+	if err != nil {
+		return common.Address{}, errGossipSignature
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
+
+// gossipStore is the subset of the IPFS block API gossiped blocks are shared
+// through.
+type gossipStore interface {
+	Put(data []byte) (cid.Cid, error)
+	Get(ctx context.Context, c cid.Cid) ([]byte, error)
+}
+
+// ipfsGossipStore stores the gossiped blocks in the embedded IPFS node.
+type ipfsGossipStore struct {
+	api coreiface.BlockAPI
+}
+
+// Put stores a raw block keyed by its keccak256 hash, the same way the chain
+// database does.
+func (s ipfsGossipStore) Put(data []byte) (cid.Cid, error) {
+	stat, err := s.api.Put(context.Background(), bytes.NewReader(data), caopts.Block.Format("raw"), caopts.Block.Hash(mh.KECCAK_256, -1))
+	if err != nil {
+		return cid.Cid{}, err
+	}
+	return stat.Path().Cid(), nil
+}
+
+// Get retrieves a raw block, locally or from the swarm.
+func (s ipfsGossipStore) Get(ctx context.Context, c cid.Cid) ([]byte, error) {
+	r, err := s.api.Get(ctx, path.IpfsPath(c))
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+// relayBook records the relays learnt from the gossip, implemented by the
+// user database.
+type relayBook interface {
+	AddRelay(typ common.RelayType, addr common.RelayMultiAdd, chainid common.ChainID, blocknum uint64, time uint32)
+}
+
+// joinedTopic is a gossip topic subscribed to.
+type joinedTopic struct {
+	topic *pubsub.Topic
+	sub   *pubsub.Subscription
+}
+
+// gossip propagates blocks, transactions and relays over the pubsub of the IPFS
+// node, a fallback for nodes devp2p can't reach. Every message is signed by an
+// account funded on the local chain, the sender of the transactions it carries,
+// and validated before it's forwarded: transactions by the pool, blocks by the
+// consensus engine before entering the fetcher, as if they had been propagated
+// by a tau peer. The gossip is paused while the data
+// cap of IPFS or of the tau protocol is reached.
+type gossip struct {
+	pm     *ProtocolManager
+	ps     *pubsub.PubSub
+	store  gossipStore
+	signer gossipSigner  // Local accounts signing the published messages
+	self   libp2ppeer.ID // IPFS peer id of the local node
+	relays relayBook
+
+	chains func() []common.ChainID // Chains whose relays are exchanged
+	addrs  func() []string         // Multiaddresses of the local node
+
+	topics map[string]*joinedTopic // Topics subscribed to, by name
+	seen   *lru.Cache              // Hashes of the seen payloads and transactions
+
+	rates     map[string]int // Messages accepted per peer and signer in the current window
+	rateStart time.Time      // Start of the current rate limit window
+	lock      sync.Mutex     // Protects the topics and the rate limits
+
+	capped uint32 // Flag whether the IPFS data cap is reached
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newGossip(pm *ProtocolManager, ps *pubsub.PubSub, store gossipStore, signer gossipSigner, self libp2ppeer.ID, relays relayBook, chains func() []common.ChainID, addrs func() []string) *gossip {
+	seen, _ := lru.New(gossipSeenCache)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &gossip{
+		pm:        pm,
+		ps:        ps,
+		store:     store,
+		signer:    signer,
+		self:      self,
+		relays:    relays,
+		chains:    chains,
+		addrs:     addrs,
+		topics:    make(map[string]*joinedTopic),
+		seen:      seen,
+		rates:     make(map[string]int),
+		rateStart: time.Now(),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// start joins the topics of the local chain and of the followed chains, and
+// starts publishing the local blocks, transactions and relays.
+func (g *gossip) start() error {
+	if err := g.join(g.pm.chainID, gossipBlocks); err != nil {
+		return err
+	}
+	if err := g.join(g.pm.chainID, gossipTxs); err != nil {
+		return err
+	}
+	g.refresh()
+
+	g.wg.Add(1)
+	go g.loop()
+	return nil
+}
+
+// stop leaves all topics and stops publishing.
+func (g *gossip) stop() {
+	g.cancel()
+	g.wg.Wait()
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for name := range g.topics {
+		g.leave(name)
+	}
+}
+
+// loop publishes the mined blocks and new transactions, and periodically the
+// local relays.
+func (g *gossip) loop() {
+	defer g.wg.Done()
+
+	var (
+		txsCh    = make(chan core.NewTxsEvent, txChanSize)
+		txsSub   = g.pm.txpool.SubscribeNewTxsEvent(txsCh)
+		minedSub = g.pm.eventMux.Subscribe(core.NewMinedBlockEvent{})
+	)
+	defer txsSub.Unsubscribe()
+	defer minedSub.Unsubscribe()
+
+	ticker := time.NewTicker(gossipRelayInterval)
+	defer ticker.Stop()
+
+	if !g.paused() {
+		g.publishRelays()
+	}
+	for {
+		select {
+		case ev := <-txsCh:
+			if !g.paused() {
+				g.publishTxs(ev.Txs)
+			}
+
+		case obj, ok := <-minedSub.Chan():
+			if !ok {
+				return
+			}
+			if ev, ok := obj.Data.(core.NewMinedBlockEvent); ok && !g.paused() {
+				g.publishBlock(ev.Block)
+			}
+
+		case <-ticker.C:
+			g.refresh()
+			if !g.paused() {
+				g.publishRelays()
+			}
+
+		case <-g.ctx.Done():
+			return
+		}
+	}
+}
+
+// setCapped pauses or resumes the gossip as the IPFS data cap is reached or
+// lifted.
+func (g *gossip) setCapped(capped bool) {
+	if capped {
+		atomic.StoreUint32(&g.capped, 1)
+	} else {
+		atomic.StoreUint32(&g.capped, 0)
+	}
+}
+
+// paused reports whether the gossip is paused by a data cap, in which case
+// nothing is published, fetched from IPFS or forwarded.
+func (g *gossip) paused() bool {
+	return atomic.LoadUint32(&g.capped) == 1 || g.pm.isCapped()
+}
+
+// refresh joins the relay topics of the chains followed since the last refresh
+// and leaves those of the unfollowed ones.
+func (g *gossip) refresh() {
+	followed := make(map[string]bool)
+	for _, chain := range g.chains() {
+		name := gossipTopic(chain, gossipRelays)
+		followed[name] = true
+		if err := g.join(chain, gossipRelays); err != nil {
+			log.Debug("Failed to join relay topic", "topic", name, "err", err)
+		}
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for name := range g.topics {
+		if strings.HasSuffix(name, "/"+gossipRelays) && !followed[name] {
+			g.leave(name)
+		}
+	}
+}
+
+// join subscribes to a topic, validating its messages before they're forwarded.
+func (g *gossip) join(chain common.ChainID, kind string) error {
+	name := gossipTopic(chain, kind)
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, ok := g.topics[name]; ok {
+		return nil
+	}
+	validator := func(ctx context.Context, from libp2ppeer.ID, msg *pubsub.Message) bool {
+		if err := g.validate(chain, kind, from, msg.Data); err != nil {
+			log.Trace("Rejected gossip message", "topic", name, "peer", from, "err", err)
+			return false
+		}
+		return true
+	}
+	if err := g.ps.RegisterTopicValidator(name, validator, pubsub.WithValidatorTimeout(gossipFetchTimeout)); err != nil {
+		return err
+	}
+	topic, err := g.ps.Join(name)
+	if err != nil {
+		g.ps.UnregisterTopicValidator(name)
+		return err
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		topic.Close()
+		g.ps.UnregisterTopicValidator(name)
+		return err
+	}
+	g.topics[name] = &joinedTopic{topic: topic, sub: sub}
+
+	// The messages are delivered by the validator, only drain the subscription
+	go func() {
+		for {
+			if _, err := sub.Next(g.ctx); err != nil {
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// leave unsubscribes from a topic. The lock must be held.
+func (g *gossip) leave(name string) {
+	joined := g.topics[name]
+	delete(g.topics, name)
+
+	joined.sub.Cancel()
+	if err := joined.topic.Close(); err != nil {
+		log.Trace("Failed to close gossip topic", "topic", name, "err", err)
+	}
+	g.ps.UnregisterTopicValidator(name)
+}
+
+// validate checks the envelope of a message and hands its payload to the chain
+// machinery, returning an error if it must not be forwarded.
+func (g *gossip) validate(chain common.ChainID, kind string, from libp2ppeer.ID, data []byte) error {
+	// Messages published locally have been validated when created
+	if from == g.self {
+		return nil
+	}
+	if g.paused() {
+		return errGossipCapped
+	}
+	topic := gossipTopic(chain, kind)
+
+	var packet gossipPacket
+	if err := rlp.DecodeBytes(data, &packet); err != nil {
+		return err
+	}
+	signer, err := packet.signer(topic)
+	if err != nil {
+		return err
+	}
+	if !g.funded(signer) {
+		return errGossipUnfunded
+	}
+	sent := time.Unix(int64(packet.Time), 0)
+	if age := time.Since(sent); age > gossipMaxAge || age < -gossipMaxSkew {
+		return errGossipStale
+	}
+	// Drop payloads seen before, whoever signed them, and enforce the rate limits
+	hash := crypto.Keccak256Hash(packet.Payload)
+	if g.seen.Contains(hash) {
+		return errGossipDuplicate
+	}
+	if !g.allow("peer:"+from.Pretty(), "signer:"+signer.Hex()) {
+		return errGossipRateLimit
+	}
+	g.seen.Add(hash, nil)
+
+	switch kind {
+	case gossipBlocks:
+		return g.handleBlock(from, packet.Payload)
+	case gossipTxs:
+		return g.handleTxs(signer, packet.Payload)
+	case gossipRelays:
+		return g.handleRelays(chain, packet.Payload)
+	}
+	return fmt.Errorf("unknown gossip kind %q", kind)
+}
+
+// funded reports whether the given account holds funds on the local chain,
+// binding the rate limits of the signers to accounts that can't be created for
+// free.
+func (g *gossip) funded(account common.Address) bool {
+	statedb, err := g.pm.blockchain.State()
+	if err != nil {
+		return false
+	}
+	return statedb.GetBalance(account).Sign() > 0
+}
+
+// allow counts a message against the rate limits of the given keys, reporting
+// whether none is exceeded.
+func (g *gossip) allow(keys ...string) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if time.Since(g.rateStart) > gossipRateWindow {
+		g.rates = make(map[string]int)
+		g.rateStart = time.Now()
+	}
+	for _, key := range keys {
+		if g.rates[key] >= gossipRateLimit {
+			return false
+		}
+	}
+	for _, key := range keys {
+		g.rates[key]++
+	}
+	return true
+}
+
+// handleBlock retrieves a gossiped block from IPFS, verifies its header and
+// schedules it for import through the fetcher.
+func (g *gossip) handleBlock(from libp2ppeer.ID, payload []byte) error {
+	var announce gossipBlock
+	if err := rlp.DecodeBytes(payload, &announce); err != nil {
+		return err
+	}
+	if g.pm.blockchain.HasBlock(announce.Hash, announce.Number) {
+		return errGossipKnown
+	}
+	c, err := cid.Cast(announce.CID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(g.ctx, gossipFetchTimeout)
+	defer cancel()
+
+	blob, err := g.store.Get(ctx, c)
+	if err != nil {
+		return err
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(blob, block); err != nil {
+		return err
+	}
+	if block.Hash() != announce.Hash || block.NumberU64() != announce.Number {
+		return errGossipMismatch
+	}
+	if types.DeriveSha(block.Transactions()) != block.TxHash() {
+		return errGossipMismatch
+	}
+	// Blocks of unknown ancestry are imported but not forwarded, the fetcher
+	// queues them until their parent arrives
+	verr := g.pm.blockchain.Engine().VerifyHeader(g.pm.blockchain, block.Header(), true)
+	if verr != nil && verr != consensus.ErrUnknownAncestor {
+		return verr
+	}
+	block.ReceivedAt = time.Now()
+	if err := g.pm.fetcher.Enqueue("gossip:"+from.Pretty(), block); err != nil {
+		return err
+	}
+	return verr
+}
+
+// handleTxs adds gossiped transactions to the pool, all sent by the signer of
+// the message.
+func (g *gossip) handleTxs(signer common.Address, payload []byte) error {
+	if atomic.LoadUint32(&g.pm.acceptTxs) == 0 {
+		return errGossipNotSynced
+	}
+	var txs []*types.Transaction
+	if err := rlp.DecodeBytes(payload, &txs); err != nil {
+		return err
+	}
+	if len(txs) > gossipMaxTxs {
+		return fmt.Errorf("too many transactions: %d", len(txs))
+	}
+	for i, tx := range txs {
+		if tx == nil {
+			return fmt.Errorf("transaction %d is nil", i)
+		}
+		if types.SenderOf(*tx) != signer {
+			return errGossipSender
+		}
+		// Don't gossip the transactions back once they enter the pool
+		g.seen.Add((*tx).Hash(), nil)
+	}
+	for _, err := range g.pm.txpool.AddRemotes(txs) {
+		if err == nil {
+			return nil
+		}
+	}
+	return errGossipUseless
+}
+
+// handleRelays records the relays gossiped on a chain.
+func (g *gossip) handleRelays(chain common.ChainID, payload []byte) error {
+	var relays []gossipRelay
+	if err := rlp.DecodeBytes(payload, &relays); err != nil {
+		return err
+	}
+	if len(relays) > gossipMaxRelays {
+		return fmt.Errorf("too many relays: %d", len(relays))
+	}
+	for _, relay := range relays {
+		if !strings.HasPrefix(string(relay.Addr), "/") {
+			return fmt.Errorf("invalid relay address %q", relay.Addr)
+		}
+	}
+	now := uint32(time.Now().Unix())
+	for _, relay := range relays {
+		g.relays.AddRelay(relay.Type, relay.Addr, chain, relay.Number, now)
+	}
+	return nil
+}
+
+// publish signs a payload with the given local account and publishes it on the
+// topic of the given chain and kind.
+func (g *gossip) publish(chain common.ChainID, kind string, account common.Address, payload interface{}) error {
+	name := gossipTopic(chain, kind)
+
+	g.lock.Lock()
+	joined := g.topics[name]
+	g.lock.Unlock()
+	if joined == nil {
+		return fmt.Errorf("gossip topic %s not joined", name)
+	}
+	packet, err := g.pack(name, account, payload)
+	if err != nil {
+		return err
+	}
+	data, err := rlp.EncodeToBytes(packet)
+	if err != nil {
+		return err
+	}
+	return joined.topic.Publish(g.ctx, data)
+}
+
+// pack creates the envelope of a payload on the given topic signed by the given
+// local account, marking it as seen.
+func (g *gossip) pack(topic string, account common.Address, payload interface{}) (*gossipPacket, error) {
+	blob, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	packet := &gossipPacket{Payload: blob, Time: uint64(time.Now().Unix())}
+	if packet.Sig, err = g.signer.SignData(account, gossipSigData(topic, packet.Payload, packet.Time)); err != nil {
+		return nil, err
+	}
+	g.seen.Add(crypto.Keccak256Hash(blob), nil)
+	return packet, nil
+}
+
+// publishBlock stores a mined block in IPFS and publishes its CID.
+func (g *gossip) publishBlock(block *types.Block) {
+	account, err := g.signer.Account()
+	if err != nil {
+		log.Debug("No account to gossip block with", "err", err)
+		return
+	}
+	blob, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		log.Error("Failed to encode gossiped block", "err", err)
+		return
+	}
+	c, err := g.store.Put(blob)
+	if err != nil {
+		log.Warn("Failed to store gossiped block", "number", block.Number(), "hash", block.Hash(), "err", err)
+		return
+	}
+	announce := &gossipBlock{Hash: block.Hash(), Number: block.NumberU64(), CID: c.Bytes()}
+	if err := g.publish(g.pm.chainID, gossipBlocks, account, announce); err != nil {
+		log.Debug("Failed to gossip block", "number", block.Number(), "hash", block.Hash(), "err", err)
+	}
+}
+
+// publishTxs publishes the transactions of the local accounts not seen on the
+// gossip yet, each batch signed by their sender. Remote transactions are left
+// to their own senders.
+func (g *gossip) publishTxs(txs []*types.Transaction) {
+	locals := make(map[common.Address]bool)
+	for _, account := range g.pm.txpool.Locals() {
+		locals[account] = true
+	}
+	var (
+		senders []common.Address
+		batches = make(map[common.Address][]*types.Transaction)
+	)
+	for _, tx := range txs {
+		sender := types.SenderOf(*tx)
+		if !locals[sender] {
+			continue
+		}
+		hash := (*tx).Hash()
+		if g.seen.Contains(hash) {
+			continue
+		}
+		g.seen.Add(hash, nil)
+		if batches[sender] == nil {
+			senders = append(senders, sender)
+		}
+		if batches[sender] = append(batches[sender], tx); len(batches[sender]) == gossipMaxTxs {
+			g.publishBatch(sender, batches[sender])
+			batches[sender] = batches[sender][:0]
+		}
+	}
+	for _, sender := range senders {
+		if batch := batches[sender]; len(batch) > 0 {
+			g.publishBatch(sender, batch)
+		}
+	}
+}
+
+// publishBatch publishes a batch of transactions of the given local sender.
+func (g *gossip) publishBatch(sender common.Address, batch []*types.Transaction) {
+	if err := g.publish(g.pm.chainID, gossipTxs, sender, batch); err != nil {
+		log.Debug("Failed to gossip transactions", "sender", sender, "count", len(batch), "err", err)
+	}
+}
+
+// localRelays returns the circuit relays the local node is reachable through,
+// the part of its relayed addresses preceding the circuit.
+func (g *gossip) localRelays() []gossipRelay {
+	var (
+		relays []gossipRelay
+		number = g.pm.blockchain.CurrentHeader().Number.Uint64()
+	)
+	for _, addr := range g.addrs() {
+		if i := strings.Index(addr, "/p2p-circuit"); i > 0 && len(relays) < gossipMaxRelays {
+			relays = append(relays, gossipRelay{Type: gossipCircuitRelay, Addr: common.RelayMultiAdd(addr[:i]), Number: number})
+		}
+	}
+	return relays
+}
+
+// publishRelays publishes the local relays on the topics of the followed chains.
+func (g *gossip) publishRelays() {
+	relays := g.localRelays()
+	if len(relays) == 0 {
+		return
+	}
+	account, err := g.signer.Account()
+	if err != nil {
+		log.Debug("No account to gossip relays with", "err", err)
+		return
+	}
+	for _, chain := range g.chains() {
+		if err := g.publish(chain, gossipRelays, account, relays); err != nil {
+			log.Debug("Failed to gossip relays", "chain", string(chain[:8]), "err", err)
+		}
+	}
+}
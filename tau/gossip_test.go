@@ -0,0 +1,317 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/userdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/downloader"
+	cid "github.com/ipfs/go-cid"
+	libp2ppeer "github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+)
+
+// testGossipStore is an in-memory IPFS block store.
+type testGossipStore map[string][]byte
+
+func (s testGossipStore) Put(data []byte) (cid.Cid, error) {
+	hash, err := mh.Sum(data, mh.KECCAK_256, -1)
+	if err != nil {
+		return cid.Cid{}, err
+	}
+	c := cid.NewCidV1(cid.Raw, hash)
+	s[c.KeyString()] = data
+	return c, nil
+}
+
+func (s testGossipStore) Get(ctx context.Context, c cid.Cid) ([]byte, error) {
+	if data, ok := s[c.KeyString()]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("block %v not found", c)
+}
+
+// testRelayBook collects the recorded relays.
+type testRelayBook []common.RelayMultiAdd
+
+func (b *testRelayBook) AddRelay(typ common.RelayType, addr common.RelayMultiAdd, chainid common.ChainID, blocknum uint64, time uint32) {
+	*b = append(*b, addr)
+}
+
+// testGossipSigner signs with the key of a single account.
+type testGossipSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s testGossipSigner) Account() (common.Address, error) {
+	return crypto.PubkeyToAddress(s.key.PublicKey), nil
+}
+
+func (s testGossipSigner) SignData(account common.Address, data []byte) ([]byte, error) {
+	if account != crypto.PubkeyToAddress(s.key.PublicKey) {
+		return nil, errors.New("unknown account")
+	}
+	return crypto.Sign(crypto.Keccak256(data), s.key)
+}
+
+// newTestGossip creates a gossip instance of the given protocol manager, not
+// attached to any pubsub, signing with the funded test account.
+func newTestGossip(pm *ProtocolManager, relays relayBook) *gossip {
+	return newTestGossipWithKey(pm, relays, testBankKey)
+}
+
+// newTestGossipWithKey creates a gossip instance of the given protocol manager,
+// not attached to any pubsub, signing with the given key.
+func newTestGossipWithKey(pm *ProtocolManager, relays relayBook, key *ecdsa.PrivateKey) *gossip {
+	return newGossip(pm, nil, make(testGossipStore), testGossipSigner{key}, libp2ppeer.ID("local"), relays, func() []common.ChainID { return nil }, func() []string { return nil })
+}
+
+// encode creates the signed message of a payload, as published by the gossip.
+func (g *gossip) encode(t *testing.T, kind string, payload interface{}) []byte {
+	account, _ := g.signer.Account()
+	packet, err := g.pack(gossipTopic(g.pm.chainID, kind), account, payload)
+	if err != nil {
+		t.Fatalf("failed to pack payload: %v", err)
+	}
+	data, err := rlp.EncodeToBytes(packet)
+	if err != nil {
+		t.Fatalf("failed to encode packet: %v", err)
+	}
+	return data
+}
+
+// Tests that gossiped transactions are delivered to the pool once, and that
+// malformed envelopes are rejected.
+func TestGossipTxs(t *testing.T) {
+	added := make(chan []*types.Transaction, 1)
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, added)
+	defer pm.Stop()
+
+	var (
+		local  = newTestGossip(pm, nil)
+		remote = newTestGossip(pm, nil)
+		from   = libp2ppeer.ID("remote")
+		txs    = []*types.Transaction{newTestTransaction(testBankKey, 0, 100)}
+		data   = remote.encode(t, gossipTxs, txs)
+	)
+	if err := local.validate(pm.chainID, gossipTxs, from, data); err != errGossipNotSynced {
+		t.Fatalf("unsynced delivery error mismatch: have %v, want %v", err, errGossipNotSynced)
+	}
+	atomic.StoreUint32(&pm.acceptTxs, 1)
+
+	local = newTestGossip(pm, nil)
+	if err := local.validate(pm.chainID, gossipTxs, from, data); err != nil {
+		t.Fatalf("failed to validate transactions: %v", err)
+	}
+	select {
+	case delivered := <-added:
+		if len(delivered) != 1 || (*delivered[0]).Hash() != (*txs[0]).Hash() {
+			t.Fatalf("delivered transactions mismatch")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("transactions not delivered to the pool")
+	}
+	if err := local.validate(pm.chainID, gossipTxs, from, data); err != errGossipDuplicate {
+		t.Fatalf("duplicate error mismatch: have %v, want %v", err, errGossipDuplicate)
+	}
+	// The received transactions must not be gossiped back
+	if !local.seen.Contains((*txs[0]).Hash()) {
+		t.Fatalf("received transaction not marked as seen")
+	}
+	// Reject envelopes with broken signatures or out of their validity window
+	packet := &gossipPacket{Payload: []byte{0xc0}, Time: uint64(time.Now().Unix()), Sig: []byte{1}}
+	if err := local.validate(pm.chainID, gossipTxs, from, mustEncode(t, packet)); err != errGossipSignature {
+		t.Fatalf("broken signature error mismatch: have %v, want %v", err, errGossipSignature)
+	}
+	packet = &gossipPacket{Payload: []byte{0xc0}, Time: uint64(time.Now().Add(-2 * gossipMaxAge).Unix())}
+	packet.Sig, _ = crypto.Sign(crypto.Keccak256(gossipSigData(gossipTopic(pm.chainID, gossipTxs), packet.Payload, packet.Time)), testBankKey)
+	if err := local.validate(pm.chainID, gossipTxs, from, mustEncode(t, packet)); err != errGossipStale {
+		t.Fatalf("stale message error mismatch: have %v, want %v", err, errGossipStale)
+	}
+}
+
+// Tests that gossiped blocks are retrieved from IPFS and checked against their
+// announcement.
+func TestGossipBlocks(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 1, nil, nil)
+	defer pm.Stop()
+
+	var (
+		local  = newTestGossip(pm, nil)
+		remote = newTestGossip(pm, nil)
+		from   = libp2ppeer.ID("remote")
+		head   = pm.blockchain.CurrentBlock()
+	)
+	local.store = remote.store
+
+	blob, _ := rlp.EncodeToBytes(head)
+	c, _ := remote.store.Put(blob)
+
+	known := &gossipBlock{Hash: head.Hash(), Number: head.NumberU64(), CID: c.Bytes()}
+	if err := local.validate(pm.chainID, gossipBlocks, from, remote.encode(t, gossipBlocks, known)); err != errGossipKnown {
+		t.Fatalf("known block error mismatch: have %v, want %v", err, errGossipKnown)
+	}
+	forged := &gossipBlock{Hash: common.Hash{1}, Number: head.NumberU64(), CID: c.Bytes()}
+	if err := local.validate(pm.chainID, gossipBlocks, from, remote.encode(t, gossipBlocks, forged)); err != errGossipMismatch {
+		t.Fatalf("forged block error mismatch: have %v, want %v", err, errGossipMismatch)
+	}
+}
+
+// Tests that gossiped relays are recorded, and that the messages of a signer are
+// rate limited.
+func TestGossipRelays(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, nil)
+	defer pm.Stop()
+
+	var (
+		book   = new(testRelayBook)
+		local  = newTestGossip(pm, book)
+		remote = newTestGossip(pm, nil)
+	)
+	for i := 0; i < gossipRateLimit; i++ {
+		relays := []gossipRelay{{Type: gossipCircuitRelay, Addr: common.RelayMultiAdd(fmt.Sprintf("/ip4/10.0.0.1/tcp/%d", i))}}
+		// Spread the messages over peers, only the signer limit applies
+		from := libp2ppeer.ID(fmt.Sprintf("remote-%d", i))
+		if err := local.validate(pm.chainID, gossipRelays, from, remote.encode(t, gossipRelays, relays)); err != nil {
+			t.Fatalf("relay message %d rejected: %v", i, err)
+		}
+	}
+	if len(*book) != gossipRateLimit {
+		t.Fatalf("recorded relay count mismatch: have %d, want %d", len(*book), gossipRateLimit)
+	}
+	relays := []gossipRelay{{Type: gossipCircuitRelay, Addr: "/ip4/10.0.0.2/tcp/1"}}
+	if err := local.validate(pm.chainID, gossipRelays, libp2ppeer.ID("other"), remote.encode(t, gossipRelays, relays)); err != errGossipRateLimit {
+		t.Fatalf("rate limit error mismatch: have %v, want %v", err, errGossipRateLimit)
+	}
+	invalid := []gossipRelay{{Type: gossipCircuitRelay, Addr: "10.0.0.3"}}
+	if err := newTestGossip(pm, book).validate(pm.chainID, gossipRelays, libp2ppeer.ID("other"), remote.encode(t, gossipRelays, invalid)); err == nil {
+		t.Fatalf("invalid relay address accepted")
+	}
+}
+
+// Tests that messages are only accepted from funded signers, and transactions
+// only from their sender.
+func TestGossipSigner(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, nil)
+	defer pm.Stop()
+	atomic.StoreUint32(&pm.acceptTxs, 1)
+
+	var (
+		local    = newTestGossip(pm, new(testRelayBook))
+		from     = libp2ppeer.ID("remote")
+		fresh, _ = crypto.GenerateKey()
+		relays   = []gossipRelay{{Type: gossipCircuitRelay, Addr: "/ip4/10.0.0.1/tcp/1"}}
+	)
+	unfunded := newTestGossipWithKey(pm, nil, fresh)
+	if err := local.validate(pm.chainID, gossipRelays, from, unfunded.encode(t, gossipRelays, relays)); err != errGossipUnfunded {
+		t.Fatalf("unfunded signer error mismatch: have %v, want %v", err, errGossipUnfunded)
+	}
+	// A funded signer can't relay the transactions of other accounts
+	txs := []*types.Transaction{newTestTransaction(fresh, 0, 100)}
+	if err := local.validate(pm.chainID, gossipTxs, from, newTestGossip(pm, nil).encode(t, gossipTxs, txs)); err != errGossipSender {
+		t.Fatalf("foreign transaction error mismatch: have %v, want %v", err, errGossipSender)
+	}
+}
+
+// Tests that only the transactions of the local accounts are published.
+func TestGossipPublishLocalTxs(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, nil)
+	defer pm.Stop()
+
+	fresh, _ := crypto.GenerateKey()
+	pm.txpool.(*testTxPool).locals = []common.Address{testBank}
+
+	var (
+		g      = newTestGossip(pm, nil)
+		local  = newTestTransaction(testBankKey, 0, 100)
+		remote = newTestTransaction(fresh, 0, 100)
+	)
+	g.publishTxs([]*types.Transaction{local, remote})
+
+	if !g.seen.Contains((*local).Hash()) {
+		t.Fatalf("local transaction not published")
+	}
+	if g.seen.Contains((*remote).Hash()) {
+		t.Fatalf("remote transaction published")
+	}
+}
+
+// Tests that the relay book is bounded, evicting the least recently learnt
+// relays.
+func TestRelayBookBounded(t *testing.T) {
+	udb := userdb.NewUserdb(rawdb.NewMemoryDatabase())
+	for i := 0; i < 2*gossipRateLimit*gossipMaxRelays; i++ {
+		udb.AddRelay(gossipCircuitRelay, common.RelayMultiAdd(fmt.Sprintf("/ip4/10.0.0.1/tcp/%d", i)), common.ChainID{'a'}, 0, uint32(i))
+	}
+	relays := udb.Relays()
+	if len(relays) >= 2*gossipRateLimit*gossipMaxRelays {
+		t.Fatalf("relay book not bounded: %d relays", len(relays))
+	}
+	for _, relay := range relays {
+		if relay.Time < uint32(2*gossipRateLimit*gossipMaxRelays-len(relays)) {
+			t.Fatalf("relay learnt at %d kept over newer ones", relay.Time)
+		}
+	}
+}
+
+func mustEncode(t *testing.T, val interface{}) []byte {
+	data, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", val, err)
+	}
+	return data
+}
+
+// Tests that the gossip stops forwarding messages while the IPFS or the tau
+// data cap is reached.
+func TestGossipCapped(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, nil)
+	defer pm.Stop()
+	atomic.StoreUint32(&pm.acceptTxs, 1)
+
+	var (
+		local  = newTestGossip(pm, nil)
+		remote = newTestGossip(pm, nil)
+		from   = libp2ppeer.ID("remote")
+		data   = remote.encode(t, gossipTxs, []*types.Transaction{newTestTransaction(testBankKey, 0, 100)})
+	)
+	local.setCapped(true)
+	if err := local.validate(pm.chainID, gossipTxs, from, data); err != errGossipCapped {
+		t.Fatalf("IPFS capped error mismatch: have %v, want %v", err, errGossipCapped)
+	}
+	local.setCapped(false)
+	pm.SetCapped(true)
+	if err := local.validate(pm.chainID, gossipTxs, from, data); err != errGossipCapped {
+		t.Fatalf("tau capped error mismatch: have %v, want %v", err, errGossipCapped)
+	}
+	pm.SetCapped(false)
+	if err := local.validate(pm.chainID, gossipTxs, from, data); err != nil {
+		t.Fatalf("failed to validate transactions after the caps lifted: %v", err)
+	}
+}
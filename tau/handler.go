@@ -17,11 +17,13 @@
 package tau
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -34,11 +36,14 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/reputation"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/traffic"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/downloader"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/fetcher"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+	lru "github.com/hashicorp/golang-lru"
 )
 
 const (
@@ -57,15 +62,28 @@ var (
 	syncChallengeTimeout = 15 * time.Second // Time allowance for a node to reply to the sync progress challenge
 )
 
+// protoError is a breach of the wire protocol by a remote peer.
+type protoError struct {
+	code errCode
+	msg  string
+}
+
+func (e *protoError) Error() string {
+	return fmt.Sprintf("%v - %v", e.code, e.msg)
+}
+
 func errResp(code errCode, format string, v ...interface{}) error {
-	return fmt.Errorf("%v - %v", code, fmt.Sprintf(format, v...))
+	return &protoError{code: code, msg: fmt.Sprintf(format, v...)}
 }
 
 type ProtocolManager struct {
 	networkID uint64
+	chainID   common.ChainID // Id of the chain served from the local blockchain
 
 	fastSync  uint32 // Flag whtauer fast sync is enabled (gets disabled if we already have blocks)
 	acceptTxs uint32 // Flag whtauer we're considered synchronised (enables transaction processing)
+	noSync    uint32 // Flag whtauer chain synchronisation is suspended by the device policy
+	capped    uint32 // Flag whtauer chain traffic is suspended by the data cap
 
 	checkpointNumber uint64      // Block number for the sync progress validator to cross reference
 	checkpointHash   common.Hash // Block hash for the sync progress validator to cross reference
@@ -76,7 +94,16 @@ type ProtocolManager struct {
 
 	downloader *downloader.Downloader
 	fetcher    *fetcher.Fetcher
+	txFetcher  *fetcher.TxFetcher
 	peers      *peerSet
+	traffic    *traffic.Accountant
+
+	compactPending map[common.Hash]*compactBlock // Compact blocks awaiting missing transactions
+	compactSent    *lru.Cache                    // Blocks recently propagated, to serve missing transactions from
+	compactLock    sync.Mutex
+
+	ipldPeers *ipldTracker  // Tracker of the IPFS peers following the chains, nil if disabled
+	chains    chainFollower // Chains followed besides the local one, nil if none
 
 	eventMux      *event.TypeMux
 	txsCh         chan core.NewTxsEvent
@@ -98,20 +125,26 @@ type ProtocolManager struct {
 
 // NewProtocolManager returns a new Tau sub protocol manager. The Tau sub protocol manages peers capable
 // with the Tau network.
-func NewProtocolManager(config *params.ChainConfig, checkpoint *params.TrustedCheckpoint, mode downloader.SyncMode, networkID uint64, mux *event.TypeMux, txpool txPool, engine consensus.Engine, blockchain *core.BlockChain, chaindb taudb.IpfsStore, cacheLimit int, whitelist map[uint64]common.Hash) (*ProtocolManager, error) {
+func NewProtocolManager(config *params.ChainConfig, checkpoint *params.TrustedCheckpoint, mode downloader.SyncMode, networkID uint64, mux *event.TypeMux, txpool txPool, engine consensus.Engine, blockchain *core.BlockChain, chaindb taudb.IpfsStore, cacheLimit int, accountant *traffic.Accountant, whitelist map[uint64]common.Hash) (*ProtocolManager, error) {
 	// Create the protocol manager with the base fields
 	manager := &ProtocolManager{
 		networkID:   networkID,
+		chainID:     headerChainID(blockchain.Genesis().Header()),
 		eventMux:    mux,
 		txpool:      txpool,
 		blockchain:  blockchain,
 		peers:       newPeerSet(),
+		traffic:     accountant,
 		whitelist:   whitelist,
 		newPeerCh:   make(chan *peer),
 		noMorePeers: make(chan struct{}),
 		txsyncCh:    make(chan *txsync),
 		quitSync:    make(chan struct{}),
+
+		compactPending: make(map[common.Hash]*compactBlock),
 	}
+	manager.compactSent, _ = lru.New(maxCompactSent)
+
 	if mode == downloader.FullSync {
 		// The database seems empty as the current block is the genesis. Yet the fast
 		// block is ahead, so fast sync was enabled for this node at a certain point.
@@ -141,7 +174,7 @@ func NewProtocolManager(config *params.ChainConfig, checkpoint *params.TrustedCh
 		manager.checkpointHash = checkpoint.SectionHead
 	}
 
-	manager.downloader = downloader.New(manager.checkpointNumber, chaindb, manager.eventMux, blockchain, nil, manager.removePeer)
+	manager.downloader = downloader.New(manager.checkpointNumber, chaindb, manager.eventMux, blockchain, nil, manager.penalisePeer(reputation.BadChain))
 
 	// Construct the fetcher (short sync)
 	validator := func(header *types.Header) error {
@@ -176,7 +209,32 @@ func NewProtocolManager(config *params.ChainConfig, checkpoint *params.TrustedCh
 		}
 		return n, err
 	}
-	manager.fetcher = fetcher.New(blockchain.GetBlockByHash, validator, manager.BroadcastBlock, heighter, inserter, manager.removePeer)
+	manager.fetcher = fetcher.New(blockchain.GetBlockByHash, validator, manager.BroadcastBlock, heighter, inserter, manager.penalisePeer(reputation.BadBlock))
+
+	// Construct the transaction fetcher retrieving announced transactions
+	hasTx := func(hash common.Hash) bool {
+		return manager.txpool.Get(hash) != nil
+	}
+	addTxs := func(peer string, txs []*types.Transaction) []error {
+		errs := manager.txpool.AddRemotes(txs)
+		manager.reportTxs(peer, errs)
+		return errs
+	}
+	fetchTxs := func(peer string, hashes []common.Hash) error {
+		p := manager.peers.Peer(peer)
+		if p == nil {
+			return errNotRegistered
+		}
+		return p.RequestTxs(hashes)
+	}
+	penaliseTxs := func(peer string, offence fetcher.TxOffence) {
+		if offence == fetcher.TxRequestStalled {
+			manager.reportPeer(peer, reputation.StalledRequest)
+		} else {
+			manager.reportPeer(peer, reputation.UselessAnnounce)
+		}
+	}
+	manager.txFetcher = fetcher.NewTxFetcher(hasTx, addTxs, fetchTxs, penaliseTxs)
 
 	return manager, nil
 }
@@ -224,6 +282,7 @@ func (pm *ProtocolManager) removePeer(id string) {
 
 	// Unregister the peer from the downloader and Tau peer set
 	pm.downloader.UnregisterPeer(id)
+	pm.txFetcher.Drop(id)
 	if err := pm.peers.Unregister(id); err != nil {
 		log.Error("Peer removal failed", "peer", id, "err", err)
 	}
@@ -233,10 +292,47 @@ func (pm *ProtocolManager) removePeer(id string) {
 	}
 }
 
+// reportPeer records the behaviour of a peer with the reputation tracker,
+// dropping the peer if it got banned.
+func (pm *ProtocolManager) reportPeer(id string, event reputation.Event) {
+	if pm.peers.Report(id, event) {
+		pm.removePeer(id)
+	}
+}
+
+// penalisePeer returns a callback dropping a peer for the given misbehaviour,
+// used by the sync mechanisms which drop peers outright.
+func (pm *ProtocolManager) penalisePeer(event reputation.Event) func(id string) {
+	return func(id string) {
+		pm.peers.Report(id, event)
+		pm.removePeer(id)
+	}
+}
+
+// reportTxs scores a peer by the pool's verdict on the transactions it relayed.
+// Transactions that were merely known or underpriced are not held against it.
+func (pm *ProtocolManager) reportTxs(peer string, errs []error) {
+	var useful, invalid int
+	for _, err := range errs {
+		switch err {
+		case nil:
+			useful++
+		case core.ErrInvalidSender, core.ErrNegativeValue, core.ErrOversizedData, core.ErrIntrinsicGas:
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		pm.reportPeer(peer, reputation.InvalidTx)
+	} else if useful > 0 {
+		pm.reportPeer(peer, reputation.UsefulTxs)
+	}
+}
+
 func (pm *ProtocolManager) Start(maxPeers int) {
 	pm.maxPeers = maxPeers
 
-	// broadcast transactions
+	// broadcast and retrieve transactions
+	pm.txFetcher.Start()
 	pm.txsCh = make(chan core.NewTxsEvent, txChanSize)
 	pm.txsSub = pm.txpool.SubscribeNewTxsEvent(pm.txsCh)
 	go pm.txBroadcastLoop()
@@ -262,6 +358,7 @@ func (pm *ProtocolManager) Stop() {
 
 	// Quit fetcher, txsyncLoop.
 	close(pm.quitSync)
+	pm.txFetcher.Stop()
 
 	// Disconnect existing sessions.
 	// This also closes the gate for any new registrations on the peer set.
@@ -276,7 +373,8 @@ func (pm *ProtocolManager) Stop() {
 }
 
 func (pm *ProtocolManager) newPeer(pv int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
-	return newPeer(pv, p, newMeteredMsgWriter(rw))
+	id := p.ID()
+	return newPeer(pv, p, newMeteredMsgWriter(newAccountedMsgReadWriter(rw, pm.traffic, fmt.Sprintf("%x", id[:8]))))
 }
 
 // handle is the callback invoked to manage the life cycle of an tau peer. When
@@ -289,14 +387,7 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	p.Log().Debug("Tau peer connected", "name", p.Name())
 
 	// Execute the Tau handshake
-	var (
-		genesis = pm.blockchain.Genesis()
-		head    = pm.blockchain.CurrentHeader()
-		hash    = head.Hash()
-		number  = head.Number.Uint64()
-		td      = pm.blockchain.GetTd(hash, number)
-	)
-	if err := p.Handshake(pm.networkID, td, hash, genesis.Hash()); err != nil {
+	if err := p.Handshake(pm.networkID, pm.chainStatuses()); err != nil {
 		p.Log().Debug("Tau handshake failed", "err", err)
 		return err
 	}
@@ -310,6 +401,16 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	}
 	defer pm.removePeer(p.id)
 
+	// Remember the IPFS identity of peers connected over libp2p for IPLD sync
+	if pm.ipldPeers != nil {
+		pm.ipldPeers.recordPeer(p)
+	}
+	// Peers sharing only other followed chains take no part in syncing, nor in
+	// the propagation of the local chain
+	if !p.Follows(pm.chainID) {
+		p.Log().Debug("Tau peer shares no default chain", "chains", len(p.shared))
+		return pm.handleMsgs(p)
+	}
 	// Register the peer in the downloader. If the downloader considers it banned, we disconnect
 	if err := pm.downloader.RegisterPeer(p.id, p.version, p); err != nil {
 		return err
@@ -343,10 +444,18 @@ func (pm *ProtocolManager) handle(p *peer) error {
 			return err
 		}
 	}
-	// Handle incoming messages until the connection is torn down
+	return pm.handleMsgs(p)
+}
+
+// handleMsgs handles the incoming messages of a peer until the connection is
+// torn down.
+func (pm *ProtocolManager) handleMsgs(p *peer) error {
 	for {
 		if err := pm.handleMsg(p); err != nil {
 			p.Log().Debug("Tau message handling failed", "err", err)
+			if _, ok := err.(*protoError); ok {
+				pm.peers.Report(p.id, reputation.ProtocolViolation)
+			}
 			return err
 		}
 	}
@@ -365,6 +474,22 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 	}
 	defer msg.Discard()
 
+	// From tau/64 on, every message but the status is tagged with its chain
+	if p.version >= tau64 && msg.Code != StatusMsg {
+		var packet chainPacket
+		if err := msg.Decode(&packet); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		if !p.Follows(packet.ChainID) {
+			return errResp(ErrUnknownChain, "%s", packet.ChainID[:8])
+		}
+		msg.Payload, msg.Size = bytes.NewReader(packet.Payload), uint32(len(packet.Payload))
+
+		// Messages of the other followed chains have no local blockchain to go to
+		if packet.ChainID != pm.chainID {
+			return pm.handleFollowedMsg(p, packet.ChainID, msg)
+		}
+	}
 	// Handle the message depending on its contents
 	switch {
 	case msg.Code == StatusMsg:
@@ -509,6 +634,8 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			err := pm.downloader.DeliverHeaders(p.id, headers)
 			if err != nil {
 				log.Debug("Failed to deliver headers", "err", err)
+			} else if len(headers) > 0 {
+				pm.reportPeer(p.id, reputation.UsefulDelivery)
 			}
 		}
 
@@ -560,6 +687,8 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			err := pm.downloader.DeliverBodies(p.id, transactions)
 			if err != nil {
 				log.Debug("Failed to deliver bodies", "err", err)
+			} else if len(transactions) > 0 {
+				pm.reportPeer(p.id, reputation.UsefulDelivery)
 			}
 		}
 
@@ -610,7 +739,10 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		for _, block := range announces {
 			p.MarkBlock(block.Hash)
 		}
-		// Schedule all the unknown hashes for retrieval
+		// Schedule all the unknown hashes for retrieval, unless capped
+		if pm.isCapped() {
+			break
+		}
 		unknown := make(newBlockHashesData, 0, len(announces))
 		for _, block := range announces {
 			if !pm.blockchain.HasBlock(block.Hash, block.Number) {
@@ -636,25 +768,55 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		// Mark the peer as owning the block and schedule it for import
 		p.MarkBlock(request.Block.Hash())
 		pm.fetcher.Enqueue(p.id, request.Block)
+		pm.updatePropagator(p, request.Block.Header(), request.TD)
 
-		// Assuming the block is importable by the peer, but possibly not yet done so,
-		// calculate the head hash and TD that the peer truly must have.
-		var (
-			trueHead = request.Block.ParentHash()
-			trueTD   = new(big.Int).Sub(request.TD, request.Block.Difficulty())
-		)
-		// Update the peer's total difficulty if better than the previous
-		if _, td := p.Head(); trueTD.Cmp(td) > 0 {
-			p.SetHead(trueHead, trueTD)
-
-			// Schedule a sync if above ours. Note, this will not fire a sync for a gap of
-			// a single block (as the true TD is below the propagated block), however this
-			// scenario should easily be covered by the fetcher.
-			currentBlock := pm.blockchain.CurrentBlock()
-			if trueTD.Cmp(pm.blockchain.GetTd(currentBlock.Hash(), currentBlock.NumberU64())) > 0 {
-				go pm.synchronise(p)
+	case p.version >= tau64 && msg.Code == CompactBlockMsg:
+		// Retrieve and decode the compactly propagated block
+		var request compactBlockData
+		if err := msg.Decode(&request); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		if err := request.sanityCheck(); err != nil {
+			return err
+		}
+		// Mark the peer as owning the block and rebuild it from the pool,
+		// unless capped as the missing transactions would need fetching
+		p.MarkBlock(request.Header.Hash())
+		if pm.isCapped() {
+			break
+		}
+		if err := pm.handleCompactBlock(p, &request); err != nil {
+			return err
+		}
+		pm.updatePropagator(p, request.Header, request.TD)
+
+	case p.version >= tau64 && msg.Code == GetBlockTxsMsg:
+		// Decode the retrieval message
+		var request getBlockTxsData
+		if err := msg.Decode(&request); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		// Gather the requested transactions, an unknown block or index yields none
+		var txs []*types.Transaction
+		if block := pm.compactSource(request.Hash); block != nil {
+			body := block.Transactions()
+			for _, index := range request.Indexes {
+				if index >= uint64(len(body)) {
+					txs = nil
+					break
+				}
+				txs = append(txs, body[index])
 			}
 		}
+		return p.SendBlockTxs(request.Hash, txs)
+
+	case p.version >= tau64 && msg.Code == BlockTxsMsg:
+		// The missing transactions of a compact block arrived
+		var response blockTxsData
+		if err := msg.Decode(&response); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		pm.handleBlockTxs(p, &response)
 
 	case msg.Code == TxMsg:
 		// Transactions arrived, make sure we have a valid and fresh chain to handle them
@@ -673,7 +835,69 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			}
 			p.MarkTransaction((*tx).Hash())
 		}
-		pm.txpool.AddRemotes(txs)
+		pm.txFetcher.Enqueue(p.id, txs, false)
+
+	case p.version >= tau64 && msg.Code == NewPooledTransactionHashesMsg:
+		// Transactions were announced, schedule the unknown ones for retrieval
+		if atomic.LoadUint32(&pm.acceptTxs) == 0 {
+			break
+		}
+		var announces newPooledTxHashesData
+		if err := msg.Decode(&announces); err != nil {
+			return errResp(ErrDecode, "msg %v: %v", msg, err)
+		}
+		for _, announce := range announces {
+			p.MarkTransaction(announce.Hash)
+		}
+		if pm.isCapped() {
+			break
+		}
+		pm.txFetcher.Notify(p.id, announces, time.Now())
+
+	case p.version >= tau64 && msg.Code == GetPooledTransactionsMsg:
+		// Decode the retrieval message
+		msgStream := rlp.NewStream(msg.Payload, uint64(msg.Size))
+		if _, err := msgStream.List(); err != nil {
+			return err
+		}
+		// Gather transactions until the fetch or network limits is reached
+		var (
+			hash  common.Hash
+			bytes common.StorageSize
+			txs   []*types.Transaction
+		)
+		for bytes < softResponseLimit {
+			// Retrieve the hash of the next transaction
+			if err := msgStream.Decode(&hash); err == rlp.EOL {
+				break
+			} else if err != nil {
+				return errResp(ErrDecode, "msg %v: %v", msg, err)
+			}
+			// Retrieve the requested transaction, skipping unknown ones
+			if tx := pm.txpool.Get(hash); tx != nil {
+				txs = append(txs, tx)
+				bytes += (*tx).Size()
+			}
+		}
+		return p.SendPooledTransactions(txs)
+
+	case p.version >= tau64 && msg.Code == PooledTransactionsMsg:
+		// Requested transactions arrived, make sure we have a valid and fresh chain to handle them
+		if atomic.LoadUint32(&pm.acceptTxs) == 0 {
+			break
+		}
+		var txs []*types.Transaction
+		if err := msg.Decode(&txs); err != nil {
+			return errResp(ErrDecode, "msg %v: %v", msg, err)
+		}
+		for i, tx := range txs {
+			// Validate and mark the remote transaction
+			if tx == nil {
+				return errResp(ErrDecode, "transaction %d is nil", i)
+			}
+			p.MarkTransaction((*tx).Hash())
+		}
+		pm.txFetcher.Enqueue(p.id, txs, true)
 
 	default:
 		return errResp(ErrInvalidMsgCode, "%v", msg.Code)
@@ -681,9 +905,93 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 	return nil
 }
 
+// updatePropagator updates the head of a peer which propagated a block and
+// schedules a sync if it's ahead of us.
+func (pm *ProtocolManager) updatePropagator(p *peer, header *types.Header, blockTD *big.Int) {
+	// Assuming the block is importable by the peer, but possibly not yet done so,
+	// calculate the head hash and TD that the peer truly must have.
+	var (
+		trueHead = header.ParentHash
+		trueTD   = new(big.Int).Sub(blockTD, header.Difficulty)
+	)
+	// Update the peer's total difficulty if better than the previous
+	if _, td := p.Head(); trueTD.Cmp(td) > 0 {
+		p.SetHead(trueHead, trueTD)
+
+		// Schedule a sync if above ours. Note, this will not fire a sync for a gap of
+		// a single block (as the true TD is below the propagated block), however this
+		// scenario should easily be covered by the fetcher.
+		currentBlock := pm.blockchain.CurrentBlock()
+		if trueTD.Cmp(pm.blockchain.GetTd(currentBlock.Hash(), currentBlock.NumberU64())) > 0 {
+			go pm.synchronise(p)
+		}
+	}
+}
+
+// handleFollowedMsg handles a message of a chain followed by the user but not
+// backed by the local blockchain. Queries are answered empty so the peer does not
+// wait on them, everything else is dropped until the chain is synced locally.
+func (pm *ProtocolManager) handleFollowedMsg(p *peer, chain common.ChainID, msg p2p.Msg) error {
+	switch {
+	case msg.Code == StatusMsg:
+		return errResp(ErrExtraStatusMsg, "uncontrolled status message")
+
+	case msg.Code == GetBlockHeadersMsg:
+		return p.sendChain(chain, BlockHeadersMsg, []*types.Header{})
+
+	case msg.Code == GetBlockBodiesMsg:
+		return p.sendChain(chain, BlockBodiesMsg, []rlp.RawValue{})
+
+	case msg.Code == GetNodeDataMsg:
+		return p.sendChain(chain, NodeDataMsg, [][]byte{})
+
+	case msg.Code == GetPooledTransactionsMsg:
+		return p.sendChain(chain, PooledTransactionsMsg, []rlp.RawValue{})
+
+	case msg.Code < protocolLengths[uint(p.version)]:
+		p.Log().Trace("Dropped message of followed chain", "chain", string(chain[:8]), "code", msg.Code)
+		return nil
+
+	default:
+		return errResp(ErrInvalidMsgCode, "%v", msg.Code)
+	}
+}
+
+// chainStatuses returns the state of the chains followed locally to advertise
+// in the handshake, the default chain of the local blockchain first. The other
+// followed chains have no local blockchain, so their state is left empty.
+func (pm *ProtocolManager) chainStatuses() []chainStatus {
+	var (
+		genesis = pm.blockchain.Genesis()
+		head    = pm.blockchain.CurrentHeader()
+		hash    = head.Hash()
+		number  = head.Number.Uint64()
+		td      = pm.blockchain.GetTd(hash, number)
+	)
+	chains := []chainStatus{{ChainID: pm.chainID, TD: td, Head: hash, Genesis: genesis.Hash()}}
+	if pm.chains == nil {
+		return chains
+	}
+	followed := pm.chains.FollowedChains()
+	sort.Slice(followed, func(i, j int) bool { return bytes.Compare(followed[i][:], followed[j][:]) < 0 })
+	for _, id := range followed {
+		if len(chains) >= maxStatusChains {
+			break
+		}
+		if id != pm.chainID {
+			chains = append(chains, chainStatus{ChainID: id, TD: new(big.Int)})
+		}
+	}
+	return chains
+}
+
 // BroadcastBlock will either propagate a block to a subset of it's peers, or
-// will only announce it's availability (depending what's requested).
+// will only announce it's availability (depending what's requested). Nothing is
+// sent while the data cap is reached.
 func (pm *ProtocolManager) BroadcastBlock(block *types.Block, propagate bool) {
+	if pm.isCapped() {
+		return
+	}
 	hash := block.Hash()
 	peers := pm.peers.PeersWithoutBlock(hash)
 
@@ -706,6 +1014,7 @@ func (pm *ProtocolManager) BroadcastBlock(block *types.Block, propagate bool) {
 			transferLen = len(peers)
 		}
 		transfer := peers[:transferLen]
+		pm.compactSent.Add(hash, block)
 		for _, peer := range transfer {
 			peer.AsyncSendNewBlock(block, td)
 		}
@@ -721,23 +1030,39 @@ func (pm *ProtocolManager) BroadcastBlock(block *types.Block, propagate bool) {
 	}
 }
 
-// BroadcastTxs will propagate a batch of transactions to all peers which are not known to
-// already have the given transaction.
+// BroadcastTxs will propagate a batch of transactions to the peers which are not
+// known to already have the given transaction. Only a square root subset of the
+// peers receives the full transactions, the rest is sent announcements to fetch
+// them on demand. Peers predating tau/64 always receive the full transactions.
+// Nothing is sent while the data cap is reached.
 func (pm *ProtocolManager) BroadcastTxs(txs types.Transactions) {
-	var txset = make(map[*peer]types.Transactions)
-
+	if pm.isCapped() {
+		return
+	}
+	var (
+		txset  = make(map[*peer]types.Transactions)
+		annset = make(map[*peer]types.Transactions)
+	)
 	// Broadcast transactions to a batch of peers not knowing about it
 	for _, tx := range txs {
 		peers := pm.peers.PeersWithoutTx((*tx).Hash())
-		for _, peer := range peers {
-			txset[peer] = append(txset[peer], tx)
+
+		direct := int(math.Sqrt(float64(len(peers))))
+		for i, peer := range peers {
+			if i < direct || peer.version < tau64 {
+				txset[peer] = append(txset[peer], tx)
+			} else {
+				annset[peer] = append(annset[peer], tx)
+			}
 		}
 		log.Trace("Broadcast transaction", "hash", (*tx).Hash(), "recipients", len(peers))
 	}
-	// FIXME include this again: peers = peers[:int(math.Sqrt(float64(len(peers))))]
 	for peer, txs := range txset {
 		peer.AsyncSendTransactions(txs)
 	}
+	for peer, txs := range annset {
+		peer.AsyncSendPooledTransactionHashes(txs)
+	}
 }
 
 // Mined broadcast loop
@@ -768,6 +1093,7 @@ func (pm *ProtocolManager) txBroadcastLoop() {
 // known about the host peer.
 type NodeInfo struct {
 	Network    uint64              `json:"network"`    // Tau network ID (1=Frontier, 2=Morden, Ropsten=3, Rinkeby=4)
+	ChainID    string              `json:"chainId"`    // Id of the default chain served by the host
 	Difficulty *big.Int            `json:"difficulty"` // Total difficulty of the host's blockchain
 	Genesis    common.Hash         `json:"genesis"`    // SHA3 hash of the host's genesis block
 	Config     *params.ChainConfig `json:"config"`     // Chain configuration for the fork rules
@@ -779,6 +1105,7 @@ func (pm *ProtocolManager) NodeInfo() *NodeInfo {
 	currentBlock := pm.blockchain.CurrentBlock()
 	return &NodeInfo{
 		Network:    pm.networkID,
+		ChainID:    string(pm.chainID[:]),
 		Difficulty: pm.blockchain.GetTd(currentBlock.Hash(), currentBlock.NumberU64()),
 		Genesis:    pm.blockchain.Genesis().Hash(),
 		Config:     pm.blockchain.Config(),
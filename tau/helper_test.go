@@ -39,7 +39,9 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/traffic"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
 )
 
 var (
@@ -66,7 +68,7 @@ func newTestProtocolManager(mode downloader.SyncMode, blocks int, generator func
 	if _, err := blockchain.InsertChain(chain); err != nil {
 		panic(err)
 	}
-	pm, err := NewProtocolManager(gspec.Config, nil, mode, DefaultConfig.NetworkId, evmux, &testTxPool{added: newtx}, engine, blockchain, db, 1, nil)
+	pm, err := NewProtocolManager(gspec.Config, nil, mode, DefaultConfig.NetworkId, evmux, &testTxPool{added: newtx}, engine, blockchain, db, 1, traffic.NewAccountant(), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -91,6 +93,7 @@ type testTxPool struct {
 	txFeed event.Feed
 	pool   []*types.Transaction        // Collection of all transactions
 	added  chan<- []*types.Transaction // Notification channel for new transactions
+	locals []common.Address            // Accounts whose transactions are local
 
 	lock sync.RWMutex // Protects the transaction pool
 }
@@ -108,6 +111,19 @@ func (p *testTxPool) AddRemotes(txs []*types.Transaction) []error {
 	return make([]error, len(txs))
 }
 
+// Get returns the transaction with the given hash if it is in the pool.
+func (p *testTxPool) Get(hash common.Hash) *types.Transaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for _, tx := range p.pool {
+		if (*tx).Hash() == hash {
+			return tx
+		}
+	}
+	return nil
+}
+
 // Pending returns all the transactions known to the pool
 func (p *testTxPool) Pending() (map[common.Address]types.Transactions, error) {
 	p.lock.RLock()
@@ -124,6 +140,14 @@ func (p *testTxPool) Pending() (map[common.Address]types.Transactions, error) {
 	return batches, nil
 }
 
+// Locals returns the accounts whose transactions are local.
+func (p *testTxPool) Locals() []common.Address {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.locals
+}
+
 func (p *testTxPool) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
 	return p.txFeed.Subscribe(ch)
 }
@@ -137,8 +161,9 @@ func newTestTransaction(from *ecdsa.PrivateKey, nonce uint64, datasize int) *typ
 
 // testPeer is a simulated peer to allow testing direct network calls.
 type testPeer struct {
-	net p2p.MsgReadWriter // Network layer reader/writer to simulate remote messaging
-	app *p2p.MsgPipeRW    // Application layer reader/writer to simulate the local side
+	net   p2p.MsgReadWriter // Network layer reader/writer to simulate remote messaging
+	app   *p2p.MsgPipeRW    // Application layer reader/writer to simulate the local side
+	chain common.ChainID    // Chain tagged on the tau/64 messages sent to the local side
 	*peer
 }
 
@@ -163,9 +188,11 @@ func newTestPeer(name string, version int, pm *ProtocolManager, shake bool) (*te
 			errc <- p2p.DiscQuitting
 		}
 	}()
-	tp := &testPeer{app: app, net: net, peer: peer}
+	tp := &testPeer{app: app, net: net, chain: pm.chainID, peer: peer}
 	// Execute any implicitly requested handshakes and return
-	if shake {
+	if shake && version >= tau64 {
+		tp.handshake64(nil, pm.chainStatuses())
+	} else if shake {
 		var (
 			genesis = pm.blockchain.Genesis()
 			head    = pm.blockchain.CurrentHeader()
@@ -194,6 +221,35 @@ func (p *testPeer) handshake(t *testing.T, td *big.Int, head common.Hash, genesi
 	}
 }
 
+// handshake64 simulates a trivial tau/64 handshake that expects the same chains
+// from the remote side as we are simulating locally.
+func (p *testPeer) handshake64(t *testing.T, chains []chainStatus) {
+	msg := &statusData64{
+		ProtocolVersion: uint32(p.version),
+		NetworkId:       DefaultConfig.NetworkId,
+		Chains:          chains,
+	}
+	if err := p2p.ExpectMsg(p.app, StatusMsg, msg); err != nil {
+		t.Fatalf("status recv: %v", err)
+	}
+	if err := p2p.Send(p.app, StatusMsg, msg); err != nil {
+		t.Fatalf("status send: %v", err)
+	}
+}
+
+// send delivers a message of the default chain to the protocol manager, wrapped
+// into a chain envelope from tau/64 on.
+func (p *testPeer) send(code uint64, data interface{}) error {
+	if p.version < tau64 {
+		return p2p.Send(p.app, code, data)
+	}
+	payload, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return err
+	}
+	return p2p.Send(p.app, code, &chainPacket{ChainID: p.chain, Payload: payload})
+}
+
 // close terminates the local side of the peer, notifying the remote protocol
 // manager of termination.
 func (p *testPeer) close() {
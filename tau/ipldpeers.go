@@ -0,0 +1,236 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/userdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/stream"
+	"github.com/libp2p/go-libp2p-core/host"
+	libp2ppeer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+)
+
+const (
+	ipldProbeInterval = 10 * time.Minute   // Interval of checking the liveness of the IPLD peers
+	ipldProbeBatch    = 16                 // Number of least recently seen peers checked per round
+	ipldProbeTimeout  = 30 * time.Second   // Time allowed for a peer to answer a liveness check
+	ipldPeerExpiry    = 7 * 24 * time.Hour // Time after which a peer not seen is evicted
+)
+
+// ipldPeerBook records the IPFS peers known to follow the chains, implemented by
+// the user database.
+type ipldPeerBook interface {
+	AddIPLDPeer(chainid common.ChainID, peer common.IPLDPeerID, blocknum uint64)
+	AllIPLDPeers() []userdb.IPLDPeer
+	IPLDPeerAlive(peer common.IPLDPeerID)
+	IPLDPeerFailed(peer common.IPLDPeerID) bool
+	EvictIPLDPeers(before time.Time) int
+}
+
+// ipldChain is the part of the blockchain the IPLD peer tracker watches.
+type ipldChain interface {
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+	GetHeaderByHash(hash common.Hash) *types.Header
+}
+
+// ipldTracker fills the IPLD peer book with the miners of the canonical blocks
+// and the peers met over libp2p streams, and evicts the ones found dead.
+type ipldTracker struct {
+	book    ipldPeerBook
+	chain   ipldChain
+	chainID common.ChainID
+
+	ping func(ctx context.Context, id libp2ppeer.ID) error // Liveness check, nil until a libp2p host is attached
+	lock sync.Mutex                                        // Protects the liveness check
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newIPLDTracker creates a tracker of the IPLD peers of the given chain.
+func newIPLDTracker(book ipldPeerBook, chain ipldChain, chainID common.ChainID) *ipldTracker {
+	return &ipldTracker{
+		book:    book,
+		chain:   chain,
+		chainID: chainID,
+		quit:    make(chan struct{}),
+	}
+}
+
+// start records the miners of the new canonical blocks and periodically checks
+// the liveness of the known peers.
+func (t *ipldTracker) start() {
+	t.wg.Add(1)
+	go t.loop()
+}
+
+// stop terminates the tracker.
+func (t *ipldTracker) stop() {
+	close(t.quit)
+	t.wg.Wait()
+}
+
+// setHost makes the tracker check the liveness of the peers by pinging them
+// through the given libp2p host.
+func (t *ipldTracker) setHost(h host.Host) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.ping = func(ctx context.Context, id libp2ppeer.ID) error {
+		res, ok := <-ping.Ping(ctx, h, id)
+		if !ok {
+			return ctx.Err()
+		}
+		return res.Error
+	}
+}
+
+func (t *ipldTracker) loop() {
+	defer t.wg.Done()
+
+	chainCh := make(chan core.ChainEvent, 16)
+	chainSub := t.chain.SubscribeChainEvent(chainCh)
+	defer chainSub.Unsubscribe()
+
+	probe := time.NewTicker(ipldProbeInterval)
+	defer probe.Stop()
+
+	for {
+		select {
+		case ev := <-chainCh:
+			t.recordBlock(ev.Block)
+
+		case <-probe.C:
+			// Don't hold up the chain events while waiting for the peers
+			t.wg.Add(1)
+			go func() {
+				defer t.wg.Done()
+				t.probe()
+			}()
+
+		case <-chainSub.Err():
+			return
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// recordBlock records the IPFS peer that mined a block of the chain.
+func (t *ipldTracker) recordBlock(block *types.Block) {
+	addr := block.Header().IpfsCoinbase
+	miner := string(bytes.TrimRight(addr[:], "\x00"))
+	if miner == "" {
+		return
+	}
+	if _, err := libp2ppeer.IDB58Decode(miner); err != nil {
+		log.Trace("Skipping invalid block miner", "number", block.NumberU64(), "miner", miner, "err", err)
+		return
+	}
+	t.book.AddIPLDPeer(t.chainID, common.IPLDPeerID(miner), block.NumberU64())
+}
+
+// recordPeer records a tau peer connected over a libp2p stream as following the
+// chains it shares with the local node. Peers connected over plain devp2p don't
+// reveal their IPFS identity.
+func (t *ipldTracker) recordPeer(p *peer) {
+	addr, ok := p.RemoteAddr().(stream.Addr)
+	if !ok {
+		return
+	}
+	id := common.IPLDPeerID(libp2ppeer.IDB58Encode(addr.Peer))
+	for chain := range p.shared {
+		var number uint64
+		if chain == t.chainID {
+			hash, _ := p.Head()
+			if header := t.chain.GetHeaderByHash(hash); header != nil {
+				number = header.Number.Uint64()
+			}
+		}
+		t.book.AddIPLDPeer(chain, id, number)
+	}
+}
+
+// probe evicts the peers not seen for too long and checks the liveness of the
+// least recently seen ones.
+func (t *ipldTracker) probe() {
+	if evicted := t.book.EvictIPLDPeers(time.Now().Add(-ipldPeerExpiry)); evicted > 0 {
+		log.Debug("Evicted expired IPLD peers", "count", evicted)
+	}
+	t.lock.Lock()
+	check := t.ping
+	t.lock.Unlock()
+	if check == nil {
+		return
+	}
+	// Check every peer once, however many chains it follows
+	var (
+		peers []userdb.IPLDPeer
+		seen  = make(map[common.IPLDPeerID]bool)
+	)
+	for _, p := range t.book.AllIPLDPeers() {
+		if !seen[p.ID] {
+			seen[p.ID] = true
+			peers = append(peers, p)
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].LastSeen.Before(peers[j].LastSeen) })
+	if len(peers) > ipldProbeBatch {
+		peers = peers[:ipldProbeBatch]
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), ipldProbeTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-t.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, p := range peers {
+		id, err := libp2ppeer.IDB58Decode(string(p.ID))
+		if err != nil {
+			t.book.IPLDPeerFailed(p.ID)
+			continue
+		}
+		wg.Add(1)
+		go func(p userdb.IPLDPeer, id libp2ppeer.ID) {
+			defer wg.Done()
+			if err := check(ctx, id); err != nil {
+				if t.book.IPLDPeerFailed(p.ID) {
+					log.Debug("Evicted unresponsive IPLD peer", "id", p.ID, "err", err)
+				}
+				return
+			}
+			t.book.IPLDPeerAlive(p.ID)
+		}(p, id)
+	}
+	wg.Wait()
+}
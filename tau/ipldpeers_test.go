@@ -0,0 +1,144 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/userdb"
+	libp2ppeer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+var (
+	testIPLDPeer1 = common.IPLDPeerID("QmYyQSo1c1Ym7orWxLYvCrM2EmxFTANf8wXmmE7DWjhx5N")
+	testIPLDPeer2 = common.IPLDPeerID("QmSoLPppuBtQSGwKDZT2M73ULpjvfd3aZ6ha4oFGL1KrGM")
+)
+
+// newIPLDTestBlock creates a block of the given number mined by the given peer.
+func newIPLDTestBlock(number int64, miner common.IPLDPeerID) *types.Block {
+	header := &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(1)}
+	copy(header.IpfsCoinbase[:], miner)
+	return types.NewBlockWithHeader(header)
+}
+
+// Tests that the miners of the canonical blocks are recorded with the highest
+// block they were seen at, and that invalid miner ids are skipped.
+func TestIPLDPeerBlocks(t *testing.T) {
+	var (
+		udb     = userdb.NewUserdb(rawdb.NewMemoryDatabase())
+		chainID = common.ChainID{'a'}
+		tracker = newIPLDTracker(udb, nil, chainID)
+	)
+	tracker.recordBlock(newIPLDTestBlock(5, testIPLDPeer1))
+	tracker.recordBlock(newIPLDTestBlock(3, testIPLDPeer1))
+	tracker.recordBlock(newIPLDTestBlock(4, "not a peer id"))
+	tracker.recordBlock(newIPLDTestBlock(6, ""))
+
+	peers := udb.ChainIPLDPeers(chainID)
+	if len(peers) != 1 {
+		t.Fatalf("recorded peer count mismatch: have %d, want 1", len(peers))
+	}
+	if peers[0].ID != testIPLDPeer1 || peers[0].BlockNumber != 5 {
+		t.Fatalf("recorded peer mismatch: have %s at %d, want %s at 5", peers[0].ID, peers[0].BlockNumber, testIPLDPeer1)
+	}
+	if id, ok := udb.SelectIPLDPeer(chainID); !ok || id != testIPLDPeer1 {
+		t.Fatalf("selected peer mismatch: have %s, want %s", id, testIPLDPeer1)
+	}
+	if _, ok := udb.SelectIPLDPeer(common.ChainID{'b'}); ok {
+		t.Fatalf("peer selected for unknown chain")
+	}
+}
+
+// Tests that peers failing their liveness checks are evicted, while the ones
+// answering are kept.
+func TestIPLDPeerLiveness(t *testing.T) {
+	var (
+		udb     = userdb.NewUserdb(rawdb.NewMemoryDatabase())
+		chainID = common.ChainID{'a'}
+		tracker = newIPLDTracker(udb, nil, chainID)
+	)
+	tracker.recordBlock(newIPLDTestBlock(1, testIPLDPeer1))
+	tracker.recordBlock(newIPLDTestBlock(2, testIPLDPeer2))
+
+	dead, _ := libp2ppeer.IDB58Decode(string(testIPLDPeer2))
+	tracker.ping = func(ctx context.Context, id libp2ppeer.ID) error {
+		if id == dead {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+	tracker.probe()
+
+	// A single failure keeps the peer, but the live one is preferred
+	if peers := udb.ChainIPLDPeers(chainID); len(peers) != 2 {
+		t.Fatalf("peer count mismatch after first check: have %d, want 2", len(peers))
+	}
+	for i := 0; i < 10; i++ {
+		if id, _ := udb.SelectIPLDPeer(chainID); id != testIPLDPeer1 {
+			t.Fatalf("failing peer selected over live one")
+		}
+	}
+	for i := 0; i < 5; i++ {
+		tracker.probe()
+	}
+	peers := udb.ChainIPLDPeers(chainID)
+	if len(peers) != 1 || peers[0].ID != testIPLDPeer1 {
+		t.Fatalf("unresponsive peer not evicted: %v", peers)
+	}
+	if peers[0].Failures != 0 {
+		t.Fatalf("live peer failure count mismatch: have %d, want 0", peers[0].Failures)
+	}
+}
+
+// Tests that the IPLD peer book and the liveness state of its peers survive a
+// reopening of the user database, evicted peers included.
+func TestIPLDPeerPersistence(t *testing.T) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		udb     = userdb.NewUserdb(db)
+		chainID = common.ChainID{'a'}
+	)
+	udb.AddIPLDPeer(chainID, testIPLDPeer1, 5)
+	udb.AddIPLDPeer(chainID, testIPLDPeer2, 7)
+	udb.IPLDPeerFailed(testIPLDPeer1)
+	for i := 0; i < 3; i++ {
+		udb.IPLDPeerFailed(testIPLDPeer2)
+	}
+	peers := userdb.NewUserdb(db).ChainIPLDPeers(chainID)
+	if len(peers) != 1 {
+		t.Fatalf("persisted peer count mismatch: have %d, want 1", len(peers))
+	}
+	if peers[0].ID != testIPLDPeer1 || peers[0].BlockNumber != 5 || peers[0].Failures != 1 {
+		t.Fatalf("persisted peer mismatch: have %+v", peers[0])
+	}
+	if peers[0].LastSeen.IsZero() {
+		t.Fatalf("persisted peer last seen time missing")
+	}
+	if n := userdb.NewUserdb(db).EvictIPLDPeers(peers[0].LastSeen.Add(time.Second)); n != 1 {
+		t.Fatalf("evicted peer count mismatch: have %d, want 1", n)
+	}
+	if peers := userdb.NewUserdb(db).AllIPLDPeers(); len(peers) != 0 {
+		t.Fatalf("evicted peers persisted: %v", peers)
+	}
+}
@@ -26,6 +26,10 @@ var (
 	propTxnInTrafficMeter    = metrics.NewRegisteredMeter("tau/prop/txns/in/traffic", nil)
 	propTxnOutPacketsMeter   = metrics.NewRegisteredMeter("tau/prop/txns/out/packets", nil)
 	propTxnOutTrafficMeter   = metrics.NewRegisteredMeter("tau/prop/txns/out/traffic", nil)
+	propTxAnnInPacketsMeter  = metrics.NewRegisteredMeter("tau/prop/txhashes/in/packets", nil)
+	propTxAnnInTrafficMeter  = metrics.NewRegisteredMeter("tau/prop/txhashes/in/traffic", nil)
+	propTxAnnOutPacketsMeter = metrics.NewRegisteredMeter("tau/prop/txhashes/out/packets", nil)
+	propTxAnnOutTrafficMeter = metrics.NewRegisteredMeter("tau/prop/txhashes/out/traffic", nil)
 	propHashInPacketsMeter   = metrics.NewRegisteredMeter("tau/prop/hashes/in/packets", nil)
 	propHashInTrafficMeter   = metrics.NewRegisteredMeter("tau/prop/hashes/in/traffic", nil)
 	propHashOutPacketsMeter  = metrics.NewRegisteredMeter("tau/prop/hashes/out/packets", nil)
@@ -34,6 +38,10 @@ var (
 	propBlockInTrafficMeter  = metrics.NewRegisteredMeter("tau/prop/blocks/in/traffic", nil)
 	propBlockOutPacketsMeter = metrics.NewRegisteredMeter("tau/prop/blocks/out/packets", nil)
 	propBlockOutTrafficMeter = metrics.NewRegisteredMeter("tau/prop/blocks/out/traffic", nil)
+	propCmpctInPacketsMeter  = metrics.NewRegisteredMeter("tau/prop/compact/in/packets", nil)
+	propCmpctInTrafficMeter  = metrics.NewRegisteredMeter("tau/prop/compact/in/traffic", nil)
+	propCmpctOutPacketsMeter = metrics.NewRegisteredMeter("tau/prop/compact/out/packets", nil)
+	propCmpctOutTrafficMeter = metrics.NewRegisteredMeter("tau/prop/compact/out/traffic", nil)
 	reqHeaderInPacketsMeter  = metrics.NewRegisteredMeter("tau/req/headers/in/packets", nil)
 	reqHeaderInTrafficMeter  = metrics.NewRegisteredMeter("tau/req/headers/in/traffic", nil)
 	reqHeaderOutPacketsMeter = metrics.NewRegisteredMeter("tau/req/headers/out/packets", nil)
@@ -42,6 +50,14 @@ var (
 	reqBodyInTrafficMeter    = metrics.NewRegisteredMeter("tau/req/bodies/in/traffic", nil)
 	reqBodyOutPacketsMeter   = metrics.NewRegisteredMeter("tau/req/bodies/out/packets", nil)
 	reqBodyOutTrafficMeter   = metrics.NewRegisteredMeter("tau/req/bodies/out/traffic", nil)
+	reqTxnInPacketsMeter     = metrics.NewRegisteredMeter("tau/req/txns/in/packets", nil)
+	reqTxnInTrafficMeter     = metrics.NewRegisteredMeter("tau/req/txns/in/traffic", nil)
+	reqTxnOutPacketsMeter    = metrics.NewRegisteredMeter("tau/req/txns/out/packets", nil)
+	reqTxnOutTrafficMeter    = metrics.NewRegisteredMeter("tau/req/txns/out/traffic", nil)
+	reqBlkTxInPacketsMeter   = metrics.NewRegisteredMeter("tau/req/blocktxs/in/packets", nil)
+	reqBlkTxInTrafficMeter   = metrics.NewRegisteredMeter("tau/req/blocktxs/in/traffic", nil)
+	reqBlkTxOutPacketsMeter  = metrics.NewRegisteredMeter("tau/req/blocktxs/out/packets", nil)
+	reqBlkTxOutTrafficMeter  = metrics.NewRegisteredMeter("tau/req/blocktxs/out/traffic", nil)
 	reqStateInPacketsMeter   = metrics.NewRegisteredMeter("tau/req/states/in/packets", nil)
 	reqStateInTrafficMeter   = metrics.NewRegisteredMeter("tau/req/states/in/traffic", nil)
 	reqStateOutPacketsMeter  = metrics.NewRegisteredMeter("tau/req/states/out/packets", nil)
@@ -91,6 +107,15 @@ func (rw *meteredMsgReadWriter) ReadMsg() (p2p.Msg, error) {
 	case rw.version >= tau63 && msg.Code == NodeDataMsg:
 		packets, traffic = reqStateInPacketsMeter, reqStateInTrafficMeter
 
+	case rw.version >= tau64 && msg.Code == PooledTransactionsMsg:
+		packets, traffic = reqTxnInPacketsMeter, reqTxnInTrafficMeter
+	case rw.version >= tau64 && msg.Code == NewPooledTransactionHashesMsg:
+		packets, traffic = propTxAnnInPacketsMeter, propTxAnnInTrafficMeter
+	case rw.version >= tau64 && msg.Code == CompactBlockMsg:
+		packets, traffic = propCmpctInPacketsMeter, propCmpctInTrafficMeter
+	case rw.version >= tau64 && msg.Code == BlockTxsMsg:
+		packets, traffic = reqBlkTxInPacketsMeter, reqBlkTxInTrafficMeter
+
 	case msg.Code == NewBlockHashesMsg:
 		packets, traffic = propHashInPacketsMeter, propHashInTrafficMeter
 	case msg.Code == NewBlockMsg:
@@ -116,6 +141,15 @@ func (rw *meteredMsgReadWriter) WriteMsg(msg p2p.Msg) error {
 	case rw.version >= tau63 && msg.Code == NodeDataMsg:
 		packets, traffic = reqStateOutPacketsMeter, reqStateOutTrafficMeter
 
+	case rw.version >= tau64 && msg.Code == PooledTransactionsMsg:
+		packets, traffic = reqTxnOutPacketsMeter, reqTxnOutTrafficMeter
+	case rw.version >= tau64 && msg.Code == NewPooledTransactionHashesMsg:
+		packets, traffic = propTxAnnOutPacketsMeter, propTxAnnOutTrafficMeter
+	case rw.version >= tau64 && msg.Code == CompactBlockMsg:
+		packets, traffic = propCmpctOutPacketsMeter, propCmpctOutTrafficMeter
+	case rw.version >= tau64 && msg.Code == BlockTxsMsg:
+		packets, traffic = reqBlkTxOutPacketsMeter, reqBlkTxOutTrafficMeter
+
 	case msg.Code == NewBlockHashesMsg:
 		packets, traffic = propHashOutPacketsMeter, propHashOutTrafficMeter
 	case msg.Code == NewBlockMsg:
@@ -20,13 +20,16 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/reputation"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/fetcher"
 	mapset "github.com/deckarep/golang-set"
 )
 
@@ -45,6 +48,10 @@ const (
 	// contain a single transaction, or thousands.
 	maxQueuedTxs = 128
 
+	// maxQueuedTxAnns is the maximum number of transaction announcement lists to
+	// queue up before dropping broadcasts.
+	maxQueuedTxAnns = 128
+
 	// maxQueuedProps is the maximum number of block propagations to queue up before
 	// dropping broadcasts. There's not much point in queueing stale blocks, so a few
 	// that might cover uncles should be enough.
@@ -64,6 +71,7 @@ type PeerInfo struct {
 	Version    int      `json:"version"`    // Tau protocol version negotiated
 	Difficulty *big.Int `json:"difficulty"` // Total difficulty of the peer's blockchain
 	Head       string   `json:"head"`       // SHA3 hash of the peer's best owned block
+	Chains     []string `json:"chains"`     // Ids of the chains followed by both sides
 }
 
 // propEvent is a block propagation, waiting for its turn in the broadcast queue.
@@ -81,30 +89,36 @@ type peer struct {
 	version  int         // Protocol version negotiated
 	syncDrop *time.Timer // Timed connection dropper if sync progress isn't validated in time
 
+	chain  common.ChainID          // Chain of the local blockchain, tagged on outgoing messages
+	shared map[common.ChainID]bool // Chains followed by both sides
+
 	head common.Hash
 	td   *big.Int
 	lock sync.RWMutex
 
-	knownTxs    mapset.Set                // Set of transaction hashes known to be known by this peer
-	knownBlocks mapset.Set                // Set of block hashes known to be known by this peer
-	queuedTxs   chan []*types.Transaction // Queue of transactions to broadcast to the peer
-	queuedProps chan *propEvent           // Queue of blocks to broadcast to the peer
-	queuedAnns  chan *types.Block         // Queue of blocks to announce to the peer
-	term        chan struct{}             // Termination channel to stop the broadcaster
+	knownTxs     mapset.Set                // Set of transaction hashes known to be known by this peer
+	knownBlocks  mapset.Set                // Set of block hashes known to be known by this peer
+	queuedTxs    chan []*types.Transaction // Queue of transactions to broadcast to the peer
+	queuedTxAnns chan []*types.Transaction // Queue of transactions to announce to the peer
+	queuedProps  chan *propEvent           // Queue of blocks to broadcast to the peer
+	queuedAnns   chan *types.Block         // Queue of blocks to announce to the peer
+	term         chan struct{}             // Termination channel to stop the broadcaster
 }
 
 func newPeer(version int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
 	return &peer{
-		Peer:        p,
-		rw:          rw,
-		version:     version,
-		id:          fmt.Sprintf("%x", p.ID().Bytes()[:8]),
-		knownTxs:    mapset.NewSet(),
-		knownBlocks: mapset.NewSet(),
-		queuedTxs:   make(chan []*types.Transaction, maxQueuedTxs),
-		queuedProps: make(chan *propEvent, maxQueuedProps),
-		queuedAnns:  make(chan *types.Block, maxQueuedAnns),
-		term:        make(chan struct{}),
+		Peer:         p,
+		rw:           rw,
+		version:      version,
+		id:           fmt.Sprintf("%x", p.ID().Bytes()[:8]),
+		shared:       make(map[common.ChainID]bool),
+		knownTxs:     mapset.NewSet(),
+		knownBlocks:  mapset.NewSet(),
+		queuedTxs:    make(chan []*types.Transaction, maxQueuedTxs),
+		queuedTxAnns: make(chan []*types.Transaction, maxQueuedTxAnns),
+		queuedProps:  make(chan *propEvent, maxQueuedProps),
+		queuedAnns:   make(chan *types.Block, maxQueuedAnns),
+		term:         make(chan struct{}),
 	}
 }
 
@@ -120,8 +134,18 @@ func (p *peer) broadcast() {
 			}
 			p.Log().Trace("Broadcast transactions", "count", len(txs))
 
+		case txs := <-p.queuedTxAnns:
+			if err := p.SendNewPooledTransactionHashes(txs); err != nil {
+				return
+			}
+			p.Log().Trace("Announced transactions", "count", len(txs))
+
 		case prop := <-p.queuedProps:
-			if err := p.SendNewBlock(prop.block, prop.td); err != nil {
+			send := p.SendNewBlock
+			if p.version >= tau64 {
+				send = p.SendCompactBlock
+			}
+			if err := send(prop.block, prop.td); err != nil {
 				return
 			}
 			p.Log().Trace("Propagated block", "number", prop.block.Number(), "hash", prop.block.Hash(), "td", prop.td)
@@ -147,11 +171,39 @@ func (p *peer) close() {
 func (p *peer) Info() *PeerInfo {
 	hash, td := p.Head()
 
-	return &PeerInfo{
+	info := &PeerInfo{
 		Version:    p.version,
 		Difficulty: td,
 		Head:       hash.Hex(),
 	}
+	for id := range p.shared {
+		info.Chains = append(info.Chains, string(id[:]))
+	}
+	sort.Strings(info.Chains)
+	return info
+}
+
+// Follows reports whether the peer follows the given chain too.
+func (p *peer) Follows(id common.ChainID) bool {
+	return p.shared[id]
+}
+
+// send writes a message belonging to the local chain to the peer. From tau/64
+// on, the payload is wrapped into an envelope carrying the chain id.
+func (p *peer) send(msgcode uint64, data interface{}) error {
+	if p.version < tau64 {
+		return p2p.Send(p.rw, msgcode, data)
+	}
+	return p.sendChain(p.chain, msgcode, data)
+}
+
+// sendChain writes a tau/64 message belonging to the given chain to the peer.
+func (p *peer) sendChain(chain common.ChainID, msgcode uint64, data interface{}) error {
+	payload, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return err
+	}
+	return p2p.Send(p.rw, msgcode, &chainPacket{ChainID: chain, Payload: payload})
 }
 
 // Head retrieves a copy of the current head hash and total difficulty of the
@@ -203,7 +255,7 @@ func (p *peer) SendTransactions(txs types.Transactions) error {
 	for p.knownTxs.Cardinality() >= maxKnownTxs {
 		p.knownTxs.Pop()
 	}
-	return p2p.Send(p.rw, TxMsg, txs)
+	return p.send(TxMsg, txs)
 }
 
 // AsyncSendTransactions queues list of transactions propagation to a remote
@@ -223,6 +275,45 @@ func (p *peer) AsyncSendTransactions(txs []*types.Transaction) {
 	}
 }
 
+// SendNewPooledTransactionHashes announces the availability of a batch of
+// transactions, together with their kinds and sizes, and includes the hashes in
+// the peer's transaction hash set for future reference.
+func (p *peer) SendNewPooledTransactionHashes(txs []*types.Transaction) error {
+	request := make(newPooledTxHashesData, len(txs))
+	for i, tx := range txs {
+		hash := (*tx).Hash()
+		p.knownTxs.Add(hash)
+		request[i] = fetcher.TxAnnounce{Hash: hash, Kind: types.KindOf(*tx), Size: uint32((*tx).Size())}
+	}
+	for p.knownTxs.Cardinality() >= maxKnownTxs {
+		p.knownTxs.Pop()
+	}
+	return p.send(NewPooledTransactionHashesMsg, request)
+}
+
+// AsyncSendPooledTransactionHashes queues a list of transactions to announce to
+// a remote peer. If the peer's announcement queue is full, the event is silently
+// dropped.
+func (p *peer) AsyncSendPooledTransactionHashes(txs []*types.Transaction) {
+	select {
+	case p.queuedTxAnns <- txs:
+		// Mark all the transactions as known, but ensure we don't overflow our limits
+		for _, tx := range txs {
+			p.knownTxs.Add((*tx).Hash())
+		}
+		for p.knownTxs.Cardinality() >= maxKnownTxs {
+			p.knownTxs.Pop()
+		}
+	default:
+		p.Log().Debug("Dropping transaction announcement", "count", len(txs))
+	}
+}
+
+// SendPooledTransactions sends the requested transactions to the peer.
+func (p *peer) SendPooledTransactions(txs []*types.Transaction) error {
+	return p.send(PooledTransactionsMsg, txs)
+}
+
 // SendNewBlockHashes announces the availability of a number of blocks through
 // a hash notification.
 func (p *peer) SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error {
@@ -238,7 +329,7 @@ func (p *peer) SendNewBlockHashes(hashes []common.Hash, numbers []uint64) error
 		request[i].Hash = hashes[i]
 		request[i].Number = numbers[i]
 	}
-	return p2p.Send(p.rw, NewBlockHashesMsg, request)
+	return p.send(NewBlockHashesMsg, request)
 }
 
 // AsyncSendNewBlockHash queues the availability of a block for propagation to a
@@ -264,7 +355,24 @@ func (p *peer) SendNewBlock(block *types.Block, td *big.Int) error {
 	for p.knownBlocks.Cardinality() >= maxKnownBlocks {
 		p.knownBlocks.Pop()
 	}
-	return p2p.Send(p.rw, NewBlockMsg, []interface{}{block, td})
+	return p.send(NewBlockMsg, []interface{}{block, td})
+}
+
+// SendCompactBlock propagates a block to a remote peer in its compact form,
+// carrying in full only the transactions the peer isn't known to have.
+func (p *peer) SendCompactBlock(block *types.Block, td *big.Int) error {
+	// Mark all the block hash as known, but ensure we don't overflow our limits
+	p.knownBlocks.Add(block.Hash())
+	for p.knownBlocks.Cardinality() >= maxKnownBlocks {
+		p.knownBlocks.Pop()
+	}
+	known := func(hash common.Hash) bool { return p.knownTxs.Contains(hash) }
+	return p.send(CompactBlockMsg, newCompactBlock(block, td, known))
+}
+
+// SendBlockTxs sends the requested transactions of a compact block to the peer.
+func (p *peer) SendBlockTxs(hash common.Hash, txs []*types.Transaction) error {
+	return p.send(BlockTxsMsg, &blockTxsData{Hash: hash, Txs: txs})
 }
 
 // AsyncSendNewBlock queues an entire block for propagation to a remote peer. If
@@ -284,79 +392,109 @@ func (p *peer) AsyncSendNewBlock(block *types.Block, td *big.Int) {
 
 // SendBlockHeaders sends a batch of block headers to the remote peer.
 func (p *peer) SendBlockHeaders(headers []*types.Header) error {
-	return p2p.Send(p.rw, BlockHeadersMsg, headers)
+	return p.send(BlockHeadersMsg, headers)
 }
 
 // SendBlockBodies sends a batch of block contents to the remote peer.
 func (p *peer) SendBlockBodies(bodies []*blockBody) error {
-	return p2p.Send(p.rw, BlockBodiesMsg, blockBodiesData(bodies))
+	return p.send(BlockBodiesMsg, blockBodiesData(bodies))
 }
 
 // SendBlockBodiesRLP sends a batch of block contents to the remote peer from
 // an already RLP encoded format.
 func (p *peer) SendBlockBodiesRLP(bodies []rlp.RawValue) error {
-	return p2p.Send(p.rw, BlockBodiesMsg, bodies)
+	return p.send(BlockBodiesMsg, bodies)
 }
 
 // SendNodeDataRLP sends a batch of arbitrary internal data, corresponding to the
 // hashes requested.
 func (p *peer) SendNodeData(data [][]byte) error {
-	return p2p.Send(p.rw, NodeDataMsg, data)
+	return p.send(NodeDataMsg, data)
 }
 
 // RequestOneHeader is a wrapper around the header query functions to fetch a
 // single header. It is used solely by the fetcher.
 func (p *peer) RequestOneHeader(hash common.Hash) error {
 	p.Log().Debug("Fetching single header", "hash", hash)
-	return p2p.Send(p.rw, GetBlockHeadersMsg, &getBlockHeadersData{Origin: hashOrNumber{Hash: hash}, Amount: uint64(1), Skip: uint64(0), Reverse: false})
+	return p.send(GetBlockHeadersMsg, &getBlockHeadersData{Origin: hashOrNumber{Hash: hash}, Amount: uint64(1), Skip: uint64(0), Reverse: false})
 }
 
 // RequestHeadersByHash fetches a batch of blocks' headers corresponding to the
 // specified header query, based on the hash of an origin block.
 func (p *peer) RequestHeadersByHash(origin common.Hash, amount int, skip int, reverse bool) error {
 	p.Log().Debug("Fetching batch of headers", "count", amount, "fromhash", origin, "skip", skip, "reverse", reverse)
-	return p2p.Send(p.rw, GetBlockHeadersMsg, &getBlockHeadersData{Origin: hashOrNumber{Hash: origin}, Amount: uint64(amount), Skip: uint64(skip), Reverse: reverse})
+	return p.send(GetBlockHeadersMsg, &getBlockHeadersData{Origin: hashOrNumber{Hash: origin}, Amount: uint64(amount), Skip: uint64(skip), Reverse: reverse})
 }
 
 // RequestHeadersByNumber fetches a batch of blocks' headers corresponding to the
 // specified header query, based on the number of an origin block.
 func (p *peer) RequestHeadersByNumber(origin uint64, amount int, skip int, reverse bool) error {
 	p.Log().Debug("Fetching batch of headers", "count", amount, "fromnum", origin, "skip", skip, "reverse", reverse)
-	return p2p.Send(p.rw, GetBlockHeadersMsg, &getBlockHeadersData{Origin: hashOrNumber{Number: origin}, Amount: uint64(amount), Skip: uint64(skip), Reverse: reverse})
+	return p.send(GetBlockHeadersMsg, &getBlockHeadersData{Origin: hashOrNumber{Number: origin}, Amount: uint64(amount), Skip: uint64(skip), Reverse: reverse})
 }
 
 // RequestBodies fetches a batch of blocks' bodies corresponding to the hashes
 // specified.
 func (p *peer) RequestBodies(hashes []common.Hash) error {
 	p.Log().Debug("Fetching batch of block bodies", "count", len(hashes))
-	return p2p.Send(p.rw, GetBlockBodiesMsg, hashes)
+	return p.send(GetBlockBodiesMsg, hashes)
+}
+
+// RequestTxs fetches a batch of transactions announced by the peer.
+func (p *peer) RequestTxs(hashes []common.Hash) error {
+	p.Log().Debug("Fetching batch of transactions", "count", len(hashes))
+	return p.send(GetPooledTransactionsMsg, hashes)
+}
+
+// RequestBlockTxs fetches the transactions of a compact block propagated by the
+// peer, which couldn't be found in the local pool.
+func (p *peer) RequestBlockTxs(hash common.Hash, indexes []uint64) error {
+	p.Log().Debug("Fetching missing block transactions", "hash", hash, "count", len(indexes))
+	return p.send(GetBlockTxsMsg, &getBlockTxsData{Hash: hash, Indexes: indexes})
 }
 
 // RequestNodeData fetches a batch of arbitrary data from a node's known state
 // data, corresponding to the specified hashes.
 func (p *peer) RequestNodeData(hashes []common.Hash) error {
 	p.Log().Debug("Fetching batch of state data", "count", len(hashes))
-	return p2p.Send(p.rw, GetNodeDataMsg, hashes)
+	return p.send(GetNodeDataMsg, hashes)
 }
 
 // Handshake executes the tau protocol handshake, negotiating version number,
-// network IDs, difficulties, head and genesis blocks.
-func (p *peer) Handshake(network uint64, td *big.Int, head common.Hash, genesis common.Hash) error {
+// network IDs and the followed chains with their difficulties, head and genesis
+// blocks. The first local chain is the default one, the only chain shared with
+// peers older than tau/64.
+func (p *peer) Handshake(network uint64, chains []chainStatus) error {
 	// Send out own handshake in a new thread
 	errc := make(chan error, 2)
-	var status statusData // safe to read after two values have been received from errc
+	var remote []chainStatus // safe to read after two values have been received from errc
 
+	local := chains[0]
 	go func() {
+		if p.version >= tau64 {
+			errc <- p2p.Send(p.rw, StatusMsg, &statusData64{
+				ProtocolVersion: uint32(p.version),
+				NetworkId:       network,
+				Chains:          chains,
+			})
+			return
+		}
 		errc <- p2p.Send(p.rw, StatusMsg, &statusData{
 			ProtocolVersion: uint32(p.version),
 			NetworkId:       network,
-			TD:              td,
-			CurrentBlock:    head,
-			GenesisBlock:    genesis,
+			TD:              local.TD,
+			CurrentBlock:    local.Head,
+			GenesisBlock:    local.Genesis,
 		})
 	}()
 	go func() {
-		errc <- p.readStatus(network, &status, genesis)
+		var err error
+		if p.version >= tau64 {
+			remote, err = p.readStatus64(network, chains)
+		} else {
+			remote, err = p.readStatus(network, local)
+		}
+		errc <- err
 	}()
 	timeout := time.NewTimer(handshakeTimeout)
 	defer timeout.Stop()
@@ -370,35 +508,97 @@ func (p *peer) Handshake(network uint64, td *big.Int, head common.Hash, genesis
 			return p2p.DiscReadTimeout
 		}
 	}
-	p.td, p.head = status.TD, status.CurrentBlock
+	p.chain, p.td, p.head = local.ChainID, new(big.Int), common.Hash{}
+	for _, status := range remote {
+		p.shared[status.ChainID] = true
+		if status.ChainID == local.ChainID {
+			p.td, p.head = status.TD, status.Head
+		}
+	}
 	return nil
 }
 
-func (p *peer) readStatus(network uint64, status *statusData, genesis common.Hash) (err error) {
+// readStatus reads a pre tau/64 status message, returning the state of the
+// default chain, the only one such peers follow.
+func (p *peer) readStatus(network uint64, local chainStatus) ([]chainStatus, error) {
 	msg, err := p.rw.ReadMsg()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if msg.Code != StatusMsg {
-		return errResp(ErrNoStatusMsg, "first msg has code %x (!= %x)", msg.Code, StatusMsg)
+		return nil, errResp(ErrNoStatusMsg, "first msg has code %x (!= %x)", msg.Code, StatusMsg)
 	}
 	if msg.Size > protocolMaxMsgSize {
-		return errResp(ErrMsgTooLarge, "%v > %v", msg.Size, protocolMaxMsgSize)
+		return nil, errResp(ErrMsgTooLarge, "%v > %v", msg.Size, protocolMaxMsgSize)
 	}
 	// Decode the handshake and make sure everything matches
+	var status statusData
 	if err := msg.Decode(&status); err != nil {
-		return errResp(ErrDecode, "msg %v: %v", msg, err)
+		return nil, errResp(ErrDecode, "msg %v: %v", msg, err)
 	}
-	if status.GenesisBlock != genesis {
-		return errResp(ErrGenesisBlockMismatch, "%x (!= %x)", status.GenesisBlock[:8], genesis[:8])
+	if status.GenesisBlock != local.Genesis {
+		return nil, errResp(ErrGenesisBlockMismatch, "%x (!= %x)", status.GenesisBlock[:8], local.Genesis[:8])
 	}
 	if status.NetworkId != network {
-		return errResp(ErrNetworkIdMismatch, "%d (!= %d)", status.NetworkId, network)
+		return nil, errResp(ErrNetworkIdMismatch, "%d (!= %d)", status.NetworkId, network)
 	}
 	if int(status.ProtocolVersion) != p.version {
-		return errResp(ErrProtocolVersionMismatch, "%d (!= %d)", status.ProtocolVersion, p.version)
+		return nil, errResp(ErrProtocolVersionMismatch, "%d (!= %d)", status.ProtocolVersion, p.version)
 	}
-	return nil
+	return []chainStatus{{ChainID: local.ChainID, TD: status.TD, Head: status.CurrentBlock, Genesis: status.GenesisBlock}}, nil
+}
+
+// readStatus64 reads a tau/64 status message, returning the state of the
+// advertised chains also followed locally. Peers sharing no chain are rejected.
+// The genesis of followed chains without a local blockchain is not checked.
+func (p *peer) readStatus64(network uint64, local []chainStatus) ([]chainStatus, error) {
+	msg, err := p.rw.ReadMsg()
+	if err != nil {
+		return nil, err
+	}
+	if msg.Code != StatusMsg {
+		return nil, errResp(ErrNoStatusMsg, "first msg has code %x (!= %x)", msg.Code, StatusMsg)
+	}
+	if msg.Size > protocolMaxMsgSize {
+		return nil, errResp(ErrMsgTooLarge, "%v > %v", msg.Size, protocolMaxMsgSize)
+	}
+	// Decode the handshake and make sure everything matches
+	var status statusData64
+	if err := msg.Decode(&status); err != nil {
+		return nil, errResp(ErrDecode, "msg %v: %v", msg, err)
+	}
+	if status.NetworkId != network {
+		return nil, errResp(ErrNetworkIdMismatch, "%d (!= %d)", status.NetworkId, network)
+	}
+	if int(status.ProtocolVersion) != p.version {
+		return nil, errResp(ErrProtocolVersionMismatch, "%d (!= %d)", status.ProtocolVersion, p.version)
+	}
+	if len(status.Chains) > maxStatusChains {
+		return nil, errResp(ErrDecode, "too many chains: %d > %d", len(status.Chains), maxStatusChains)
+	}
+	genesis := make(map[common.ChainID]common.Hash, len(local))
+	for _, chain := range local {
+		genesis[chain.ChainID] = chain.Genesis
+	}
+	var shared []chainStatus
+	for _, chain := range status.Chains {
+		want, ok := genesis[chain.ChainID]
+		if !ok {
+			continue
+		}
+		if want != (common.Hash{}) && chain.Genesis != want {
+			return nil, errResp(ErrGenesisBlockMismatch, "%x (!= %x)", chain.Genesis[:8], want[:8])
+		}
+		if chain.TD == nil {
+			return nil, errResp(ErrDecode, "missing difficulty of chain %s", chain.ChainID[:8])
+		}
+		delete(genesis, chain.ChainID) // Ignore duplicates
+		shared = append(shared, chain)
+	}
+	if len(shared) == 0 {
+		return nil, errResp(ErrNoSharedChain, "%d chains advertised", len(status.Chains))
+	}
+	return shared, nil
 }
 
 // String implements fmt.Stringer.
@@ -411,9 +611,10 @@ func (p *peer) String() string {
 // peerSet represents the collection of active peers currently participating in
 // the Tau sub-protocol.
 type peerSet struct {
-	peers  map[string]*peer
-	lock   sync.RWMutex
-	closed bool
+	peers      map[string]*peer
+	reputation *reputation.Tracker // Scores peers by their behaviour, nil until the server runs
+	lock       sync.RWMutex
+	closed     bool
 }
 
 // newPeerSet creates a new peer set to track the active participants.
@@ -458,6 +659,31 @@ func (ps *peerSet) Unregister(id string) error {
 	return nil
 }
 
+// SetReputation sets the tracker scoring the peers by their behaviour.
+func (ps *peerSet) SetReputation(tracker *reputation.Tracker) {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	ps.reputation = tracker
+}
+
+// Report records the behaviour of a registered peer, returning whether the peer
+// is banned as a result and should be dropped.
+func (ps *peerSet) Report(id string, event reputation.Event) bool {
+	ps.lock.RLock()
+	p, tracker := ps.peers[id], ps.reputation
+	ps.lock.RUnlock()
+
+	if p == nil {
+		return false
+	}
+	if banned := tracker.Report(p.ID(), event); banned {
+		p.Log().Debug("Peer banned for misbehaviour", "event", event, "score", tracker.Score(p.ID()))
+		return true
+	}
+	return false
+}
+
 // Peer retrieves the registered peer with the given id.
 func (ps *peerSet) Peer(id string) *peer {
 	ps.lock.RLock()
@@ -474,37 +700,54 @@ func (ps *peerSet) Len() int {
 	return len(ps.peers)
 }
 
-// PeersWithoutBlock retrieves a list of peers that do not have a given block in
-// their set of known hashes.
+// ChainLen returns the number of peers following the given chain.
+func (ps *peerSet) ChainLen(id common.ChainID) int {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	count := 0
+	for _, p := range ps.peers {
+		if p.Follows(id) {
+			count++
+		}
+	}
+	return count
+}
+
+// PeersWithoutBlock retrieves a list of peers following the local chain that do
+// not have a given block in their set of known hashes.
 func (ps *peerSet) PeersWithoutBlock(hash common.Hash) []*peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
 
 	list := make([]*peer, 0, len(ps.peers))
 	for _, p := range ps.peers {
-		if !p.knownBlocks.Contains(hash) {
+		if p.Follows(p.chain) && !p.knownBlocks.Contains(hash) {
 			list = append(list, p)
 		}
 	}
 	return list
 }
 
-// PeersWithoutTx retrieves a list of peers that do not have a given transaction
-// in their set of known hashes.
+// PeersWithoutTx retrieves a list of peers following the local chain that do not
+// have a given transaction in their set of known hashes.
 func (ps *peerSet) PeersWithoutTx(hash common.Hash) []*peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
 
 	list := make([]*peer, 0, len(ps.peers))
 	for _, p := range ps.peers {
-		if !p.knownTxs.Contains(hash) {
+		if p.Follows(p.chain) && !p.knownTxs.Contains(hash) {
 			list = append(list, p)
 		}
 	}
 	return list
 }
 
-// BestPeer retrieves the known peer with the currently highest total difficulty.
+// BestPeer retrieves the known peer of the local chain with the currently highest
+// total difficulty.
+// Peers in good standing are preferred over the ones with a negative reputation,
+// which are only picked if no better peer is available.
 func (ps *peerSet) BestPeer() *peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
@@ -512,10 +755,16 @@ func (ps *peerSet) BestPeer() *peer {
 	var (
 		bestPeer *peer
 		bestTd   *big.Int
+		bestGood bool
 	)
 	for _, p := range ps.peers {
-		if _, td := p.Head(); bestPeer == nil || td.Cmp(bestTd) > 0 {
-			bestPeer, bestTd = p, td
+		if !p.Follows(p.chain) {
+			continue
+		}
+		_, td := p.Head()
+		good := ps.reputation.Score(p.ID()) >= 0
+		if bestPeer == nil || (good && !bestGood) || (good == bestGood && td.Cmp(bestTd) > 0) {
+			bestPeer, bestTd, bestGood = p, td, good
 		}
 	}
 	return bestPeer
@@ -17,6 +17,8 @@
 package tau
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -26,25 +28,30 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/event"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/fetcher"
 )
 
 // Constants to match up protocol versions and messages
 const (
 	tau62 = 62
 	tau63 = 63
+	tau64 = 64
 )
 
 // protocolName is the official short name of the protocol used during capability negotiation.
 const protocolName = "tau"
 
 // ProtocolVersions are the supported versions of the tau protocol (first is primary).
-var ProtocolVersions = []uint{tau63}
+// Peers only speaking tau/63 are still served, limited to the default chain.
+var ProtocolVersions = []uint{tau64, tau63}
 
 // protocolLengths are the number of implemented message corresponding to different protocol versions.
-var protocolLengths = map[uint]uint64{tau63: 17, tau62: 8}
+var protocolLengths = map[uint]uint64{tau64: 17, tau63: 17, tau62: 8}
 
 const protocolMaxMsgSize = 10 * 1024 * 1024 // Maximum cap on the size of a protocol message
 
+const maxStatusChains = 1024 // Maximum number of chains advertised in a tau/64 handshake
+
 // tau protocol message codes
 const (
 	// Protocol messages belonging to tau/62
@@ -57,6 +64,14 @@ const (
 	BlockBodiesMsg     = 0x06
 	NewBlockMsg        = 0x07
 
+	// Protocol messages belonging to tau/64
+	NewPooledTransactionHashesMsg = 0x08
+	GetPooledTransactionsMsg      = 0x09
+	PooledTransactionsMsg         = 0x0a
+	CompactBlockMsg               = 0x0b
+	GetBlockTxsMsg                = 0x0c
+	BlockTxsMsg                   = 0x0f
+
 	// Protocol messages belonging to tau/63
 	GetNodeDataMsg = 0x0d
 	NodeDataMsg    = 0x0e
@@ -74,6 +89,8 @@ const (
 	ErrNoStatusMsg
 	ErrExtraStatusMsg
 	ErrSuspendedPeer
+	ErrNoSharedChain
+	ErrUnknownChain
 )
 
 func (e errCode) String() string {
@@ -91,21 +108,36 @@ var errorToString = map[int]string{
 	ErrNoStatusMsg:             "No status message",
 	ErrExtraStatusMsg:          "Extra status message",
 	ErrSuspendedPeer:           "Suspended peer",
+	ErrNoSharedChain:           "No shared chain",
+	ErrUnknownChain:            "Unknown chain",
 }
 
 type txPool interface {
 	// AddRemotes should add the given transactions to the pool.
 	AddRemotes([]*types.Transaction) []error
 
+	// Get should return the transaction with the given hash if it is contained
+	// in the pool, nil otherwise.
+	Get(hash common.Hash) *types.Transaction
+
 	// Pending should return pending transactions.
 	// The slice should be modifiable by the caller.
 	Pending() (map[common.Address]types.Transactions, error)
 
+	// Locals should return the accounts whose transactions are local.
+	Locals() []common.Address
+
 	// SubscribeNewTxsEvent should return an event subscription of
 	// NewTxsEvent and send events to the given channel.
 	SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription
 }
 
+// chainFollower is the source of the chains followed by the user.
+type chainFollower interface {
+	// FollowedChains should return the ids of all followed chains.
+	FollowedChains() []common.ChainID
+}
+
 // statusData is the network packet for the status message.
 type statusData struct {
 	ProtocolVersion uint32
@@ -115,6 +147,40 @@ type statusData struct {
 	GenesisBlock    common.Hash
 }
 
+// chainStatus is the state of a single chain advertised in the tau/64 handshake.
+type chainStatus struct {
+	ChainID common.ChainID
+	TD      *big.Int
+	Head    common.Hash
+	Genesis common.Hash
+}
+
+// statusData64 is the network packet for the tau/64 status message, carrying
+// the state of every chain the sender follows.
+type statusData64 struct {
+	ProtocolVersion uint32
+	NetworkId       uint64
+	Chains          []chainStatus
+}
+
+// chainPacket is the tau/64 envelope of every message following the handshake,
+// tagging the payload with the chain it belongs to.
+type chainPacket struct {
+	ChainID common.ChainID
+	Payload rlp.RawValue
+}
+
+// headerChainID returns the id of the chain a header belongs to, the hex
+// encoding of the chain hash it carries.
+func headerChainID(header *types.Header) common.ChainID {
+	var id common.ChainID
+	hex.Encode(id[:], header.ChainID[:])
+	return id
+}
+
+// newPooledTxHashesData is the network packet for the transaction announcements.
+type newPooledTxHashesData []fetcher.TxAnnounce
+
 // newBlockHashesData is the network packet for the block announcements.
 type newBlockHashesData []struct {
 	Hash   common.Hash // Hash of one particular block being announced
@@ -184,6 +250,63 @@ func (request *newBlockData) sanityCheck() error {
 	return nil
 }
 
+// compactBlockData is the network packet of a block propagated compactly: the
+// header, the short ids of all its transactions, and the transactions the
+// receiver most likely misses.
+type compactBlockData struct {
+	Header    *types.Header
+	TD        *big.Int
+	ShortIDs  []uint64      // Short ids of the transactions, in block order
+	Prefilled []prefilledTx // Transactions sent in full, in block order
+}
+
+// prefilledTx is a transaction sent in full in a compact block.
+type prefilledTx struct {
+	Index uint64
+	Tx    *types.Transaction
+}
+
+// getBlockTxsData is the network packet requesting the transactions of a
+// compact block the receiver couldn't find in its pool.
+type getBlockTxsData struct {
+	Hash    common.Hash
+	Indexes []uint64
+}
+
+// blockTxsData is the network packet delivering the requested transactions of
+// a compact block, in the order requested.
+type blockTxsData struct {
+	Hash common.Hash
+	Txs  []*types.Transaction
+}
+
+// sanityCheck verifies that the values are reasonable, as a DoS protection
+func (request *compactBlockData) sanityCheck() error {
+	if request.Header == nil {
+		return errors.New("missing header")
+	}
+	if err := request.Header.SanityCheck(); err != nil {
+		return err
+	}
+	if tdlen := request.TD.BitLen(); tdlen > 100 {
+		return fmt.Errorf("too large block TD: bitlen %d", tdlen)
+	}
+	if len(request.ShortIDs) > maxCompactTxs {
+		return fmt.Errorf("too many transactions: %d", len(request.ShortIDs))
+	}
+	next := uint64(0)
+	for _, prefilled := range request.Prefilled {
+		if prefilled.Index < next || prefilled.Index >= uint64(len(request.ShortIDs)) {
+			return fmt.Errorf("invalid prefilled transaction index %d", prefilled.Index)
+		}
+		if prefilled.Tx == nil {
+			return fmt.Errorf("prefilled transaction %d is nil", prefilled.Index)
+		}
+		next = prefilled.Index + 1
+	}
+	return nil
+}
+
 // blockBody represents the data content of a single block.
 type blockBody struct {
 	Transactions []*types.Transaction // Transactions contained within a block
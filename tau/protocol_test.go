@@ -18,6 +18,7 @@ package tau
 
 import (
 	"fmt"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -92,9 +93,141 @@ func testStatusMsgErrors(t *testing.T, protocol int) {
 	}
 }
 
+// Tests that tau/64 handshake failures are detected and reported correctly.
+func TestStatusMsgErrors64(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, nil)
+	defer pm.Stop()
+
+	var (
+		local   = pm.chainStatuses()[0]
+		foreign = chainStatus{TD: local.TD, Head: local.Head, Genesis: common.Hash{3}}
+		forked  = local
+	)
+	copy(foreign.ChainID[:], "foreign")
+	forked.Genesis = common.Hash{3}
+
+	tests := []struct {
+		code      uint64
+		data      interface{}
+		wantError error
+	}{
+		{
+			code: StatusMsg, data: statusData64{tau64, 999, []chainStatus{local}},
+			wantError: errResp(ErrNetworkIdMismatch, "999 (!= %d)", DefaultConfig.NetworkId),
+		},
+		{
+			code: StatusMsg, data: statusData64{tau64, DefaultConfig.NetworkId, []chainStatus{foreign}},
+			wantError: errResp(ErrNoSharedChain, "1 chains advertised"),
+		},
+		{
+			code: StatusMsg, data: statusData64{tau64, DefaultConfig.NetworkId, []chainStatus{foreign, forked}},
+			wantError: errResp(ErrGenesisBlockMismatch, "0300000000000000 (!= %x)", local.Genesis.Bytes()[:8]),
+		},
+	}
+	for i, test := range tests {
+		p, errc := newTestPeer("peer", tau64, pm, false)
+		go p2p.Send(p.app, test.code, test.data)
+
+		select {
+		case err := <-errc:
+			if err == nil {
+				t.Errorf("test %d: protocol returned nil error, want %q", i, test.wantError)
+			} else if err.Error() != test.wantError.Error() {
+				t.Errorf("test %d: wrong error: got %q, want %q", i, err, test.wantError)
+			}
+		case <-time.After(2 * time.Second):
+			t.Errorf("protocol did not shut down within 2 seconds")
+		}
+		p.close()
+	}
+}
+
+// Tests that tau/64 messages tagged with a chain not shared with the peer are
+// rejected.
+func TestUnknownChainMsg64(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, nil)
+	p, errc := newTestPeer("peer", tau64, pm, true)
+	defer pm.Stop()
+	defer p.close()
+
+	packet := &chainPacket{Payload: []byte{0xc0}}
+	copy(packet.ChainID[:], "foreign")
+	go p2p.Send(p.app, TxMsg, packet)
+
+	select {
+	case err := <-errc:
+		if want := errResp(ErrUnknownChain, "%s", packet.ChainID[:8]); err == nil || err.Error() != want.Error() {
+			t.Errorf("wrong error: got %v, want %v", err, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Errorf("protocol did not shut down within 2 seconds")
+	}
+}
+
+// testFollower is a static set of followed chains.
+type testFollower []common.ChainID
+
+func (f testFollower) FollowedChains() []common.ChainID { return f }
+
+// Tests that the chains followed without a local blockchain are advertised in
+// the tau/64 handshake and that their queries are answered instead of dropping
+// the peer, which is kept out of the sync and propagation of the local chain.
+func TestFollowedChainMsg64(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, nil)
+	defer pm.Stop()
+
+	var followed common.ChainID
+	copy(followed[:], "followed")
+	pm.chains = testFollower{pm.chainID, followed}
+
+	chains := pm.chainStatuses()
+	if len(chains) != 2 || chains[0].ChainID != pm.chainID || chains[1].ChainID != followed {
+		t.Fatalf("advertised chains mismatch: %v", chains)
+	}
+	p, errc := newTestPeer("peer", tau64, pm, false)
+	defer p.close()
+
+	// Handshake sharing only the followed chain, with a genesis unknown locally
+	if err := p2p.ExpectMsg(p.app, StatusMsg, &statusData64{tau64, DefaultConfig.NetworkId, chains}); err != nil {
+		t.Fatalf("status recv: %v", err)
+	}
+	remote := chainStatus{ChainID: followed, TD: big.NewInt(1), Genesis: common.Hash{3}}
+	if err := p2p.Send(p.app, StatusMsg, &statusData64{tau64, DefaultConfig.NetworkId, []chainStatus{remote}}); err != nil {
+		t.Fatalf("status send: %v", err)
+	}
+	// Query the followed chain and expect an empty answer tagged with it
+	query, _ := rlp.EncodeToBytes(&getBlockHeadersData{Origin: hashOrNumber{Number: 1}, Amount: 1})
+	if err := p2p.Send(p.app, GetBlockHeadersMsg, &chainPacket{ChainID: followed, Payload: query}); err != nil {
+		t.Fatalf("query send: %v", err)
+	}
+	empty, _ := rlp.EncodeToBytes([]*types.Header{})
+	if err := p2p.ExpectMsg(p.app, BlockHeadersMsg, &chainPacket{ChainID: followed, Payload: empty}); err != nil {
+		t.Fatalf("headers recv: %v", err)
+	}
+	select {
+	case err := <-errc:
+		t.Fatalf("peer dropped: %v", err)
+	default:
+	}
+	// The peer is registered, but never picked for the local chain
+	if pm.peers.Peer(p.peer.id) == nil {
+		t.Fatalf("side chain peer not registered")
+	}
+	if peer := pm.peers.BestPeer(); peer != nil {
+		t.Errorf("side chain peer picked for sync: %v", peer)
+	}
+	if peers := pm.peers.PeersWithoutTx(common.Hash{1}); len(peers) != 0 {
+		t.Errorf("side chain peer picked for transaction propagation: %v", peers)
+	}
+	if peers := pm.peers.PeersWithoutBlock(common.Hash{1}); len(peers) != 0 {
+		t.Errorf("side chain peer picked for block propagation: %v", peers)
+	}
+}
+
 // This test checks that received transactions are added to the local pool.
 func TestRecvTransactions62(t *testing.T) { testRecvTransactions(t, 62) }
 func TestRecvTransactions63(t *testing.T) { testRecvTransactions(t, 63) }
+func TestRecvTransactions64(t *testing.T) { testRecvTransactions(t, 64) }
 
 func testRecvTransactions(t *testing.T, protocol int) {
 	txAdded := make(chan []*types.Transaction)
@@ -105,7 +238,7 @@ func testRecvTransactions(t *testing.T, protocol int) {
 	defer p.close()
 
 	tx := newTestTransaction(testAccount, 0, 0)
-	if err := p2p.Send(p.app, TxMsg, []interface{}{tx}); err != nil {
+	if err := p.send(TxMsg, []interface{}{tx}); err != nil {
 		t.Fatalf("send error: %v", err)
 	}
 	select {
@@ -44,6 +44,9 @@ type txsync struct {
 
 // syncTransactions starts sending all currently pending transactions to the given peer.
 func (pm *ProtocolManager) syncTransactions(p *peer) {
+	if pm.isCapped() {
+		return
+	}
 	var txs types.Transactions
 	pending, _ := pm.txpool.Pending()
 	for _, batch := range pending {
@@ -160,12 +163,46 @@ func (pm *ProtocolManager) syncer() {
 	}
 }
 
+// SetSyncAllowed suspends or resumes chain synchronisation. Suspending also
+// aborts any sync cycle in progress.
+func (pm *ProtocolManager) SetSyncAllowed(allowed bool) {
+	if allowed {
+		atomic.StoreUint32(&pm.noSync, 0)
+		return
+	}
+	atomic.StoreUint32(&pm.noSync, 1)
+	pm.downloader.Cancel()
+}
+
+// SetCapped suspends or resumes all chain traffic as the data cap of the tau
+// protocol is reached or lifted: synchronisation, block and transaction
+// propagation, and the retrieval of announced blocks and transactions. Only
+// the requests of the remote peers are still served. Suspending also aborts
+// any sync cycle in progress.
+func (pm *ProtocolManager) SetCapped(capped bool) {
+	if !capped {
+		atomic.StoreUint32(&pm.capped, 0)
+		return
+	}
+	atomic.StoreUint32(&pm.capped, 1)
+	pm.downloader.Cancel()
+}
+
+// isCapped reports whether the data cap of the tau protocol is reached.
+func (pm *ProtocolManager) isCapped() bool {
+	return atomic.LoadUint32(&pm.capped) == 1
+}
+
 // synchronise tries to sync up our local block chain with a remote peer.
 func (pm *ProtocolManager) synchronise(peer *peer) {
 	// Short circuit if no peers are available
 	if peer == nil {
 		return
 	}
+	// Don't sync while the device policy asks us to stay idle, or the data cap is reached
+	if atomic.LoadUint32(&pm.noSync) == 1 || pm.isCapped() {
+		return
+	}
 	// Make sure the peer's TD is higher than our own
 	currentBlock := pm.blockchain.CurrentBlock()
 	td := pm.blockchain.GetTd(currentBlock.Hash(), currentBlock.NumberU64())
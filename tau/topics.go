@@ -0,0 +1,254 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/log"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/discv5"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+)
+
+const (
+	topicRefreshInterval = time.Minute // Interval of syncing the topics with the followed chains
+	topicSearchFast      = time.Second // Search period of chains short of peers
+	topicSearchSlow      = time.Minute // Search period of chains with enough peers
+	minChainPeers        = 3           // Number of peers per chain below which it's searched fast
+
+	chainFilterBits   = 256 // Size of the followed chain filter in bits
+	chainFilterHashes = 3   // Number of bits set per chain in the filter
+)
+
+// chainTopic returns the discv5 topic a chain is advertised under.
+func chainTopic(chain common.ChainID) discv5.Topic {
+	return discv5.Topic(fmt.Sprintf("tau@%x", crypto.Keccak256(chain[:])[:16]))
+}
+
+// chainFilter is the "tauchains" ENR entry, a bloom filter of the chains the
+// node follows. It lets peers tell whether a node may serve a chain before
+// dialing it, at a fixed cost in the size limited record, see chainDialFilter.
+// It's a separate entry rather than a field of the "tau" entry, which older
+// nodes couldn't decode.
+type chainFilter []byte
+
+// ENRKey implements enr.Entry.
+func (f chainFilter) ENRKey() string {
+	return "tauchains"
+}
+
+// newChainFilter creates the filter of the given chains.
+func newChainFilter(chains []common.ChainID) chainFilter {
+	f := make(chainFilter, chainFilterBits/8)
+	for _, chain := range chains {
+		for _, bit := range chainFilterIndexes(chain) {
+			f[bit/8] |= 1 << (bit % 8)
+		}
+	}
+	return f
+}
+
+// Contains reports whether the chain may be followed by the node advertising
+// the filter. Malformed filters contain every chain.
+func (f chainFilter) Contains(chain common.ChainID) bool {
+	if len(f) != chainFilterBits/8 {
+		return true
+	}
+	for _, bit := range chainFilterIndexes(chain) {
+		if f[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// chainFilterIndexes returns the filter bits set by a chain.
+func chainFilterIndexes(chain common.ChainID) []uint {
+	hash := crypto.Keccak256(chain[:])
+	indexes := make([]uint, chainFilterHashes)
+	for i := range indexes {
+		indexes[i] = (uint(hash[2*i])<<8 | uint(hash[2*i+1])) % chainFilterBits
+	}
+	return indexes
+}
+
+// chainDialFilter returns the dial filter of the tau protocol, accepting the
+// discovered nodes whose chain filter contains one of the given chains. Nodes
+// without the entry are accepted, their records may predate it.
+func chainDialFilter(chains func() []common.ChainID) func(*enode.Node) bool {
+	return func(n *enode.Node) bool {
+		var f chainFilter
+		if err := n.Load(&f); err != nil {
+			return true
+		}
+		for _, chain := range chains() {
+			if f.Contains(chain) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// topicNetwork is the subset of the discv5 network used for chain discovery.
+type topicNetwork interface {
+	RegisterTopic(topic discv5.Topic, stop <-chan struct{})
+	SearchTopic(topic discv5.Topic, setPeriod <-chan time.Duration, found chan<- *discv5.Node, lookup chan<- bool)
+}
+
+// candidateDialer accepts the nodes found on the chain topics for dialing,
+// implemented by the p2p server.
+type candidateDialer interface {
+	AddCandidate(node *enode.Node)
+}
+
+// chainDiscovery advertises the followed chains, both as discv5 topics and in
+// the local node record, and searches the topics for peers of the chains.
+type chainDiscovery struct {
+	net    topicNetwork     // Topic discovery network, nil if discv5 is disabled
+	dialer candidateDialer  // Dialer of the peers found on the topics
+	local  *enode.LocalNode // Local node record to advertise the chain filter in
+
+	chains func() []common.ChainID  // Chains followed locally
+	peers  func(common.ChainID) int // Number of peers connected per chain
+
+	topics map[common.ChainID]chan struct{} // Stop channels of the chains being advertised
+	quit   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newChainDiscovery(net topicNetwork, dialer candidateDialer, local *enode.LocalNode, chains func() []common.ChainID, peers func(common.ChainID) int) *chainDiscovery {
+	return &chainDiscovery{
+		net:    net,
+		dialer: dialer,
+		local:  local,
+		chains: chains,
+		peers:  peers,
+		topics: make(map[common.ChainID]chan struct{}),
+		quit:   make(chan struct{}),
+	}
+}
+
+// start starts advertising and searching the followed chains.
+func (d *chainDiscovery) start() {
+	d.wg.Add(1)
+	go d.loop()
+}
+
+// stop stops advertising and searching all chains.
+func (d *chainDiscovery) stop() {
+	close(d.quit)
+	d.wg.Wait()
+}
+
+// loop keeps the advertised chains in sync with the followed ones.
+func (d *chainDiscovery) loop() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(topicRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		d.refresh()
+		select {
+		case <-ticker.C:
+		case <-d.quit:
+			for _, stop := range d.topics {
+				close(stop)
+			}
+			return
+		}
+	}
+}
+
+// refresh updates the chain filter of the local record and starts or stops the
+// topics of the chains followed or unfollowed since the last refresh.
+func (d *chainDiscovery) refresh() {
+	chains := d.chains()
+	if d.local != nil {
+		d.local.Set(newChainFilter(chains))
+	}
+	if d.net == nil {
+		return
+	}
+	followed := make(map[common.ChainID]bool)
+	for _, chain := range chains {
+		followed[chain] = true
+		if _, ok := d.topics[chain]; !ok {
+			stop := make(chan struct{})
+			d.topics[chain] = stop
+
+			d.wg.Add(1)
+			go d.serve(chain, stop)
+		}
+	}
+	for chain, stop := range d.topics {
+		if !followed[chain] {
+			close(stop)
+			delete(d.topics, chain)
+		}
+	}
+}
+
+// serve advertises a chain topic and searches it for peers, fast while the
+// chain is short of peers, until stopped.
+func (d *chainDiscovery) serve(chain common.ChainID, stop chan struct{}) {
+	defer d.wg.Done()
+
+	var (
+		topic     = chainTopic(chain)
+		setPeriod = make(chan time.Duration, 1)
+		found     = make(chan *discv5.Node, 16)
+		fast      = true
+	)
+	go d.net.RegisterTopic(topic, stop)
+	go d.net.SearchTopic(topic, setPeriod, found, nil)
+	setPeriod <- topicSearchFast
+
+	ticker := time.NewTicker(topicRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case n := <-found:
+			pubkey, err := n.ID.Pubkey()
+			if err != nil {
+				continue
+			}
+			log.Trace("Found chain peer", "topic", topic, "addr", n.IP, "tcp", n.TCP)
+			d.dialer.AddCandidate(enode.NewV4(pubkey, n.IP, int(n.TCP), int(n.UDP)))
+
+		case <-ticker.C:
+			if short := d.peers(chain) < minChainPeers; short != fast {
+				fast = short
+				if fast {
+					setPeriod <- topicSearchFast
+				} else {
+					setPeriod <- topicSearchSlow
+				}
+			}
+
+		case <-stop:
+			close(setPeriod)
+			return
+		}
+	}
+}
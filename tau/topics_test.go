@@ -0,0 +1,168 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/discv5"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/enode"
+)
+
+func TestChainFilter(t *testing.T) {
+	followed := []common.ChainID{{1}, {2}}
+	filter := newChainFilter(followed)
+
+	for _, chain := range followed {
+		if !filter.Contains(chain) {
+			t.Errorf("followed chain %x missing from filter", chain[:4])
+		}
+	}
+	misses := 0
+	for i := 0; i < 100; i++ {
+		if !filter.Contains(common.ChainID{0xff, byte(i)}) {
+			misses++
+		}
+	}
+	if misses < 90 {
+		t.Errorf("filter too permissive: %d of 100 unfollowed chains rejected", misses)
+	}
+	if !chainFilter(nil).Contains(common.ChainID{3}) {
+		t.Errorf("malformed filter rejected a chain")
+	}
+}
+
+// Tests that discovered nodes are only dialed if their chain filter contains a
+// local chain, or if their record carries no filter.
+func TestChainDialFilter(t *testing.T) {
+	db, _ := enode.OpenDB("")
+	defer db.Close()
+
+	record := func(entries ...chainFilter) *enode.Node {
+		key, _ := crypto.GenerateKey()
+		ln := enode.NewLocalNode(db, key)
+		for _, e := range entries {
+			ln.Set(e)
+		}
+		return ln.Node()
+	}
+	filter := chainDialFilter(func() []common.ChainID { return []common.ChainID{{1}, {2}} })
+
+	if !filter(record(newChainFilter([]common.ChainID{{2}, {5}}))) {
+		t.Errorf("node following a local chain rejected")
+	}
+	if filter(record(newChainFilter([]common.ChainID{{0xff, 1}}))) {
+		t.Errorf("node following no local chain accepted")
+	}
+	if !filter(record()) {
+		t.Errorf("node without chain filter rejected")
+	}
+}
+
+// testTopicNetwork is a discv5 network recording topic registrations and
+// answering every search with a fixed node.
+type testTopicNetwork struct {
+	node       *discv5.Node
+	registered map[discv5.Topic]bool
+	lock       sync.Mutex
+}
+
+func (n *testTopicNetwork) RegisterTopic(topic discv5.Topic, stop <-chan struct{}) {
+	n.lock.Lock()
+	n.registered[topic] = true
+	n.lock.Unlock()
+
+	<-stop
+
+	n.lock.Lock()
+	delete(n.registered, topic)
+	n.lock.Unlock()
+}
+
+func (n *testTopicNetwork) SearchTopic(topic discv5.Topic, setPeriod <-chan time.Duration, found chan<- *discv5.Node, lookup chan<- bool) {
+	for range setPeriod {
+		found <- n.node
+	}
+}
+
+func (n *testTopicNetwork) isRegistered(topic discv5.Topic) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.registered[topic]
+}
+
+// testCandidateDialer collects the suggested dial candidates.
+type testCandidateDialer chan *enode.Node
+
+func (d testCandidateDialer) AddCandidate(node *enode.Node) { d <- node }
+
+// Tests that followed chains are advertised as topics, that the nodes found on
+// them are dialed, and that unfollowed chains are withdrawn.
+func TestChainDiscovery(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	var (
+		network = &testTopicNetwork{
+			node:       discv5.NewNode(discv5.PubkeyID(&key.PublicKey), net.IP{10, 0, 0, 1}, 30303, 30303),
+			registered: make(map[discv5.Topic]bool),
+		}
+		dialer = make(testCandidateDialer, 4)
+		chains = []common.ChainID{{1}, {2}}
+		lock   sync.Mutex
+	)
+	disc := newChainDiscovery(network, dialer, nil, func() []common.ChainID {
+		lock.Lock()
+		defer lock.Unlock()
+		return chains
+	}, func(common.ChainID) int { return 0 })
+	disc.start()
+	defer disc.stop()
+
+	for i := 0; i < len(chains); i++ {
+		select {
+		case node := <-dialer:
+			if node.ID() != enode.PubkeyToIDV4(&key.PublicKey) || node.TCP() != 30303 {
+				t.Fatalf("candidate mismatch: have %v", node)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no candidate found on chain topic %d", i)
+		}
+	}
+	for _, chain := range chains {
+		if !network.isRegistered(chainTopic(chain)) {
+			t.Fatalf("chain %x not advertised", chain[:4])
+		}
+	}
+	// Unfollow a chain and check that its topic is withdrawn
+	lock.Lock()
+	chains = chains[:1]
+	lock.Unlock()
+	disc.refresh()
+
+	time.Sleep(100 * time.Millisecond)
+	if network.isRegistered(chainTopic(common.ChainID{2})) {
+		t.Fatalf("unfollowed chain still advertised")
+	}
+	if !network.isRegistered(chainTopic(common.ChainID{1})) {
+		t.Fatalf("followed chain withdrawn")
+	}
+}
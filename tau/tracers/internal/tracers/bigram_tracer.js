@@ -1,47 +0,0 @@
-// Copyright 2018 The go-tau Authors
-// This file is part of the go-tau library.
-//
-// The go-tau library is free software: you can redistribute it and/or modify
-// it under the terms of the GNU Lesser General Public License as published by
-// the Free Software Foundation, either version 3 of the License, or
-// (at your option) any later version.
-//
-// The go-tau library is distributed in the hope that it will be useful,
-// but WITHOUT ANY WARRANTY; without even the implied warranty of
-// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
-// GNU Lesser General Public License for more details.
-//
-// You should have received a copy of the GNU Lesser General Public License
-// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
-
-{
-    // hist is the counters of opcode bigrams
-    hist: {},
-    // lastOp is last operation
-    lastOp: '',
-    // execution depth of last op
-    lastDepth: 0,
-    // step is invoked for every opcode that the VM executes.
-    step: function(log, db) {
-        var op = log.op.toString();
-        var depth = log.getDepth();
-        if (depth == this.lastDepth){
-            var key = this.lastOp+'-'+op;
-            if (this.hist[key]){
-                this.hist[key]++;
-            }
-            else {
-                this.hist[key] = 1;
-            }
-        }
-        this.lastOp = op;
-        this.lastDepth = depth;
-    },
-    // fault is invoked when the actual execution of an opcode fails.
-    fault: function(log, db) {},
-    // result is invoked when all the opcodes have been iterated over and returns
-    // the final result of the tracing.
-    result: function(ctx) {
-        return this.hist;
-    },
-}
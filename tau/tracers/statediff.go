@@ -0,0 +1,384 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package tracers implements the native state-diff tracer used by the debug
+// tracing APIs.
+package tracers
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/consensus/tauhash"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb"
+)
+
+// Kinds of coin flows.
+const (
+	FlowTransfer = "transfer" // Value sent by a transaction
+	FlowFee      = "fee"      // Fee share paid to the block's coinbase
+	FlowRelay    = "relay"    // Fee share paid to the relay fund
+	FlowBurn     = "burn"     // Fee share destroyed
+	FlowReward   = "reward"   // Block reward created for the coinbase
+)
+
+// Flow is a single movement of coins. Burned coins have no recipient and
+// newly issued ones no sender.
+type Flow struct {
+	Kind   string          `json:"kind"`
+	From   *common.Address `json:"from,omitempty"`
+	To     *common.Address `json:"to,omitempty"`
+	Amount *hexutil.Big    `json:"amount"`
+}
+
+// AmountDiff is the change of a balance or supply counter.
+type AmountDiff struct {
+	From *hexutil.Big `json:"from"`
+	To   *hexutil.Big `json:"to"`
+}
+
+// NonceDiff is the change of an account nonce.
+type NonceDiff struct {
+	From hexutil.Uint64 `json:"from"`
+	To   hexutil.Uint64 `json:"to"`
+}
+
+// Profile is the JSON representation of a profile record.
+type Profile struct {
+	ContactName hexutil.Bytes  `json:"contactName"`
+	Name        string         `json:"name"`
+	CID         hexutil.Bytes  `json:"cid"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
+
+// ProfileDiff is the change of a profile record. From is nil for an address
+// publishing its first profile.
+type ProfileDiff struct {
+	From *Profile `json:"from"`
+	To   *Profile `json:"to"`
+}
+
+// AccountDiff lists the changed fields of an account.
+type AccountDiff struct {
+	Balance *AmountDiff  `json:"balance,omitempty"`
+	Nonce   *NonceDiff   `json:"nonce,omitempty"`
+	Profile *ProfileDiff `json:"profile,omitempty"`
+}
+
+// SupplyDiff lists the changed supply counters.
+type SupplyDiff struct {
+	Issued *AmountDiff `json:"issued,omitempty"`
+	Burned *AmountDiff `json:"burned,omitempty"`
+}
+
+// Record is a chain or message record created by a transaction. These are
+// kept by the chain and message indexes, not in the state.
+type Record struct {
+	Kind    types.TxKind  `json:"kind"`
+	ID      string        `json:"id"`                // Chain ID or message hash
+	Thread  *common.Hash  `json:"thread,omitempty"`  // Root of the thread of a message
+	Referid *common.Hash  `json:"referid,omitempty"` // Message replied to
+	Name    string        `json:"name,omitempty"`    // Name of a chain
+	Title   string        `json:"title,omitempty"`
+	Content hexutil.Bytes `json:"content,omitempty"` // CID of the message or chain description
+}
+
+// TxTrace is the trace of a single transaction.
+type TxTrace struct {
+	Hash     common.Hash                     `json:"hash"`
+	Kind     types.TxKind                    `json:"kind"`
+	From     common.Address                  `json:"from"`
+	Fee      *hexutil.Big                    `json:"fee,omitempty"`
+	Flows    []*Flow                         `json:"flows"`
+	Accounts map[common.Address]*AccountDiff `json:"accounts"`
+	Supply   *SupplyDiff                     `json:"supply,omitempty"`
+	Records  []*Record                       `json:"records,omitempty"`
+	Error    string                          `json:"error,omitempty"`
+}
+
+// RewardTrace is the trace of the reward application finalizing a block.
+type RewardTrace struct {
+	Coinbase common.Address                  `json:"coinbase"`
+	Flows    []*Flow                         `json:"flows"`
+	Accounts map[common.Address]*AccountDiff `json:"accounts"`
+	Supply   *SupplyDiff                     `json:"supply,omitempty"`
+}
+
+// accountState is the part of an account a trace diffs.
+type accountState struct {
+	balance *big.Int
+	nonce   uint64
+	profile *state.Profile
+}
+
+// StateDiffTracer is a core.StateTracer recording the state diff, the coin
+// flows and the created records of every transaction it is shown, as well as
+// the block reward. Every balance change of a traced block is explained by
+// exactly one of the recorded flows.
+type StateDiffTracer struct {
+	config *params.ChainConfig
+	db     taudb.KeyValueReader // Chain database resolving the message threads
+	txs    []*TxTrace
+	reward *RewardTrace
+
+	statedb     *state.StateDB                   // State the current transition is applied to
+	pre         map[common.Address]*accountState // Touched accounts before the current transition
+	touched     []common.Address                 // Touched accounts in the order they were seen
+	supply      *state.Supply                    // Supply counters before the current transition
+	flows       *[]*Flow                         // Flow list of the current transition
+	blockReward *big.Int                         // Reward paid by the block being finalized
+}
+
+// NewStateDiffTracer creates a new state-diff tracer for blocks of the chain
+// with the given config, stored in db.
+func NewStateDiffTracer(config *params.ChainConfig, db taudb.KeyValueReader) *StateDiffTracer {
+	return &StateDiffTracer{config: config, db: db}
+}
+
+// Transactions returns the traces of the transactions processed so far.
+func (t *StateDiffTracer) Transactions() []*TxTrace {
+	return t.txs
+}
+
+// Reward returns the trace of the block reward, or nil if the block was not
+// finalized.
+func (t *StateDiffTracer) Reward() *RewardTrace {
+	return t.reward
+}
+
+// begin starts tracing a transition on top of statedb. Accounts are only
+// snapshotted once they are touched, the state itself is never copied.
+func (t *StateDiffTracer) begin(statedb *state.StateDB, flows *[]*Flow) {
+	t.statedb = statedb
+	t.pre = make(map[common.Address]*accountState)
+	t.touched = nil
+	t.supply = statedb.GetSupply()
+	t.flows = flows
+}
+
+// touch snapshots an account the current transition changes. Accounts seen
+// for the first time by a flow were already credited or debited by it, the
+// received amount (negative for payments) is taken off their balance to get
+// back the value they had before the transition.
+func (t *StateDiffTracer) touch(statedb *state.StateDB, addr common.Address, received *big.Int) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+	balance := statedb.GetBalance(addr)
+	if received != nil {
+		balance = new(big.Int).Sub(balance, received)
+	}
+	t.pre[addr] = &accountState{
+		balance: balance,
+		nonce:   statedb.GetNonce(addr),
+		profile: statedb.GetProfile(addr).Copy(),
+	}
+	t.touched = append(t.touched, addr)
+}
+
+// flow records a coin movement of the current transition. Both parties must
+// have been touched beforehand.
+func (t *StateDiffTracer) flow(kind string, from, to *common.Address, amount *big.Int) {
+	if amount == nil || amount.Sign() == 0 {
+		return
+	}
+	*t.flows = append(*t.flows, &Flow{Kind: kind, From: from, To: to, Amount: (*hexutil.Big)(new(big.Int).Set(amount))})
+}
+
+// end diffs the touched accounts and the supply against their snapshots taken
+// during the transition.
+func (t *StateDiffTracer) end(statedb *state.StateDB) (map[common.Address]*AccountDiff, *SupplyDiff) {
+	accounts := make(map[common.Address]*AccountDiff)
+	for _, addr := range t.touched {
+		if diff := diffAccount(t.pre[addr], statedb, addr); diff != nil {
+			accounts[addr] = diff
+		}
+	}
+	var (
+		post   = statedb.GetSupply()
+		supply = &SupplyDiff{
+			Issued: diffAmount(t.supply.Issued, post.Issued),
+			Burned: diffAmount(t.supply.Burned, post.Burned),
+		}
+	)
+	if supply.Issued == nil && supply.Burned == nil {
+		supply = nil
+	}
+	t.statedb, t.pre, t.touched, t.supply, t.flows = nil, nil, nil, nil, nil
+	return accounts, supply
+}
+
+// CaptureTxStart implements core.StateTracer.
+func (t *StateDiffTracer) CaptureTxStart(statedb *state.StateDB, tx *types.Transaction, from common.Address) {
+	trace := &TxTrace{
+		Hash:    (*tx).Hash(),
+		Kind:    types.KindOf(*tx),
+		From:    from,
+		Flows:   []*Flow{},
+		Records: txRecords(t.db, *tx),
+	}
+	t.txs = append(t.txs, trace)
+	t.begin(statedb, &trace.Flows)
+	t.touch(statedb, from, nil)
+}
+
+// CaptureTransfer implements core.StateTracer.
+func (t *StateDiffTracer) CaptureTransfer(from, to common.Address, value *big.Int) {
+	t.touch(t.statedb, from, new(big.Int).Neg(value))
+	t.touch(t.statedb, to, value)
+	t.flow(FlowTransfer, &from, &to, value)
+}
+
+// CaptureFee implements core.StateTracer.
+func (t *StateDiffTracer) CaptureFee(from common.Address, fee *big.Int, split *core.FeeSplit) {
+	t.txs[len(t.txs)-1].Fee = (*hexutil.Big)(new(big.Int).Set(fee))
+
+	// The fee shares were all paid out already, the relay fund and the
+	// coinbase may be one and the same account.
+	coinbase := split.Coinbase
+	received := new(big.Int).Set(split.Miner)
+	if split.RelayFund != nil {
+		if *split.RelayFund == coinbase {
+			received.Add(received, split.Relay)
+		} else {
+			t.touch(t.statedb, *split.RelayFund, split.Relay)
+		}
+	}
+	t.touch(t.statedb, coinbase, received)
+
+	t.flow(FlowBurn, &from, nil, split.Burned)
+	if split.RelayFund != nil {
+		fund := *split.RelayFund
+		t.flow(FlowRelay, &from, &fund, split.Relay)
+	}
+	t.flow(FlowFee, &from, &coinbase, split.Miner)
+}
+
+// CaptureTxEnd implements core.StateTracer.
+func (t *StateDiffTracer) CaptureTxEnd(statedb *state.StateDB, err error) {
+	trace := t.txs[len(t.txs)-1]
+	trace.Accounts, trace.Supply = t.end(statedb)
+	if err != nil {
+		trace.Error = err.Error()
+	}
+}
+
+// CaptureRewardStart implements core.StateTracer. The reward is computed
+// with the emission schedule of the tauhash engine, the issued supply only
+// accounts for it past the supply fork.
+func (t *StateDiffTracer) CaptureRewardStart(statedb *state.StateDB, header *types.Header) {
+	t.reward = &RewardTrace{Coinbase: header.Coinbase, Flows: []*Flow{}}
+	t.begin(statedb, &t.reward.Flows)
+	t.touch(statedb, header.Coinbase, nil)
+
+	t.blockReward = nil
+	if t.config != nil && t.config.Tauash != nil {
+		t.blockReward = tauhash.BlockReward(t.config, header.Number, statedb.GetSupply().Issued)
+	}
+}
+
+// CaptureRewardEnd implements core.StateTracer.
+func (t *StateDiffTracer) CaptureRewardEnd(statedb *state.StateDB) {
+	coinbase := t.reward.Coinbase
+	t.flow(FlowReward, nil, &coinbase, t.blockReward)
+	t.reward.Accounts, t.reward.Supply = t.end(statedb)
+	t.blockReward = nil
+}
+
+// diffAccount returns the changes of an account between two states, or nil if
+// it is unchanged.
+func diffAccount(pre *accountState, post *state.StateDB, addr common.Address) *AccountDiff {
+	diff := &AccountDiff{
+		Balance: diffAmount(pre.balance, post.GetBalance(addr)),
+	}
+	if from, to := pre.nonce, post.GetNonce(addr); from != to {
+		diff.Nonce = &NonceDiff{From: hexutil.Uint64(from), To: hexutil.Uint64(to)}
+	}
+	if from, to := pre.profile, post.GetProfile(addr); !equalProfiles(from, to) {
+		diff.Profile = &ProfileDiff{From: newProfile(from), To: newProfile(to)}
+	}
+	if diff.Balance == nil && diff.Nonce == nil && diff.Profile == nil {
+		return nil
+	}
+	return diff
+}
+
+// diffAmount returns the change between two amounts, or nil if they are equal.
+func diffAmount(from, to *big.Int) *AmountDiff {
+	if from.Cmp(to) == 0 {
+		return nil
+	}
+	return &AmountDiff{
+		From: (*hexutil.Big)(new(big.Int).Set(from)),
+		To:   (*hexutil.Big)(new(big.Int).Set(to)),
+	}
+}
+
+func equalProfiles(a, b *state.Profile) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Number == b.Number && bytes.Equal(a.ContactName, b.ContactName) &&
+		bytes.Equal(a.Name, b.Name) && bytes.Equal(a.CID, b.CID)
+}
+
+func newProfile(profile *state.Profile) *Profile {
+	if profile == nil {
+		return nil
+	}
+	return &Profile{
+		ContactName: profile.ContactName,
+		Name:        string(bytes.TrimRight(profile.Name, "\x00")),
+		CID:         profile.CID,
+		BlockNumber: hexutil.Uint64(profile.Number),
+	}
+}
+
+// txRecords returns the chain and message records created by a transaction.
+func txRecords(db taudb.KeyValueReader, tx types.Transaction) []*Record {
+	switch tx := tx.(type) {
+	case *types.NewChainTx:
+		id := tx.NewChainID()
+		return []*Record{{
+			Kind:    types.NewChainTxKind,
+			ID:      string(id[:]),
+			Name:    string(bytes.TrimRight(tx.Name(), "\x00")),
+			Title:   string(bytes.TrimRight(tx.Title(), "\x00")),
+			Content: hexutil.Bytes(tx.Description()),
+		}}
+	case *types.NewMessageTx:
+		record := &Record{
+			Kind:    types.NewMessageTxKind,
+			ID:      tx.Hash().Hex(),
+			Title:   string(bytes.TrimRight(tx.Title(), "\x00")),
+			Content: hexutil.Bytes(tx.Content()),
+		}
+		thread := rawdb.MessageThread(db, tx)
+		record.Thread = &thread
+		if ref := tx.Referid(); ref != (common.Hash{}) {
+			record.Referid = &ref
+		}
+		return []*Record{record}
+	}
+	return nil
+}
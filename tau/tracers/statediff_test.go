@@ -0,0 +1,187 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/params"
+)
+
+var (
+	sender   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	receiver = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	coinbase = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	fund     = common.HexToAddress("0x4000000000000000000000000000000000000004")
+
+	// rewardConfig pays a block reward of 50 coins.
+	rewardConfig = &params.ChainConfig{Tauash: &params.TauashConfig{InitialReward: big.NewInt(50)}}
+)
+
+// Tests that a transfer is traced with its value and fee flows, and that the
+// resulting balance changes are fully explained by them.
+func TestStateDiffTransfer(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.SetBalance(sender, big.NewInt(1000))
+
+	var (
+		tracer = NewStateDiffTracer(rewardConfig, rawdb.NewMemoryDatabase())
+		tx     = types.Transaction(types.NewTransferTransaction(types.OneByte{1}, types.OneByte{0}, nil, 0, 0, big.NewInt(10), sender, receiver, big.NewInt(100)))
+	)
+	// Replay the state changes of the transaction like the state processor
+	tracer.CaptureTxStart(statedb, &tx, sender)
+
+	statedb.SubBalance(sender, big.NewInt(10))
+	statedb.SetNonce(sender, 1)
+	statedb.SubBalance(sender, big.NewInt(100))
+	statedb.AddBalance(receiver, big.NewInt(100))
+	tracer.CaptureTransfer(sender, receiver, big.NewInt(100))
+
+	statedb.AddBurned(big.NewInt(2))
+	statedb.AddBalance(fund, big.NewInt(3))
+	statedb.AddBalance(coinbase, big.NewInt(5))
+	tracer.CaptureFee(sender, big.NewInt(10), &core.FeeSplit{
+		Burned: big.NewInt(2), Relay: big.NewInt(3), RelayFund: &fund, Miner: big.NewInt(5), Coinbase: coinbase,
+	})
+	tracer.CaptureTxEnd(statedb, nil)
+
+	traces := tracer.Transactions()
+	if len(traces) != 1 {
+		t.Fatalf("trace count mismatch: have %d, want 1", len(traces))
+	}
+	trace := traces[0]
+	if trace.Kind != types.TransferTxKind || trace.From != sender || trace.Fee.ToInt().Int64() != 10 {
+		t.Errorf("trace header mismatch: %+v", trace)
+	}
+	wantFlows := []string{FlowTransfer, FlowBurn, FlowRelay, FlowFee}
+	if len(trace.Flows) != len(wantFlows) {
+		t.Fatalf("flow count mismatch: have %d, want %d", len(trace.Flows), len(wantFlows))
+	}
+	for i, kind := range wantFlows {
+		if trace.Flows[i].Kind != kind {
+			t.Errorf("flow %d kind mismatch: have %s, want %s", i, trace.Flows[i].Kind, kind)
+		}
+	}
+	// Every balance change must be explained by the flows
+	net := make(map[common.Address]*big.Int)
+	for _, flow := range trace.Flows {
+		if flow.From != nil {
+			if net[*flow.From] == nil {
+				net[*flow.From] = new(big.Int)
+			}
+			net[*flow.From].Sub(net[*flow.From], flow.Amount.ToInt())
+		}
+		if flow.To != nil {
+			if net[*flow.To] == nil {
+				net[*flow.To] = new(big.Int)
+			}
+			net[*flow.To].Add(net[*flow.To], flow.Amount.ToInt())
+		}
+	}
+	if len(trace.Accounts) != len(net) {
+		t.Fatalf("account count mismatch: have %d, want %d", len(trace.Accounts), len(net))
+	}
+	for addr, delta := range net {
+		diff := trace.Accounts[addr]
+		if diff == nil || diff.Balance == nil {
+			t.Fatalf("missing balance diff of %x", addr)
+		}
+		change := new(big.Int).Sub(diff.Balance.To.ToInt(), diff.Balance.From.ToInt())
+		if change.Cmp(delta) != 0 {
+			t.Errorf("balance change of %x mismatch: have %v, want %v", addr, change, delta)
+		}
+	}
+	if nonce := trace.Accounts[sender].Nonce; nonce == nil || nonce.From != 0 || nonce.To != 1 {
+		t.Errorf("nonce diff mismatch: %+v", nonce)
+	}
+	if trace.Supply == nil || trace.Supply.Burned == nil || trace.Supply.Burned.To.ToInt().Int64() != 2 || trace.Supply.Issued != nil {
+		t.Errorf("supply diff mismatch: %+v", trace.Supply)
+	}
+}
+
+// Tests that profile updates, message records and the block reward are traced.
+func TestStateDiffRecordsAndReward(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	tracer := NewStateDiffTracer(rewardConfig, rawdb.NewMemoryDatabase())
+
+	// Trace a profile update
+	profile := types.Transaction(types.NewPersonalInfoTransaction(types.OneByte{1}, types.OneByte{0}, nil, 0, 0, new(big.Int), sender, []byte("contact"), []byte("alice"), []byte("cid")))
+	tracer.CaptureTxStart(statedb, &profile, sender)
+	statedb.SetProfile(sender, &state.Profile{ContactName: []byte("contact"), Name: []byte("alice"), CID: []byte("cid"), Number: 1})
+	tracer.CaptureTxEnd(statedb, nil)
+
+	// Trace a message
+	message := types.Transaction(types.NewMessageTransaction(types.OneByte{1}, types.OneByte{0}, nil, 1, 0, new(big.Int), sender, common.Hash{}, []byte("hello"), []byte("content")))
+	tracer.CaptureTxStart(statedb, &message, sender)
+	tracer.CaptureTxEnd(statedb, nil)
+
+	// Trace the block reward
+	tracer.CaptureRewardStart(statedb, &types.Header{Coinbase: coinbase, Number: big.NewInt(1)})
+	statedb.AddBalance(coinbase, big.NewInt(50))
+	statedb.AddIssued(big.NewInt(50))
+	tracer.CaptureRewardEnd(statedb)
+
+	traces := tracer.Transactions()
+	if len(traces) != 2 {
+		t.Fatalf("trace count mismatch: have %d, want 2", len(traces))
+	}
+	diff := traces[0].Accounts[sender]
+	if diff == nil || diff.Profile == nil || diff.Profile.From != nil || diff.Profile.To.Name != "alice" {
+		t.Errorf("profile diff mismatch: %+v", diff)
+	}
+	if records := traces[1].Records; len(records) != 1 || records[0].Kind != types.NewMessageTxKind || records[0].Title != "hello" || *records[0].Thread != message.Hash() {
+		t.Errorf("message record mismatch: %+v", records)
+	}
+	reward := tracer.Reward()
+	if reward == nil || len(reward.Flows) != 1 || reward.Flows[0].Kind != FlowReward || reward.Flows[0].Amount.ToInt().Int64() != 50 {
+		t.Fatalf("reward flows mismatch: %+v", reward)
+	}
+	if reward.Supply == nil || reward.Supply.Issued == nil || reward.Supply.Issued.To.ToInt().Int64() != 50 {
+		t.Errorf("reward supply mismatch: %+v", reward.Supply)
+	}
+	if diff := reward.Accounts[coinbase]; diff == nil || diff.Balance.To.ToInt().Int64() != 50 {
+		t.Errorf("coinbase diff mismatch: %+v", diff)
+	}
+}
+
+// Tests that the block reward is traced before the supply fork, where the
+// issued supply does not account for it.
+func TestStateDiffRewardBeforeSupplyFork(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	tracer := NewStateDiffTracer(rewardConfig, rawdb.NewMemoryDatabase())
+
+	tracer.CaptureRewardStart(statedb, &types.Header{Coinbase: coinbase, Number: big.NewInt(1)})
+	statedb.AddBalance(coinbase, big.NewInt(50))
+	tracer.CaptureRewardEnd(statedb)
+
+	reward := tracer.Reward()
+	if reward == nil || len(reward.Flows) != 1 || reward.Flows[0].Kind != FlowReward || reward.Flows[0].Amount.ToInt().Int64() != 50 {
+		t.Fatalf("reward flows mismatch: %+v", reward)
+	}
+	if reward.Supply != nil {
+		t.Errorf("unexpected supply diff: %+v", reward.Supply)
+	}
+	if diff := reward.Accounts[coinbase]; diff == nil || diff.Balance.From.ToInt().Sign() != 0 || diff.Balance.To.ToInt().Int64() != 50 {
+		t.Errorf("coinbase diff mismatch: %+v", diff)
+	}
+}
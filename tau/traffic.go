@@ -0,0 +1,160 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"time"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/stream"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/traffic"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+// ipfsTrafficInterval is the interval of sampling the IPFS bandwidth counters.
+const ipfsTrafficInterval = 10 * time.Second
+
+// msgKinds is the name each message is accounted under in the traffic report.
+var msgKinds = map[uint64]string{
+	StatusMsg:                     "status",
+	NewBlockHashesMsg:             "blockHashes",
+	TxMsg:                         "txs",
+	GetBlockHeadersMsg:            "getHeaders",
+	BlockHeadersMsg:               "headers",
+	GetBlockBodiesMsg:             "getBodies",
+	BlockBodiesMsg:                "bodies",
+	NewBlockMsg:                   "block",
+	NewPooledTransactionHashesMsg: "txHashes",
+	GetPooledTransactionsMsg:      "getPooledTxs",
+	PooledTransactionsMsg:         "pooledTxs",
+	CompactBlockMsg:               "compactBlock",
+	GetBlockTxsMsg:                "getBlockTxs",
+	BlockTxsMsg:                   "blockTxs",
+	GetNodeDataMsg:                "getNodeData",
+	NodeDataMsg:                   "nodeData",
+}
+
+// accountedMsgReadWriter is a wrapper around a p2p.MsgReadWriter, recording the
+// traffic of each message with the traffic accountant of the node. Unlike the
+// metrics, the accounting is always enabled as it backs the data caps.
+type accountedMsgReadWriter struct {
+	p2p.MsgReadWriter                     // Wrapped message stream to account
+	accountant        *traffic.Accountant // Accountant to record the traffic with
+	peer              string              // Identifier of the remote peer
+}
+
+// newAccountedMsgReadWriter wraps a p2p MsgReadWriter with traffic accounting.
+func newAccountedMsgReadWriter(rw p2p.MsgReadWriter, accountant *traffic.Accountant, peer string) p2p.MsgReadWriter {
+	return &accountedMsgReadWriter{MsgReadWriter: rw, accountant: accountant, peer: peer}
+}
+
+func (rw *accountedMsgReadWriter) ReadMsg() (p2p.Msg, error) {
+	msg, err := rw.MsgReadWriter.ReadMsg()
+	if err != nil {
+		return msg, err
+	}
+	rw.accountant.Record(traffic.Tau, msgKind(msg.Code), rw.peer, uint64(msg.Size), 0)
+	return msg, nil
+}
+
+func (rw *accountedMsgReadWriter) WriteMsg(msg p2p.Msg) error {
+	rw.accountant.Record(traffic.Tau, msgKind(msg.Code), rw.peer, 0, uint64(msg.Size))
+	return rw.MsgReadWriter.WriteMsg(msg)
+}
+
+// msgKind returns the name a message code is accounted under.
+func msgKind(code uint64) string {
+	if kind, ok := msgKinds[code]; ok {
+		return kind
+	}
+	return "misc"
+}
+
+// ipfsTraffic records the traffic of the embedded IPFS node, sampling the
+// bandwidth counters of its libp2p host per protocol and accounting the growth
+// since the previous sample.
+type ipfsTraffic struct {
+	reporter   metrics.Reporter              // Bandwidth counters of the libp2p host
+	accountant *traffic.Accountant           // Accountant to record the traffic with
+	last       map[protocol.ID]metrics.Stats // Counters at the previous sample
+	quit       chan struct{}                 // Termination channel of the sampling loop
+	done       chan struct{}                 // Closed once the sampling loop returned
+}
+
+// newIPFSTraffic creates an accounting of the traffic reported by the given
+// bandwidth counters.
+func newIPFSTraffic(reporter metrics.Reporter, accountant *traffic.Accountant) *ipfsTraffic {
+	return &ipfsTraffic{
+		reporter:   reporter,
+		accountant: accountant,
+		last:       make(map[protocol.ID]metrics.Stats),
+		quit:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// start begins sampling the bandwidth counters.
+func (t *ipfsTraffic) start() {
+	go t.loop()
+}
+
+// stop records the traffic since the last sample and terminates the sampling.
+func (t *ipfsTraffic) stop() {
+	close(t.quit)
+	<-t.done
+}
+
+func (t *ipfsTraffic) loop() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(ipfsTrafficInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.sample()
+		case <-t.quit:
+			t.sample()
+			return
+		}
+	}
+}
+
+// sample records the traffic of every protocol since the previous sample. The
+// tau streams are skipped, they are accounted per message by the tau protocol.
+func (t *ipfsTraffic) sample() {
+	for proto, stats := range t.reporter.GetBandwidthByProtocol() {
+		if proto == stream.ProtocolID {
+			continue
+		}
+		last := t.last[proto]
+		t.last[proto] = stats
+
+		var ingress, egress uint64
+		if stats.TotalIn > last.TotalIn {
+			ingress = uint64(stats.TotalIn - last.TotalIn)
+		}
+		if stats.TotalOut > last.TotalOut {
+			egress = uint64(stats.TotalOut - last.TotalOut)
+		}
+		if ingress > 0 || egress > 0 {
+			t.accountant.Record(traffic.IPFS, string(proto), "", ingress, egress)
+		}
+	}
+}
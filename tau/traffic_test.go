@@ -0,0 +1,64 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tau
+
+import (
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/stream"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/p2p/traffic"
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+// testReporter is a libp2p bandwidth reporter serving preset counters.
+type testReporter struct {
+	metrics.Reporter
+	stats map[protocol.ID]metrics.Stats
+}
+
+func (r *testReporter) GetBandwidthByProtocol() map[protocol.ID]metrics.Stats {
+	return r.stats
+}
+
+// Tests that the IPFS traffic is accounted from the growth of the bandwidth
+// counters, leaving out the tau streams.
+func TestIPFSTraffic(t *testing.T) {
+	var (
+		bitswap    = protocol.ID("/ipfs/bitswap/1.2.0")
+		reporter   = &testReporter{stats: make(map[protocol.ID]metrics.Stats)}
+		accountant = traffic.NewAccountant()
+		sampler    = newIPFSTraffic(reporter, accountant)
+	)
+	reporter.stats[bitswap] = metrics.Stats{TotalIn: 100, TotalOut: 10}
+	reporter.stats[stream.ProtocolID] = metrics.Stats{TotalIn: 1000, TotalOut: 1000}
+	sampler.sample()
+
+	reporter.stats[bitswap] = metrics.Stats{TotalIn: 150, TotalOut: 10}
+	sampler.sample()
+
+	report := accountant.Report()
+	if have := report.Protocols[traffic.IPFS]; have == nil || have.Ingress != 150 || have.Egress != 10 {
+		t.Fatalf("ipfs traffic mismatch: have %+v, want {150 10}", have)
+	}
+	if have := report.Kinds[traffic.IPFS][string(stream.ProtocolID)]; have != nil {
+		t.Errorf("tau streams accounted as ipfs traffic: %+v", have)
+	}
+	if have := report.Protocols[traffic.Tau]; have != nil {
+		t.Errorf("unexpected tau traffic: %+v", have)
+	}
+}
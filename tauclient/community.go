@@ -0,0 +1,206 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	tau "github.com/Tau-Coin/taucoin-mobile-mining-go"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/chaindir"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/userdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/filters"
+)
+
+// Node Signed Transactions
+//
+// The methods below have the node fill in, sign and submit the transaction
+// with one of its unlocked accounts. Use SendTransaction to submit transactions
+// signed locally instead.
+
+// Transfer sends value to a receiver from an account unlocked on the node.
+func (ec *Client) Transfer(ctx context.Context, args TransferArgs) (common.Hash, error) {
+	return ec.sendTx(ctx, "tau_transfer", args.toArg())
+}
+
+// SendMessage posts a message to a community chain from an account unlocked
+// on the node.
+func (ec *Client) SendMessage(ctx context.Context, args MessageArgs) (common.Hash, error) {
+	return ec.sendTx(ctx, "tau_sendMessage", args.toArg())
+}
+
+// CreateChain announces a new community chain from an account unlocked on the
+// node.
+func (ec *Client) CreateChain(ctx context.Context, args ChainArgs) (common.Hash, error) {
+	return ec.sendTx(ctx, "tau_createChain", args.toArg())
+}
+
+// UpdateProfile publishes the profile of an account unlocked on the node.
+func (ec *Client) UpdateProfile(ctx context.Context, args ProfileArgs) (common.Hash, error) {
+	return ec.sendTx(ctx, "tau_updateProfile", args.toArg())
+}
+
+func (ec *Client) sendTx(ctx context.Context, method string, args interface{}) (common.Hash, error) {
+	var hash common.Hash
+	err := ec.c.CallContext(ctx, &hash, method, args)
+	return hash, err
+}
+
+// Profiles
+
+// ProfileAt returns the profile published by the given account as of the given
+// block. The block number can be nil, in which case the profile is taken from
+// the latest known block.
+func (ec *Client) ProfileAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*Profile, error) {
+	var profile *Profile
+	err := ec.c.CallContext(ctx, &profile, "tau_getProfile", account, toBlockNumArg(blockNumber))
+	if err == nil && profile == nil {
+		return nil, tau.NotFound
+	}
+	return profile, err
+}
+
+// SearchProfiles returns the current profiles whose name starts with prefix.
+func (ec *Client) SearchProfiles(ctx context.Context, prefix string) ([]*Profile, error) {
+	var profiles []*Profile
+	err := ec.c.CallContext(ctx, &profiles, "tau_searchProfiles", prefix)
+	return profiles, err
+}
+
+// ProfileHistory returns every profile update of the given account in
+// ascending block order.
+func (ec *Client) ProfileHistory(ctx context.Context, account common.Address) ([]ProfileUpdate, error) {
+	var history []ProfileUpdate
+	err := ec.c.CallContext(ctx, &history, "tau_getProfileHistory", account)
+	return history, err
+}
+
+// SubscribeProfileChanges subscribes to notifications about profile updates
+// of the given accounts, or of all accounts if none are given.
+func (ec *Client) SubscribeProfileChanges(ctx context.Context, accounts []common.Address, ch chan<- *Profile) (tau.Subscription, error) {
+	return ec.c.TauSubscribe(ctx, ch, "profileChanges", accounts)
+}
+
+// Chain Directory
+
+// Chain returns the directory entry of the chain with the given id.
+func (ec *Client) Chain(ctx context.Context, id common.ChainID) (*chaindir.RPCChain, error) {
+	var chain *chaindir.RPCChain
+	err := ec.c.CallContext(ctx, &chain, "tau_getChain", string(id[:]))
+	if err == nil && chain == nil {
+		return nil, tau.NotFound
+	}
+	return chain, err
+}
+
+// Chains lists up to limit directory entries following the given chain id, or
+// from the start of the directory if after is nil.
+func (ec *Client) Chains(ctx context.Context, after *common.ChainID, limit uint) ([]*chaindir.RPCChain, error) {
+	var from *string
+	if after != nil {
+		id := string(after[:])
+		from = &id
+	}
+	var chains []*chaindir.RPCChain
+	err := ec.c.CallContext(ctx, &chains, "tau_listChains", from, hexutil.Uint(limit))
+	return chains, err
+}
+
+// SearchChains returns the chains whose name starts with prefix.
+func (ec *Client) SearchChains(ctx context.Context, prefix string) ([]*chaindir.RPCChain, error) {
+	var chains []*chaindir.RPCChain
+	err := ec.c.CallContext(ctx, &chains, "tau_searchChains", prefix)
+	return chains, err
+}
+
+// FollowedChains returns the ids of the chains followed by the node.
+func (ec *Client) FollowedChains(ctx context.Context) ([]common.ChainID, error) {
+	var ids []string
+	if err := ec.c.CallContext(ctx, &ids, "tau_followedChains"); err != nil {
+		return nil, err
+	}
+	chains := make([]common.ChainID, len(ids))
+	for i, id := range ids {
+		copy(chains[i][:], id)
+	}
+	return chains, nil
+}
+
+// Relays returns the relays known to the node.
+func (ec *Client) Relays(ctx context.Context) ([]*userdb.RPCRelay, error) {
+	var relays []*userdb.RPCRelay
+	err := ec.c.CallContext(ctx, &relays, "tau_relays")
+	return relays, err
+}
+
+// Transaction History
+
+// MaxHistoryRange is the largest block range the node accepts in a single
+// historical query.
+const MaxHistoryRange = 10000
+
+// errHistoryRange is returned if a historical query spans an empty block range
+// or more blocks than the node accepts.
+var errHistoryRange = fmt.Errorf("block range must be non-empty and at most %d blocks", MaxHistoryRange)
+
+// AccountHistory returns the transactions sent or received by the given account
+// in the given inclusive block range, in ascending block order. The range can
+// span at most MaxHistoryRange blocks.
+func (ec *Client) AccountHistory(ctx context.Context, account common.Address, from, to uint64) ([]*filters.TxEvent, error) {
+	return ec.filterHistory(ctx, filters.FilterCriteria{
+		Participants: []common.Address{account},
+	}, from, to)
+}
+
+// Messages returns the messages posted to the given chain in the given inclusive
+// block range, in ascending block order. The range can span at most
+// MaxHistoryRange blocks.
+func (ec *Client) Messages(ctx context.Context, chainID []byte, from, to uint64) ([]*filters.TxEvent, error) {
+	return ec.filterHistory(ctx, filters.FilterCriteria{
+		Kinds:    []types.TxKind{types.NewMessageTxKind},
+		ChainIDs: []hexutil.Bytes{common.CopyBytes(chainID)},
+	}, from, to)
+}
+
+// Thread returns the messages of the thread rooted at the given message in the
+// given inclusive block range, in ascending block order. The range can span at
+// most MaxHistoryRange blocks.
+func (ec *Client) Thread(ctx context.Context, thread common.Hash, from, to uint64) ([]*filters.TxEvent, error) {
+	return ec.filterHistory(ctx, filters.FilterCriteria{
+		Kinds:   []types.TxKind{types.NewMessageTxKind},
+		Threads: []common.Hash{thread},
+	}, from, to)
+}
+
+// filterHistory runs a historical query over a bounded block range.
+func (ec *Client) filterHistory(ctx context.Context, crit filters.FilterCriteria, from, to uint64) ([]*filters.TxEvent, error) {
+	if to < from || to-from >= MaxHistoryRange {
+		return nil, errHistoryRange
+	}
+	crit.FromBlock, crit.ToBlock = toBlockNumber(from), toBlockNumber(to)
+	return ec.FilterTransactions(ctx, crit)
+}
+
+func toBlockNumber(number uint64) *rpc.BlockNumber {
+	n := rpc.BlockNumber(number)
+	return &n
+}
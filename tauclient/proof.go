@@ -0,0 +1,85 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	tau "github.com/Tau-Coin/taucoin-mobile-mining-go"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/crypto"
+	ipldtau "github.com/Tau-Coin/taucoin-mobile-mining-go/ipld"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/taudb/memorydb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/trie"
+)
+
+// GetProof returns the account at the given block together with its Merkle
+// proof. The block number can be nil, in which case the latest known block is
+// used. The result is not trusted until checked with VerifyAccountProof.
+func (ec *Client) GetProof(ctx context.Context, account common.Address, blockNumber *big.Int) (*AccountProof, error) {
+	var result *AccountProof
+	err := ec.c.CallContext(ctx, &result, "tau_getProof", account, toBlockNumArg(blockNumber))
+	if err == nil && result == nil {
+		return nil, tau.NotFound
+	}
+	return result, err
+}
+
+// VerifyAccountProof checks that the balance and nonce of an account proof
+// are committed to by the given state root, which must be taken from a header
+// the caller trusts. Accounts missing from the state verify with a zero
+// balance and nonce. If the proof lists cids, they must match the proof nodes,
+// which allows the nodes to be fetched from IPFS instead of the remote node.
+func VerifyAccountProof(root common.Hash, proof *AccountProof) error {
+	if len(proof.ProofCIDs) != 0 && len(proof.ProofCIDs) != len(proof.AccountProof) {
+		return fmt.Errorf("proof has %d nodes but %d cids", len(proof.AccountProof), len(proof.ProofCIDs))
+	}
+	nodes := memorydb.New()
+	for i, node := range proof.AccountProof {
+		if len(proof.ProofCIDs) != 0 {
+			if want := ipldtau.TrieNodeCid(node).String(); proof.ProofCIDs[i] != want {
+				return fmt.Errorf("proof node %d cid mismatch: have %s, want %s", i, proof.ProofCIDs[i], want)
+			}
+		}
+		nodes.Put(crypto.Keccak256(node), node)
+	}
+	value, _, err := trie.VerifyProof(root, crypto.Keccak256(proof.Address.Bytes()), nodes)
+	if err != nil {
+		return err
+	}
+	account := state.Account{Balance: new(big.Int)}
+	if value != nil {
+		if err := rlp.DecodeBytes(value, &account); err != nil {
+			return fmt.Errorf("invalid account in proof: %v", err)
+		}
+	}
+	balance := new(big.Int)
+	if proof.Balance != nil {
+		balance = proof.Balance
+	}
+	if account.Balance.Cmp(balance) != 0 {
+		return fmt.Errorf("balance mismatch: proven %v, claimed %v", account.Balance, balance)
+	}
+	if account.Nonce != proof.Nonce {
+		return fmt.Errorf("nonce mismatch: proven %d, claimed %d", account.Nonce, proof.Nonce)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauclient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/rawdb"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/state"
+	ipldtau "github.com/Tau-Coin/taucoin-mobile-mining-go/ipld"
+)
+
+// newProof returns the proof of an account in the given committed state.
+func newProof(t *testing.T, statedb *state.StateDB, addr common.Address) *AccountProof {
+	nodes, err := statedb.GetProof(addr)
+	if err != nil {
+		t.Fatalf("failed to prove account: %v", err)
+	}
+	result := &AccountProof{
+		Address: addr,
+		Balance: statedb.GetBalance(addr),
+		Nonce:   statedb.GetNonce(addr),
+	}
+	for _, node := range nodes {
+		result.AccountProof = append(result.AccountProof, node)
+		result.ProofCIDs = append(result.ProofCIDs, ipldtau.TrieNodeCid(node).String())
+	}
+	return result
+}
+
+func TestVerifyAccountProof(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	for i := byte(1); i <= 20; i++ {
+		addr := common.BytesToAddress([]byte{i})
+		statedb.SetBalance(addr, big.NewInt(int64(i)*1000))
+		statedb.SetNonce(addr, uint64(i))
+	}
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	addr := common.BytesToAddress([]byte{7})
+
+	if err := VerifyAccountProof(root, newProof(t, statedb, addr)); err != nil {
+		t.Fatalf("valid proof rejected: %v", err)
+	}
+	// Missing accounts are proven empty
+	if err := VerifyAccountProof(root, newProof(t, statedb, testSender)); err != nil {
+		t.Errorf("absence proof rejected: %v", err)
+	}
+	// Claims not matching the proven account must be rejected
+	proof := newProof(t, statedb, addr)
+	proof.Balance = big.NewInt(1)
+	if err := VerifyAccountProof(root, proof); err == nil {
+		t.Errorf("forged balance accepted")
+	}
+	proof = newProof(t, statedb, addr)
+	proof.Nonce++
+	if err := VerifyAccountProof(root, proof); err == nil {
+		t.Errorf("forged nonce accepted")
+	}
+	// Proofs against another root or with mismatching cids must be rejected
+	if err := VerifyAccountProof(common.HexToHash("0xdead"), newProof(t, statedb, addr)); err == nil {
+		t.Errorf("proof accepted against wrong root")
+	}
+	proof = newProof(t, statedb, addr)
+	proof.ProofCIDs[0] = proof.ProofCIDs[len(proof.ProofCIDs)-1]
+	if len(proof.ProofCIDs) > 1 {
+		if err := VerifyAccountProof(root, proof); err == nil {
+			t.Errorf("mismatching cid accepted")
+		}
+	}
+}
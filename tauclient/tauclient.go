@@ -30,6 +30,8 @@ import (
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rlp"
 	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/feeoracle"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/filters"
 )
 
 // Client defines typed wrappers for the Tau RPC API.
@@ -156,28 +158,38 @@ type rpcTransaction struct {
 }
 
 type txExtraInfo struct {
+	Kind        types.TxKind    `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
 	BlockNumber *string         `json:"blockNumber,omitempty"`
 	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
 	From        *common.Address `json:"from,omitempty"`
 }
 
+// UnmarshalJSON decodes the transaction of the kind announced by the server.
 func (tx *rpcTransaction) UnmarshalJSON(msg []byte) error {
-	if err := json.Unmarshal(msg, &tx.tx); err != nil {
+	if err := json.Unmarshal(msg, &tx.txExtraInfo); err != nil {
 		return err
 	}
-	return json.Unmarshal(msg, &tx.txExtraInfo)
+	inner, err := types.NewTxOfKind(tx.Kind)
+	if err != nil {
+		return err
+	}
+	if err := inner.UnmarshalJSON(tx.Payload); err != nil {
+		return err
+	}
+	tx.tx = &inner
+	return nil
 }
 
 // TransactionByHash returns the transaction with the given hash.
 func (ec *Client) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
 	var json *rpcTransaction
 	err = ec.c.CallContext(ctx, &json, "tau_getTransactionByHash", hash)
-	temp := *(json.tx)
 	if err != nil {
 		return nil, false, err
 	} else if json == nil {
 		return nil, false, tau.NotFound
-	} else if _, r, _ := temp.RawSignatureValues(); r == nil {
+	} else if _, r, _ := (*json.tx).RawSignatureValues(); r == nil {
 		return nil, false, fmt.Errorf("server returned transaction without signature")
 	}
 	if json.From != nil && json.BlockHash != nil {
@@ -200,7 +212,7 @@ func (ec *Client) TransactionSender(ctx context.Context, tx *types.Transaction,
 	}
 	var meta struct {
 		Hash common.Hash
-		From common.Address
+		From *common.Address
 	}
 	if err = ec.c.CallContext(ctx, &meta, "tau_getTransactionByBlockHashAndIndex", block, hexutil.Uint64(index)); err != nil {
 		return common.Address{}, err
@@ -208,7 +220,10 @@ func (ec *Client) TransactionSender(ctx context.Context, tx *types.Transaction,
 	if meta.Hash == (common.Hash{}) || meta.Hash != (*tx).Hash() {
 		return common.Address{}, errors.New("wrong inclusion block/index")
 	}
-	return meta.From, nil
+	if meta.From == nil {
+		return common.Address{}, errors.New("invalid transaction signature")
+	}
+	return *meta.From, nil
 }
 
 // TransactionCount returns the total number of transactions in the given block.
@@ -222,13 +237,12 @@ func (ec *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (
 func (ec *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
 	var json *rpcTransaction
 	err := ec.c.CallContext(ctx, &json, "tau_getTransactionByBlockHashAndIndex", blockHash, hexutil.Uint64(index))
-	temp := *(json.tx)
 	if err != nil {
 		return nil, err
 	}
 	if json == nil {
 		return nil, tau.NotFound
-	} else if _, r, _ := temp.RawSignatureValues(); r == nil {
+	} else if _, r, _ := (*json.tx).RawSignatureValues(); r == nil {
 		return nil, fmt.Errorf("server returned transaction without signature")
 	}
 	if json.From != nil && json.BlockHash != nil {
@@ -237,6 +251,17 @@ func (ec *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash,
 	return json.tx, err
 }
 
+// TransactionReceipt returns the receipt of an included transaction. The
+// receipt is not available for pending transactions.
+func (ec *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*Receipt, error) {
+	var r *Receipt
+	err := ec.c.CallContext(ctx, &r, "tau_getTransactionReceipt", txHash)
+	if err == nil && r == nil {
+		return nil, tau.NotFound
+	}
+	return r, err
+}
+
 func toBlockNumArg(number *big.Int) string {
 	if number == nil {
 		return "latest"
@@ -283,6 +308,26 @@ func (ec *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header)
 	return ec.c.TauSubscribe(ctx, ch, "newHeads")
 }
 
+// SubscribePendingTransactions subscribes to notifications about transactions
+// matching the given criteria entering the transaction pool.
+func (ec *Client) SubscribePendingTransactions(ctx context.Context, crit filters.FilterCriteria, ch chan<- *filters.TxEvent) (tau.Subscription, error) {
+	return ec.c.TauSubscribe(ctx, ch, "newPendingTransactions", crit)
+}
+
+// SubscribeTransactions subscribes to notifications about transactions matching
+// the given criteria being included in, or removed from, the canonical chain.
+func (ec *Client) SubscribeTransactions(ctx context.Context, crit filters.FilterCriteria, ch chan<- *filters.TxEvent) (tau.Subscription, error) {
+	return ec.c.TauSubscribe(ctx, ch, "transactions", crit)
+}
+
+// FilterTransactions executes a historical query over the criteria's block
+// range and returns the matching transactions.
+func (ec *Client) FilterTransactions(ctx context.Context, crit filters.FilterCriteria) ([]*filters.TxEvent, error) {
+	var result []*filters.TxEvent
+	err := ec.c.CallContext(ctx, &result, "tau_getTransactions", crit)
+	return result, err
+}
+
 // State Access
 
 // NetworkID returns the network ID (also known as the chain ID) for this chain.
@@ -298,7 +343,7 @@ func (ec *Client) NetworkID(ctx context.Context) (*big.Int, error) {
 	return version, nil
 }
 
-// BalanceAt returns the wei balance of the given account.
+// BalanceAt returns the balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (ec *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
 	var result hexutil.Big
@@ -306,11 +351,14 @@ func (ec *Client) BalanceAt(ctx context.Context, account common.Address, blockNu
 	return (*big.Int)(&result), err
 }
 
-// StorageAt returns the value of key in the contract storage of the given account.
-// The block number can be nil, in which case the value is taken from the latest known block.
-func (ec *Client) MessageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
-	var result hexutil.Bytes
-	err := ec.c.CallContext(ctx, &result, "tau_getStorageAt", account, key, toBlockNumArg(blockNumber))
+// SupplyAt returns the coin supply as of the given block.
+// The block number can be nil, in which case the supply is taken from the latest known block.
+func (ec *Client) SupplyAt(ctx context.Context, blockNumber *big.Int) (*Supply, error) {
+	var result *Supply
+	err := ec.c.CallContext(ctx, &result, "tau_getSupply", toBlockNumArg(blockNumber))
+	if err == nil && result == nil {
+		return nil, tau.NotFound
+	}
 	return result, err
 }
 
@@ -324,20 +372,13 @@ func (ec *Client) NonceAt(ctx context.Context, account common.Address, blockNumb
 
 // Pending State
 
-// PendingBalanceAt returns the wei balance of the given account in the pending state.
+// PendingBalanceAt returns the balance of the given account in the pending state.
 func (ec *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
 	var result hexutil.Big
 	err := ec.c.CallContext(ctx, &result, "tau_getBalance", account, "pending")
 	return (*big.Int)(&result), err
 }
 
-// PendingStorageAt returns the value of key in the contract storage of the given account in the pending state.
-func (ec *Client) PendingMessageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
-	var result hexutil.Bytes
-	err := ec.c.CallContext(ctx, &result, "tau_getStorageAt", account, key, "pending")
-	return result, err
-}
-
 // PendingNonceAt returns the account nonce of the given account in the pending state.
 // This is the nonce that should be used for the next transaction.
 func (ec *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
@@ -353,39 +394,9 @@ func (ec *Client) PendingTransactionCount(ctx context.Context) (uint, error) {
 	return uint(num), err
 }
 
-// TODO: SubscribePendingTransactions (needs server side)
-
-// Contract Calling
-
-// CallContract executes a message call transaction, which is directly executed in the VM
-// of the node, but never mined into the blockchain.
-//
-// blockNumber selects the block height at which the call runs. It can be nil, in which
-// case the code is taken from the latest known block. Note that state from very old
-// blocks might not be available.
-func (ec *Client) CallContract(ctx context.Context, msg tau.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	var hex hexutil.Bytes
-	err := ec.c.CallContext(ctx, &hex, "tau_call", toCallArg(msg), toBlockNumArg(blockNumber))
-	if err != nil {
-		return nil, err
-	}
-	return hex, nil
-}
-
-// PendingCallContract executes a message call transaction using the EVM.
-// The state seen by the contract call is the pending state.
-func (ec *Client) PendingCallContract(ctx context.Context, msg tau.CallMsg) ([]byte, error) {
-	var hex hexutil.Bytes
-	err := ec.c.CallContext(ctx, &hex, "tau_call", toCallArg(msg), "pending")
-	if err != nil {
-		return nil, err
-	}
-	return hex, nil
-}
-
-// SuggestGasPrice retrieves the currently suggested gas price to allow a timely
-// execution of a transaction.
-func (ec *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+// SuggestFee retrieves the currently suggested transaction fee to allow a
+// timely inclusion of a transaction.
+func (ec *Client) SuggestFee(ctx context.Context) (*big.Int, error) {
 	var hex hexutil.Big
 	if err := ec.c.CallContext(ctx, &hex, "tau_gasPrice"); err != nil {
 		return nil, err
@@ -393,23 +404,24 @@ func (ec *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	return (*big.Int)(&hex), nil
 }
 
-// EstimateGas tries to estimate the gas needed to execute a specific transaction based on
-// the current pending state of the backend blockchain. There is no guarantee that this is
-// the true gas limit requirement as other transactions may be added or removed by miners,
-// but it should provide a basis for setting a reasonable default.
-func (ec *Client) EstimateGas(ctx context.Context, msg tau.CallMsg) (uint64, error) {
-	var hex hexutil.Uint64
-	err := ec.c.CallContext(ctx, &hex, "tau_estimateGas", toCallArg(msg))
-	if err != nil {
-		return 0, err
-	}
-	return uint64(hex), nil
+// SuggestFeeOf retrieves the fees suggested for a transaction of the given
+// kind at several urgency levels.
+func (ec *Client) SuggestFeeOf(ctx context.Context, kind types.TxKind) (*feeoracle.Suggestion, error) {
+	var suggestion *feeoracle.Suggestion
+	err := ec.c.CallContext(ctx, &suggestion, "tau_suggestFee", map[string]interface{}{"kind": kind})
+	return suggestion, err
 }
 
-// SendTransaction injects a signed transaction into the pending pool for execution.
-//
-// If the transaction was a contract creation use the TransactionReceipt method to get the
-// contract address after the transaction has been mined.
+// SuggestReplacementFee retrieves the fee to resubmit a stuck pending
+// transaction with, along with the current suggestions for its kind.
+func (ec *Client) SuggestReplacementFee(ctx context.Context, hash common.Hash) (*feeoracle.Suggestion, error) {
+	var suggestion *feeoracle.Suggestion
+	err := ec.c.CallContext(ctx, &suggestion, "tau_suggestFee", map[string]interface{}{"replace": hash})
+	return suggestion, err
+}
+
+// SendTransaction injects a signed transaction into the pending pool. Use
+// TransactionReceipt to follow its inclusion.
 func (ec *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
 	data, err := rlp.EncodeToBytes(tx)
 	if err != nil {
@@ -417,23 +429,3 @@ func (ec *Client) SendTransaction(ctx context.Context, tx *types.Transaction) er
 	}
 	return ec.c.CallContext(ctx, nil, "tau_sendRawTransaction", common.ToHex(data))
 }
-
-func toCallArg(msg tau.CallMsg) interface{} {
-	arg := map[string]interface{}{
-		"from": msg.From,
-		"to":   msg.To,
-	}
-	if len(msg.Data) > 0 {
-		arg["data"] = hexutil.Bytes(msg.Data)
-	}
-	if msg.Value != nil {
-		arg["value"] = (*hexutil.Big)(msg.Value)
-	}
-	if msg.Gas != 0 {
-		arg["gas"] = hexutil.Uint64(msg.Gas)
-	}
-	if msg.GasPrice != nil {
-		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
-	}
-	return arg
-}
@@ -0,0 +1,222 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauclient
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	tau "github.com/Tau-Coin/taucoin-mobile-mining-go"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/rpc"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/tau/filters"
+)
+
+var (
+	testSender = common.HexToAddress("0x0102030405060708090a0b0c0d0e0f1011121314")
+	testBlock  = common.HexToHash("0xbeef")
+	testChain  common.ChainID
+)
+
+func init() {
+	copy(testChain[:], "c0ffee")
+}
+
+// testService serves canned responses in the tau namespace.
+type testService struct {
+	txs     map[common.Hash]types.Transaction
+	queries []filters.FilterCriteria // Historical queries received
+}
+
+func (s *testService) GetTransactionByHash(hash common.Hash) (map[string]interface{}, error) {
+	tx, ok := s.txs[hash]
+	if !ok {
+		return nil, nil
+	}
+	payload, err := tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"kind":        types.KindOf(tx),
+		"hash":        hash,
+		"from":        types.SenderOf(tx),
+		"blockHash":   testBlock,
+		"blockNumber": "0x1",
+		"payload":     json.RawMessage(payload),
+	}, nil
+}
+
+func (s *testService) GetTransactionReceipt(hash common.Hash) *rpcReceipt {
+	tx, ok := s.txs[hash]
+	if !ok {
+		return nil
+	}
+	return &rpcReceipt{
+		TxHash:      hash,
+		Kind:        types.KindOf(tx),
+		From:        types.SenderOf(tx),
+		BlockHash:   testBlock,
+		BlockNumber: 1,
+		Fee:         (*hexutil.Big)(tx.Fee()),
+	}
+}
+
+func (s *testService) GetTransactions(crit filters.FilterCriteria) []*filters.TxEvent {
+	s.queries = append(s.queries, crit)
+	number := hexutil.Uint64(*crit.FromBlock)
+	return []*filters.TxEvent{{Kind: types.TransferTxKind, BlockNumber: &number}}
+}
+
+func (s *testService) FollowedChains() []string {
+	return []string{string(testChain[:])}
+}
+
+func newTestClient(t *testing.T, txs ...types.Transaction) *Client {
+	client, _ := newTestService(t, txs...)
+	return client
+}
+
+func newTestService(t *testing.T, txs ...types.Transaction) (*Client, *testService) {
+	service := &testService{txs: make(map[common.Hash]types.Transaction)}
+	for _, tx := range txs {
+		service.txs[tx.Hash()] = tx
+	}
+	server := rpc.NewServer()
+	if err := server.RegisterName("tau", service); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	client := NewClient(rpc.DialInProc(server))
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	return client, service
+}
+
+// Tests that transactions of every kind are decoded into their concrete type.
+func TestTransactionByHashKinds(t *testing.T) {
+	var (
+		chainid = types.Byte32s("tau")
+		fee     = big.NewInt(3)
+		txs     = []types.Transaction{
+			types.NewTransferTransaction(types.OneByte{1}, types.OneByte{0}, chainid, 1, 100, fee, testSender, common.Address{0xaa}, big.NewInt(10)),
+			types.NewMessageTransaction(types.OneByte{1}, types.OneByte{0}, chainid, 2, 100, fee, testSender, common.Hash{}, types.Byte144s("hello"), types.Byte32s("cid")),
+			types.NewPersonalInfoTransaction(types.OneByte{1}, types.OneByte{0}, chainid, 3, 100, fee, testSender, types.Byte32s("contact"), types.Byte20s("alice"), types.Byte32s("cid")),
+		}
+	)
+	client := newTestClient(t, txs...)
+
+	for _, want := range txs {
+		tx, pending, err := client.TransactionByHash(context.Background(), want.Hash())
+		if err != nil {
+			t.Fatalf("%v: failed to retrieve transaction: %v", types.KindOf(want), err)
+		}
+		if pending {
+			t.Errorf("%v: included transaction reported pending", types.KindOf(want))
+		}
+		if kind := types.KindOf(*tx); kind != types.KindOf(want) {
+			t.Errorf("kind mismatch: have %v, want %v", kind, types.KindOf(want))
+		}
+		if (*tx).Hash() != want.Hash() {
+			t.Errorf("%v: hash mismatch: have %x, want %x", types.KindOf(want), (*tx).Hash(), want.Hash())
+		}
+	}
+	if _, _, err := client.TransactionByHash(context.Background(), common.Hash{0xff}); err != tau.NotFound {
+		t.Errorf("unknown transaction: have error %v, want %v", err, tau.NotFound)
+	}
+}
+
+func TestTransactionReceipt(t *testing.T) {
+	tx := types.NewMessageTransaction(types.OneByte{1}, types.OneByte{0}, types.Byte32s("tau"), 1, 100, big.NewInt(7), testSender, common.Hash{}, nil, nil)
+	client := newTestClient(t, tx)
+
+	receipt, err := client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("failed to retrieve receipt: %v", err)
+	}
+	if receipt.Kind != types.NewMessageTxKind || receipt.From != testSender || receipt.Fee.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("receipt mismatch: %+v", receipt)
+	}
+	if _, err := client.TransactionReceipt(context.Background(), common.Hash{0xff}); err != tau.NotFound {
+		t.Errorf("unknown receipt: have error %v, want %v", err, tau.NotFound)
+	}
+}
+
+func TestFollowedChains(t *testing.T) {
+	client := newTestClient(t)
+
+	chains, err := client.FollowedChains(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retrieve followed chains: %v", err)
+	}
+	if len(chains) != 1 || chains[0] != testChain {
+		t.Errorf("followed chains mismatch: have %v, want [%v]", chains, testChain)
+	}
+}
+
+// Tests that history queries are sent as a single query over the requested
+// range, and that unbounded ranges are rejected.
+func TestAccountHistoryRanges(t *testing.T) {
+	client, service := newTestService(t)
+
+	history, err := client.AccountHistory(context.Background(), testSender, 5, MaxHistoryRange+4)
+	if err != nil {
+		t.Fatalf("failed to retrieve history: %v", err)
+	}
+	if len(service.queries) != 1 || len(history) != 1 {
+		t.Fatalf("query count mismatch: have %d queries and %d events, want 1", len(service.queries), len(history))
+	}
+	crit := service.queries[0]
+	if *crit.FromBlock != 5 || *crit.ToBlock != MaxHistoryRange+4 {
+		t.Errorf("range mismatch: have %d-%d, want %d-%d", *crit.FromBlock, *crit.ToBlock, 5, MaxHistoryRange+4)
+	}
+	if len(crit.Participants) != 1 || crit.Participants[0] != testSender || len(crit.Senders) != 0 || len(crit.Receivers) != 0 {
+		t.Errorf("criteria mismatch: %+v", crit)
+	}
+	for _, r := range [][2]uint64{{0, MaxHistoryRange}, {10, 9}} {
+		if _, err := client.AccountHistory(context.Background(), testSender, r[0], r[1]); err != errHistoryRange {
+			t.Errorf("range %d-%d: error mismatch: have %v, want %v", r[0], r[1], err, errHistoryRange)
+		}
+	}
+	if len(service.queries) != 1 {
+		t.Errorf("invalid ranges queried the node")
+	}
+}
+
+func TestThreadQuery(t *testing.T) {
+	client, service := newTestService(t)
+
+	thread := common.HexToHash("0x1234")
+	if _, err := client.Thread(context.Background(), thread, 10, 20); err != nil {
+		t.Fatalf("failed to retrieve thread: %v", err)
+	}
+	if len(service.queries) != 1 {
+		t.Fatalf("query count mismatch: have %d, want 1", len(service.queries))
+	}
+	crit := service.queries[0]
+	if *crit.FromBlock != 10 || *crit.ToBlock != 20 {
+		t.Errorf("range mismatch: have %d-%d, want 10-20", *crit.FromBlock, *crit.ToBlock)
+	}
+	if len(crit.Threads) != 1 || crit.Threads[0] != thread || len(crit.Kinds) != 1 || crit.Kinds[0] != types.NewMessageTxKind {
+		t.Errorf("criteria mismatch: %+v", crit)
+	}
+}
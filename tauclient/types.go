@@ -0,0 +1,301 @@
+// Copyright 2020 The go-tau Authors
+// This file is part of the go-tau library.
+//
+// The go-tau library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-tau library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-tau library. If not, see <http://www.gnu.org/licenses/>.
+
+package tauclient
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/common/hexutil"
+	"github.com/Tau-Coin/taucoin-mobile-mining-go/core/types"
+)
+
+// TxArgs are the fields shared by the transactions the node fills in and signs.
+// Nil fields are filled in by the node.
+type TxArgs struct {
+	From      common.Address
+	Fee       *big.Int
+	Nonce     *uint64
+	ChainID   []byte
+	Timestamp *uint64
+}
+
+func (args *TxArgs) toArg() map[string]interface{} {
+	arg := map[string]interface{}{
+		"from": args.From,
+	}
+	if args.Fee != nil {
+		arg["fee"] = (*hexutil.Big)(args.Fee)
+	}
+	if args.Nonce != nil {
+		arg["nonce"] = hexutil.Uint64(*args.Nonce)
+	}
+	if args.ChainID != nil {
+		arg["chainId"] = hexutil.Bytes(args.ChainID)
+	}
+	if args.Timestamp != nil {
+		arg["timestamp"] = hexutil.Uint64(*args.Timestamp)
+	}
+	return arg
+}
+
+// TransferArgs are the arguments of a transfer signed by the node.
+type TransferArgs struct {
+	TxArgs
+	To    common.Address
+	Value *big.Int
+}
+
+func (args *TransferArgs) toArg() map[string]interface{} {
+	arg := args.TxArgs.toArg()
+	arg["to"] = args.To
+	if args.Value != nil {
+		arg["value"] = (*hexutil.Big)(args.Value)
+	}
+	return arg
+}
+
+// MessageArgs are the arguments of a message signed by the node. A nil ReferID
+// starts a new thread.
+type MessageArgs struct {
+	TxArgs
+	ReferID *common.Hash
+	Title   []byte
+	Content []byte
+}
+
+func (args *MessageArgs) toArg() map[string]interface{} {
+	arg := args.TxArgs.toArg()
+	if args.ReferID != nil {
+		arg["referId"] = *args.ReferID
+	}
+	arg["title"] = hexutil.Bytes(args.Title)
+	arg["content"] = hexutil.Bytes(args.Content)
+	return arg
+}
+
+// ChainArgs are the arguments of a chain announcement signed by the node.
+type ChainArgs struct {
+	TxArgs
+	Name        []byte
+	Contact     []byte
+	Title       []byte
+	Description []byte
+}
+
+func (args *ChainArgs) toArg() map[string]interface{} {
+	arg := args.TxArgs.toArg()
+	arg["name"] = hexutil.Bytes(args.Name)
+	arg["contact"] = hexutil.Bytes(args.Contact)
+	arg["title"] = hexutil.Bytes(args.Title)
+	arg["description"] = hexutil.Bytes(args.Description)
+	return arg
+}
+
+// ProfileArgs are the arguments of a profile update signed by the node.
+type ProfileArgs struct {
+	TxArgs
+	ContactName []byte
+	Name        []byte
+	Profile     []byte
+}
+
+func (args *ProfileArgs) toArg() map[string]interface{} {
+	arg := args.TxArgs.toArg()
+	arg["contactName"] = hexutil.Bytes(args.ContactName)
+	arg["name"] = hexutil.Bytes(args.Name)
+	arg["profile"] = hexutil.Bytes(args.Profile)
+	return arg
+}
+
+// Receipt is the outcome of an included transaction.
+type Receipt struct {
+	TxHash           common.Hash
+	Kind             types.TxKind
+	From             common.Address
+	BlockHash        common.Hash
+	BlockNumber      uint64
+	TransactionIndex uint64
+	Fee              *big.Int
+	FeeBurned        *big.Int // Part of the fee destroyed
+	FeeRelay         *big.Int // Part of the fee paid to the relay
+	FeeMiner         *big.Int // Part of the fee paid to the miner
+}
+
+type rpcReceipt struct {
+	TxHash           common.Hash    `json:"transactionHash"`
+	Kind             types.TxKind   `json:"kind"`
+	From             common.Address `json:"from"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	Fee              *hexutil.Big   `json:"fee"`
+	FeeBurned        *hexutil.Big   `json:"feeBurned"`
+	FeeRelay         *hexutil.Big   `json:"feeRelay"`
+	FeeMiner         *hexutil.Big   `json:"feeMiner"`
+}
+
+// UnmarshalJSON decodes a receipt returned by the node.
+func (r *Receipt) UnmarshalJSON(input []byte) error {
+	var dec rpcReceipt
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*r = Receipt{
+		TxHash:           dec.TxHash,
+		Kind:             dec.Kind,
+		From:             dec.From,
+		BlockHash:        dec.BlockHash,
+		BlockNumber:      uint64(dec.BlockNumber),
+		TransactionIndex: uint64(dec.TransactionIndex),
+		Fee:              (*big.Int)(dec.Fee),
+		FeeBurned:        (*big.Int)(dec.FeeBurned),
+		FeeRelay:         (*big.Int)(dec.FeeRelay),
+		FeeMiner:         (*big.Int)(dec.FeeMiner),
+	}
+	return nil
+}
+
+// Supply is the coin supply as of a block.
+type Supply struct {
+	Issued     *big.Int // Coins issued as mining rewards
+	Burned     *big.Int // Coins destroyed with the fees
+	MaxSupply  *big.Int // Cap of the issued coins, nil if uncapped
+	NextReward *big.Int // Reward of the next block
+}
+
+type rpcSupply struct {
+	Issued     *hexutil.Big `json:"issued"`
+	Burned     *hexutil.Big `json:"burned"`
+	MaxSupply  *hexutil.Big `json:"maxSupply"`
+	NextReward *hexutil.Big `json:"nextReward"`
+}
+
+// UnmarshalJSON decodes a supply returned by the node.
+func (s *Supply) UnmarshalJSON(input []byte) error {
+	var dec rpcSupply
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*s = Supply{
+		Issued:     (*big.Int)(dec.Issued),
+		Burned:     (*big.Int)(dec.Burned),
+		MaxSupply:  (*big.Int)(dec.MaxSupply),
+		NextReward: (*big.Int)(dec.NextReward),
+	}
+	return nil
+}
+
+// Profile is the personal information published by an account.
+type Profile struct {
+	Address     common.Address
+	ContactName []byte
+	Name        string
+	CID         []byte // CID of the profile document in IPFS
+	BlockNumber uint64 // Block the profile was last updated in
+}
+
+type rpcProfile struct {
+	Address     common.Address `json:"address"`
+	ContactName hexutil.Bytes  `json:"contactName"`
+	Name        string         `json:"name"`
+	CID         hexutil.Bytes  `json:"cid"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
+
+// UnmarshalJSON decodes a profile returned by the node.
+func (p *Profile) UnmarshalJSON(input []byte) error {
+	var dec rpcProfile
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*p = Profile{
+		Address:     dec.Address,
+		ContactName: dec.ContactName,
+		Name:        dec.Name,
+		CID:         dec.CID,
+		BlockNumber: uint64(dec.BlockNumber),
+	}
+	return nil
+}
+
+// ProfileUpdate is a transaction that updated the profile of an account.
+type ProfileUpdate struct {
+	BlockNumber uint64
+	TxHash      common.Hash
+}
+
+type rpcProfileUpdate struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxHash      common.Hash    `json:"transactionHash"`
+}
+
+// UnmarshalJSON decodes a profile update returned by the node.
+func (u *ProfileUpdate) UnmarshalJSON(input []byte) error {
+	var dec rpcProfileUpdate
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*u = ProfileUpdate{
+		BlockNumber: uint64(dec.BlockNumber),
+		TxHash:      dec.TxHash,
+	}
+	return nil
+}
+
+// AccountProof is an account claimed by the node together with its Merkle
+// proof. If ProofCIDs is not empty, it lists the IPFS cid of every proof node.
+type AccountProof struct {
+	Address      common.Address
+	BlockHash    common.Hash
+	BlockNumber  uint64
+	AccountProof [][]byte
+	ProofCIDs    []string
+	Balance      *big.Int
+	Nonce        uint64
+}
+
+type rpcAccountProof struct {
+	Address      common.Address  `json:"address"`
+	BlockHash    common.Hash     `json:"blockHash"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	ProofCIDs    []string        `json:"proofCids"`
+	Balance      *hexutil.Big    `json:"balance"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+}
+
+// UnmarshalJSON decodes an account proof returned by the node.
+func (p *AccountProof) UnmarshalJSON(input []byte) error {
+	var dec rpcAccountProof
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*p = AccountProof{
+		Address:     dec.Address,
+		BlockHash:   dec.BlockHash,
+		BlockNumber: uint64(dec.BlockNumber),
+		ProofCIDs:   dec.ProofCIDs,
+		Balance:     (*big.Int)(dec.Balance),
+		Nonce:       uint64(dec.Nonce),
+	}
+	for _, node := range dec.AccountProof {
+		p.AccountProof = append(p.AccountProof, node)
+	}
+	return nil
+}
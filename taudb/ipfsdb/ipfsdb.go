@@ -63,11 +63,7 @@ func (db *Database) Has(key []byte) (bool, error) {
 
 // Get retrieves the given key if it's present in the key-value store.
 func (db *Database) Get(key []byte) ([]byte, error) {
-	dat, err := db.idb.Get(key)
-	if err != nil {
-		return nil, err
-	}
-	return dat, nil
+	return db.idb.Get(key)
 }
 
 // Put inserts the given value into the key-value store.